@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/iskendria-pub/iskendria/blockchain"
+	"github.com/iskendria-pub/iskendria/cliIskendria"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/config"
+	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/model"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+The archive tool exports the complete Iskendria state and recreates
+it on a fresh network. Build it with go build -o iskendria-archive
+./archive. Usage:
+
+  iskendria-archive export <archive>
+      Writes all addresses under the Iskendria namespace to a JSON file.
+  iskendria-archive genesis <archive> <key dir> <batch file>
+      Writes a batch that recreates the archived state, to be used
+      with sawadm genesis.
+  iskendria-archive import <archive> <key dir>
+      Sends the commands that recreate the archived state to the
+      REST API of a network that has not been bootstrapped.
+
+State cannot be copied, because it can only be written by commands.
+The commands are made with the command builders and are signed with
+the keys of the original persons. The key directory holds key pairs
+<name>.pub and <name>.priv. Documents like manuscripts and reviews are
+taken from the document directory, which has a file for every document
+named after its hash. New ids are generated, and timestamps are the
+times of the commands.
+
+The commands are applied to a blockchain stub while they are made.
+The stub state is checked against the archive before anything is
+written. After an import, the state of the network is checked too.
+Records that cannot be recreated because a key or a document is
+missing are listed in the report.
+*/
+
+const usage = `Usage:
+  iskendria-archive export <archive>
+  iskendria-archive genesis <archive> <key dir> <batch file>
+  iskendria-archive import <archive> <key dir>`
+
+// Sawtooth does not accept arbitrarily large batches.
+const MAX_IMPORT_BATCH_SIZE = 100
+
+const IMPORT_BATCH_TIMEOUT = time.Minute
+
+type archive struct {
+	Namespace string          `json:"namespace"`
+	Entries   []*archiveEntry `json:"entries"`
+}
+
+type archiveEntry struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	Data    []byte `json:"data"`
+}
+
+func main() {
+	defaults := config.GetDefaults()
+	defaults.DatabaseFile = "archive.db"
+	args, err := config.Load(defaults)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.SetOutput(ioutil.Discard)
+	switch {
+	case len(args) == 2 && args[0] == "export":
+		err = exportArchive(args[1])
+	case len(args) == 4 && args[0] == "genesis":
+		err = writeGenesisBatch(args[1], args[2], args[3])
+	case len(args) == 3 && args[0] == "import":
+		err = importArchive(args[1], args[2])
+	default:
+		err = errors.New(usage)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func exportArchive(fname string) error {
+	state, err := blockchain.GetAllState(model.Namespace)
+	if err != nil {
+		return err
+	}
+	a, err := getArchive(state)
+	if err != nil {
+		return err
+	}
+	contents, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d addresses\n", len(a.Entries))
+	return ioutil.WriteFile(fname, contents, 0644)
+}
+
+func getArchive(state map[string][]byte) (*archive, error) {
+	addresses := make([]string, 0, len(state))
+	for address := range state {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	result := &archive{
+		Namespace: model.Namespace,
+		Entries:   make([]*archiveEntry, len(addresses)),
+	}
+	for i, address := range addresses {
+		message, err := model.UnmarshalState(address, state[address])
+		if err != nil {
+			return nil, err
+		}
+		result.Entries[i] = &archiveEntry{
+			Address: address,
+			Type:    proto.MessageName(message),
+			Data:    state[address],
+		}
+	}
+	return result, nil
+}
+
+func readArchive(fname string) (*archive, error) {
+	contents, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	result := &archive{}
+	if err = json.Unmarshal(contents, result); err != nil {
+		return nil, errors.New("Could not parse archive: " + err.Error())
+	}
+	if result.Namespace != model.Namespace {
+		return nil, errors.New(fmt.Sprintf("Archive has namespace %s, expected %s",
+			result.Namespace, model.Namespace))
+	}
+	return result, nil
+}
+
+func writeGenesisBatch(archiveFile, keyDir, batchFile string) error {
+	g, err := generateFromFiles(archiveFile, keyDir)
+	if err != nil {
+		return err
+	}
+	batchListBytes, _, err := blockchain.GetBatchListBytes(g.commands)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Writing %d commands to %s\n", len(g.commands), batchFile)
+	return ioutil.WriteFile(batchFile, batchListBytes, 0644)
+}
+
+func importArchive(archiveFile, keyDir string) error {
+	g, err := generateFromFiles(archiveFile, keyDir)
+	if err != nil {
+		return err
+	}
+	dbLogger := log.New(ioutil.Discard, "db", log.Flags())
+	dao.Init(config.Current.DatabaseFile, dbLogger)
+	defer dao.Shutdown(dbLogger)
+	outputter := func(s string) { fmt.Print(s) }
+	for start := 0; start < len(g.commands); start += MAX_IMPORT_BATCH_SIZE {
+		end := start + MAX_IMPORT_BATCH_SIZE
+		if end > len(g.commands) {
+			end = len(g.commands)
+		}
+		batchId, err := blockchain.SendCommands(g.commands[start:end], outputter)
+		if err != nil {
+			return err
+		}
+		status, err := blockchain.WaitForBatch(batchId, IMPORT_BATCH_TIMEOUT)
+		if err != nil {
+			return err
+		}
+		if status.StatusCode != blockchain.BATCH_STATUS_COMMITTED {
+			return errors.New(fmt.Sprintf("Batch %s was not committed, status %s%s",
+				batchId, status.StatusCode.String(), formatInvalidTransactions(status)))
+		}
+	}
+	state, err := blockchain.GetAllState(model.Namespace)
+	if err != nil {
+		return err
+	}
+	r := checkRecreatedState(g.archived, state, g.newIds)
+	fmt.Println("Check of the network against the archive:")
+	r.Write(os.Stdout)
+	if len(r.Divergences) >= 1 {
+		return errors.New("The state of the network does not match the archive")
+	}
+	return nil
+}
+
+func formatInvalidTransactions(status *blockchain.BatchStatus) string {
+	result := ""
+	for _, it := range status.InvalidTransactions {
+		result = result + fmt.Sprintf("\n  %s: %s", it.TransactionId, it.Message)
+	}
+	return result
+}
+
+// generateFromFiles makes the commands and checks the result against
+// the archive. Records that were skipped are divergences, so a partial
+// state is never written.
+func generateFromFiles(archiveFile, keyDir string) (*generator, error) {
+	a, err := readArchive(archiveFile)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := decodeArchive(a)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := readKeys(keyDir)
+	if err != nil {
+		return nil, err
+	}
+	g := newGenerator(archived, keys, readDocumentFromDir(config.Current.DocumentDir))
+	if err = g.run(); err != nil {
+		return nil, err
+	}
+	r := checkRecreatedState(archived, g.getShadowState(), g.newIds)
+	r.Notes = append(g.notes, r.Notes...)
+	fmt.Println("Check of the generated commands against the archive:")
+	r.Write(os.Stdout)
+	if len(r.Divergences) >= 1 {
+		return nil, errors.New("The generated commands do not recreate the archive, nothing was written")
+	}
+	return g, nil
+}
+
+func readKeys(keyDir string) (map[string]*command.CryptoIdentity, error) {
+	publicKeyFiles, err := filepath.Glob(filepath.Join(keyDir, "*.pub"))
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*command.CryptoIdentity)
+	for _, publicKeyFile := range publicKeyFiles {
+		privateKeyFile := strings.TrimSuffix(publicKeyFile, ".pub") + ".priv"
+		cryptoIdentity, err := cliIskendria.ReadCryptoIdentity(publicKeyFile, privateKeyFile)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Could not read key pair %s: %s", publicKeyFile, err.Error()))
+		}
+		cryptoIdentity.PublicKeyStr = cryptoIdentity.PublicKey.AsHex()
+		result[cryptoIdentity.PublicKeyStr] = cryptoIdentity
+	}
+	return result, nil
+}
+
+type documentReader func(theHash string) ([]byte, bool)
+
+func readDocumentFromDir(documentDir string) documentReader {
+	return func(theHash string) ([]byte, bool) {
+		data, err := ioutil.ReadFile(filepath.Join(documentDir, theHash))
+		if err != nil || model.HashBytes(data) != theHash {
+			return nil, false
+		}
+		return data, true
+	}
+}
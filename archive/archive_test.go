@@ -0,0 +1,288 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/iskendria-pub/iskendria/cliIskendria"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/model"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecreateArchivedState(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	f := newFixture(t)
+	defer f.cleanup()
+	f.createState(t)
+	g := f.generate(t, f.readKeys(t))
+	if len(g.notes) != 0 {
+		t.Errorf("Expected no notes, got %v", g.notes)
+	}
+	r := checkRecreatedState(f.readArchive(t), g.getShadowState(), g.newIds)
+	if len(r.Divergences) != 0 {
+		t.Errorf("Expected no divergences, got %v", r.Divergences)
+	}
+}
+
+func TestMissingKeyIsReported(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	f := newFixture(t)
+	defer f.cleanup()
+	f.createState(t)
+	if err := os.Remove(filepath.Join(f.keyDir, "reviewer.priv")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(f.keyDir, "reviewer.pub")); err != nil {
+		t.Fatal(err)
+	}
+	g := f.generate(t, f.readKeys(t))
+	if len(g.notes) != 1 {
+		t.Errorf("Expected a note about the skipped review, got %v", g.notes)
+	}
+	r := checkRecreatedState(f.readArchive(t), g.getShadowState(), g.newIds)
+	// The review is missing and the published manuscript has no used review
+	if len(r.Divergences) != 1 {
+		t.Errorf("Expected the missing review as the only divergence, got %v", r.Divergences)
+	}
+}
+
+type fixture struct {
+	tempDir     string
+	keyDir      string
+	documentDir string
+	archiveFile string
+	identities  map[string]*command.CryptoIdentity
+	ba          *recordingAccess
+	seq         int
+}
+
+func newFixture(t *testing.T) *fixture {
+	tempDir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fixture{
+		tempDir:     tempDir,
+		keyDir:      filepath.Join(tempDir, "keys"),
+		documentDir: filepath.Join(tempDir, "documents"),
+		archiveFile: filepath.Join(tempDir, "archive.json"),
+		identities:  make(map[string]*command.CryptoIdentity),
+		ba: &recordingAccess{
+			state:    make(map[string][]byte),
+			delegate: command.NewBlockchainStub(ignoreEvent, log.New(ioutil.Discard, "", 0)),
+		},
+	}
+	for _, dir := range []string{f.keyDir, f.documentDir} {
+		if err = os.Mkdir(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"major", "author", "coauthor", "editor", "otherEditor", "reviewer", "blindReviewer",
+		"revisingReviewer"} {
+		publicKeyFile := filepath.Join(f.keyDir, name+".pub")
+		privateKeyFile := filepath.Join(f.keyDir, name+".priv")
+		if err = cliIskendria.CreateKeyPair(publicKeyFile, privateKeyFile); err != nil {
+			t.Fatal(err)
+		}
+		f.identities[name], err = cliIskendria.ReadCryptoIdentity(publicKeyFile, privateKeyFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return f
+}
+
+func (f *fixture) cleanup() {
+	_ = os.RemoveAll(f.tempDir)
+}
+
+func (f *fixture) run(t *testing.T, c *command.Command) {
+	f.seq++
+	if err := command.RunCommandForTest(c, fmt.Sprintf("transaction%d", f.seq), f.ba); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *fixture) document(t *testing.T, contents string) []byte {
+	data := []byte(contents)
+	if err := ioutil.WriteFile(filepath.Join(f.documentDir, model.HashBytes(data)), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func (f *fixture) createPerson(t *testing.T, name, majorId string) string {
+	c, personId := command.GetPersonCreateCommand(&command.PersonCreate{
+		PublicKey: f.identities[name].PublicKeyStr,
+		Name:      name,
+		Email:     name + "@xxx.nl",
+	}, majorId, f.identities["major"], int32(0))
+	f.run(t, c)
+	return personId
+}
+
+// createState creates every kind of record, using the command builders
+// like the client does.
+func (f *fixture) createState(t *testing.T) {
+	major := f.identities["major"]
+	bootstrap := command.GetBootstrapCommand(&command.Bootstrap{
+		Name:  "major",
+		Email: "major@xxx.nl",
+	}, major)
+	f.run(t, bootstrap)
+	majorId := bootstrap.Command.Signer
+	authorId := f.createPerson(t, "author", majorId)
+	coauthorId := f.createPerson(t, "coauthor", majorId)
+	editorId := f.createPerson(t, "editor", majorId)
+	reviewerId := f.createPerson(t, "reviewer", majorId)
+	otherEditorId := f.createPerson(t, "otherEditor", majorId)
+	blindReviewerId := f.createPerson(t, "blindReviewer", majorId)
+	revisingReviewerId := f.createPerson(t, "revisingReviewer", majorId)
+	f.run(t, command.GetPersonUpdatePropertiesCommand(authorId,
+		&dao.PersonUpdate{Name: "author", Email: "author@xxx.nl"},
+		&dao.PersonUpdate{Name: "author", Email: "author@xxx.nl", Organization: "University", Country: "NL"},
+		authorId, f.identities["author"], int32(0)))
+	f.run(t, command.GetCommandPersonUpdateBiography(authorId, "",
+		f.document(t, "Biography of author"), authorId, f.identities["author"], int32(0)))
+	for _, personId := range []string{authorId, coauthorId, reviewerId} {
+		f.run(t, command.GetPersonUpdateSetSignedCommand(personId, majorId, major, int32(0)))
+	}
+	editor := f.identities["editor"]
+	journalCreate, journalId := command.GetCommandJournalCreate(&command.Journal{
+		Title: "Journal",
+	}, editorId, editor, int32(0))
+	f.run(t, journalCreate)
+	f.run(t, command.GetCommandJournalUpdateDescription(journalId, "",
+		f.document(t, "Description of journal"), editorId, editor, int32(0)))
+	f.run(t, command.GetCommandEditorInvite(journalId, otherEditorId, editorId, editor, int32(0)))
+	f.run(t, command.GetCommandJournalUpdateAuthorization(journalId, true, majorId, major, int32(0)))
+	volumeCreate, volumeId := command.GetCommandVolumeCreate(&command.Volume{
+		JournalId:              journalId,
+		Issue:                  "1",
+		LogicalPublicationTime: int64(1000),
+		VolumeNumber:           int32(3),
+	}, editorId, editor, int32(0))
+	f.run(t, volumeCreate)
+	f.run(t, command.GetCommandVolumeUpdate(volumeId, journalId,
+		&command.VolumeProperties{Issue: "1", VolumeNumber: int32(3)},
+		&command.VolumeProperties{Issue: "1", VolumeNumber: int32(3), Year: int32(2019), PublicationDate: "2019-03-01"},
+		editorId, editor, int32(0)))
+	manuscriptCreate, manuscriptId := command.GetCommandManuscriptCreate(&command.ManuscriptCreate{
+		TheManuscript: f.document(t, "Manuscript"),
+		CommitMsg:     "First version",
+		Title:         "Title",
+		AuthorId:      []string{authorId, coauthorId},
+		JournalId:     journalId,
+	}, authorId, f.identities["author"], int32(0))
+	f.run(t, manuscriptCreate)
+	threadId := manuscriptCreate.Command.GetCommandManuscriptCreate().ManuscriptThreadId
+	f.run(t, command.GetCommandManuscriptAcceptAuthorship(&dao.Manuscript{
+		Id:       manuscriptId,
+		ThreadId: threadId,
+		Authors: []*dao.Author{
+			{PersonId: authorId, DidSign: true, AuthorNumber: int32(0)},
+			{PersonId: coauthorId, DidSign: false, AuthorNumber: int32(1)},
+		},
+	}, coauthorId, f.identities["coauthor"], int32(0)))
+	f.run(t, command.GetCommandManuscriptAllowReview(threadId,
+		[]dao.ReferenceThreadItem{{Id: manuscriptId, Status: model.GetManuscriptStatusString(model.ManuscriptStatus_new)}},
+		journalId, editorId, editor, int32(0)))
+	reviewCreate, reviewId := command.GetCommandWritePositiveReview(&command.ReviewCreate{
+		ManuscriptId: manuscriptId,
+		JournalId:    journalId,
+		TheReview:    f.document(t, "Review"),
+	}, reviewerId, f.identities["reviewer"], int32(0))
+	f.run(t, reviewCreate)
+	blindReviewCreate, blindReviewSecret := command.GetCommandWriteBlindReview(&command.ReviewCreate{
+		ManuscriptId: manuscriptId,
+		JournalId:    journalId,
+		TheReview:    f.document(t, "Blind review"),
+	}, model.Judgement_NEGATIVE, blindReviewerId, f.identities["blindReviewer"])
+	f.run(t, blindReviewCreate)
+	firstRevisionCreate, firstRevisionId := command.GetCommandWriteNegativeReview(&command.ReviewCreate{
+		ManuscriptId: manuscriptId,
+		JournalId:    journalId,
+		TheReview:    f.document(t, "Review to be revised"),
+	}, revisingReviewerId, f.identities["revisingReviewer"], int32(0))
+	f.run(t, firstRevisionCreate)
+	secondRevisionCreate, secondRevisionId := command.GetCommandReviewRevise(&command.ReviewRevise{
+		PreviousReviewId:     firstRevisionId,
+		PreviousManuscriptId: manuscriptId,
+		ManuscriptId:         manuscriptId,
+		JournalId:            journalId,
+		TheReview:            f.document(t, "Revised review"),
+	}, model.Judgement_POSITIVE, revisingReviewerId, f.identities["revisingReviewer"], int32(0))
+	f.run(t, secondRevisionCreate)
+	f.run(t, command.GetCommandManuscriptPublish(&command.ManuscriptJudge{
+		ManuscriptId: manuscriptId,
+		ReviewId:     []string{reviewId, blindReviewSecret.ReviewId, secondRevisionId},
+	}, journalId, editorId, editor, int32(0)))
+	f.run(t, command.GetCommandReviewReveal(blindReviewSecret, manuscriptId, f.identities["blindReviewer"], int32(0)))
+	f.run(t, command.GetCommandManuscriptAssign(&command.ManuscriptAssign{
+		ManuscriptId: manuscriptId,
+		VolumeId:     volumeId,
+		FirstPage:    "1",
+		LastPage:     "10",
+	}, journalId, "", editorId, editor, int32(0)))
+	f.run(t, command.GetCommandVolumeSeal(volumeId, journalId, editorId, editor, int32(0)))
+	newVersion, _ := command.GetCommandManuscriptCreateNewVersion(&command.ManuscriptCreateNewVersion{
+		TheManuscript:        f.document(t, "Manuscript, second version"),
+		CommitMsg:            "Second version",
+		Title:                "Title",
+		AuthorId:             []string{coauthorId},
+		PreviousManuscriptId: manuscriptId,
+		ThreadId:             threadId,
+		JournalId:            journalId,
+	}, []dao.ReferenceThreadItem{{Id: manuscriptId, Status: model.GetManuscriptStatusString(model.ManuscriptStatus_assigned)}},
+		[]string{authorId, coauthorId}, coauthorId, f.identities["coauthor"], int32(0))
+	f.run(t, newVersion)
+	f.run(t, command.GetCommandJournalUpdateState(
+		journalId, model.JournalState_journalClosed, editorId, editor, int32(0)))
+	f.run(t, command.GetPersonUpdateIncBalanceCommand(reviewerId, int32(10), majorId, major, int32(0)))
+	f.run(t, command.GetSettingsUpdateCommand(
+		&dao.Settings{}, &dao.Settings{PricePersonEdit: int32(5)}, nil, majorId, major, int32(0)))
+	a, err := getArchive(f.ba.state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(f.archiveFile, contents, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *fixture) readArchive(t *testing.T) *archivedState {
+	a, err := readArchive(f.archiveFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived, err := decodeArchive(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return archived
+}
+
+func (f *fixture) readKeys(t *testing.T) map[string]*command.CryptoIdentity {
+	keys, err := readKeys(f.keyDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func (f *fixture) generate(t *testing.T, keys map[string]*command.CryptoIdentity) *generator {
+	g := newGenerator(f.readArchive(t), keys, readDocumentFromDir(f.documentDir))
+	if err := g.run(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
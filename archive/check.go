@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/iskendria-pub/iskendria/model"
+	"github.com/iskendria-pub/iskendria/report"
+	"sort"
+)
+
+/*
+checkRecreatedState compares the archived records with the recreated
+records. Ids, creation times and modification times cannot be
+preserved. They are cleared before comparing, and references to other
+records are translated with newIds. The editors of a journal are
+compared irrespective of their order, because the first editor of a
+recreated journal is the editor that created it.
+*/
+func checkRecreatedState(archived *archivedState, state map[string][]byte, newIds map[string]string) *report.Report {
+	r := &report.Report{
+		Notes:       make([]string, 0),
+		Divergences: make([]string, 0),
+	}
+	recreated, err := decodeState(state)
+	if err != nil {
+		r.AddDivergence("Could not decode the recreated state: %s", err.Error())
+		return r
+	}
+	if !proto.Equal(archived.settings.PriceList, recreated.settings.PriceList) {
+		r.AddDivergence("The price list differs:\n%s",
+			formatDifference(archived.settings.PriceList, recreated.settings.PriceList))
+	}
+	if archived.settings.ApprovalThreshold != recreated.settings.ApprovalThreshold {
+		r.AddDivergence("The approval threshold differs, archived %d, recreated %d",
+			archived.settings.ApprovalThreshold, recreated.settings.ApprovalThreshold)
+	}
+	if archived.settings.MinimumFamilyVersion != recreated.settings.MinimumFamilyVersion {
+		r.AddDivergence("The minimum family version differs, archived %q, recreated %q",
+			archived.settings.MinimumFamilyVersion, recreated.settings.MinimumFamilyVersion)
+	}
+	// The archived major count can be zero or outdated, see DATA.md, so
+	// the majors are counted instead. The recreated majors are only
+	// counted when the approval threshold is set.
+	archivedMajorCount := int32(0)
+	for _, p := range archived.persons {
+		if p.IsMajor {
+			archivedMajorCount++
+		}
+	}
+	if recreated.settings.MajorCount != int32(0) && archivedMajorCount != recreated.settings.MajorCount {
+		r.AddDivergence("The major count differs, archived %d majors, recreated %d",
+			archivedMajorCount, recreated.settings.MajorCount)
+	}
+	c := &checker{
+		r:        r,
+		newIds:   newIds,
+		expected: make(map[string]bool),
+	}
+	for id, p := range archived.persons {
+		c.compare(id, p, recreated.persons[newIds[id]])
+	}
+	for id, j := range archived.journals {
+		c.compare(id, j, recreated.journals[newIds[id]])
+	}
+	for id, v := range archived.volumes {
+		c.compare(id, v, recreated.volumes[newIds[id]])
+	}
+	for id, m := range archived.manuscripts {
+		c.compare(id, m, recreated.manuscripts[newIds[id]])
+	}
+	for id, t := range archived.manuscriptThreads {
+		c.compare(id, t, recreated.manuscriptThreads[newIds[id]])
+	}
+	for id, rv := range archived.reviews {
+		c.compare(id, rv, recreated.reviews[newIds[id]])
+	}
+	for id, ri := range archived.reviewInvitations {
+		c.compare(id, ri, recreated.reviewInvitations[newIds[id]])
+	}
+	unexpected := make([]string, 0)
+	for address := range state {
+		if address != model.GetSettingsAddress() && !c.expected[address] {
+			unexpected = append(unexpected, address)
+		}
+	}
+	sort.Strings(unexpected)
+	for _, address := range unexpected {
+		r.AddDivergence("Address %s was not expected", address)
+	}
+	sort.Strings(r.Divergences)
+	return r
+}
+
+type checker struct {
+	r        *report.Report
+	newIds   map[string]string
+	expected map[string]bool
+}
+
+func (c *checker) compare(archivedId string, archivedRecord, recreatedRecord proto.Message) {
+	newId, isRecreated := c.newIds[archivedId]
+	if !isRecreated {
+		c.r.AddDivergence("%s %s was not recreated", proto.MessageName(archivedRecord), archivedId)
+		return
+	}
+	c.expected[newId] = true
+	if isNilMessage(recreatedRecord) {
+		c.r.AddDivergence("%s %s was recreated as %s, but that address is empty",
+			proto.MessageName(archivedRecord), archivedId, newId)
+		return
+	}
+	expected := c.normalize(archivedRecord, true)
+	actual := c.normalize(recreatedRecord, false)
+	if !proto.Equal(expected, actual) {
+		c.r.AddDivergence("%s %s, recreated as %s, differs:\n%s",
+			proto.MessageName(archivedRecord), archivedId, newId, formatDifference(expected, actual))
+	}
+}
+
+func isNilMessage(m proto.Message) bool {
+	switch v := m.(type) {
+	case *model.StatePerson:
+		return v == nil
+	case *model.StateJournal:
+		return v == nil
+	case *model.StateVolume:
+		return v == nil
+	case *model.StateManuscript:
+		return v == nil
+	case *model.StateManuscriptThread:
+		return v == nil
+	case *model.StateReview:
+		return v == nil
+	case *model.StateReviewInvitation:
+		return v == nil
+	}
+	return m == nil
+}
+
+// normalize returns a copy without the fields that cannot be preserved.
+// The references of an archived record are translated to new ids.
+func (c *checker) normalize(record proto.Message, isArchived bool) proto.Message {
+	mapId := func(id string) string {
+		if !isArchived || id == "" {
+			return id
+		}
+		if newId, found := c.newIds[id]; found {
+			return newId
+		}
+		return "not recreated: " + id
+	}
+	result := proto.Clone(record)
+	switch v := result.(type) {
+	case *model.StatePerson:
+		v.Id = ""
+		v.CreatedOn = int64(0)
+		v.ModifiedOn = int64(0)
+	case *model.StateJournal:
+		v.Id = ""
+		v.CreatedOn = int64(0)
+		v.ModifiedOn = int64(0)
+		for _, ei := range v.EditorInfo {
+			ei.EditorId = mapId(ei.EditorId)
+		}
+		sort.Slice(v.EditorInfo, func(i, j int) bool {
+			return v.EditorInfo[i].EditorId < v.EditorInfo[j].EditorId
+		})
+	case *model.StateVolume:
+		v.Id = ""
+		v.CreatedOn = int64(0)
+		v.JournalId = mapId(v.JournalId)
+		// The page ranges repeat the pages of the manuscripts, which are
+		// compared. Manuscripts assigned with family version 1 have none.
+		v.PageRange = nil
+	case *model.StateManuscript:
+		v.Id = ""
+		v.CreatedOn = int64(0)
+		v.ModifiedOn = int64(0)
+		v.ThreadId = mapId(v.ThreadId)
+		v.JournalId = mapId(v.JournalId)
+		v.VolumeId = mapId(v.VolumeId)
+		for _, a := range v.Author {
+			a.AuthorId = mapId(a.AuthorId)
+		}
+	case *model.StateManuscriptThread:
+		v.Id = ""
+		for i := range v.ManuscriptId {
+			v.ManuscriptId[i] = mapId(v.ManuscriptId[i])
+		}
+	case *model.StateReview:
+		v.Id = ""
+		v.CreatedOn = int64(0)
+		v.ManuscriptId = mapId(v.ManuscriptId)
+		v.ReviewAuthorId = mapId(v.ReviewAuthorId)
+		v.InvitationId = mapId(v.InvitationId)
+		v.PreviousReviewId = mapId(v.PreviousReviewId)
+		v.NextReviewId = mapId(v.NextReviewId)
+		// A recreated blind review has a new key and salt.
+		v.ReviewerCommitment = ""
+		v.ReviewKey = ""
+		v.SealedProof = ""
+	case *model.StateReviewInvitation:
+		v.Id = ""
+		v.CreatedOn = int64(0)
+		v.ModifiedOn = int64(0)
+		v.ManuscriptId = mapId(v.ManuscriptId)
+		v.ThreadId = mapId(v.ThreadId)
+		v.JournalId = mapId(v.JournalId)
+		v.ReviewerId = mapId(v.ReviewerId)
+		v.EditorId = mapId(v.EditorId)
+	}
+	return result
+}
+
+func formatDifference(expected, actual proto.Message) string {
+	expectedJson, err := model.ToJson(expected)
+	if err != nil {
+		expectedJson = err.Error()
+	}
+	actualJson, err := model.ToJson(actual)
+	if err != nil {
+		actualJson = err.Error()
+	}
+	return fmt.Sprintf("expected %s\nactual %s", expectedJson, actualJson)
+}
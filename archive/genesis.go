@@ -0,0 +1,1215 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/sawtooth-sdk-go/processor"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/config"
+	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/model"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+)
+
+// archivedState is the archive with the records decoded by type.
+type archivedState struct {
+	settings          *model.StateSettings
+	persons           map[string]*model.StatePerson
+	journals          map[string]*model.StateJournal
+	volumes           map[string]*model.StateVolume
+	manuscripts       map[string]*model.StateManuscript
+	manuscriptThreads map[string]*model.StateManuscriptThread
+	reviews           map[string]*model.StateReview
+	reviewInvitations map[string]*model.StateReviewInvitation
+	proposals         map[string]*model.StateProposal
+}
+
+func newArchivedState() *archivedState {
+	return &archivedState{
+		persons:           make(map[string]*model.StatePerson),
+		journals:          make(map[string]*model.StateJournal),
+		volumes:           make(map[string]*model.StateVolume),
+		manuscripts:       make(map[string]*model.StateManuscript),
+		manuscriptThreads: make(map[string]*model.StateManuscriptThread),
+		reviews:           make(map[string]*model.StateReview),
+		reviewInvitations: make(map[string]*model.StateReviewInvitation),
+		proposals:         make(map[string]*model.StateProposal),
+	}
+}
+
+func decodeArchive(a *archive) (*archivedState, error) {
+	return decodeState(getStateOfArchive(a))
+}
+
+func getStateOfArchive(a *archive) map[string][]byte {
+	result := make(map[string][]byte)
+	for _, e := range a.Entries {
+		result[e.Address] = e.Data
+	}
+	return result
+}
+
+func decodeState(state map[string][]byte) (*archivedState, error) {
+	result := newArchivedState()
+	for address, data := range state {
+		message, err := model.UnmarshalState(address, data)
+		if err != nil {
+			return nil, err
+		}
+		switch m := message.(type) {
+		case *model.StateSettings:
+			result.settings = m
+		case *model.StatePerson:
+			result.persons[address] = m
+		case *model.StateJournal:
+			result.journals[address] = m
+		case *model.StateVolume:
+			result.volumes[address] = m
+		case *model.StateManuscript:
+			result.manuscripts[address] = m
+		case *model.StateManuscriptThread:
+			result.manuscriptThreads[address] = m
+		case *model.StateReview:
+			result.reviews[address] = m
+		case *model.StateReviewInvitation:
+			result.reviewInvitations[address] = m
+		case *model.StateProposal:
+			result.proposals[address] = m
+		default:
+			return nil, errors.New(fmt.Sprintf("Unexpected type %s at address %s",
+				proto.MessageName(message), address))
+		}
+	}
+	if result.settings == nil {
+		return nil, errors.New("The archive has no settings, so the network was not bootstrapped")
+	}
+	return result, nil
+}
+
+// recordingAccess remembers the state that was written, so that the
+// recreated state can be compared with the archive.
+type recordingAccess struct {
+	state    map[string][]byte
+	delegate command.BlockchainAccess
+}
+
+var _ command.BlockchainAccess = new(recordingAccess)
+
+func (ra *recordingAccess) GetState(addresses []string) (map[string][]byte, error) {
+	return ra.delegate.GetState(addresses)
+}
+
+func (ra *recordingAccess) SetState(pairs map[string][]byte) ([]string, error) {
+	for address, data := range pairs {
+		ra.state[address] = data
+	}
+	return ra.delegate.SetState(pairs)
+}
+
+func (ra *recordingAccess) AddEvent(eventType string, attributes []processor.Attribute, eventData []byte) error {
+	return ra.delegate.AddEvent(eventType, attributes, eventData)
+}
+
+func ignoreEvent(*events_pb2.Event, *log.Logger) error {
+	return nil
+}
+
+/*
+The generator makes the commands that recreate the archived state.
+Every command is applied to a blockchain stub, the shadow, before
+the next command is made. The commands are made in dependency order:
+the bootstrap, persons, journals with their editors, volumes,
+manuscript threads with their review invitations and reviews, the
+seals of the volumes, the review policies and states of the journals,
+balances and finally the settings. The review policies come after the
+reviews, because reviews that were written before a journal accepted
+only invited reviewers would otherwise be rejected. For the same
+reason, volumes are sealed after their manuscripts were assigned, and
+journals are closed or archived after their manuscripts and volumes
+have been written. All commands are made while every price is zero, so
+no balance is changed by a price. The first major is the earliest
+archived major of whom the key is available. That major creates the
+persons and does the work that only majors can do.
+
+Ids are not preserved, because the command builders generate them.
+The map newIds maps archived ids to the ids of the recreated records.
+*/
+type generator struct {
+	archived     *archivedState
+	keys         map[string]*command.CryptoIdentity
+	readDocument documentReader
+	shadow       *recordingAccess
+	newIds       map[string]string
+	majorId      string
+	major        *command.CryptoIdentity
+	commands     []*command.Command
+	notes        []string
+}
+
+func newGenerator(
+	archived *archivedState,
+	keys map[string]*command.CryptoIdentity,
+	readDocument documentReader) *generator {
+	return &generator{
+		archived:     archived,
+		keys:         keys,
+		readDocument: readDocument,
+		shadow: &recordingAccess{
+			state:    make(map[string][]byte),
+			delegate: command.NewBlockchainStub(ignoreEvent, log.New(ioutil.Discard, "", 0)),
+		},
+		newIds:   make(map[string]string),
+		commands: make([]*command.Command, 0),
+		notes:    make([]string, 0),
+	}
+}
+
+func (g *generator) addNote(format string, args ...interface{}) {
+	g.notes = append(g.notes, fmt.Sprintf(format, args...))
+}
+
+func (g *generator) getShadowState() map[string][]byte {
+	return g.shadow.state
+}
+
+func (g *generator) run() error {
+	steps := []func() error{
+		g.bootstrap,
+		g.createPersons,
+		g.updatePersons,
+		g.createJournals,
+		g.createVolumes,
+		g.createManuscriptThreads,
+		g.sealVolumes,
+		g.updateJournalReviewPolicies,
+		g.updateJournalStates,
+		g.incrementBalances,
+		g.skipOpenProposals,
+		g.updateSettings,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply applies the command to the shadow and adds it to the commands.
+// A failing command means that the archive cannot be recreated, so the
+// error is not recoverable.
+func (g *generator) apply(c *command.Command) error {
+	transactionId := fmt.Sprintf("genesis-%d", len(g.commands))
+	err := command.ApplyModelCommand(
+		c.Command,
+		config.Current.FamilyVersion,
+		c.CryptoIdentity.PublicKeyStr,
+		transactionId,
+		command.NewAccessCheckingDecorator(g.shadow, c.InputAddresses, c.OutputAddresses))
+	if err != nil {
+		return errors.New(fmt.Sprintf("Command #%d could not be applied: %s\n%s",
+			len(g.commands), err.Error(), c.Command.String()))
+	}
+	g.commands = append(g.commands, c)
+	return nil
+}
+
+func (g *generator) getKey(personId string) *command.CryptoIdentity {
+	person, found := g.archived.persons[personId]
+	if !found {
+		return nil
+	}
+	return g.keys[strings.ToLower(person.PublicKey)]
+}
+
+func (g *generator) getDocument(theHash, description string) ([]byte, bool) {
+	data, found := g.readDocument(theHash)
+	if !found {
+		g.addNote("Skipping %s, because document %s is not available", description, theHash)
+	}
+	return data, found
+}
+
+func (g *generator) getNewManuscript(newManuscriptId string) (*model.StateManuscript, error) {
+	data, err := g.shadow.GetState([]string{newManuscriptId})
+	if err != nil {
+		return nil, err
+	}
+	result := &model.StateManuscript{}
+	if err = proto.Unmarshal(data[newManuscriptId], result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (g *generator) bootstrap() error {
+	for _, p := range g.getSortedPersons() {
+		key := g.getKey(p.Id)
+		if !p.IsMajor || key == nil {
+			continue
+		}
+		c := command.GetBootstrapCommand(&command.Bootstrap{
+			Name:  p.Name,
+			Email: p.Email,
+		}, key)
+		if err := g.apply(c); err != nil {
+			return err
+		}
+		g.majorId = c.Command.Signer
+		g.major = key
+		g.newIds[p.Id] = g.majorId
+		return nil
+	}
+	return errors.New("The key of at least one major is needed to bootstrap")
+}
+
+func (g *generator) getSortedPersons() []*model.StatePerson {
+	result := make([]*model.StatePerson, 0, len(g.archived.persons))
+	for _, p := range g.archived.persons {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedOn != result[j].CreatedOn {
+			return result[i].CreatedOn < result[j].CreatedOn
+		}
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+func (g *generator) createPersons() error {
+	for _, p := range g.getSortedPersons() {
+		if _, isCreated := g.newIds[p.Id]; isCreated {
+			continue
+		}
+		c, newPersonId := command.GetPersonCreateCommand(&command.PersonCreate{
+			PublicKey: p.PublicKey,
+			Name:      p.Name,
+			Email:     p.Email,
+		}, g.majorId, g.major, int32(0))
+		if err := g.apply(c); err != nil {
+			return err
+		}
+		g.newIds[p.Id] = newPersonId
+	}
+	return nil
+}
+
+func (g *generator) updatePersons() error {
+	for _, p := range g.getSortedPersons() {
+		newPersonId := g.newIds[p.Id]
+		orig := &dao.PersonUpdate{
+			PublicKey: p.PublicKey,
+			Name:      p.Name,
+			Email:     p.Email,
+		}
+		updated := &dao.PersonUpdate{
+			PublicKey:    p.PublicKey,
+			Name:         p.Name,
+			Email:        p.Email,
+			Organization: p.Organization,
+			Telephone:    p.Telephone,
+			Address:      p.Address,
+			PostalCode:   p.PostalCode,
+			Country:      p.Country,
+			ExtraInfo:    p.ExtraInfo,
+		}
+		commands := make([]*command.Command, 0)
+		if *orig != *updated {
+			commands = append(commands, command.GetPersonUpdatePropertiesCommand(
+				newPersonId, orig, updated, g.majorId, g.major, int32(0)))
+		}
+		if p.BiographyHash != "" {
+			if biography, found := g.getDocument(p.BiographyHash, "biography of person "+p.Id); found {
+				commands = append(commands, command.GetCommandPersonUpdateBiography(
+					newPersonId, "", biography, g.majorId, g.major, int32(0)))
+			}
+		}
+		if p.IsMajor && newPersonId != g.majorId {
+			commands = append(commands, command.GetPersonUpdateSetMajorCommand(
+				newPersonId, g.majorId, g.major, int32(0)))
+		}
+		if p.IsSigned {
+			commands = append(commands, command.GetPersonUpdateSetSignedCommand(
+				newPersonId, g.majorId, g.major, int32(0)))
+		}
+		for _, c := range commands {
+			if err := g.apply(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) getSortedJournals() []*model.StateJournal {
+	result := make([]*model.StateJournal, 0, len(g.archived.journals))
+	for _, j := range g.archived.journals {
+		result = append(result, j)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedOn != result[j].CreatedOn {
+			return result[i].CreatedOn < result[j].CreatedOn
+		}
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+// getJournalEditor returns an accepted editor of the archived journal
+// of whom the key is available.
+func (g *generator) getJournalEditor(journalId string) (string, *command.CryptoIdentity) {
+	journal, found := g.archived.journals[journalId]
+	if !found {
+		return "", nil
+	}
+	for _, ei := range journal.EditorInfo {
+		if ei.EditorState != model.EditorState_editorAccepted {
+			continue
+		}
+		if key := g.getKey(ei.EditorId); key != nil {
+			return ei.EditorId, key
+		}
+	}
+	return "", nil
+}
+
+func (g *generator) createJournals() error {
+	for _, j := range g.getSortedJournals() {
+		creatorId, creatorKey := g.getJournalEditor(j.Id)
+		if creatorKey == nil {
+			g.addNote("Skipping journal %s, because no key of an accepted editor is available", j.Id)
+			continue
+		}
+		newCreatorId := g.newIds[creatorId]
+		c, newJournalId := command.GetCommandJournalCreate(&command.Journal{
+			Title: j.Title,
+		}, newCreatorId, creatorKey, int32(0))
+		if err := g.apply(c); err != nil {
+			return err
+		}
+		g.newIds[j.Id] = newJournalId
+		commands := make([]*command.Command, 0)
+		if j.DescriptionHash != "" {
+			if description, found := g.getDocument(j.DescriptionHash, "description of journal "+j.Id); found {
+				commands = append(commands, command.GetCommandJournalUpdateDescription(
+					newJournalId, "", description, newCreatorId, creatorKey, int32(0)))
+			}
+		}
+		for _, ei := range j.EditorInfo {
+			if ei.EditorId == creatorId {
+				continue
+			}
+			commands = append(commands, command.GetCommandEditorInvite(
+				newJournalId, g.newIds[ei.EditorId], newCreatorId, creatorKey, int32(0)))
+			if ei.EditorState != model.EditorState_editorAccepted {
+				continue
+			}
+			editorKey := g.getKey(ei.EditorId)
+			if editorKey == nil {
+				g.addNote("Editor %s of journal %s remains proposed, because the key is not available",
+					ei.EditorId, j.Id)
+				continue
+			}
+			commands = append(commands, command.GetCommandEditorAcceptDuty(
+				newJournalId, g.newIds[ei.EditorId], editorKey, int32(0)))
+		}
+		if j.IsSigned {
+			commands = append(commands, command.GetCommandJournalUpdateAuthorization(
+				newJournalId, true, g.majorId, g.major, int32(0)))
+		}
+		for _, c := range commands {
+			if err := g.apply(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) createVolumes() error {
+	volumes := make([]*model.StateVolume, 0, len(g.archived.volumes))
+	for _, v := range g.archived.volumes {
+		volumes = append(volumes, v)
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		if volumes[i].CreatedOn != volumes[j].CreatedOn {
+			return volumes[i].CreatedOn < volumes[j].CreatedOn
+		}
+		return volumes[i].Id < volumes[j].Id
+	})
+	for _, v := range volumes {
+		newJournalId, isJournalCreated := g.newIds[v.JournalId]
+		if !isJournalCreated {
+			g.addNote("Skipping volume %s, because journal %s was skipped", v.Id, v.JournalId)
+			continue
+		}
+		editorId, editorKey := g.getJournalEditor(v.JournalId)
+		c, newVolumeId := command.GetCommandVolumeCreate(&command.Volume{
+			JournalId:              newJournalId,
+			Issue:                  v.Issue,
+			LogicalPublicationTime: v.LogicalPublicationTime,
+			VolumeNumber:           v.VolumeNumber,
+			IssueNumber:            v.IssueNumber,
+			Year:                   v.Year,
+			PublicationDate:        v.PublicationDate,
+		}, g.newIds[editorId], editorKey, int32(0))
+		if err := g.apply(c); err != nil {
+			return err
+		}
+		g.newIds[v.Id] = newVolumeId
+	}
+	return nil
+}
+
+// getShadowVolume reads a recreated volume, because the page ranges of
+// its manuscripts are checked before they are assigned.
+func (g *generator) getShadowVolume(newVolumeId string) (*model.StateVolume, error) {
+	result := &model.StateVolume{}
+	if err := proto.Unmarshal(g.shadow.state[newVolumeId], result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sealVolumes is done after the manuscripts are assigned, because sealed
+// volumes do not accept manuscripts.
+func (g *generator) sealVolumes() error {
+	volumeIds := make([]string, 0, len(g.archived.volumes))
+	for id := range g.archived.volumes {
+		volumeIds = append(volumeIds, id)
+	}
+	sort.Strings(volumeIds)
+	for _, id := range volumeIds {
+		v := g.archived.volumes[id]
+		newVolumeId, isVolumeCreated := g.newIds[id]
+		if !isVolumeCreated || !v.IsSealed {
+			continue
+		}
+		editorId, editorKey := g.getJournalEditor(v.JournalId)
+		c := command.GetCommandVolumeSeal(
+			newVolumeId, g.newIds[v.JournalId], g.newIds[editorId], editorKey, int32(0))
+		if err := g.apply(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSortedManuscriptThreads orders the threads by the creation
+// time of their first manuscript.
+func (g *generator) getSortedManuscriptThreads() []*model.StateManuscriptThread {
+	result := make([]*model.StateManuscriptThread, 0, len(g.archived.manuscriptThreads))
+	for _, t := range g.archived.manuscriptThreads {
+		if len(t.ManuscriptId) >= 1 {
+			result = append(result, t)
+		}
+	}
+	getCreatedOn := func(t *model.StateManuscriptThread) int64 {
+		if m, found := g.archived.manuscripts[t.ManuscriptId[0]]; found {
+			return m.CreatedOn
+		}
+		return int64(0)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if getCreatedOn(result[i]) != getCreatedOn(result[j]) {
+			return getCreatedOn(result[i]) < getCreatedOn(result[j])
+		}
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+func getSortedAuthors(m *model.StateManuscript) []*model.Author {
+	result := make([]*model.Author, len(m.Author))
+	copy(result, m.Author)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].AuthorNumber < result[j].AuthorNumber
+	})
+	return result
+}
+
+/*
+A manuscript thread is recreated in the following order. First all
+versions are submitted, each followed by the acceptance of authorship
+by its signed authors. Then the editor allows review, which makes
+every version reviewable of which all authors signed. Then the reviews
+are written and the versions are judged and assigned. This order
+results in the same state as the original order, because the thread
+only becomes reviewable as a whole and because judging only marks the
+reviews that the editor used.
+*/
+func (g *generator) createManuscriptThreads() error {
+	for _, t := range g.getSortedManuscriptThreads() {
+		if err := g.createManuscriptThread(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) createManuscriptThread(t *model.StateManuscriptThread) error {
+	first, found := g.archived.manuscripts[t.ManuscriptId[0]]
+	if !found {
+		g.addNote("Skipping manuscript thread %s, because its first manuscript is not archived", t.Id)
+		return nil
+	}
+	newJournalId, isJournalCreated := g.newIds[first.JournalId]
+	if !isJournalCreated {
+		g.addNote("Skipping manuscript thread %s, because journal %s was skipped", t.Id, first.JournalId)
+		return nil
+	}
+	historicAuthors := make([]string, 0)
+	newThreadId := ""
+	for i, archivedManuscriptId := range t.ManuscriptId {
+		m, found := g.archived.manuscripts[archivedManuscriptId]
+		if !found {
+			g.addNote("Manuscript thread %s is truncated, because manuscript %s is not archived",
+				t.Id, archivedManuscriptId)
+			break
+		}
+		var newManuscriptId string
+		var err error
+		if i == 0 {
+			newManuscriptId, newThreadId, err = g.createFirstManuscript(m, newJournalId)
+		} else {
+			newManuscriptId, err = g.createNewVersion(m, t, i, newThreadId, newJournalId, historicAuthors)
+		}
+		if err != nil {
+			return err
+		}
+		if newManuscriptId == "" {
+			break
+		}
+		g.newIds[m.Id] = newManuscriptId
+		if i == 0 {
+			g.newIds[t.Id] = newThreadId
+		}
+		if err = g.acceptAuthorship(m, newManuscriptId, newThreadId); err != nil {
+			return err
+		}
+		newManuscript, err := g.getNewManuscript(newManuscriptId)
+		if err != nil {
+			return err
+		}
+		for _, a := range newManuscript.Author {
+			if a.DidSign {
+				historicAuthors = append(historicAuthors, a.AuthorId)
+			}
+		}
+	}
+	if newThreadId == "" {
+		return nil
+	}
+	if err := g.createReviewInvitations(t); err != nil {
+		return err
+	}
+	if err := g.judgeManuscriptThread(t, newThreadId, newJournalId); err != nil {
+		return err
+	}
+	return g.withdrawManuscriptThread(t, newThreadId)
+}
+
+func (g *generator) getNewAuthorIds(m *model.StateManuscript) []string {
+	sortedAuthors := getSortedAuthors(m)
+	result := make([]string, len(sortedAuthors))
+	for i, a := range sortedAuthors {
+		result[i] = g.newIds[a.AuthorId]
+	}
+	return result
+}
+
+func (g *generator) createFirstManuscript(
+	m *model.StateManuscript, newJournalId string) (string, string, error) {
+	signerId, signerKey := g.getSubmitter(m, nil)
+	if signerKey == nil {
+		g.addNote("Skipping manuscript thread %s, because no key of a signed author is available", m.ThreadId)
+		return "", "", nil
+	}
+	theManuscript, found := g.getDocument(m.Hash, "manuscript thread "+m.ThreadId)
+	if !found {
+		return "", "", nil
+	}
+	c, newManuscriptId := command.GetCommandManuscriptCreate(&command.ManuscriptCreate{
+		TheManuscript: theManuscript,
+		CommitMsg:     m.CommitMsg,
+		Title:         m.Title,
+		AuthorId:      g.getNewAuthorIds(m),
+		JournalId:     newJournalId,
+	}, g.newIds[signerId], signerKey, int32(0))
+	if err := g.apply(c); err != nil {
+		return "", "", err
+	}
+	return newManuscriptId, c.Command.GetCommandManuscriptCreate().ManuscriptThreadId, nil
+}
+
+// getSubmitter returns a signed author of the archived manuscript of
+// whom the key is available. When historic authors are given, the
+// submitter should be one of them.
+func (g *generator) getSubmitter(
+	m *model.StateManuscript, newHistoricAuthors []string) (string, *command.CryptoIdentity) {
+	for _, a := range getSortedAuthors(m) {
+		if !a.DidSign {
+			continue
+		}
+		if newHistoricAuthors != nil && !containsString(newHistoricAuthors, g.newIds[a.AuthorId]) {
+			continue
+		}
+		if key := g.getKey(a.AuthorId); key != nil {
+			return a.AuthorId, key
+		}
+	}
+	return "", nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) createNewVersion(
+	m *model.StateManuscript,
+	t *model.StateManuscriptThread,
+	versionIndex int,
+	newThreadId,
+	newJournalId string,
+	historicAuthors []string) (string, error) {
+	signerId, signerKey := g.getSubmitter(m, historicAuthors)
+	if signerKey == nil {
+		g.addNote("Manuscript thread %s is truncated at manuscript %s, because no key of a signed historic author is available",
+			t.Id, m.Id)
+		return "", nil
+	}
+	theManuscript, found := g.getDocument(m.Hash, "manuscript "+m.Id+" and the later versions")
+	if !found {
+		return "", nil
+	}
+	threadReference, err := g.getThreadReference(t.ManuscriptId[:versionIndex])
+	if err != nil {
+		return "", err
+	}
+	c, newManuscriptId := command.GetCommandManuscriptCreateNewVersion(&command.ManuscriptCreateNewVersion{
+		TheManuscript:        theManuscript,
+		CommitMsg:            m.CommitMsg,
+		Title:                m.Title,
+		AuthorId:             g.getNewAuthorIds(m),
+		PreviousManuscriptId: g.newIds[t.ManuscriptId[versionIndex-1]],
+		ThreadId:             newThreadId,
+		JournalId:            newJournalId,
+	}, threadReference, historicAuthors, g.newIds[signerId], signerKey, int32(0))
+	if err = g.apply(c); err != nil {
+		return "", err
+	}
+	return newManuscriptId, nil
+}
+
+func (g *generator) getThreadReference(archivedManuscriptIds []string) ([]dao.ReferenceThreadItem, error) {
+	result := make([]dao.ReferenceThreadItem, 0, len(archivedManuscriptIds))
+	for _, archivedManuscriptId := range archivedManuscriptIds {
+		newManuscriptId, isCreated := g.newIds[archivedManuscriptId]
+		if !isCreated {
+			break
+		}
+		newManuscript, err := g.getNewManuscript(newManuscriptId)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dao.ReferenceThreadItem{
+			Id:     newManuscriptId,
+			Status: model.GetManuscriptStatusString(newManuscript.Status),
+		})
+	}
+	return result, nil
+}
+
+func (g *generator) acceptAuthorship(m *model.StateManuscript, newManuscriptId, newThreadId string) error {
+	for _, a := range getSortedAuthors(m) {
+		if !a.DidSign {
+			continue
+		}
+		newManuscript, err := g.getNewManuscript(newManuscriptId)
+		if err != nil {
+			return err
+		}
+		newAuthors := make([]*dao.Author, len(newManuscript.Author))
+		alreadySigned := false
+		for i, na := range newManuscript.Author {
+			newAuthors[i] = &dao.Author{
+				PersonId:     na.AuthorId,
+				DidSign:      na.DidSign,
+				AuthorNumber: na.AuthorNumber,
+			}
+			if na.AuthorId == g.newIds[a.AuthorId] {
+				alreadySigned = na.DidSign
+			}
+		}
+		if alreadySigned {
+			continue
+		}
+		key := g.getKey(a.AuthorId)
+		if key == nil {
+			g.addNote("Author %s of manuscript %s does not sign, because the key is not available",
+				a.AuthorId, m.Id)
+			continue
+		}
+		c := command.GetCommandManuscriptAcceptAuthorship(&dao.Manuscript{
+			Id:       newManuscriptId,
+			ThreadId: newThreadId,
+			Authors:  newAuthors,
+		}, g.newIds[a.AuthorId], key, int32(0))
+		if err = g.apply(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) judgeManuscriptThread(t *model.StateManuscriptThread, newThreadId, newJournalId string) error {
+	if !t.IsReviewable {
+		return nil
+	}
+	editorId, editorKey := g.getJournalEditor(g.archived.manuscripts[t.ManuscriptId[0]].JournalId)
+	newEditorId := g.newIds[editorId]
+	threadReference, err := g.getThreadReference(t.ManuscriptId)
+	if err != nil {
+		return err
+	}
+	err = g.apply(command.GetCommandManuscriptAllowReview(
+		newThreadId, threadReference, newJournalId, newEditorId, editorKey, int32(0)))
+	if err != nil {
+		return err
+	}
+	for _, archivedManuscriptId := range t.ManuscriptId {
+		newManuscriptId, isCreated := g.newIds[archivedManuscriptId]
+		if !isCreated {
+			break
+		}
+		newManuscript, err := g.getNewManuscript(newManuscriptId)
+		if err != nil {
+			return err
+		}
+		if newManuscript.Status != model.ManuscriptStatus_reviewable {
+			g.addNote("Skipping the reviews and judgement of manuscript %s, because not all authors signed",
+				archivedManuscriptId)
+			continue
+		}
+		usedReviewIds, blindReviews, err := g.writeReviews(archivedManuscriptId, newManuscriptId)
+		if err != nil {
+			return err
+		}
+		m := g.archived.manuscripts[archivedManuscriptId]
+		manuscriptJudge := &command.ManuscriptJudge{
+			ManuscriptId: newManuscriptId,
+			ReviewId:     usedReviewIds,
+		}
+		commands := make([]*command.Command, 0)
+		switch m.Status {
+		case model.ManuscriptStatus_rejected:
+			commands = append(commands, command.GetCommandManuscriptReject(
+				manuscriptJudge, newJournalId, newEditorId, editorKey, int32(0)))
+		case model.ManuscriptStatus_published:
+			commands = append(commands, command.GetCommandManuscriptPublish(
+				manuscriptJudge, newJournalId, newEditorId, editorKey, int32(0)))
+		case model.ManuscriptStatus_assigned:
+			commands = append(commands, command.GetCommandManuscriptPublish(
+				manuscriptJudge, newJournalId, newEditorId, editorKey, int32(0)))
+			newVolumeId, isVolumeCreated := g.newIds[m.VolumeId]
+			if !isVolumeCreated {
+				g.addNote("Manuscript %s is not assigned, because volume %s was skipped", m.Id, m.VolumeId)
+				break
+			}
+			newVolume, err := g.getShadowVolume(newVolumeId)
+			if err != nil {
+				return err
+			}
+			if err = command.CheckPageRange(newVolume, newManuscriptId, m.FirstPage, m.LastPage); err != nil {
+				g.addNote("Manuscript %s is not assigned to volume %s: %s", m.Id, m.VolumeId, err.Error())
+				break
+			}
+			commands = append(commands, command.GetCommandManuscriptAssign(&command.ManuscriptAssign{
+				ManuscriptId: newManuscriptId,
+				VolumeId:     newVolumeId,
+				FirstPage:    m.FirstPage,
+				LastPage:     m.LastPage,
+			}, newJournalId, "", newEditorId, editorKey, int32(0)))
+		}
+		for _, c := range commands {
+			if err := g.apply(c); err != nil {
+				return err
+			}
+		}
+		if err = g.revealReviews(archivedManuscriptId, newManuscriptId, blindReviews); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A recreatedBlindReview is a blind review that is written again
+// because the archived review was revealed.
+type recreatedBlindReview struct {
+	secret      *dao.BlindReviewSecret
+	reviewerKey *command.CryptoIdentity
+}
+
+// revealReviews reveals the recreated blind reviews. A review can only
+// be revealed when its manuscript was judged.
+func (g *generator) revealReviews(
+	archivedManuscriptId, newManuscriptId string, blindReviews []*recreatedBlindReview) error {
+	if len(blindReviews) == 0 {
+		return nil
+	}
+	newManuscript, err := g.getNewManuscript(newManuscriptId)
+	if err != nil {
+		return err
+	}
+	switch newManuscript.Status {
+	case model.ManuscriptStatus_rejected, model.ManuscriptStatus_published, model.ManuscriptStatus_assigned:
+	default:
+		g.addNote("The blind reviews of manuscript %s are not revealed, because the manuscript was not judged",
+			archivedManuscriptId)
+		return nil
+	}
+	for _, b := range blindReviews {
+		if err := g.apply(command.GetCommandReviewReveal(b.secret, newManuscriptId, b.reviewerKey, int32(0))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withdrawManuscriptThread withdraws the recreated thread when the
+// last archived manuscript was withdrawn. The statuses that the
+// manuscripts had before the withdrawal are not archived, so the
+// withdrawn manuscripts are not judged.
+func (g *generator) withdrawManuscriptThread(t *model.StateManuscriptThread, newThreadId string) error {
+	lastManuscriptId := t.ManuscriptId[len(t.ManuscriptId)-1]
+	m, found := g.archived.manuscripts[lastManuscriptId]
+	if !found || m.Status != model.ManuscriptStatus_withdrawn {
+		return nil
+	}
+	newManuscriptId, isCreated := g.newIds[lastManuscriptId]
+	if !isCreated {
+		g.addNote("Manuscript thread %s is not withdrawn, because manuscript %s was skipped",
+			t.Id, lastManuscriptId)
+		return nil
+	}
+	signerId, signerKey := g.getSubmitter(m, nil)
+	if signerKey == nil {
+		g.addNote("Manuscript thread %s is not withdrawn, because no key of a signed author is available", t.Id)
+		return nil
+	}
+	threadReference, err := g.getThreadReference(t.ManuscriptId)
+	if err != nil {
+		return err
+	}
+	return g.apply(command.GetCommandManuscriptWithdraw(
+		newManuscriptId, newThreadId, threadReference, m.WithdrawalMsg, g.newIds[signerId], signerKey, int32(0)))
+}
+
+// writeReviews writes the reviews of the manuscript. The new ids of
+// the reviews that the editor used are returned. A revealed blind review
+// is written blind again, it is returned to be revealed after the
+// judgement. The author of a blind review that was not revealed is
+// unknown, so such a review cannot be written again. A revision is
+// written as a revision of the recreated previous review, or as a new
+// review when the previous review was skipped.
+func (g *generator) writeReviews(archivedManuscriptId, newManuscriptId string) (
+	[]string, []*recreatedBlindReview, error) {
+	reviews := make([]*model.StateReview, 0)
+	for _, r := range g.archived.reviews {
+		if r.ManuscriptId == archivedManuscriptId {
+			reviews = append(reviews, r)
+		}
+	}
+	// A revision can have the same timestamp as its previous revision.
+	sort.Slice(reviews, func(i, j int) bool {
+		if reviews[i].CreatedOn != reviews[j].CreatedOn {
+			return reviews[i].CreatedOn < reviews[j].CreatedOn
+		}
+		depthI, depthJ := g.getReviewRevisionDepth(reviews[i]), g.getReviewRevisionDepth(reviews[j])
+		if depthI != depthJ {
+			return depthI < depthJ
+		}
+		return reviews[i].Id < reviews[j].Id
+	})
+	usedReviewIds := make([]string, 0)
+	blindReviews := make([]*recreatedBlindReview, 0)
+	for _, r := range reviews {
+		if r.IsBlind && r.ReviewAuthorId == "" {
+			g.addNote("Skipping review %s, because it is a blind review that was not revealed", r.Id)
+			continue
+		}
+		key := g.getKey(r.ReviewAuthorId)
+		if key == nil {
+			g.addNote("Skipping review %s, because the key of the reviewer is not available", r.Id)
+			continue
+		}
+		theReview, found := g.getDocument(r.Hash, "review "+r.Id)
+		if !found {
+			continue
+		}
+		reviewCreate := &command.ReviewCreate{
+			ManuscriptId: newManuscriptId,
+			JournalId:    g.newIds[g.archived.manuscripts[archivedManuscriptId].JournalId],
+			TheReview:    theReview,
+		}
+		if r.InvitationId != "" {
+			newInvitationId, isInvitationCreated := g.newIds[r.InvitationId]
+			if !isInvitationCreated {
+				g.addNote("Skipping review %s, because invitation %s was skipped", r.Id, r.InvitationId)
+				continue
+			}
+			reviewCreate.InvitationId = newInvitationId
+		}
+		newPreviousReviewId, isPreviousReviewCreated := g.newIds[r.PreviousReviewId]
+		if r.PreviousReviewId != "" && !isPreviousReviewCreated {
+			g.addNote("Review %s is written as a new review, because its previous revision %s was skipped",
+				r.Id, r.PreviousReviewId)
+		}
+		var c *command.Command
+		var newReviewId string
+		switch {
+		case r.PreviousReviewId != "" && isPreviousReviewCreated:
+			c, newReviewId = command.GetCommandReviewRevise(&command.ReviewRevise{
+				PreviousReviewId:     newPreviousReviewId,
+				PreviousManuscriptId: g.newIds[g.archived.reviews[r.PreviousReviewId].ManuscriptId],
+				ManuscriptId:         newManuscriptId,
+				JournalId:            reviewCreate.JournalId,
+				TheReview:            theReview,
+			}, r.Judgement, g.newIds[r.ReviewAuthorId], key, int32(0))
+		case r.IsBlind:
+			var secret *dao.BlindReviewSecret
+			c, secret = command.GetCommandWriteBlindReview(
+				reviewCreate, r.Judgement, g.newIds[r.ReviewAuthorId], key)
+			newReviewId = secret.ReviewId
+			blindReviews = append(blindReviews, &recreatedBlindReview{
+				secret:      secret,
+				reviewerKey: key,
+			})
+		case r.Judgement == model.Judgement_POSITIVE:
+			c, newReviewId = command.GetCommandWritePositiveReview(
+				reviewCreate, g.newIds[r.ReviewAuthorId], key, int32(0))
+		default:
+			c, newReviewId = command.GetCommandWriteNegativeReview(
+				reviewCreate, g.newIds[r.ReviewAuthorId], key, int32(0))
+		}
+		if err := g.apply(c); err != nil {
+			return nil, nil, err
+		}
+		g.newIds[r.Id] = newReviewId
+		if r.IsUsedByEditor {
+			usedReviewIds = append(usedReviewIds, newReviewId)
+		}
+	}
+	return usedReviewIds, blindReviews, nil
+}
+
+// getReviewRevisionDepth returns the number of earlier revisions of
+// an archived review.
+func (g *generator) getReviewRevisionDepth(r *model.StateReview) int {
+	result := 0
+	for r.PreviousReviewId != "" {
+		previous, found := g.archived.reviews[r.PreviousReviewId]
+		if !found {
+			break
+		}
+		r = previous
+		result++
+	}
+	return result
+}
+
+func (g *generator) getSortedReviewInvitations(threadId string) []*model.StateReviewInvitation {
+	result := make([]*model.StateReviewInvitation, 0)
+	for _, ri := range g.archived.reviewInvitations {
+		if ri.ThreadId == threadId {
+			result = append(result, ri)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedOn != result[j].CreatedOn {
+			return result[i].CreatedOn < result[j].CreatedOn
+		}
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+// createReviewInvitations invites the reviewers of the thread again and
+// lets them give their archived answer. When the key of the inviting
+// editor is not available, another accepted editor invites.
+func (g *generator) createReviewInvitations(t *model.StateManuscriptThread) error {
+	for _, ri := range g.getSortedReviewInvitations(t.Id) {
+		newManuscriptId, isManuscriptCreated := g.newIds[ri.ManuscriptId]
+		if !isManuscriptCreated {
+			g.addNote("Skipping review invitation %s, because manuscript %s was skipped", ri.Id, ri.ManuscriptId)
+			continue
+		}
+		editorId, editorKey := ri.EditorId, g.getKey(ri.EditorId)
+		if editorKey == nil || !g.isAcceptedEditor(ri.JournalId, editorId) {
+			editorId, editorKey = g.getJournalEditor(ri.JournalId)
+			g.addNote("Review invitation %s is made by editor %s instead of %s", ri.Id, editorId, ri.EditorId)
+		}
+		c, newInvitationId := command.GetCommandReviewerInvite(
+			newManuscriptId, g.newIds[ri.JournalId], g.newIds[ri.ReviewerId], g.newIds[editorId], editorKey, int32(0))
+		if err := g.apply(c); err != nil {
+			return err
+		}
+		g.newIds[ri.Id] = newInvitationId
+		if ri.InvitationState == model.ReviewInvitationState_invitationOpen {
+			continue
+		}
+		reviewerKey := g.getKey(ri.ReviewerId)
+		if reviewerKey == nil {
+			g.addNote("Review invitation %s remains open, because the key of the reviewer is not available", ri.Id)
+			continue
+		}
+		if ri.InvitationState == model.ReviewInvitationState_invitationAccepted {
+			c = command.GetCommandReviewInvitationAccept(newInvitationId, g.newIds[ri.ReviewerId], reviewerKey)
+		} else {
+			c = command.GetCommandReviewInvitationDecline(newInvitationId, g.newIds[ri.ReviewerId], reviewerKey)
+		}
+		if err := g.apply(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) isAcceptedEditor(journalId, personId string) bool {
+	for _, ei := range g.archived.journals[journalId].EditorInfo {
+		if ei.EditorId == personId && ei.EditorState == model.EditorState_editorAccepted {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) updateJournalReviewPolicies() error {
+	for _, j := range g.getSortedJournals() {
+		newJournalId, isJournalCreated := g.newIds[j.Id]
+		if !isJournalCreated || !j.ReviewByInvitationOnly {
+			continue
+		}
+		editorId, editorKey := g.getJournalEditor(j.Id)
+		err := g.apply(command.GetCommandJournalUpdateReviewPolicy(
+			newJournalId, true, g.newIds[editorId], editorKey, int32(0)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateJournalStates closes and archives journals. The major does
+// this, because the journal may not have an editor of whom the key is
+// available.
+func (g *generator) updateJournalStates() error {
+	for _, j := range g.getSortedJournals() {
+		newJournalId, isJournalCreated := g.newIds[j.Id]
+		if !isJournalCreated || j.JournalState == model.JournalState_journalOpen {
+			continue
+		}
+		err := g.apply(command.GetCommandJournalUpdateState(
+			newJournalId, j.JournalState, g.majorId, g.major, int32(0)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) incrementBalances() error {
+	for _, p := range g.getSortedPersons() {
+		if p.Balance == int32(0) {
+			continue
+		}
+		c := command.GetPersonUpdateIncBalanceCommand(
+			g.newIds[p.Id], p.Balance, g.majorId, g.major, int32(0))
+		if err := g.apply(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipOpenProposals only adds notes. Proposals are not recreated,
+// because the effect of an executed proposal is in the recreated records.
+// Open proposals are lost and have to be proposed again.
+func (g *generator) skipOpenProposals() error {
+	ids := make([]string, 0)
+	for id, p := range g.archived.proposals {
+		if p.ProposalState == model.ProposalState_proposalOpen {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		g.addNote("Skipping open proposal %s, because proposals are not recreated", id)
+	}
+	return nil
+}
+
+// updateSettings sets the archived prices, approval threshold and
+// minimum family version. This
+// is done last, because all other commands were made with zero prices
+// and without the need for approvals.
+func (g *generator) updateSettings() error {
+	pl := g.archived.settings.PriceList
+	if pl == nil {
+		pl = &model.PriceList{}
+	}
+	threshold := g.archived.settings.ApprovalThreshold
+	minimumFamilyVersion := g.archived.settings.MinimumFamilyVersion
+	if proto.Equal(pl, &model.PriceList{}) && threshold == int32(0) && minimumFamilyVersion == "" {
+		return nil
+	}
+	updated := &dao.Settings{
+		ApprovalThreshold:                    threshold,
+		MinimumFamilyVersion:                 minimumFamilyVersion,
+		PriceMajorEditSettings:               pl.PriceMajorEditSettings,
+		PriceMajorCreatePerson:               pl.PriceMajorCreatePerson,
+		PriceMajorChangePersonAuthorization:  pl.PriceMajorChangePersonAuthorization,
+		PriceMajorChangeJournalAuthorization: pl.PriceMajorChangeJournalAuthorization,
+		PricePersonEdit:                      pl.PricePersonEdit,
+		PriceAuthorSubmitNewManuscript:       pl.PriceAuthorSubmitNewManuscript,
+		PriceAuthorSubmitNewVersion:          pl.PriceAuthorSubmitNewVersion,
+		PriceAuthorAcceptAuthorship:          pl.PriceAuthorAcceptAuthorship,
+		PriceReviewerSubmit:                  pl.PriceReviewerSubmit,
+		PriceEditorAllowManuscriptReview:     pl.PriceEditorAllowManuscriptReview,
+		PriceEditorRejectManuscript:          pl.PriceEditorRejectManuscript,
+		PriceEditorPublishManuscript:         pl.PriceEditorPublishManuscript,
+		PriceEditorAssignManuscript:          pl.PriceEditorAssignManuscript,
+		PriceEditorCreateJournal:             pl.PriceEditorCreateJournal,
+		PriceEditorCreateVolume:              pl.PriceEditorCreateVolume,
+		PriceEditorEditJournal:               pl.PriceEditorEditJournal,
+		PriceEditorAddColleague:              pl.PriceEditorAddColleague,
+		PriceEditorAcceptDuty:                pl.PriceEditorAcceptDuty,
+		PriceAuthorWithdrawManuscript:        pl.PriceAuthorWithdrawManuscript,
+		PriceEditorInviteReviewer:            pl.PriceEditorInviteReviewer,
+	}
+	return g.apply(command.GetSettingsUpdateCommand(
+		&dao.Settings{}, updated, g.getNewMajorIds(), g.majorId, g.major, int32(0)))
+}
+
+// getNewMajorIds returns the recreated majors. They are listed to count
+// the majors when the approval threshold is set.
+func (g *generator) getNewMajorIds() []string {
+	result := make([]string, 0)
+	for _, p := range g.getSortedPersons() {
+		if p.IsMajor {
+			result = append(result, g.newIds[p.Id])
+		}
+	}
+	return result
+}
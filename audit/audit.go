@@ -0,0 +1,105 @@
+package main
+
+import (
+	"github.com/iskendria-pub/iskendria/blockchain"
+	"github.com/iskendria-pub/iskendria/config"
+	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/model"
+	"github.com/iskendria-pub/iskendria/report"
+	"io/ioutil"
+	"log"
+	"os"
+)
+
+/*
+The audit tool checks that the on-chain state, the events and the
+local database agree. It reads all blocks through the REST API and
+replays the Iskendria transactions in order against a blockchain
+stub. The replayed state is compared with the state on chain. The
+events of the replay fill a fresh database, which is compared with
+the local database of the client, major tool or portal.
+
+Build it with go build -o iskendria-audit ./audit. The log of the
+replay is written to the event log file. The report is written to
+standard output. The exit code is 1 when a divergence was found.
+*/
+
+func main() {
+	defaults := config.GetDefaults()
+	defaults.DatabaseFile = "major.db"
+	defaults.EventLogFile = "./audit-events.log"
+	if _, err := config.Load(defaults); err != nil {
+		log.Fatal(err)
+	}
+	logFile, err := os.OpenFile(config.Current.EventLogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = logFile.Close() }()
+	log.SetOutput(logFile)
+	logger := log.New(logFile, "audit", log.Flags())
+	r := audit(logger)
+	r.Write(os.Stdout)
+	if len(r.Divergences) >= 1 {
+		os.Exit(1)
+	}
+}
+
+func audit(logger *log.Logger) *report.Report {
+	r := &report.Report{}
+	localDump, err := dumpLocalDatabase(config.Current.DatabaseFile)
+	if err != nil {
+		r.AddNote("Skipping comparison with the local database: %s", err.Error())
+	}
+	blocks, err := blockchain.GetAllBlocks()
+	if err != nil {
+		r.AddDivergence("Could not read blocks: %s", err.Error())
+		return r
+	}
+	onChainState, err := blockchain.GetAllState(model.Namespace)
+	if err != nil {
+		r.AddDivergence("Could not read state: %s", err.Error())
+		return r
+	}
+	replayedState, replayedDump, err := replayWithFreshDatabase(blocks, r, logger)
+	if err != nil {
+		r.AddDivergence("Could not replay the blocks: %s", err.Error())
+		return r
+	}
+	r.AddNote("Replayed %d blocks", len(blocks))
+	compareState(replayedState, onChainState, r)
+	if localDump != nil {
+		compareDatabases(replayedDump, localDump, r)
+	}
+	return r
+}
+
+// dumpLocalDatabase does not use dao.Init, because Init would discard or
+// change the database that is audited.
+func dumpLocalDatabase(fname string) (map[string][]string, error) {
+	if _, err := os.Stat(fname); err != nil {
+		return nil, err
+	}
+	return dao.DumpEventTablesOfFile(fname)
+}
+
+func replayWithFreshDatabase(
+	blocks []*blockchain.Block, r *report.Report, logger *log.Logger) (map[string][]byte, map[string][]string, error) {
+	tempFile, err := ioutil.TempFile("", "iskendria-audit")
+	if err != nil {
+		return nil, nil, err
+	}
+	fname := tempFile.Name()
+	_ = tempFile.Close()
+	if err = os.Remove(fname); err != nil {
+		return nil, nil, err
+	}
+	dao.Init(fname, logger)
+	defer dao.ShutdownAndDelete(logger)
+	replayedState := replay(blocks, r, logger)
+	replayedDump, err := dao.DumpEventTables()
+	if err != nil {
+		return nil, nil, err
+	}
+	return replayedState, replayedDump, nil
+}
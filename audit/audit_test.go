@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/sawtooth-sdk-go/signing"
+	"github.com/iskendria-pub/iskendria/blockchain"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/model"
+	"github.com/iskendria-pub/iskendria/report"
+	"github.com/jmoiron/sqlx"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestReplayMatchesItself(t *testing.T) {
+	logger := log.New(ioutil.Discard, "", 0)
+	log.SetOutput(ioutil.Discard)
+	blocks := getTestBlocks(t)
+	r := &report.Report{}
+	state, dump, err := replayWithFreshDatabase(blocks, r, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Divergences) != 0 {
+		t.Fatalf("Replay had divergences: %v", r.Divergences)
+	}
+	_, otherDump, err := replayWithFreshDatabase(blocks, r, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dump["person"]) != 2 {
+		t.Errorf("Expected two persons in the replayed database, got %v", dump["person"])
+	}
+	compareState(state, copyState(state), r)
+	compareDatabases(dump, otherDump, r)
+	if len(r.Divergences) != 0 {
+		t.Errorf("Expected no divergences, got %v", r.Divergences)
+	}
+}
+
+func TestDivergencesAreReported(t *testing.T) {
+	logger := log.New(ioutil.Discard, "", 0)
+	log.SetOutput(ioutil.Discard)
+	blocks := getTestBlocks(t)
+	r := &report.Report{}
+	state, dump, err := replayWithFreshDatabase(blocks, r, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	onChain := copyState(state)
+	addresses := getSortedKeys(map[string]bool{
+		model.GetSettingsAddress():           true,
+		blocks[1].Transactions[0].Outputs[0]: true,
+	})
+	onChain[addresses[0]] = []byte("tampered")
+	delete(onChain, addresses[1])
+	onChain[model.CreatePersonAddress()] = []byte("unknown")
+	compareState(state, onChain, r)
+	if len(r.Divergences) != 3 {
+		t.Errorf("Expected three state divergences, got %v", r.Divergences)
+	}
+	r = &report.Report{}
+	local := map[string][]string{}
+	for table, rows := range dump {
+		local[table] = rows
+	}
+	local["person"] = append([]string{"unexpected row"}, local["person"][1:]...)
+	compareDatabases(dump, local, r)
+	if len(r.Divergences) != 2 {
+		t.Errorf("Expected a missing and an unexpected row, got %v", r.Divergences)
+	}
+}
+
+func TestInvalidTransactionIsReported(t *testing.T) {
+	logger := log.New(ioutil.Discard, "", 0)
+	log.SetOutput(ioutil.Discard)
+	blocks := getTestBlocks(t)
+	// Bootstrapping twice is invalid
+	blocks = append(blocks, &blockchain.Block{
+		BlockId:         "third block",
+		PreviousBlockId: blocks[1].BlockId,
+		Transactions:    blocks[0].Transactions,
+	})
+	r := &report.Report{}
+	if _, _, err := replayWithFreshDatabase(blocks, r, logger); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Divergences) != 1 {
+		t.Errorf("Expected the second bootstrap to be reported, got %v", r.Divergences)
+	}
+}
+
+func TestLocalDatabaseIsNotChanged(t *testing.T) {
+	logger := log.New(ioutil.Discard, "", 0)
+	fname := "testLocalDatabaseIsNotChanged.db"
+	dao.Init(fname, logger)
+	dao.Shutdown(logger)
+	defer func() { _ = os.Remove(fname) }()
+	dump, err := dumpLocalDatabase(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := dump["person"]; !found {
+		t.Errorf("Expected the person table in the dump, got %v", dump)
+	}
+	other, err := sqlx.Open("sqlite3", fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = other.Exec("PRAGMA user_version = 1")
+	_ = other.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = dumpLocalDatabase(fname); err == nil {
+		t.Error("Expected that a database with another schema version is not dumped")
+	}
+	after, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatal("The audited database was removed: " + err.Error())
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("The audited database was changed")
+	}
+}
+
+func getTestBlocks(t *testing.T) []*blockchain.Block {
+	major := getCryptoIdentity()
+	bootstrap := command.GetBootstrapCommand(&command.Bootstrap{
+		Name:  "Major",
+		Email: "major@xxx.nl",
+	}, major)
+	personCreate, _ := command.GetPersonCreateCommand(&command.PersonCreate{
+		PublicKey: getCryptoIdentity().PublicKeyStr,
+		Name:      "Person",
+		Email:     "person@xxx.nl",
+	}, bootstrap.Command.Signer, major, int32(0))
+	return []*blockchain.Block{
+		{
+			BlockId:      "first block",
+			Transactions: []*blockchain.Transaction{getTransaction(t, bootstrap, "bootstrap")},
+		},
+		{
+			BlockId:         "second block",
+			PreviousBlockId: "first block",
+			Transactions:    []*blockchain.Transaction{getTransaction(t, personCreate, "personCreate")},
+		},
+	}
+}
+
+func getTransaction(t *testing.T, c *command.Command, transactionId string) *blockchain.Transaction {
+	payload, err := proto.Marshal(c.Command)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &blockchain.Transaction{
+		TransactionId:   transactionId,
+		FamilyName:      model.FamilyName,
+		FamilyVersion:   model.FamilyVersion,
+		SignerPublicKey: c.CryptoIdentity.PublicKeyStr,
+		Inputs:          c.InputAddresses,
+		Outputs:         c.OutputAddresses,
+		Payload:         payload,
+	}
+}
+
+func getCryptoIdentity() *command.CryptoIdentity {
+	context := signing.NewSecp256k1Context()
+	privateKey := context.NewRandomPrivateKey()
+	publicKey := context.GetPublicKey(privateKey)
+	return &command.CryptoIdentity{
+		PublicKeyStr: publicKey.AsHex(),
+		PublicKey:    publicKey,
+		PrivateKey:   privateKey,
+	}
+}
+
+func copyState(state map[string][]byte) map[string][]byte {
+	result := make(map[string][]byte)
+	for address, data := range state {
+		result[address] = data
+	}
+	return result
+}
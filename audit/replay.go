@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"github.com/hyperledger/sawtooth-sdk-go/processor"
+	"github.com/iskendria-pub/iskendria/blockchain"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/model"
+	"github.com/iskendria-pub/iskendria/report"
+	"log"
+	"sort"
+)
+
+// replayAccess remembers the state that was written, so that
+// the replayed state can be compared with the state on chain.
+type replayAccess struct {
+	state    map[string][]byte
+	delegate command.BlockchainAccess
+}
+
+var _ command.BlockchainAccess = new(replayAccess)
+
+func (ra *replayAccess) GetState(addresses []string) (map[string][]byte, error) {
+	return ra.delegate.GetState(addresses)
+}
+
+func (ra *replayAccess) SetState(pairs map[string][]byte) ([]string, error) {
+	for address, data := range pairs {
+		ra.state[address] = data
+	}
+	return ra.delegate.SetState(pairs)
+}
+
+func (ra *replayAccess) AddEvent(eventType string, attributes []processor.Attribute, eventData []byte) error {
+	return ra.delegate.AddEvent(eventType, attributes, eventData)
+}
+
+// replay applies the Iskendria transactions of the blocks in order.
+// The events are handled by the DAO, which should have been
+// initialized with an empty database. The replayed state is returned.
+func replay(blocks []*blockchain.Block, r *report.Report, logger *log.Logger) map[string][]byte {
+	ra := &replayAccess{
+		state:    make(map[string][]byte),
+		delegate: command.NewBlockchainStub(dao.HandleEvent, logger),
+	}
+	for _, b := range blocks {
+		if err := dao.StartFakeBlock(b.BlockId, b.PreviousBlockId, logger); err != nil {
+			r.AddDivergence("Block %s could not be replayed: %s", b.BlockId, err.Error())
+			continue
+		}
+		for _, t := range b.Transactions {
+			if t.FamilyName != model.FamilyName {
+				continue
+			}
+			if err := replayTransaction(t, ra); err != nil {
+				r.AddDivergence("Transaction %s in block %s could not be replayed: %s",
+					t.TransactionId, b.BlockId, err.Error())
+			}
+		}
+	}
+	return ra.state
+}
+
+func replayTransaction(t *blockchain.Transaction, ba command.BlockchainAccess) error {
+	cmd, err := command.ParsePayload(t.FamilyVersion, t.Payload)
+	if err != nil {
+		return err
+	}
+	checkedAccess := command.NewAccessCheckingDecorator(ba, t.Inputs, t.Outputs)
+	return command.ApplyModelCommand(cmd, t.FamilyVersion, t.SignerPublicKey, t.TransactionId, checkedAccess)
+}
+
+func compareState(replayed, onChain map[string][]byte, r *report.Report) {
+	addresses := make(map[string]bool)
+	for address := range replayed {
+		addresses[address] = true
+	}
+	for address := range onChain {
+		addresses[address] = true
+	}
+	for _, address := range getSortedKeys(addresses) {
+		replayedData, isReplayed := replayed[address]
+		onChainData, isOnChain := onChain[address]
+		switch {
+		case !isOnChain:
+			r.AddDivergence("Address %s was written by the replay, but is not on chain", address)
+		case !isReplayed:
+			r.AddDivergence("Address %s is on chain, but was not written by the replay", address)
+		case !bytes.Equal(replayedData, onChainData):
+			r.AddDivergence("Address %s has different data on chain than in the replay", address)
+		}
+	}
+}
+
+func compareDatabases(replayed, local map[string][]string, r *report.Report) {
+	tables := make(map[string]bool)
+	for table := range replayed {
+		tables[table] = true
+	}
+	for _, table := range getSortedKeys(tables) {
+		for _, row := range getMissingRows(replayed[table], local[table]) {
+			r.AddDivergence("Table %s of the local database misses row: %s", table, row)
+		}
+		for _, row := range getMissingRows(local[table], replayed[table]) {
+			r.AddDivergence("Table %s of the local database has unexpected row: %s", table, row)
+		}
+	}
+}
+
+// getMissingRows returns the expected rows that are not in actual.
+func getMissingRows(expected, actual []string) []string {
+	counts := make(map[string]int)
+	for _, row := range actual {
+		counts[row]++
+	}
+	result := []string{}
+	for _, row := range expected {
+		if counts[row] == 0 {
+			result = append(result, row)
+			continue
+		}
+		counts[row]--
+	}
+	return result
+}
+
+func getSortedKeys(m map[string]bool) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,190 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/iskendria-pub/iskendria/config"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+/*
+The functions in this file read blocks and state through the REST
+API. The functions that read lists follow the paging of the REST API,
+so they work for chains of any length.
+*/
+
+type Block struct {
+	BlockId         string
+	BlockNum        string
+	PreviousBlockId string
+	Transactions    []*Transaction
+}
+
+type Transaction struct {
+	TransactionId   string
+	FamilyName      string
+	FamilyVersion   string
+	SignerPublicKey string
+	Inputs          []string
+	Outputs         []string
+	Payload         []byte
+}
+
+type sawtoothPaging struct {
+	Next_position string
+}
+
+type sawtoothBlockListResponse struct {
+	Data   []*sawtoothBlock
+	Paging *sawtoothPaging
+}
+
+type sawtoothBlock struct {
+	Header_signature string
+	Header           *sawtoothBlockHeader
+	Batches          []*sawtoothBatch
+}
+
+type sawtoothBlockHeader struct {
+	Block_num         string
+	Previous_block_id string
+}
+
+type sawtoothBatch struct {
+	Transactions []*sawtoothTransaction
+}
+
+type sawtoothTransaction struct {
+	Header_signature string
+	Header           *sawtoothTransactionHeader
+	Payload          []byte
+}
+
+type sawtoothTransactionHeader struct {
+	Family_name       string
+	Family_version    string
+	Signer_public_key string
+	Inputs            []string
+	Outputs           []string
+}
+
+type sawtoothStateListResponse struct {
+	Data   []*sawtoothStateEntry
+	Paging *sawtoothPaging
+}
+
+type sawtoothStateEntry struct {
+	Address string
+	Data    []byte
+}
+
+// GetAllBlocks returns all committed blocks, oldest first.
+func GetAllBlocks() ([]*Block, error) {
+	result := []*Block{}
+	position := ""
+	for {
+		requestUrl := config.Current.RestUrl + "/blocks?reverse"
+		if position != "" {
+			requestUrl += "&start=" + url.QueryEscape(position)
+		}
+		response := &sawtoothBlockListResponse{}
+		if err := getJson(requestUrl, response); err != nil {
+			return nil, err
+		}
+		for _, b := range response.Data {
+			result = append(result, b.toBlock())
+		}
+		if response.Paging == nil || response.Paging.Next_position == "" {
+			return result, nil
+		}
+		position = response.Paging.Next_position
+	}
+}
+
+func (sb *sawtoothBlock) toBlock() *Block {
+	result := &Block{
+		BlockId:      sb.Header_signature,
+		Transactions: []*Transaction{},
+	}
+	if sb.Header != nil {
+		result.BlockNum = sb.Header.Block_num
+		result.PreviousBlockId = sb.Header.Previous_block_id
+	}
+	for _, batch := range sb.Batches {
+		for _, t := range batch.Transactions {
+			result.Transactions = append(result.Transactions, t.toTransaction())
+		}
+	}
+	return result
+}
+
+func (st *sawtoothTransaction) toTransaction() *Transaction {
+	result := &Transaction{
+		TransactionId: st.Header_signature,
+		Payload:       st.Payload,
+	}
+	if st.Header != nil {
+		result.FamilyName = st.Header.Family_name
+		result.FamilyVersion = st.Header.Family_version
+		result.SignerPublicKey = st.Header.Signer_public_key
+		result.Inputs = st.Header.Inputs
+		result.Outputs = st.Header.Outputs
+	}
+	return result
+}
+
+// GetAllState returns the data of all addresses that start with
+// the prefix, which is typically a namespace.
+func GetAllState(prefix string) (map[string][]byte, error) {
+	result := make(map[string][]byte)
+	position := ""
+	for {
+		requestUrl := config.Current.RestUrl + "/state?address=" + url.QueryEscape(prefix)
+		if position != "" {
+			requestUrl += "&start=" + url.QueryEscape(position)
+		}
+		response := &sawtoothStateListResponse{}
+		if err := getJson(requestUrl, response); err != nil {
+			return nil, err
+		}
+		for _, entry := range response.Data {
+			result[entry.Address] = entry.Data
+		}
+		if response.Paging == nil || response.Paging.Next_position == "" {
+			return result, nil
+		}
+		position = response.Paging.Next_position
+	}
+}
+
+func getJson(requestUrl string, parsedResponse interface{}) error {
+	response, err := http.Get(requestUrl)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode >= 400 {
+		return errors.New(fmt.Sprintf("Request %s resulted in status code %d", requestUrl, response.StatusCode))
+	}
+	jsonBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonBody, parsedResponse)
+}
+
+type sawtoothStateResponse struct {
+	Data []byte
+}
+
+// GetState returns the data stored at the address.
+func GetState(address string) ([]byte, error) {
+	response := &sawtoothStateResponse{}
+	if err := getJson(config.Current.RestUrl+"/state/"+address, response); err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
@@ -0,0 +1,84 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseBlocks(t *testing.T) {
+	jsonBody := `{
+  "data": [
+    {
+      "batches": [
+        {
+          "header": {
+            "signer_public_key": "batcherKey",
+            "transaction_ids": ["transactionId"]
+          },
+          "header_signature": "batchId",
+          "trace": false,
+          "transactions": [
+            {
+              "header": {
+                "batcher_public_key": "batcherKey",
+                "dependencies": [],
+                "family_name": "alexandria",
+                "family_version": "1.0",
+                "inputs": ["input"],
+                "nonce": "",
+                "outputs": ["output"],
+                "payload_sha512": "hash",
+                "signer_public_key": "signerKey"
+              },
+              "header_signature": "transactionId",
+              "payload": "CAU="
+            }
+          ]
+        }
+      ],
+      "header": {
+        "batch_ids": ["batchId"],
+        "block_num": "1",
+        "previous_block_id": "previousBlockId"
+      },
+      "header_signature": "blockId"
+    }
+  ],
+  "head": "blockId",
+  "link": "http://localhost:8008/blocks?reverse",
+  "paging": {
+    "limit": null,
+    "start": null,
+    "next_position": "nextBlockId"
+  }
+}`
+	parsedResponse := &sawtoothBlockListResponse{}
+	if err := json.Unmarshal([]byte(jsonBody), parsedResponse); err != nil {
+		t.Error("Could not parse blocks: " + err.Error())
+		return
+	}
+	if parsedResponse.Paging.Next_position != "nextBlockId" {
+		t.Error("Next position mismatch: " + parsedResponse.Paging.Next_position)
+	}
+	actual := parsedResponse.Data[0].toBlock()
+	expected := &Block{
+		BlockId:         "blockId",
+		BlockNum:        "1",
+		PreviousBlockId: "previousBlockId",
+		Transactions: []*Transaction{
+			{
+				TransactionId:   "transactionId",
+				FamilyName:      "alexandria",
+				FamilyVersion:   "1.0",
+				SignerPublicKey: "signerKey",
+				Inputs:          []string{"input"},
+				Outputs:         []string{"output"},
+				Payload:         []byte{8, 5},
+			},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Block mismatch, expected %v, got %v", expected, actual)
+	}
+}
@@ -12,39 +12,39 @@ import (
 	"github.com/hyperledger/sawtooth-sdk-go/signing"
 	"github.com/iskendria-pub/iskendria/cli"
 	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/config"
+	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/logging"
 	"github.com/iskendria-pub/iskendria/model"
+	"github.com/iskendria-pub/iskendria/util"
 	"io/ioutil"
 	"log"
-	"net"
 	"net/http"
-	"os"
 	"strings"
+	"time"
 )
 
-const restPort = "8008"
-
 func getFirstBlock(logger *log.Logger) (string, error) {
-	url := fmt.Sprintf("http://%s:%s/blocks?reverse", getIp(), restPort)
-	logger.Printf("Reading %s...\n", url)
+	url := config.Current.RestUrl + "/blocks?reverse"
+	requestLogger := logging.New(logger).With("url", url)
+	requestLogger.Debug("Reading first block")
 	response, err := http.Get(url)
 	if err != nil {
 		return "", err
 	}
 	defer func() { _ = response.Body.Close }()
-	logger.Printf("Got status code %d\n", response.StatusCode)
+	requestLogger.Debug("Got response", "statusCode", response.StatusCode)
 	if response.StatusCode >= 400 {
 		return "", errors.New(fmt.Sprintf("Request for blocks resulted in status code %d", response.StatusCode))
 	}
-	logger.Println("Headers are:")
-	for _, h := range response.Header {
-		logger.Printf("  %s\n", h)
+	for name, values := range response.Header {
+		requestLogger.Debug("Response header", "name", name, "values", strings.Join(values, ", "))
 	}
 	jsonBody, err := ioutil.ReadAll(response.Body)
 	if err != nil {
 		return "", err
 	}
-	logger.Printf("Read %d response bytes\n", len(jsonBody))
-	logger.Printf("Response as string is:\n%s\n", string(jsonBody))
+	requestLogger.Debug("Read response", "numBytes", len(jsonBody), "body", string(jsonBody))
 	parsedResponse := &sawtoothBlocksResponse{}
 	err = json.Unmarshal(jsonBody, parsedResponse)
 	if err != nil {
@@ -66,94 +66,211 @@ type blocksData struct {
 	Header_signature string
 }
 
-func GetBatchStatus(batchId string) string {
-	result := strings.Builder{}
-	url := fmt.Sprintf("http://%s:%s/batch_statuses?id=%s", getIp(), restPort, batchId)
-	result.WriteString("Sending to URL: " + url + "\n")
+type BatchStatusCode int32
+
+const (
+	BATCH_STATUS_UNKNOWN   = BatchStatusCode(0)
+	BATCH_STATUS_PENDING   = BatchStatusCode(1)
+	BATCH_STATUS_COMMITTED = BatchStatusCode(2)
+	BATCH_STATUS_INVALID   = BatchStatusCode(3)
+)
+
+var batchStatusCodeNames = map[BatchStatusCode]string{
+	BATCH_STATUS_UNKNOWN:   "UNKNOWN",
+	BATCH_STATUS_PENDING:   "PENDING",
+	BATCH_STATUS_COMMITTED: "COMMITTED",
+	BATCH_STATUS_INVALID:   "INVALID",
+}
+
+func (c BatchStatusCode) String() string {
+	return batchStatusCodeNames[c]
+}
+
+func GetBatchStatusCode(name string) BatchStatusCode {
+	for code, codeName := range batchStatusCodeNames {
+		if codeName == name {
+			return code
+		}
+	}
+	return BATCH_STATUS_UNKNOWN
+}
+
+type BatchStatus struct {
+	BatchId    string
+	StatusCode BatchStatusCode
+	// The messages of the processor.InvalidTransactionError
+	// instances that made the batch invalid.
+	InvalidTransactions []*InvalidTransaction
+}
+
+type InvalidTransaction struct {
+	TransactionId string
+	Message       string
+}
+
+type sawtoothBatchStatusesResponse struct {
+	Data []*sawtoothBatchStatus
+	Link string
+}
+
+type sawtoothBatchStatus struct {
+	Id                   string
+	Status               string
+	Invalid_transactions []*sawtoothInvalidTransaction
+}
+
+type sawtoothInvalidTransaction struct {
+	Id      string
+	Message string
+}
+
+func GetBatchStatus(batchId string) (*BatchStatus, error) {
+	return getBatchStatus(batchId, 0)
+}
+
+// WaitForBatch waits until the batch is no longer pending or the
+// timeout has elapsed. The REST API does the waiting. The timeout
+// is rounded up to whole seconds.
+func WaitForBatch(batchId string, timeout time.Duration) (*BatchStatus, error) {
+	waitSeconds := int((timeout + time.Second - 1) / time.Second)
+	if waitSeconds < 1 {
+		waitSeconds = 1
+	}
+	return getBatchStatus(batchId, waitSeconds)
+}
+
+func getBatchStatus(batchId string, waitSeconds int) (*BatchStatus, error) {
+	url := fmt.Sprintf("%s/batch_statuses?id=%s", config.Current.RestUrl, batchId)
+	if waitSeconds >= 1 {
+		url = fmt.Sprintf("%s&wait=%d", url, waitSeconds)
+	}
 	response, err := http.Get(url)
 	if err != nil {
-		return err.Error()
-	}
-	msg := fmt.Sprintf("Got status code %d\n", response.StatusCode)
-	result.WriteString(msg)
-	writeMeaningOfstatusCode(response.StatusCode, &result)
-	if response.Body != nil {
-		defer func() { _ = response.Body.Close }()
+		return nil, err
 	}
+	defer func() { _ = response.Body.Close() }()
 	if response.StatusCode >= 400 {
-		return result.String()
+		return nil, errors.New(fmt.Sprintf("Request for batch status resulted in status code %d: %s",
+			response.StatusCode, getMeaningOfStatusCode(response.StatusCode)))
 	}
 	jsonBody, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		result.WriteString(err.Error() + "\n")
-		return result.String()
+		return nil, err
+	}
+	parsedResponse := &sawtoothBatchStatusesResponse{}
+	err = json.Unmarshal(jsonBody, parsedResponse)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsedResponse.Data) != 1 {
+		return nil, errors.New(fmt.Sprintf("Expected one batch status, got %d", len(parsedResponse.Data)))
+	}
+	return parsedResponse.Data[0].toBatchStatus(), nil
+}
+
+func (sbs *sawtoothBatchStatus) toBatchStatus() *BatchStatus {
+	result := &BatchStatus{
+		BatchId:             sbs.Id,
+		StatusCode:          GetBatchStatusCode(sbs.Status),
+		InvalidTransactions: make([]*InvalidTransaction, len(sbs.Invalid_transactions)),
+	}
+	for i, it := range sbs.Invalid_transactions {
+		result.InvalidTransactions[i] = &InvalidTransaction{
+			TransactionId: it.Id,
+			Message:       it.Message,
+		}
 	}
-	result.WriteString(fmt.Sprintf("Read %d response bytes\n", len(jsonBody)))
-	result.WriteString(fmt.Sprintf("Response as string is:\n%s\n", string(jsonBody)))
-	return result.String()
+	return result
 }
 
-func writeMeaningOfstatusCode(statusCode int, result *strings.Builder) {
+func getMeaningOfStatusCode(statusCode int) string {
 	switch statusCode {
-	case 200:
-		result.WriteString("Found batch\n")
 	case 400:
-		result.WriteString("Bad request\n")
+		return "Bad request"
 	case 404:
-		result.WriteString("Batch not found\n")
+		return "Batch not found"
 	case 500:
-		result.WriteString("Internal server error\n")
+		return "Internal server error"
 	case 503:
-		result.WriteString("Service unavailable\n")
+		return "Service unavailable"
 	}
+	return "Unexpected status code"
 }
 
 func SendCommand(c *command.Command, outputter cli.Outputter) error {
-	ip := os.Getenv(envVarIp)
-	if net.ParseIP(ip) == nil {
-		return errors.New(fmt.Sprintf("Environment variable %s should hold an ip address, but is %s",
-			envVarIp, ip))
+	_, err := SendCommands([]*command.Command{c}, outputter)
+	return err
+}
+
+// SendCommands sends the commands as one batch, so that they are applied
+// atomically. The batch id is returned.
+func SendCommands(commands []*command.Command, outputter cli.Outputter) (string, error) {
+	batchListBytes, batchSignature, err := GetBatchListBytes(commands)
+	if err != nil {
+		return "", err
 	}
-	ipAndPort := ip + ":" + restPort
-	payloadBytes, err := proto.Marshal(c.Command)
+	url := config.Current.RestUrl + "/batches"
+	outputter(fmt.Sprintf("Sending %d command(s) to %s\n", len(commands), url))
+	response, err := http.Post(
+		url,
+		"application/octet-stream",
+		bytes.NewBuffer(batchListBytes))
 	if err != nil {
-		return err
+		return "", err
 	}
-	context := signing.CreateContext(c.CryptoIdentity.PrivateKey.GetAlgorithmName())
-	payloadSha512 := model.HashBytes(payloadBytes)
-	rawTransactionHeader := &transaction_pb2.TransactionHeader{
-		SignerPublicKey:  c.CryptoIdentity.PublicKey.AsHex(),
-		FamilyName:       model.FamilyName,
-		FamilyVersion:    model.FamilyVersion,
-		Dependencies:     []string{},
-		BatcherPublicKey: c.CryptoIdentity.PublicKey.AsHex(),
-		Inputs:           c.InputAddresses,
-		Outputs:          c.OutputAddresses,
-		PayloadSha512:    payloadSha512,
+	defer func() { _ = response.Body.Close() }()
+	outputter(fmt.Sprintf("Response status code: %d\n", response.StatusCode))
+	if response.StatusCode >= 400 {
+		return "", errors.New(fmt.Sprintf("Submitting batch resulted in status code %d", response.StatusCode))
 	}
-	transactionHeaderBytes, err := proto.Marshal(rawTransactionHeader)
+	batchSeq, err := dao.AddBatch(getBatchRecord(commands, batchSignature))
 	if err != nil {
-		return err
+		outputter(fmt.Sprintf("Batch %s was sent, but could not be saved in the batch history: %s\n",
+			batchSignature, err.Error()))
+		return batchSignature, nil
 	}
-	signature := hex.EncodeToString(context.Sign(transactionHeaderBytes, c.CryptoIdentity.PrivateKey))
-	transaction := &transaction_pb2.Transaction{
-		Header:          transactionHeaderBytes,
-		HeaderSignature: signature,
-		Payload:         payloadBytes,
+	outputter(fmt.Sprintf("You can request the status of batch %s using reference number %d\n",
+		batchSignature, batchSeq))
+	return batchSignature, nil
+}
+
+// GetBatchListBytes serializes a batch list with one batch that holds
+// the commands. The batch is signed with the key of the first command.
+// A transaction depends on the earlier transactions of which the outputs
+// overlap with its inputs or outputs. The batch id is returned too.
+func GetBatchListBytes(commands []*command.Command) ([]byte, string, error) {
+	if len(commands) == 0 {
+		return nil, "", errors.New("Cannot send an empty list of commands")
+	}
+	batcher := commands[0].CryptoIdentity
+	transactions := make([]*transaction_pb2.Transaction, len(commands))
+	transactionSignatures := make([]string, len(commands))
+	for i, c := range commands {
+		dependencies := getDependencies(c, commands[:i], transactionSignatures[:i])
+		transaction, err := getTransaction(c, batcher, dependencies)
+		if err != nil {
+			return nil, "", err
+		}
+		transactions[i] = transaction
+		transactionSignatures[i] = transaction.HeaderSignature
+		logging.Default().Debug("Created transaction",
+			"transactionId", transaction.HeaderSignature, "command", GetCommandType(c))
 	}
-	transactionSignatures := []string{transaction.HeaderSignature}
+	context := signing.CreateContext(batcher.PrivateKey.GetAlgorithmName())
 	rawBatchHeader := &batch_pb2.BatchHeader{
-		SignerPublicKey: c.CryptoIdentity.PublicKey.AsHex(),
+		SignerPublicKey: batcher.PublicKey.AsHex(),
 		TransactionIds:  transactionSignatures,
 	}
 	batchHeaderBytes, err := proto.Marshal(rawBatchHeader)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
-	batchSignature := hex.EncodeToString(context.Sign(batchHeaderBytes, c.CryptoIdentity.PrivateKey))
+	batchSignature := hex.EncodeToString(context.Sign(batchHeaderBytes, batcher.PrivateKey))
+	logging.Default().Debug("Created batch",
+		"batchId", batchSignature, "transactionIds", strings.Join(transactionSignatures, " "))
 	batch := &batch_pb2.Batch{
 		Header:          batchHeaderBytes,
-		Transactions:    []*transaction_pb2.Transaction{transaction},
+		Transactions:    transactions,
 		HeaderSignature: batchSignature,
 	}
 	rawBatchList := &batch_pb2.BatchList{
@@ -161,45 +278,83 @@ func SendCommand(c *command.Command, outputter cli.Outputter) error {
 	}
 	batchListBytes, err := proto.Marshal(rawBatchList)
 	if err != nil {
-		return err
-	}
-	url := fmt.Sprintf("http://%s/batches", ipAndPort)
-	outputter(fmt.Sprintf("Sending command to %s\n", url))
-	response, err := http.Post(
-		url,
-		"application/octet-stream",
-		bytes.NewBuffer(batchListBytes))
-	if err != nil {
-		return err
+		return nil, "", err
 	}
-	outputter(fmt.Sprintf("Response status code: %d\n", response.StatusCode))
-	batchSeq := TheBatchSequenceNumbers.add(batchSignature)
-	outputter(fmt.Sprintf("You can request the status of batch %s using reference number %d\n",
-		batchSignature, batchSeq))
-	return nil
+	return batchListBytes, batchSignature, nil
 }
 
-type BatchSequenceNumbers struct {
-	nextSeq int32
-	data    map[int32]string
+func getBatchRecord(commands []*command.Command, batchId string) *dao.Batch {
+	commandTypes := make([]string, len(commands))
+	targetIds := []string{}
+	seenTargetIds := make(map[string]bool)
+	for i, c := range commands {
+		commandTypes[i] = GetCommandType(c)
+		for _, address := range c.OutputAddresses {
+			if address == c.Command.Signer || address == model.GetSettingsAddress() || seenTargetIds[address] {
+				continue
+			}
+			seenTargetIds[address] = true
+			targetIds = append(targetIds, address)
+		}
+	}
+	return &dao.Batch{
+		BatchId:     batchId,
+		Signer:      commands[0].Command.Signer,
+		CommandType: strings.Join(commandTypes, " "),
+		TargetIds:   strings.Join(targetIds, " "),
+		SubmitTime:  model.GetCurrentTime(),
+		Status:      BATCH_STATUS_PENDING.String(),
+		SignerKey:   commands[0].CryptoIdentity.PublicKeyStr,
+	}
 }
 
-func (b *BatchSequenceNumbers) add(batchId string) int32 {
-	theSeq := b.nextSeq
-	b.data[theSeq] = batchId
-	b.nextSeq++
-	return theSeq
+func GetCommandType(c *command.Command) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", c.Command.Body), "*model.Command_")
 }
 
-func (b *BatchSequenceNumbers) Get(seq int32) (string, error) {
-	batchId, found := b.data[seq]
-	if !found {
-		return "", errors.New(fmt.Sprintf("No batch for batch sequence number: %d", seq))
+func getTransaction(
+	c *command.Command, batcher *command.CryptoIdentity, dependencies []string) (*transaction_pb2.Transaction, error) {
+	payloadBytes, err := proto.Marshal(c.Command)
+	if err != nil {
+		return nil, err
+	}
+	context := signing.CreateContext(c.CryptoIdentity.PrivateKey.GetAlgorithmName())
+	payloadSha512 := model.HashBytes(payloadBytes)
+	rawTransactionHeader := &transaction_pb2.TransactionHeader{
+		SignerPublicKey:  c.CryptoIdentity.PublicKey.AsHex(),
+		FamilyName:       model.FamilyName,
+		FamilyVersion:    config.Current.FamilyVersion,
+		Dependencies:     dependencies,
+		BatcherPublicKey: batcher.PublicKey.AsHex(),
+		Inputs:           c.InputAddresses,
+		Outputs:          c.OutputAddresses,
+		PayloadSha512:    payloadSha512,
+	}
+	transactionHeaderBytes, err := proto.Marshal(rawTransactionHeader)
+	if err != nil {
+		return nil, err
 	}
-	return batchId, nil
+	signature := hex.EncodeToString(context.Sign(transactionHeaderBytes, c.CryptoIdentity.PrivateKey))
+	return &transaction_pb2.Transaction{
+		Header:          transactionHeaderBytes,
+		HeaderSignature: signature,
+		Payload:         payloadBytes,
+	}, nil
 }
 
-var TheBatchSequenceNumbers = &BatchSequenceNumbers{
-	nextSeq: 0,
-	data:    make(map[int32]string),
+func getDependencies(c *command.Command, earlierCommands []*command.Command, earlierIds []string) []string {
+	addresses := util.StringSliceToSet(c.InputAddresses)
+	for _, address := range c.OutputAddresses {
+		addresses[address] = true
+	}
+	result := []string{}
+	for i, earlier := range earlierCommands {
+		for _, address := range earlier.OutputAddresses {
+			if addresses[address] {
+				result = append(result, earlierIds[i])
+				break
+			}
+		}
+	}
+	return result
 }
@@ -0,0 +1,72 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"github.com/iskendria-pub/iskendria/command"
+	"reflect"
+	"testing"
+)
+
+func TestGetDependencies(t *testing.T) {
+	earlierCommands := []*command.Command{
+		{
+			InputAddresses:  []string{"a", "b"},
+			OutputAddresses: []string{"b"},
+		},
+		{
+			InputAddresses:  []string{"c"},
+			OutputAddresses: []string{"c"},
+		},
+		{
+			InputAddresses:  []string{"d"},
+			OutputAddresses: []string{"e"},
+		},
+	}
+	earlierIds := []string{"first", "second", "third"}
+	c := &command.Command{
+		InputAddresses:  []string{"a", "b", "d"},
+		OutputAddresses: []string{"e"},
+	}
+	actual := getDependencies(c, earlierCommands, earlierIds)
+	expected := []string{"first", "third"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Dependencies mismatch, expected %v, got %v", expected, actual)
+	}
+}
+
+func TestParseBatchStatus(t *testing.T) {
+	jsonBody := `{
+  "data": [
+    {
+      "id": "batchId",
+      "invalid_transactions": [
+        {
+          "id": "transactionId",
+          "message": "Insufficient balance"
+        }
+      ],
+      "status": "INVALID"
+    }
+  ],
+  "link": "http://localhost:8008/batch_statuses?id=batchId"
+}`
+	parsedResponse := &sawtoothBatchStatusesResponse{}
+	if err := json.Unmarshal([]byte(jsonBody), parsedResponse); err != nil {
+		t.Error("Could not parse batch status: " + err.Error())
+		return
+	}
+	actual := parsedResponse.Data[0].toBatchStatus()
+	expected := &BatchStatus{
+		BatchId:    "batchId",
+		StatusCode: BATCH_STATUS_INVALID,
+		InvalidTransactions: []*InvalidTransaction{
+			{
+				TransactionId: "transactionId",
+				Message:       "Insufficient balance",
+			},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Batch status mismatch, expected %v, got %v", expected, actual)
+	}
+}
@@ -8,21 +8,24 @@ import (
 	"github.com/hyperledger/sawtooth-sdk-go/protobuf/client_event_pb2"
 	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
 	"github.com/hyperledger/sawtooth-sdk-go/protobuf/validator_pb2"
+	"github.com/iskendria-pub/iskendria/config"
 	"github.com/pebbe/zmq4"
 	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/logging"
 	"log"
 	"os"
+	"sync/atomic"
+	"time"
 )
 
-const zmqEventPort = "4004"
-
 var EventStreamStatusChannel = make(chan *EventStreamStatus, eventStreamStatusChannelCapacity)
 
 const eventStreamStatusChannelCapacity = 20
 
 type EventStreamStatus struct {
-	StatusCode EventStreamStatusCode
-	Msg        string
+	StatusCode    EventStreamStatusCode
+	Msg           string
+	NumReconnects int32
 }
 
 type EventStreamStatusCode int32
@@ -31,45 +34,103 @@ const (
 	EVENT_STREAM_STATUS_STOPPED      = EventStreamStatusCode(0)
 	EVENT_STREAM_STATUS_INITIALIZING = EventStreamStatusCode(1)
 	EVENT_STREAM_STATUS_RUNNING      = EventStreamStatusCode(2)
+	EVENT_STREAM_STATUS_RECONNECTING = EventStreamStatusCode(3)
+)
+
+const (
+	initialReconnectDelay = time.Second
+	maxReconnectDelay     = time.Minute
 )
 
+var numReconnects int32 = 0
+
+// sendEventStreamStatus does not block when the channel is full, because
+// the clients only read it when the user enters a command. The event
+// stream should keep reconnecting meanwhile. The oldest status is
+// dropped to make room, so the latest status is always kept.
+func sendEventStreamStatus(statusCode EventStreamStatusCode, msg string) {
+	status := &EventStreamStatus{
+		StatusCode:    statusCode,
+		Msg:           msg,
+		NumReconnects: atomic.LoadInt32(&numReconnects),
+	}
+	for {
+		select {
+		case EventStreamStatusChannel <- status:
+			return
+		default:
+		}
+		select {
+		case <-EventStreamStatusChannel:
+		default:
+		}
+	}
+}
+
+// RequestEventStream keeps the database up to date with the blockchain.
+// When the connection fails, it reconnects with exponential backoff and
+// resubscribes from the last block that was applied to the database.
 func RequestEventStream(eventHandler dao.EventHandler, fname, tag string) {
 	file, err := os.Create(fname)
 	if err != nil {
-		EventStreamStatusChannel <- &EventStreamStatus{
-			StatusCode: EVENT_STREAM_STATUS_STOPPED,
-			Msg: fmt.Sprintf("Could not create logfile %s, error %s",
-				fname, err.Error()),
-		}
+		sendEventStreamStatus(EVENT_STREAM_STATUS_STOPPED,
+			fmt.Sprintf("Could not create logfile %s, error %s", fname, err.Error()))
 		return
 	}
 	defer func() { _ = file.Close() }()
 	logger := log.New(file, tag, log.Flags())
-	firstBlock, err := getFirstBlock(logger)
+	reconnectDelay := initialReconnectDelay
+	for {
+		wasRunning := false
+		eventRequester := func(c *messaging.ZmqConnection) error {
+			wasRunning = true
+			return handleEvents(eventHandler, c, logger)
+		}
+		err = subscribeFromLastBlock(eventRequester, logger)
+		if wasRunning {
+			reconnectDelay = initialReconnectDelay
+		}
+		logging.New(logger).Warn("Event stream failed", "error", err, "reconnectDelay", reconnectDelay)
+		dao.ResetContext(logger)
+		atomic.AddInt32(&numReconnects, 1)
+		sendEventStreamStatus(EVENT_STREAM_STATUS_RECONNECTING,
+			fmt.Sprintf("Event stream failed: %s, reconnecting in %s", err.Error(), reconnectDelay))
+		time.Sleep(reconnectDelay)
+		reconnectDelay *= 2
+		if reconnectDelay > maxReconnectDelay {
+			reconnectDelay = maxReconnectDelay
+		}
+	}
+}
+
+func subscribeFromLastBlock(eventRequester eventListener, logger *log.Logger) error {
+	lastBlock, err := dao.GetLastBlockId()
 	if err != nil {
-		EventStreamStatusChannel <- &EventStreamStatus{
-			StatusCode: EVENT_STREAM_STATUS_STOPPED,
-			Msg:        "Could not get first block: " + err.Error(),
+		return errors.New("Could not get last applied block: " + err.Error())
+	}
+	if lastBlock != "" {
+		logging.New(logger).Info("Resuming from last applied block", "blockId", lastBlock)
+		err = doWithSawtoothZmqConnection(eventRequester, lastBlock)
+		if err != errUnknownBlock {
+			return err
 		}
-		return
+		logging.New(logger).Warn("Last applied block is unknown to the blockchain, rebuilding database",
+			"blockId", lastBlock)
+		sendEventStreamStatus(EVENT_STREAM_STATUS_INITIALIZING,
+			"Last applied block is unknown to the blockchain, rebuilding database...")
+		dao.Reset(logger)
 	}
-	eventRequester := func(c *messaging.ZmqConnection) error {
-		return handleEvents(eventHandler, c, logger)
+	firstBlock, err := getFirstBlock(logger)
+	if err != nil {
+		return errors.New("Could not get first block: " + err.Error())
 	}
-	withSawtoothZmqConnection(eventRequester, firstBlock)
+	return doWithSawtoothZmqConnection(eventRequester, firstBlock)
 }
 
+var errUnknownBlock = errors.New("The last known block is unknown to the blockchain")
+
 func handleEvents(eventHandler dao.EventHandler, connection *messaging.ZmqConnection, logger *log.Logger) error {
-	EventStreamStatusChannel <- &EventStreamStatus{
-		StatusCode: EVENT_STREAM_STATUS_RUNNING,
-		Msg:        "Requesting events from blockchain...",
-	}
-	defer func() {
-		EventStreamStatusChannel <- &EventStreamStatus{
-			StatusCode: EVENT_STREAM_STATUS_STOPPED,
-			Msg:        "Stopped.",
-		}
-	}()
+	sendEventStreamStatus(EVENT_STREAM_STATUS_RUNNING, "Requesting events from blockchain...")
 	for {
 		_, message, err := connection.RecvMsg()
 		if err != nil {
@@ -82,30 +143,20 @@ func handleEvents(eventHandler dao.EventHandler, connection *messaging.ZmqConnec
 	}
 }
 
-func withSawtoothZmqConnection(theEventListener eventListener, firstBlock string) {
-	err := doWithSawtoothZmqConnection(theEventListener, firstBlock)
-	if err != nil {
-		EventStreamStatusChannel <- &EventStreamStatus{
-			StatusCode: EVENT_STREAM_STATUS_STOPPED,
-			Msg:        err.Error(),
-		}
-	}
-}
-
-func doWithSawtoothZmqConnection(theEventListner eventListener, firstBlock string) error {
+func doWithSawtoothZmqConnection(theEventListner eventListener, lastKnownBlock string) error {
 	ctx, err := zmq4.NewContext()
 	if err != nil {
 		return err
 	}
-	url := "tcp://" + getIp() + ":" + zmqEventPort
-	connection, err := messaging.NewConnection(ctx, zmq4.DEALER, url, false)
+	defer func() { _ = ctx.Term() }()
+	connection, err := messaging.NewConnection(ctx, zmq4.DEALER, config.Current.ZmqUrl, false)
 	if err != nil {
 		return err
 	}
 	defer connection.Close()
 	request := &client_event_pb2.ClientEventsSubscribeRequest{
 		Subscriptions:     getEventSubscriptions(),
-		LastKnownBlockIds: []string{firstBlock},
+		LastKnownBlockIds: []string{lastKnownBlock},
 	}
 	serializedRequest, err := proto.Marshal(request)
 	if err != nil {
@@ -124,17 +175,18 @@ func doWithSawtoothZmqConnection(theEventListner eventListener, firstBlock strin
 	responseContent := &client_event_pb2.ClientEventsSubscribeResponse{}
 	err = proto.Unmarshal(response.Content, responseContent)
 	if err != nil {
-		EventStreamStatusChannel <- &EventStreamStatus{
-			StatusCode: EVENT_STREAM_STATUS_STOPPED,
-			Msg: fmt.Sprintf("Could not unmarshal ClientEventsSubscribeResponse, error: %s",
-				err.Error()),
-		}
-		return err
+		return errors.New(fmt.Sprintf("Could not unmarshal ClientEventsSubscribeResponse, error: %s",
+			err.Error()))
+	}
+	if responseContent.Status == client_event_pb2.ClientEventsSubscribeResponse_UNKNOWN_BLOCK {
+		return errUnknownBlock
 	}
 	if responseContent.Status != client_event_pb2.ClientEventsSubscribeResponse_OK {
 		return errors.New("ClientEventsSubscribeResponse is negative")
 	}
-	defer sendCloseSubscriptionRequest(connection)
+	// The event listener only returns when the connection failed. There is
+	// no use in unsubscribing then; the validator drops the subscription
+	// when the connection is closed.
 	return theEventListner(connection)
 }
 
@@ -151,31 +203,6 @@ func getEventSubscriptions() []*events_pb2.EventSubscription {
 	return result
 }
 
-func sendCloseSubscriptionRequest(connection *messaging.ZmqConnection) {
-	eventsUnsubscribeRequest := &client_event_pb2.ClientEventsUnsubscribeRequest{}
-	serializedUnsubscibeRequest, err := proto.Marshal(eventsUnsubscribeRequest)
-	if err != nil {
-		panic(err)
-	}
-	corrId, err := connection.SendNewMsg(
-		validator_pb2.Message_CLIENT_EVENTS_UNSUBSCRIBE_REQUEST, serializedUnsubscibeRequest)
-	if err != nil {
-		panic(err)
-	}
-	_, eventsUnsubscribeResponse, err := connection.RecvMsgWithId(corrId)
-	if err != nil {
-		panic(err)
-	}
-	responseContent := &client_event_pb2.ClientEventsUnsubscribeResponse{}
-	err = proto.Unmarshal(eventsUnsubscribeResponse.Content, responseContent)
-	if err != nil {
-		panic(err)
-	}
-	if responseContent.Status != client_event_pb2.ClientEventsUnsubscribeResponse_OK {
-		panic("Negative response on client events unsubscribe request")
-	}
-}
-
 func handleEventsMessage(message *validator_pb2.Message, eventHandler dao.EventHandler, logger *log.Logger) error {
 	if message.MessageType != validator_pb2.Message_CLIENT_EVENTS {
 		return errors.New("Received message is not requested for")
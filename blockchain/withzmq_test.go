@@ -0,0 +1,27 @@
+package blockchain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSendEventStreamStatusDropsOldest(t *testing.T) {
+	numSent := eventStreamStatusChannelCapacity + 5
+	for i := 0; i < numSent; i++ {
+		sendEventStreamStatus(EVENT_STREAM_STATUS_RECONNECTING, fmt.Sprintf("attempt %d", i))
+	}
+	if len(EventStreamStatusChannel) != eventStreamStatusChannelCapacity {
+		t.Errorf("Expected a full channel, got %d statuses", len(EventStreamStatusChannel))
+	}
+	var last *EventStreamStatus
+	first := <-EventStreamStatusChannel
+	for len(EventStreamStatusChannel) > 0 {
+		last = <-EventStreamStatusChannel
+	}
+	if first.Msg != "attempt 5" {
+		t.Errorf("Expected the oldest statuses to be dropped, got first %s", first.Msg)
+	}
+	if last.Msg != fmt.Sprintf("attempt %d", numSent-1) {
+		t.Errorf("Expected the latest status to be kept, got last %s", last.Msg)
+	}
+}
@@ -28,6 +28,19 @@ func outputToStdout(value string) {
 	fmt.Print(value)
 }
 
+// Confirm asks the end user a yes/no question and reads the answer.
+// Handlers can use it before they do something that cannot be undone.
+// Any answer other than y or yes means no.
+func Confirm(outputter Outputter, question string) bool {
+	outputter(question + " [y/N] ")
+	line, _, err := inp.readLine()
+	if err != nil && err != io.EOF {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
+
 func getValue(word string, expectedType reflect.Type) (reflect.Value, error) {
 	switch expectedType.Kind() {
 	case reflect.String:
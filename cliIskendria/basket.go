@@ -0,0 +1,137 @@
+package cliIskendria
+
+import (
+	"fmt"
+	"github.com/iskendria-pub/iskendria/blockchain"
+	"github.com/iskendria-pub/iskendria/cli"
+	"github.com/iskendria-pub/iskendria/command"
+	"strings"
+	"sync"
+)
+
+/*
+The transaction basket allows the end user to combine commands
+into one batch. The commands of a batch are applied atomically:
+either all of them succeed or none of them. When the basket is
+open, commands are collected instead of sent. The basket is sent
+when the end user submits it.
+*/
+type transactionBasket struct {
+	mux      sync.Mutex
+	isOpen   bool
+	commands []*command.Command
+}
+
+var TheBasket = &transactionBasket{
+	mux:      sync.Mutex{},
+	commands: []*command.Command{},
+}
+
+func (b *transactionBasket) open() bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.isOpen {
+		return false
+	}
+	b.isOpen = true
+	return true
+}
+
+// addIfOpen returns the number of commands in the basket,
+// or -1 if the basket is not open.
+func (b *transactionBasket) addIfOpen(c *command.Command) int {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if !b.isOpen {
+		return -1
+	}
+	b.commands = append(b.commands, c)
+	return len(b.commands)
+}
+
+func (b *transactionBasket) getCommands() []*command.Command {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	result := make([]*command.Command, len(b.commands))
+	copy(result, b.commands)
+	return result
+}
+
+func (b *transactionBasket) close() []*command.Command {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	result := b.commands
+	b.isOpen = false
+	b.commands = []*command.Command{}
+	return result
+}
+
+var BasketGroup = &cli.Cli{
+	FullDescription: strings.TrimSpace(`
+Welcome to the transaction basket commands. When the basket is open,
+commands are collected instead of sent. Submit the basket to send all
+collected commands as one batch that is applied atomically.`),
+	OneLineDescription: "Transaction basket",
+	Name:               "basket",
+	Handlers: []cli.Handler{
+		&cli.SingleLineHandler{
+			Name:     "open",
+			Handler:  basketOpen,
+			ArgNames: []string{},
+		},
+		&cli.SingleLineHandler{
+			Name:     "show",
+			Handler:  basketShow,
+			ArgNames: []string{},
+		},
+		&cli.SingleLineHandler{
+			Name:     "discard",
+			Handler:  basketDiscard,
+			ArgNames: []string{},
+		},
+		&cli.SingleLineHandler{
+			Name:     "submit",
+			Handler:  basketSubmit,
+			ArgNames: []string{},
+		},
+	},
+}
+
+func basketOpen(outputter cli.Outputter) {
+	if !TheBasket.open() {
+		outputter("The transaction basket is already open\n")
+		return
+	}
+	outputter("Opened transaction basket, commands are collected until you submit or discard\n")
+}
+
+func basketShow(outputter cli.Outputter) {
+	commands := TheBasket.getCommands()
+	if len(commands) == 0 {
+		outputter("The transaction basket is empty\n")
+		return
+	}
+	for i, c := range commands {
+		outputter(fmt.Sprintf("%d: %s\n", i, blockchain.GetCommandType(c)))
+	}
+}
+
+func basketDiscard(outputter cli.Outputter) {
+	commands := TheBasket.close()
+	outputter(fmt.Sprintf("Discarded %d command(s), the transaction basket is closed\n", len(commands)))
+}
+
+// The basket is kept when sending fails, so that the end
+// user can submit again.
+func basketSubmit(outputter cli.Outputter) {
+	commands := TheBasket.getCommands()
+	if len(commands) == 0 {
+		outputter("The transaction basket is empty, nothing to submit\n")
+		return
+	}
+	if err := sendAndWait(commands, outputter); err != nil {
+		outputter(ToIoError(err))
+		return
+	}
+	TheBasket.close()
+}
@@ -0,0 +1,171 @@
+package cliIskendria
+
+import (
+	"fmt"
+	"github.com/iskendria-pub/iskendria/blockchain"
+	"github.com/iskendria-pub/iskendria/cli"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/dao"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// When positive, sending a command waits at most this number of seconds
+// until the batch is committed or invalid.
+var waitForBatchSeconds int32 = 0
+
+func setWaitForBatches(outputter cli.Outputter, timeoutSeconds int32) {
+	if timeoutSeconds < 0 {
+		outputter("The timeout should not be negative\n")
+		return
+	}
+	atomic.StoreInt32(&waitForBatchSeconds, timeoutSeconds)
+	if timeoutSeconds == 0 {
+		outputter("Commands are sent without waiting for their batch\n")
+		return
+	}
+	outputter(fmt.Sprintf("After sending a command, waiting at most %d seconds for its batch\n", timeoutSeconds))
+}
+
+// SendCommand sends a command to the blockchain, or adds
+// it to the transaction basket if the basket is open.
+func SendCommand(c *command.Command, outputter cli.Outputter) error {
+	numCommands := TheBasket.addIfOpen(c)
+	if numCommands >= 0 {
+		outputter(fmt.Sprintf("Added command to transaction basket, basket has %d command(s)\n", numCommands))
+		return nil
+	}
+	return sendAndWait([]*command.Command{c}, outputter)
+}
+
+// SendCommandOutsideBasket sends a command in a batch of its own,
+// also when the transaction basket is open. A batch is signed with the
+// key of its first command, so a blind review should not share a batch
+// with the commands of its author.
+func SendCommandOutsideBasket(c *command.Command, outputter cli.Outputter) error {
+	return sendAndWait([]*command.Command{c}, outputter)
+}
+
+func sendAndWait(commands []*command.Command, outputter cli.Outputter) error {
+	batchId, err := blockchain.SendCommands(commands, outputter)
+	if err != nil {
+		return err
+	}
+	timeoutSeconds := atomic.LoadInt32(&waitForBatchSeconds)
+	if timeoutSeconds == 0 {
+		return nil
+	}
+	status, err := blockchain.WaitForBatch(batchId, time.Duration(timeoutSeconds)*time.Second)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not get batch status: %s\n", err.Error()))
+		return nil
+	}
+	outputter(formatBatchStatus(status))
+	saveBatchStatus(status, outputter)
+	return nil
+}
+
+// batches shows the batches sent by the logged-in identity.
+func batches(outputter cli.Outputter) {
+	if !IsLoggedIn() {
+		outputter("You should login to see the batches you sent\n")
+		return
+	}
+	daoBatches, err := dao.GetBatchesOfKey(LoggedIn().PublicKeyStr)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not read batch history: %s\n", err.Error()))
+		return
+	}
+	table := cli.NewTable(len(daoBatches), 4)
+	for i, b := range daoBatches {
+		refreshBatch(b, outputter)
+		table.Set(i, 0, fmt.Sprintf("%d", b.Seq))
+		table.Set(i, 1, formatTime(b.SubmitTime))
+		table.Set(i, 2, b.Status)
+		table.Set(i, 3, b.CommandType)
+	}
+	outputter(table.String())
+}
+
+func batch(outputter cli.Outputter, batchSeq int32) {
+	daoBatch, err := dao.GetBatch(batchSeq)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not read batch %d: %s\n", batchSeq, err.Error()))
+		return
+	}
+	if daoBatch == nil || !IsLoggedIn() || daoBatch.SignerKey != LoggedIn().PublicKeyStr {
+		outputter(fmt.Sprintf("No batch known for batchSeq %d\n", batchSeq))
+		return
+	}
+	refreshBatch(daoBatch, outputter)
+	outputter(cli.StructToTable(daoBatchToBatchView(daoBatch)).String())
+}
+
+// Only batches that are not yet committed or invalid
+// can change status.
+func refreshBatch(daoBatch *dao.Batch, outputter cli.Outputter) {
+	if daoBatch.Status == blockchain.BATCH_STATUS_COMMITTED.String() ||
+		daoBatch.Status == blockchain.BATCH_STATUS_INVALID.String() {
+		return
+	}
+	status, err := blockchain.GetBatchStatus(daoBatch.BatchId)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not refresh status of batch %d: %s\n", daoBatch.Seq, err.Error()))
+		return
+	}
+	daoBatch.Status = status.StatusCode.String()
+	daoBatch.StatusMessage = getStatusMessage(status)
+	saveBatchStatus(status, outputter)
+}
+
+func saveBatchStatus(status *blockchain.BatchStatus, outputter cli.Outputter) {
+	err := dao.UpdateBatchStatus(status.BatchId, status.StatusCode.String(), getStatusMessage(status))
+	if err != nil {
+		outputter(fmt.Sprintf("Could not save status of batch %s: %s\n", status.BatchId, err.Error()))
+	}
+}
+
+func getStatusMessage(status *blockchain.BatchStatus) string {
+	messages := make([]string, len(status.InvalidTransactions))
+	for i, it := range status.InvalidTransactions {
+		messages[i] = it.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func daoBatchToBatchView(daoBatch *dao.Batch) *BatchView {
+	return &BatchView{
+		Seq:           daoBatch.Seq,
+		BatchId:       daoBatch.BatchId,
+		Signer:        daoBatch.Signer,
+		CommandType:   daoBatch.CommandType,
+		TargetIds:     daoBatch.TargetIds,
+		SubmitTime:    formatTime(daoBatch.SubmitTime),
+		Status:        daoBatch.Status,
+		StatusMessage: daoBatch.StatusMessage,
+	}
+}
+
+// This type represents a batch as it has to be shown to
+// end users. It is like dao.Batch but the submit time is
+// formatted as string.
+type BatchView struct {
+	Seq           int32
+	BatchId       string
+	Signer        string
+	CommandType   string
+	TargetIds     string
+	SubmitTime    string
+	Status        string
+	StatusMessage string
+}
+
+func formatBatchStatus(status *blockchain.BatchStatus) string {
+	result := strings.Builder{}
+	result.WriteString(fmt.Sprintf("Batch %s has status %s\n", status.BatchId, status.StatusCode.String()))
+	for _, it := range status.InvalidTransactions {
+		result.WriteString(fmt.Sprintf("  Transaction %s is invalid: %s\n", it.TransactionId, it.Message))
+	}
+	return result.String()
+}
@@ -23,6 +23,9 @@ func awaitEventStreamRunning(outputter cli.Outputter) {
 		case blockchain.EVENT_STREAM_STATUS_STOPPED:
 			outputter(formatEventStreamMessage("Could not initialize event stream from blockchain, stopping."))
 			return
+		case blockchain.EVENT_STREAM_STATUS_RECONNECTING:
+			outputter(formatEventStreamMessage("Could not connect to blockchain, retrying in the background."))
+			return
 		case blockchain.EVENT_STREAM_STATUS_RUNNING:
 			return
 		}
@@ -30,18 +33,20 @@ func awaitEventStreamRunning(outputter cli.Outputter) {
 }
 
 func eventStatus(outputter cli.Outputter) {
-	outputter(LastEventStatus.Get() + "\n")
+	outputter(fmt.Sprintf("%s, reconnects: %d\n", LastEventStatus.Get(), LastEventStatus.GetNumReconnects()))
 }
 
 type ThreadSafeLastEventStatus struct {
 	mux             sync.Mutex
 	lastEventStatus string
+	numReconnects   int32
 }
 
-func (es *ThreadSafeLastEventStatus) set(value string) {
+func (es *ThreadSafeLastEventStatus) set(value string, numReconnects int32) {
 	es.mux.Lock()
 	defer es.mux.Unlock()
 	es.lastEventStatus = value
+	es.numReconnects = numReconnects
 }
 
 func (es *ThreadSafeLastEventStatus) Get() string {
@@ -50,6 +55,12 @@ func (es *ThreadSafeLastEventStatus) Get() string {
 	return es.lastEventStatus
 }
 
+func (es *ThreadSafeLastEventStatus) GetNumReconnects() int32 {
+	es.mux.Lock()
+	defer es.mux.Unlock()
+	return es.numReconnects
+}
+
 var LastEventStatus = &ThreadSafeLastEventStatus{
 	mux: sync.Mutex{},
 }
@@ -63,11 +74,13 @@ func ReadEventStreamStatus() *blockchain.EventStreamStatus {
 func updateLastEventStatus(status *blockchain.EventStreamStatus) {
 	switch status.StatusCode {
 	case blockchain.EVENT_STREAM_STATUS_STOPPED:
-		LastEventStatus.set("STOPPED")
+		LastEventStatus.set("STOPPED", status.NumReconnects)
 	case blockchain.EVENT_STREAM_STATUS_RUNNING:
-		LastEventStatus.set("RUNNING")
+		LastEventStatus.set("RUNNING", status.NumReconnects)
 	case blockchain.EVENT_STREAM_STATUS_INITIALIZING:
-		LastEventStatus.set("INITIALIZING")
+		LastEventStatus.set("INITIALIZING", status.NumReconnects)
+	case blockchain.EVENT_STREAM_STATUS_RECONNECTING:
+		LastEventStatus.set("RECONNECTING", status.NumReconnects)
 	}
 }
 
@@ -106,19 +119,19 @@ var CommonDiagnosticsGroup = &cli.Cli{
 			ArgNames: []string{},
 		},
 		&cli.SingleLineHandler{
-			Name:     "batchStatus",
-			Handler:  batchStatus,
+			Name:     "batches",
+			Handler:  batches,
+			ArgNames: []string{},
+		},
+		&cli.SingleLineHandler{
+			Name:     "batch",
+			Handler:  batch,
 			ArgNames: []string{"batch seq"},
 		},
+		&cli.SingleLineHandler{
+			Name:     "waitForBatches",
+			Handler:  setWaitForBatches,
+			ArgNames: []string{"timeout seconds, 0 for no waiting"},
+		},
 	},
 }
-
-func batchStatus(outputter cli.Outputter, batchSeq int32) {
-	batchId, err := blockchain.TheBatchSequenceNumbers.Get(batchSeq)
-	if err != nil {
-		outputter(fmt.Sprintf("No batch id known for batchSeq %d\n", batchSeq))
-	}
-	outputter(fmt.Sprintf("batchSeq %d corresponds to batch id %s\n", batchSeq, batchId))
-	result := blockchain.GetBatchStatus(batchId)
-	outputter(result)
-}
@@ -58,20 +58,24 @@ func getEditorsWithState(
 
 func JournalToJournalWithoutEditorsView(journal *dao.JournalIncludingProposedEditors) *JournalWithoutEditorsView {
 	return &JournalWithoutEditorsView{
-		JournalId:       journal.JournalId,
-		CreatedOn:       formatTime(journal.CreatedOn),
-		ModifiedOn:      formatTime(journal.ModifiedOn),
-		Title:           journal.Title,
-		IsSigned:        journal.IsSigned,
-		Descriptionhash: journal.Descriptionhash,
+		JournalId:              journal.JournalId,
+		CreatedOn:              formatTime(journal.CreatedOn),
+		ModifiedOn:             formatTime(journal.ModifiedOn),
+		Title:                  journal.Title,
+		IsSigned:               journal.IsSigned,
+		Descriptionhash:        journal.Descriptionhash,
+		ReviewByInvitationOnly: journal.ReviewByInvitationOnly,
+		JournalState:           journal.JournalState,
 	}
 }
 
 type JournalWithoutEditorsView struct {
-	JournalId       string
-	CreatedOn       string
-	ModifiedOn      string
-	Title           string
-	IsSigned        bool
-	Descriptionhash string
+	JournalId              string
+	CreatedOn              string
+	ModifiedOn             string
+	Title                  string
+	IsSigned               bool
+	Descriptionhash        string
+	ReviewByInvitationOnly bool
+	JournalState           string
 }
@@ -45,22 +45,33 @@ func IsLoggedIn() bool {
 }
 
 func Login(publicKeyFile, privateKeyFile string) error {
-	publicKey, publicKeyAsString, err := ReadPublicKeyFile(publicKeyFile)
+	cryptoIdentity, err := ReadCryptoIdentity(publicKeyFile, privateKeyFile)
 	if err != nil {
 		return err
 	}
+	loggedIn = *cryptoIdentity
+	return nil
+}
+
+// ReadCryptoIdentity reads a key pair and checks that the keys match.
+func ReadCryptoIdentity(publicKeyFile, privateKeyFile string) (*command.CryptoIdentity, error) {
+	publicKey, publicKeyAsString, err := ReadPublicKeyFile(publicKeyFile)
+	if err != nil {
+		return nil, err
+	}
 	privateKey, err := readPrivateKeyFile(privateKeyFile)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	err = signAndVerifyChallengeString(publicKey, privateKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	loggedIn.PublicKeyStr = publicKeyAsString
-	loggedIn.PublicKey = publicKey
-	loggedIn.PrivateKey = privateKey
-	return nil
+	return &command.CryptoIdentity{
+		PublicKeyStr: publicKeyAsString,
+		PublicKey:    publicKey,
+		PrivateKey:   privateKey,
+	}, nil
 }
 
 func ReadPublicKeyFile(publicKeyFile string) (signing.PublicKey, string, error) {
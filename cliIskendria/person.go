@@ -3,7 +3,6 @@ package cliIskendria
 import (
 	"errors"
 	"fmt"
-	"github.com/iskendria-pub/iskendria/blockchain"
 	"github.com/iskendria-pub/iskendria/cli"
 	"github.com/iskendria-pub/iskendria/command"
 	"github.com/iskendria-pub/iskendria/dao"
@@ -135,7 +134,7 @@ func SendCommandAsPerson(outputter cli.Outputter, commandFactory func() *command
 	if !CheckBootstrappedAndKnownPerson(outputter) {
 		return
 	}
-	if err := blockchain.SendCommand(commandFactory(), outputter); err != nil {
+	if err := SendCommand(commandFactory(), outputter); err != nil {
 		outputter(ToIoError(err))
 	}
 }
@@ -151,7 +150,34 @@ func PersonUpdate(outputter cli.Outputter, newPerson *dao.PersonUpdate) {
 		LoggedInPerson.Id,
 		LoggedIn(),
 		Settings.PricePersonEdit)
-	if err := blockchain.SendCommand(theCommand, outputter); err != nil {
+	if err := SendCommand(theCommand, outputter); err != nil {
 		outputter(ToIoError(err))
 	}
 }
+
+// ShowBalanceHistory shows the balance changes of a person, oldest
+// first. The columns are the time, the amount, the resulting balance,
+// the type of the command that caused the change and the transaction.
+func ShowBalanceHistory(outputter cli.Outputter, personId string) {
+	changes, err := dao.GetBalanceHistory(personId)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not read balance history of person %s: %s\n", personId, err.Error()))
+		return
+	}
+	table := cli.NewTable(len(changes), 5)
+	for i, c := range changes {
+		table.Set(i, 0, formatTime(c.Timestamp))
+		table.Set(i, 1, fmt.Sprintf("%+d", c.Amount))
+		table.Set(i, 2, fmt.Sprintf("%d", c.Balance))
+		table.Set(i, 3, formatBalanceCommandType(c.CommandType))
+		table.Set(i, 4, c.TransactionId)
+	}
+	outputter(table.String())
+}
+
+func formatBalanceCommandType(commandType string) string {
+	if commandType == "" {
+		return "unknown"
+	}
+	return commandType
+}
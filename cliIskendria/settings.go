@@ -87,6 +87,8 @@ func daoSettingsToSettingsView(settings *dao.Settings) *SettingsView {
 	result.PriceEditorEditJournal = settings.PriceEditorEditJournal
 	result.PriceEditorAddColleague = settings.PriceEditorAddColleague
 	result.PriceEditorAcceptDuty = settings.PriceEditorAcceptDuty
+	result.PriceAuthorWithdrawManuscript = settings.PriceAuthorWithdrawManuscript
+	result.PriceEditorInviteReviewer = settings.PriceEditorInviteReviewer
 	return result
 }
 
@@ -111,4 +113,6 @@ type SettingsView struct {
 	PriceEditorEditJournal               int32
 	PriceEditorAddColleague              int32
 	PriceEditorAcceptDuty                int32
+	PriceAuthorWithdrawManuscript        int32
+	PriceEditorInviteReviewer            int32
 }
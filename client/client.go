@@ -1,12 +1,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"github.com/iskendria-pub/iskendria/blockchain"
 	"github.com/iskendria-pub/iskendria/cli"
 	"github.com/iskendria-pub/iskendria/cliIskendria"
 	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/config"
 	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/model"
 	"io/ioutil"
 	"log"
 	"os"
@@ -29,6 +31,7 @@ func main() {
 		EventPager:         cliIskendria.PageEventStreamMessages,
 		Handlers: append(cliIskendria.CommonRootHandlers,
 			cliIskendria.CommonDiagnosticsGroup,
+			cliIskendria.BasketGroup,
 			&cli.Cli{
 				FullDescription:    "Welcome to the settings commands",
 				OneLineDescription: "Settings",
@@ -48,6 +51,16 @@ func main() {
 						ReferenceValueGetterArgNames: []string{},
 						Action:                       cliIskendria.PersonUpdate,
 					},
+					&cli.SingleLineHandler{
+						Name:     "transfer",
+						Handler:  personTransfer,
+						ArgNames: []string{"person id", "amount"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "balanceHistory",
+						Handler:  balanceHistory,
+						ArgNames: []string{},
+					},
 					&cli.Cli{
 						FullDescription:    "Welcome to the person biography commands",
 						OneLineDescription: "Update/Verify/Remove biography",
@@ -138,12 +151,50 @@ func main() {
 						Handler:  resignAsEditor,
 						ArgNames: []string{"journal id"},
 					},
+					&cli.SingleLineHandler{
+						Name:     "reviewByInvitationOnly",
+						Handler:  journalReviewByInvitationOnly,
+						ArgNames: []string{"journal id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "reviewByAnyone",
+						Handler:  journalReviewByAnyone,
+						ArgNames: []string{"journal id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "openJournal",
+						Handler:  journalOpen,
+						ArgNames: []string{"journal id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "closeJournal",
+						Handler:  journalClose,
+						ArgNames: []string{"journal id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "archiveJournal",
+						Handler:  journalArchive,
+						ArgNames: []string{"journal id"},
+					},
 					&cli.StructRunnerHandler{
 						FullDescription:    "Welcome to the volume create dialog",
 						OneLineDescription: "Create volume",
 						Name:               "createVolume",
 						Action:             volumeCreate,
 					},
+					&cli.StructRunnerHandler{
+						FullDescription:              "Welcome to the volume update dialog",
+						OneLineDescription:           "Update volume",
+						Name:                         "updateVolume",
+						ReferenceValueGetter:         volumeUpdateReference,
+						ReferenceValueGetterArgNames: []string{"volume id"},
+						Action:                       volumeUpdate,
+					},
+					&cli.SingleLineHandler{
+						Name:     "sealVolume",
+						Handler:  volumeSeal,
+						ArgNames: []string{"volume id"},
+					},
 				),
 			},
 			&cli.Cli{
@@ -173,6 +224,26 @@ func main() {
 						Handler:  manuscriptAllowReview,
 						ArgNames: []string{"manuscript id"},
 					},
+					&cli.SingleLineHandler{
+						Name:     "inviteReviewer",
+						Handler:  inviteReviewer,
+						ArgNames: []string{"manuscript id", "reviewer person id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "acceptReviewInvitation",
+						Handler:  acceptReviewInvitation,
+						ArgNames: []string{"invitation id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "declineReviewInvitation",
+						Handler:  declineReviewInvitation,
+						ArgNames: []string{"invitation id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "listReviewInvitations",
+						Handler:  listReviewInvitations,
+						ArgNames: []string{},
+					},
 					&cli.StructRunnerHandler{
 						FullDescription:    "Add positive review about manuscript",
 						OneLineDescription: "Add positive review about manuscript",
@@ -185,6 +256,44 @@ func main() {
 						Name:               "addReviewNegative",
 						Action:             addNegativeReview,
 					},
+					&cli.StructRunnerHandler{
+						FullDescription: "Replace your review by a positive revision. The revision can be about " +
+							"a later version of the manuscript. This is possible until the editor used the review.",
+						OneLineDescription: "Revise review, new judgement positive",
+						Name:               "reviseReviewPositive",
+						Action:             revisePositiveReview,
+					},
+					&cli.StructRunnerHandler{
+						FullDescription: "Replace your review by a negative revision. The revision can be about " +
+							"a later version of the manuscript. This is possible until the editor used the review.",
+						OneLineDescription: "Revise review, new judgement negative",
+						Name:               "reviseReviewNegative",
+						Action:             reviseNegativeReview,
+					},
+					&cli.StructRunnerHandler{
+						FullDescription: "Add positive review about manuscript without showing that you wrote it. " +
+							"You can reveal that you wrote the review after the manuscript is judged.",
+						OneLineDescription: "Add blind positive review about manuscript",
+						Name:               "addBlindReviewPositive",
+						Action:             addBlindPositiveReview,
+					},
+					&cli.StructRunnerHandler{
+						FullDescription: "Add negative review about manuscript without showing that you wrote it. " +
+							"You can reveal that you wrote the review after the manuscript is judged.",
+						OneLineDescription: "Add blind negative review about manuscript",
+						Name:               "addBlindReviewNegative",
+						Action:             addBlindNegativeReview,
+					},
+					&cli.SingleLineHandler{
+						Name:     "revealReview",
+						Handler:  revealReview,
+						ArgNames: []string{"review id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "listBlindReviews",
+						Handler:  listBlindReviews,
+						ArgNames: []string{},
+					},
 					&cli.StructRunnerHandler{
 						FullDescription:    "Publish manuscript",
 						OneLineDescription: "Publish manuscript",
@@ -203,15 +312,27 @@ func main() {
 						Name:               "assign",
 						Action:             manuscriptAssign,
 					},
+					&cli.StructRunnerHandler{
+						FullDescription:    "Withdraw manuscript that is not yet published",
+						OneLineDescription: "Withdraw manuscript",
+						Name:               "withdraw",
+						Action:             manuscriptWithdraw,
+					},
 				},
 			},
 		),
 	}
+	defaults := config.GetDefaults()
+	defaults.DatabaseFile = "client.db"
+	defaults.EventLogFile = "./client-events.log"
+	if _, err := config.Load(defaults); err != nil {
+		log.Fatal(err)
+	}
 	fmt.Print(makeGreen)
 	dbLogger := log.New(os.Stdout, "db", log.Flags())
-	dao.Init("client.db", dbLogger)
+	dao.Init(config.Current.DatabaseFile, dbLogger)
 	defer dao.Shutdown(dbLogger)
-	cliIskendria.InitEventStream("./client-events.log", "client")
+	cliIskendria.InitEventStream(config.Current.EventLogFile, "client")
 	context.Run()
 }
 
@@ -240,7 +361,7 @@ func personUpdateBiography(outputter cli.Outputter, biographyFileName string) {
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PricePersonEdit)
-	err = blockchain.SendCommand(theCommand, outputter)
+	err = cliIskendria.SendCommand(theCommand, outputter)
 	if err != nil {
 		outputter("Error sending command to blockchain: " + err.Error() + "\n")
 	}
@@ -256,7 +377,7 @@ func personRemoveBiography(outputter cli.Outputter) {
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PricePersonEdit)
-	err := blockchain.SendCommand(theCommand, outputter)
+	err := cliIskendria.SendCommand(theCommand, outputter)
 	if err != nil {
 		outputter("Error sending command to blockchain: " + err.Error() + "\n")
 	}
@@ -300,7 +421,7 @@ func journalCreate(outputter cli.Outputter, journal *command.Journal) {
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceEditorCreateJournal)
-	if err := blockchain.SendCommand(cmd, outputter); err != nil {
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err) + "\n")
 		return
 	}
@@ -335,7 +456,7 @@ func journalUpdateProperties(outputter cli.Outputter, journal *command.Journal)
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceEditorEditJournal)
-	if err := blockchain.SendCommand(theCommand, outputter); err != nil {
+	if err := cliIskendria.SendCommand(theCommand, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err))
 	}
 }
@@ -362,7 +483,7 @@ func journalUpdateDescription(outputter cli.Outputter, journalId, descriptionFil
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceEditorEditJournal)
-	err = blockchain.SendCommand(theCommand, outputter)
+	err = cliIskendria.SendCommand(theCommand, outputter)
 	if err != nil {
 		outputter("Error sending command to blockchain: " + err.Error() + "\n")
 	}
@@ -384,7 +505,7 @@ func journalRemoveDescription(outputter cli.Outputter, journalId string) {
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceEditorEditJournal)
-	err = blockchain.SendCommand(theCommand, outputter)
+	err = cliIskendria.SendCommand(theCommand, outputter)
 	if err != nil {
 		outputter("Error sending command to blockchain: " + err.Error() + "\n")
 	}
@@ -443,6 +564,80 @@ func resignAsEditor(outputter cli.Outputter, journalId string) {
 	})
 }
 
+func journalReviewByInvitationOnly(outputter cli.Outputter, journalId string) {
+	journalUpdateReviewPolicy(outputter, journalId, true)
+}
+
+func journalReviewByAnyone(outputter cli.Outputter, journalId string) {
+	journalUpdateReviewPolicy(outputter, journalId, false)
+}
+
+func journalUpdateReviewPolicy(outputter cli.Outputter, journalId string, reviewByInvitationOnly bool) {
+	cliIskendria.SendCommandAsPerson(outputter, func() *command.Command {
+		return command.GetCommandJournalUpdateReviewPolicy(
+			journalId,
+			reviewByInvitationOnly,
+			cliIskendria.LoggedInPerson.Id,
+			cliIskendria.LoggedIn(),
+			cliIskendria.Settings.PriceEditorEditJournal)
+	})
+}
+
+func journalOpen(outputter cli.Outputter, journalId string) {
+	journalUpdateState(outputter, journalId, model.JournalState_journalOpen)
+}
+
+func journalClose(outputter cli.Outputter, journalId string) {
+	journalUpdateState(outputter, journalId, model.JournalState_journalClosed)
+}
+
+func journalArchive(outputter cli.Outputter, journalId string) {
+	journalUpdateState(outputter, journalId, model.JournalState_journalArchived)
+}
+
+func journalUpdateState(outputter cli.Outputter, journalId string, journalState model.JournalState) {
+	cliIskendria.SendCommandAsPerson(outputter, func() *command.Command {
+		return command.GetCommandJournalUpdateState(
+			journalId,
+			journalState,
+			cliIskendria.LoggedInPerson.Id,
+			cliIskendria.LoggedIn(),
+			cliIskendria.Settings.PriceEditorEditJournal)
+	})
+}
+
+func personTransfer(outputter cli.Outputter, personId string, amount int32) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	receiver, err := dao.GetPersonById(personId)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not find person %s, error: %s\n", personId, err.Error()))
+		return
+	}
+	question := fmt.Sprintf("Transfer %d from your balance to %s (%s)?", amount, receiver.Name, personId)
+	if !cli.Confirm(outputter, question) {
+		outputter("Transfer cancelled\n")
+		return
+	}
+	cmd := command.GetCommandBalanceTransfer(
+		personId,
+		amount,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn(),
+		int32(0))
+	if err = cliIskendria.SendCommand(cmd, outputter); err != nil {
+		outputter(cliIskendria.ToIoError(err) + "\n")
+	}
+}
+
+func balanceHistory(outputter cli.Outputter) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	cliIskendria.ShowBalanceHistory(outputter, cliIskendria.LoggedInPerson.Id)
+}
+
 func volumeCreate(outputter cli.Outputter, volume *command.Volume) {
 	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
 		return
@@ -452,13 +647,76 @@ func volumeCreate(outputter cli.Outputter, volume *command.Volume) {
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceEditorCreateVolume)
-	if err := blockchain.SendCommand(cmd, outputter); err != nil {
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err) + "\n")
 		return
 	}
 	outputter("The volumeId of the created volume is: " + volumeId + "\n")
 }
 
+func volumeUpdateReference(outputter cli.Outputter, volumeId string) *command.VolumeProperties {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return nil
+	}
+	daoVolume, err := dao.GetVolume(volumeId)
+	if err != nil {
+		outputter(fmt.Sprintf("Volume does not exist: %s, detailed error message: %s\n",
+			volumeId, err.Error()))
+		return nil
+	}
+	originalVolume = daoVolume
+	return &command.VolumeProperties{
+		Issue:           daoVolume.Issue,
+		VolumeNumber:    daoVolume.VolumeNumber,
+		IssueNumber:     daoVolume.IssueNumber,
+		Year:            daoVolume.Year,
+		PublicationDate: daoVolume.PublicationDate,
+	}
+}
+
+var originalVolume *dao.Volume
+
+func volumeUpdate(outputter cli.Outputter, volume *command.VolumeProperties) {
+	theCommand := command.GetCommandVolumeUpdate(
+		originalVolume.VolumeId,
+		originalVolume.JournalId,
+		&command.VolumeProperties{
+			Issue:           originalVolume.Issue,
+			VolumeNumber:    originalVolume.VolumeNumber,
+			IssueNumber:     originalVolume.IssueNumber,
+			Year:            originalVolume.Year,
+			PublicationDate: originalVolume.PublicationDate,
+		},
+		volume,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn(),
+		cliIskendria.Settings.PriceEditorEditJournal)
+	if err := cliIskendria.SendCommand(theCommand, outputter); err != nil {
+		outputter(cliIskendria.ToIoError(err))
+	}
+}
+
+func volumeSeal(outputter cli.Outputter, volumeId string) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	volume, err := dao.GetVolume(volumeId)
+	if err != nil {
+		outputter(fmt.Sprintf("Volume does not exist: %s, detailed error message: %s\n",
+			volumeId, err.Error()))
+		return
+	}
+	cmd := command.GetCommandVolumeSeal(
+		volumeId,
+		volume.JournalId,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn(),
+		cliIskendria.Settings.PriceEditorEditJournal)
+	if err = cliIskendria.SendCommand(cmd, outputter); err != nil {
+		outputter(cliIskendria.ToIoError(err) + "\n")
+	}
+}
+
 func manuscriptCreate(outputter cli.Outputter, manuscriptCreate *ManuscriptCreate) {
 	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
 		return
@@ -478,7 +736,7 @@ func manuscriptCreate(outputter cli.Outputter, manuscriptCreate *ManuscriptCreat
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceAuthorSubmitNewManuscript)
-	if err := blockchain.SendCommand(cmd, outputter); err != nil {
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err))
 	}
 	outputter("The manuscriptId of the created manuscript is: " + manuscriptId + "\n")
@@ -534,7 +792,7 @@ func manuscriptCreateNewVersion(outputter cli.Outputter, manuscriptCreateNewVers
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceAuthorSubmitNewVersion)
-	if err := blockchain.SendCommand(cmd, outputter); err != nil {
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err))
 		return
 	}
@@ -564,7 +822,7 @@ func manuscriptAcceptAuthorship(outputter cli.Outputter, manuscriptId string) {
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceAuthorAcceptAuthorship)
-	if err := blockchain.SendCommand(cmd, outputter); err != nil {
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err))
 		return
 	}
@@ -592,7 +850,7 @@ func manuscriptAllowReview(outputter cli.Outputter, manuscriptId string) {
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceEditorAllowManuscriptReview)
-	if err := blockchain.SendCommand(cmd, outputter); err != nil {
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err))
 		return
 	}
@@ -613,9 +871,10 @@ func addReview(outputter cli.Outputter, r *ReviewCreation, commandCreator review
 	cr, err := getCommandReviewCreate(r)
 	if err != nil {
 		outputter(err.Error() + "\n")
+		return
 	}
 	cmd, reviewId := commandCreator(cr)
-	err = blockchain.SendCommand(cmd, outputter)
+	err = cliIskendria.SendCommand(cmd, outputter)
 	if err != nil {
 		outputter(cliIskendria.ToIoError(err))
 		return
@@ -623,9 +882,12 @@ func addReview(outputter cli.Outputter, r *ReviewCreation, commandCreator review
 	outputter(fmt.Sprintf("The id of the created review is: %s\n", reviewId))
 }
 
+// ReviewCreation is the input of a review. InvitationId is only
+// needed when the reviewer was invited.
 type ReviewCreation struct {
 	ManuscriptId string
 	FileName     string
+	InvitationId string
 }
 
 type reviewCreatorType func(*command.ReviewCreate) (*command.Command, string)
@@ -635,8 +897,15 @@ func getCommandReviewCreate(r *ReviewCreation) (*command.ReviewCreate, error) {
 	if err != nil {
 		return nil, err
 	}
+	manuscript, err := dao.GetManuscript(r.ManuscriptId)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Unknown manuscript id: %s, error message: %s",
+			r.ManuscriptId, err.Error()))
+	}
 	return &command.ReviewCreate{
 		ManuscriptId: r.ManuscriptId,
+		JournalId:    manuscript.JournalId,
+		InvitationId: r.InvitationId,
 		TheReview:    reviewData,
 	}, nil
 }
@@ -657,6 +926,172 @@ func getCommandReviewSubmitNegative(cr *command.ReviewCreate) (*command.Command,
 		cliIskendria.Settings.PriceReviewerSubmit)
 }
 
+func revisePositiveReview(outputter cli.Outputter, r *ReviewRevision) {
+	reviseReview(outputter, r, model.Judgement_POSITIVE)
+}
+
+func reviseNegativeReview(outputter cli.Outputter, r *ReviewRevision) {
+	reviseReview(outputter, r, model.Judgement_NEGATIVE)
+}
+
+// ReviewRevision is the input of a new revision of a review.
+// ManuscriptId is the manuscript version that the revision is about.
+type ReviewRevision struct {
+	PreviousReviewId string
+	ManuscriptId     string
+	FileName         string
+}
+
+func reviseReview(outputter cli.Outputter, r *ReviewRevision, judgement model.Judgement) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	reviewData, err := ioutil.ReadFile(r.FileName)
+	if err != nil {
+		outputter(err.Error() + "\n")
+		return
+	}
+	previousReview, err := dao.GetReview(r.PreviousReviewId)
+	if err != nil {
+		outputter(fmt.Sprintf("Unknown review id: %s, error message: %s\n",
+			r.PreviousReviewId, err.Error()))
+		return
+	}
+	manuscript, err := dao.GetManuscript(r.ManuscriptId)
+	if err != nil {
+		outputter(fmt.Sprintf("Unknown manuscript id: %s, error message: %s\n",
+			r.ManuscriptId, err.Error()))
+		return
+	}
+	cmd, reviewId := command.GetCommandReviewRevise(
+		&command.ReviewRevise{
+			PreviousReviewId:     r.PreviousReviewId,
+			PreviousManuscriptId: previousReview.ManuscriptId,
+			ManuscriptId:         r.ManuscriptId,
+			JournalId:            manuscript.JournalId,
+			TheReview:            reviewData,
+		},
+		judgement,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn(),
+		cliIskendria.Settings.PriceReviewerSubmit)
+	err = cliIskendria.SendCommand(cmd, outputter)
+	if err != nil {
+		outputter(cliIskendria.ToIoError(err))
+		return
+	}
+	outputter(fmt.Sprintf("The id of the new revision is: %s\n", reviewId))
+}
+
+func addBlindPositiveReview(outputter cli.Outputter, r *BlindReviewCreation) {
+	addBlindReview(outputter, r, model.Judgement_POSITIVE)
+}
+
+func addBlindNegativeReview(outputter cli.Outputter, r *BlindReviewCreation) {
+	addBlindReview(outputter, r, model.Judgement_NEGATIVE)
+}
+
+// BlindReviewCreation is the input of a blind review. Blind reviews
+// cannot be written on invitation, because the invitation shows who
+// the reviewer is.
+type BlindReviewCreation struct {
+	ManuscriptId string
+	FileName     string
+}
+
+// The secret of the review is saved before sending the review. Without
+// the secret, the review can never be revealed.
+func addBlindReview(outputter cli.Outputter, r *BlindReviewCreation, judgement model.Judgement) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	cr, err := getCommandReviewCreate(&ReviewCreation{
+		ManuscriptId: r.ManuscriptId,
+		FileName:     r.FileName,
+	})
+	if err != nil {
+		outputter(err.Error() + "\n")
+		return
+	}
+	cmd, secret := command.GetCommandWriteBlindReview(
+		cr,
+		judgement,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn())
+	if err = dao.AddBlindReviewSecret(secret); err != nil {
+		outputter(fmt.Sprintf("Could not save the secret of the review, review was not sent: %s\n", err.Error()))
+		return
+	}
+	if err = cliIskendria.SendCommandOutsideBasket(cmd, outputter); err != nil {
+		outputter(cliIskendria.ToIoError(err))
+		return
+	}
+	outputter(fmt.Sprintf("The id of the created review is: %s\n", secret.ReviewId))
+	outputter("You need the following to reveal the review. Keep a copy in case this computer is lost:\n")
+	outputter(fmt.Sprintf("    Salt: %s\n", secret.Salt))
+	outputter(fmt.Sprintf("    Authorization: %s\n", secret.Authorization))
+}
+
+func revealReview(outputter cli.Outputter, reviewId string) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	secret, err := dao.GetBlindReviewSecret(reviewId)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not read the secret of the review: %s\n", err.Error()))
+		return
+	}
+	if secret == nil || secret.ReviewerId != cliIskendria.LoggedInPerson.Id {
+		outputter(fmt.Sprintf("You did not write blind review %s on this computer\n", reviewId))
+		return
+	}
+	review, err := dao.GetReview(reviewId)
+	if err != nil {
+		outputter(fmt.Sprintf("Unknown review id: %s, error message: %s\n", reviewId, err.Error()))
+		return
+	}
+	cmd := command.GetCommandReviewReveal(
+		secret,
+		review.ManuscriptId,
+		cliIskendria.LoggedIn(),
+		cliIskendria.Settings.PriceReviewerSubmit)
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
+		outputter(cliIskendria.ToIoError(err))
+		return
+	}
+}
+
+// listBlindReviews lists the blind reviews that the logged-in person
+// wrote on this computer.
+func listBlindReviews(outputter cli.Outputter) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	secrets, err := dao.GetBlindReviewSecretsOfReviewer(cliIskendria.LoggedInPerson.Id)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not get blind reviews: %s\n", err.Error()))
+		return
+	}
+	if len(secrets) == 0 {
+		outputter("You did not write blind reviews on this computer\n")
+		return
+	}
+	table := cli.NewTable(len(secrets), 2)
+	for i, secret := range secrets {
+		table.Set(i, 0, secret.ReviewId)
+		review, err := dao.GetReview(secret.ReviewId)
+		switch {
+		case err != nil:
+			table.Set(i, 1, "not on the blockchain")
+		case review.ReviewAuthorId == "":
+			table.Set(i, 1, "blind")
+		default:
+			table.Set(i, 1, "revealed")
+		}
+	}
+	outputter("Your blind reviews (review id, status):\n\n" + table.String() + "\n")
+}
+
 func manuscriptPublish(outputter cli.Outputter, judge *command.ManuscriptJudge) {
 	manuscriptJudge(outputter, judge, getPositiveJudgeCommand)
 }
@@ -675,7 +1110,7 @@ func manuscriptJudge(outputter cli.Outputter, judge *command.ManuscriptJudge, co
 		return
 	}
 	cmd := commandGetter(judge, manuscript.JournalId)
-	err = blockchain.SendCommand(cmd, outputter)
+	err = cliIskendria.SendCommand(cmd, outputter)
 	if err != nil {
 		outputter(cliIskendria.ToIoError(err))
 		return
@@ -714,11 +1149,112 @@ func manuscriptAssign(outputter cli.Outputter, manuscriptAssign *command.Manuscr
 	cmd := command.GetCommandManuscriptAssign(
 		manuscriptAssign,
 		manuscript.JournalId,
+		manuscript.VolumeId,
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceEditorAssignManuscript)
-	err = blockchain.SendCommand(cmd, outputter)
+	err = cliIskendria.SendCommand(cmd, outputter)
+	if err != nil {
+		outputter(cliIskendria.ToIoError(err))
+	}
+}
+
+func manuscriptWithdraw(outputter cli.Outputter, manuscriptWithdraw *ManuscriptWithdraw) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	manuscript, err := dao.GetManuscript(manuscriptWithdraw.ManuscriptId)
+	if err != nil {
+		outputter(fmt.Sprintf("Unknown manuscript id: %s, error message: %s",
+			manuscriptWithdraw.ManuscriptId, err.Error()))
+		return
+	}
+	referenceThread, err := dao.GetReferenceThread(manuscript.ThreadId)
+	if err != nil {
+		outputter(fmt.Sprintf("Error getting version history of manuscript: %s", err.Error()))
+		return
+	}
+	cmd := command.GetCommandManuscriptWithdraw(
+		manuscript.Id,
+		manuscript.ThreadId,
+		referenceThread,
+		manuscriptWithdraw.CommitMsg,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn(),
+		cliIskendria.Settings.PriceAuthorWithdrawManuscript)
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
+		outputter(cliIskendria.ToIoError(err))
+		return
+	}
+}
+
+type ManuscriptWithdraw struct {
+	ManuscriptId string
+	CommitMsg    string
+}
+
+func inviteReviewer(outputter cli.Outputter, manuscriptId, reviewerId string) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	manuscript, err := dao.GetManuscript(manuscriptId)
 	if err != nil {
+		outputter(fmt.Sprintf("Unknown manuscript id: %s, error message: %s",
+			manuscriptId, err.Error()))
+		return
+	}
+	cmd, invitationId := command.GetCommandReviewerInvite(
+		manuscript.Id,
+		manuscript.JournalId,
+		reviewerId,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn(),
+		cliIskendria.Settings.PriceEditorInviteReviewer)
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err))
+		return
+	}
+	outputter(fmt.Sprintf("The id of the created invitation is: %s\n", invitationId))
+}
+
+func acceptReviewInvitation(outputter cli.Outputter, invitationId string) {
+	cliIskendria.SendCommandAsPerson(outputter, func() *command.Command {
+		return command.GetCommandReviewInvitationAccept(
+			invitationId,
+			cliIskendria.LoggedInPerson.Id,
+			cliIskendria.LoggedIn())
+	})
+}
+
+func declineReviewInvitation(outputter cli.Outputter, invitationId string) {
+	cliIskendria.SendCommandAsPerson(outputter, func() *command.Command {
+		return command.GetCommandReviewInvitationDecline(
+			invitationId,
+			cliIskendria.LoggedInPerson.Id,
+			cliIskendria.LoggedIn())
+	})
+}
+
+func listReviewInvitations(outputter cli.Outputter) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	invitations, err := dao.GetOpenReviewInvitations(cliIskendria.LoggedInPerson.Id)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not get review invitations: %s\n", err.Error()))
+		return
+	}
+	if len(invitations) == 0 {
+		outputter("You have no open review invitations\n")
+		return
+	}
+	table := cli.NewTable(len(invitations), 4)
+	for i, ri := range invitations {
+		table.Set(i, 0, ri.Id)
+		table.Set(i, 1, ri.ManuscriptTitle)
+		table.Set(i, 2, ri.JournalTitle)
+		table.Set(i, 3, ri.EditorName)
 	}
+	outputter("Open review invitations (invitation id, manuscript, journal, editor):\n\n" +
+		table.String() + "\n")
 }
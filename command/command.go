@@ -5,9 +5,10 @@ import (
 	"fmt"
 	"github.com/hyperledger/sawtooth-sdk-go/processor"
 	"github.com/hyperledger/sawtooth-sdk-go/signing"
+	"github.com/iskendria-pub/iskendria/logging"
 	"github.com/iskendria-pub/iskendria/model"
 	"github.com/iskendria-pub/iskendria/util"
-	"log"
+	"strings"
 )
 
 type Command struct {
@@ -23,25 +24,43 @@ type CryptoIdentity struct {
 	PrivateKey   signing.PrivateKey
 }
 
+// ApplyModelCommand applies the command with the rules of the family
+// version of its transaction.
 func ApplyModelCommand(
 	command *model.Command,
+	familyVersion string,
 	signerKey string,
 	transactionId string,
 	ba BlockchainAccess) error {
+	rules, err := getRuleSet(familyVersion)
+	if err != nil {
+		return toTypedError(err)
+	}
 	ce := &commandExecution{
 		command:          command,
+		rules:            rules,
 		signerKey:        signerKey,
 		transactionId:    transactionId,
 		blockchainAccess: ba,
+		logger: logging.Default().With(
+			"transactionId", transactionId, "signer", signerKey, "familyVersion", familyVersion),
 	}
-	return ce.run()
+	if ba != nil {
+		ce.blockchainAccess = &loggingDecorator{
+			delegate: &internalErrorDecorator{delegate: ba},
+			logger:   ce.logger,
+		}
+	}
+	return toTypedError(ce.run())
 }
 
 type commandExecution struct {
 	command          *model.Command
+	rules            *ruleSet
 	signerKey        string
 	transactionId    string
 	blockchainAccess BlockchainAccess
+	logger           *logging.Logger
 }
 
 func (ce *commandExecution) run() error {
@@ -60,26 +79,35 @@ func (ce *commandExecution) run() error {
 	if ce.blockchainAccess == nil {
 		return errors.New("Cannot run command with nil blockchainAccess")
 	}
-	if !model.IsPersonAddress(ce.command.Signer) {
+	if !isBlindReview(ce.command) && !model.IsPersonAddress(ce.command.Signer) {
 		return errors.New("Bootstrap: signer id is not a person address: " + ce.command.Signer)
 	}
 	return ce.doRun()
 }
 
 func (ce *commandExecution) doRun() error {
-	log.Println("Entering commandExecution.doRun...")
-	log.Printf("Transaction id is %s, signer = %s\n",
-		ce.transactionId, ce.signerKey)
-	defer log.Println("Left commandExecution.doRun")
-	log.Printf("Have model command: %s\n", ce.command.String())
+	ce.logger.Debug("Applying command", "signerId", ce.command.Signer, "command", ce.command.String())
 	u, err := ce.check()
 	if err != nil {
+		ce.logger.Debug("Command rejected", "error", err)
 		return err
 	}
+	if u == nil || len(u.updates) == 0 {
+		if ce.rules.rejectCommandWithoutUpdates {
+			err = errors.New("Command does not change anything")
+			ce.logger.Debug("Command rejected", "error", err)
+			return err
+		}
+	}
 	if u == nil {
 		return nil
 	}
-	return ce.runUpdater(u)
+	if err = ce.runUpdater(u); err != nil {
+		ce.logger.Debug("Could not apply command", "error", err)
+		return err
+	}
+	ce.logger.Debug("Applied command")
+	return nil
 }
 
 func (ce *commandExecution) check() (*updater, error) {
@@ -90,15 +118,90 @@ func (ce *commandExecution) check() (*updater, error) {
 		u, err = ce.checkBootstrap(ce.command.GetBootstrap())
 	case nil:
 		return nil, nil
+	case *model.Command_CommandManuscriptWithdraw:
+		if !ce.rules.allowManuscriptWithdraw {
+			return nil, errors.New("Withdrawing a manuscript is not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		u, err = ce.checkNonBootstrap()
+	case *model.Command_CommandJournalUpdateReviewPolicy,
+		*model.Command_CommandReviewerInvite,
+		*model.Command_CommandReviewInvitationAccept,
+		*model.Command_CommandReviewInvitationDecline:
+		if !ce.rules.allowReviewInvitations {
+			return nil, errors.New("Reviewer invitations are not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		u, err = ce.checkNonBootstrap()
+	case *model.Command_CommandWriteBlindReview:
+		if !ce.rules.allowBlindReviews {
+			return nil, errors.New("Blind reviews are not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		u, err = ce.checkBlindReview()
+	case *model.Command_CommandReviewReveal:
+		if !ce.rules.allowBlindReviews {
+			return nil, errors.New("Blind reviews are not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		u, err = ce.checkNonBootstrap()
+	case *model.Command_CommandJournalUpdateState:
+		if !ce.rules.allowJournalStates {
+			return nil, errors.New("Closing or archiving a journal is not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		u, err = ce.checkNonBootstrap()
+	case *model.Command_CommandVolumeUpdate,
+		*model.Command_CommandVolumeSeal:
+		if !ce.rules.allowVolumeUpdates {
+			return nil, errors.New("Updating or sealing a volume is not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		u, err = ce.checkNonBootstrap()
+	case *model.Command_CommandBalanceTransfer:
+		if !ce.rules.allowBalanceTransfers {
+			return nil, errors.New("Transferring balance is not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		u, err = ce.checkNonBootstrap()
+	case *model.Command_CommandProposalCreate,
+		*model.Command_CommandProposalApprove,
+		*model.Command_CommandProposalCancel:
+		if !ce.rules.allowProposals {
+			return nil, errors.New("Proposals are not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		u, err = ce.checkNonBootstrap()
+	case *model.Command_CommandSettingsUpdate:
+		if ce.command.GetCommandSettingsUpdate().ApprovalThresholdUpdate != nil && !ce.rules.allowProposals {
+			return nil, errors.New("Updating the approval threshold is not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		if ce.command.GetCommandSettingsUpdate().MinimumFamilyVersionUpdate != nil &&
+			!ce.rules.allowMinimumFamilyVersion {
+			return nil, errors.New("Updating the minimum family version is not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		u, err = ce.checkNonBootstrap()
+	case *model.Command_CommandReviewRevise:
+		if !ce.rules.allowReviewRevisions {
+			return nil, errors.New("Revising a review is not supported in family version " +
+				ce.rules.familyVersion)
+		}
+		u, err = ce.checkNonBootstrap()
 	default:
 		u, err = ce.checkNonBootstrap()
 	}
 	return u, err
 }
 
+func isBlindReview(c *model.Command) bool {
+	_, result := c.Body.(*model.Command_CommandWriteBlindReview)
+	return result
+}
+
 func (ce *commandExecution) checkBootstrap(bootstrap *model.CommandBootstrap) (*updater, error) {
-	log.Println("Entering commandExecution.checkBootstrap")
-	defer log.Println("Left commandExecution.checkBootstrap")
+	ce.logger.Debug("Checking bootstrap")
 	if bootstrap.PriceList == nil {
 		return nil, errors.New("Bootstrap: missing price list")
 	}
@@ -109,7 +212,8 @@ func (ce *commandExecution) checkBootstrap(bootstrap *model.CommandBootstrap) (*
 	requested := []string{model.GetSettingsAddress(), ce.command.Signer}
 	data, err := ce.blockchainAccess.GetState(requested)
 	if err != nil {
-		return nil, errors.New(fmt.Sprintf("Could not read settings address or signer address: %v", requested))
+		return nil, addErrorContext(
+			fmt.Sprintf("Could not read settings address or signer address: %v", requested), err)
 	}
 	err = us.add(data, requested)
 	if err != nil {
@@ -161,6 +265,9 @@ func (ce *commandExecution) checkNonBootstrap() (*updater, error) {
 	if u.getAddressState(model.GetSettingsAddress()) != ADDRESS_FILLED {
 		return nil, errors.New("Blockchain has not been bootstrapped")
 	}
+	if err := ce.checkMinimumFamilyVersion(u.settings); err != nil {
+		return nil, err
+	}
 	if u.getAddressState(ce.command.Signer) != ADDRESS_FILLED {
 		return nil, errors.New("Signer does not exist: " + ce.command.Signer)
 	}
@@ -174,7 +281,9 @@ func (ce *commandExecution) checkNonBootstrap() (*updater, error) {
 			balance, ce.command.Price))
 	}
 	nbce := &nonBootstrapCommandExecution{
+		rules:             ce.rules,
 		verifiedSignerId:  ce.command.Signer,
+		commandType:       getCommandType(ce.command),
 		price:             ce.command.Price,
 		timestamp:         ce.command.Timestamp,
 		blockchainAccess:  ce.blockchainAccess,
@@ -183,9 +292,58 @@ func (ce *commandExecution) checkNonBootstrap() (*updater, error) {
 	return nbce.check(ce.command)
 }
 
+// checkBlindReview checks a command that is not signed by a person.
+// A blind review is signed with a key of its own, so there is no
+// signer to verify and no balance to charge.
+func (ce *commandExecution) checkBlindReview() (*updater, error) {
+	if ce.command.Signer != "" {
+		return nil, errors.New("A blind review should not have a signer, got " + ce.command.Signer)
+	}
+	if ce.command.Price != int32(0) {
+		return nil, errors.New("A blind review should have price zero")
+	}
+	if ce.command.GetCommandWriteBlindReview().ReviewKey != ce.signerKey {
+		return nil, errors.New("A blind review should be signed with its review key")
+	}
+	u := newUnmarshalledState()
+	addressesToRead := []string{model.GetSettingsAddress()}
+	addressData, err := ce.blockchainAccess.GetState(addressesToRead)
+	if err != nil {
+		return nil, err
+	}
+	err = u.add(addressData, addressesToRead)
+	if err != nil {
+		return nil, err
+	}
+	if u.getAddressState(model.GetSettingsAddress()) != ADDRESS_FILLED {
+		return nil, errors.New("Blockchain has not been bootstrapped")
+	}
+	if err := ce.checkMinimumFamilyVersion(u.settings); err != nil {
+		return nil, err
+	}
+	nbce := &nonBootstrapCommandExecution{
+		rules:             ce.rules,
+		commandType:       getCommandType(ce.command),
+		price:             int32(0),
+		timestamp:         ce.command.Timestamp,
+		blockchainAccess:  ce.blockchainAccess,
+		unmarshalledState: u,
+	}
+	return nbce.check(ce.command)
+}
+
+// checkMinimumFamilyVersion rejects transactions of a family version
+// that the majors no longer accept.
+func (ce *commandExecution) checkMinimumFamilyVersion(settings *model.StateSettings) error {
+	if model.IsFamilyVersionBelow(ce.rules.familyVersion, settings.MinimumFamilyVersion) {
+		return errors.New(fmt.Sprintf("Family version %s is below the minimum family version %s",
+			ce.rules.familyVersion, settings.MinimumFamilyVersion))
+	}
+	return nil
+}
+
 func (ce *commandExecution) runUpdater(u *updater) error {
-	log.Println("Entering commandExecution.runUpdater...")
-	defer log.Println("Left commandExecution.runUpdater")
+	ce.logger.Debug("Updating state", "numUpdates", len(u.updates))
 	if err := ce.updateState(u); err != nil {
 		return err
 	}
@@ -209,8 +367,10 @@ func (ce *commandExecution) updateState(u *updater) error {
 		return err
 	}
 	if len(writtenAddresses) < len(addressesToWrite) {
-		return errors.New(fmt.Sprintf("Could not write all addresses to be written. Tried: %v, succeeded: %v",
-			addressesToWrite, writtenAddresses))
+		return &InternalError{
+			Msg: fmt.Sprintf("Could not write all addresses to be written. Tried: %v, succeeded: %v",
+				addressesToWrite, writtenAddresses),
+		}
 	}
 	return nil
 }
@@ -230,7 +390,6 @@ func (ce *commandExecution) writeTransactionControlEvent(numNonControlEvents int
 	numEventsString := fmt.Sprintf("%d", numNonControlEvents+1)
 	timestampString := fmt.Sprintf("%d", ce.command.Timestamp)
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_TRANSACTION_CONTROL
-	log.Println("Sending event of type: " + eventType)
 	err := ce.blockchainAccess.AddEvent(
 		eventType,
 		[]processor.Attribute{
@@ -256,7 +415,9 @@ func (ce *commandExecution) writeTransactionControlEvent(numNonControlEvents int
 }
 
 type nonBootstrapCommandExecution struct {
+	rules             *ruleSet
 	verifiedSignerId  string
+	commandType       string
 	price             int32
 	timestamp         int64
 	blockchainAccess  BlockchainAccess
@@ -287,6 +448,8 @@ func (nbce *nonBootstrapCommandExecution) checkSpecific(c *model.Command) (*upda
 		return nbce.checkJournalEditorInvite(c.GetCommandJournalEditorInvite())
 	case *model.Command_CommandJournalEditorAcceptDuty:
 		return nbce.checkJournalEditorAcceptDuty(c.GetCommandJournalEditorAcceptDuty())
+	case *model.Command_CommandJournalUpdateReviewPolicy:
+		return nbce.checkJournalUpdateReviewPolicy(c.GetCommandJournalUpdateReviewPolicy())
 	case *model.Command_CommandVolumeCreate:
 		return nbce.checkVolumeCreate(c.GetCommandVolumeCreate())
 	case *model.Command_PersonCreate:
@@ -297,6 +460,8 @@ func (nbce *nonBootstrapCommandExecution) checkSpecific(c *model.Command) (*upda
 		return nbce.checkPersonUpdateAuthorization(c.GetCommandUpdateAuthorization())
 	case *model.Command_CommandPersonUpdateBalanceIncrement:
 		return nbce.checkPersonUpdateIncBalance(c.GetCommandPersonUpdateBalanceIncrement())
+	case *model.Command_CommandBalanceTransfer:
+		return nbce.checkBalanceTransfer(c.GetCommandBalanceTransfer())
 	case *model.Command_CommandManuscriptCreate:
 		return nbce.checkManuscriptCreate(c.GetCommandManuscriptCreate())
 	case *model.Command_CommandManuscriptCreateNewVersion:
@@ -311,19 +476,55 @@ func (nbce *nonBootstrapCommandExecution) checkSpecific(c *model.Command) (*upda
 		return nbce.checkManuscriptJudge(c.GetCommandManuscriptJudge())
 	case *model.Command_CommandManuscriptAssign:
 		return nbce.checkManuscriptAssign(c.GetCommandManuscriptAssign())
+	case *model.Command_CommandManuscriptWithdraw:
+		return nbce.checkManuscriptWithdraw(c.GetCommandManuscriptWithdraw())
+	case *model.Command_CommandReviewerInvite:
+		return nbce.checkReviewerInvite(c.GetCommandReviewerInvite())
+	case *model.Command_CommandReviewInvitationAccept:
+		return nbce.checkReviewInvitationAnswer(
+			c.GetCommandReviewInvitationAccept().InvitationId, model.ReviewInvitationState_invitationAccepted)
+	case *model.Command_CommandReviewInvitationDecline:
+		return nbce.checkReviewInvitationAnswer(
+			c.GetCommandReviewInvitationDecline().InvitationId, model.ReviewInvitationState_invitationDeclined)
+	case *model.Command_CommandWriteBlindReview:
+		return nbce.checkWriteBlindReview(c.GetCommandWriteBlindReview())
+	case *model.Command_CommandReviewReveal:
+		return nbce.checkReviewReveal(c.GetCommandReviewReveal())
+	case *model.Command_CommandReviewRevise:
+		return nbce.checkReviewRevise(c.GetCommandReviewRevise())
+	case *model.Command_CommandJournalUpdateState:
+		return nbce.checkJournalUpdateState(c.GetCommandJournalUpdateState())
+	case *model.Command_CommandVolumeUpdate:
+		return nbce.checkVolumeUpdate(c.GetCommandVolumeUpdate())
+	case *model.Command_CommandVolumeSeal:
+		return nbce.checkVolumeSeal(c.GetCommandVolumeSeal())
+	case *model.Command_CommandProposalCreate:
+		return nbce.checkProposalCreate(c.GetCommandProposalCreate())
+	case *model.Command_CommandProposalApprove:
+		return nbce.checkProposalApprove(c.GetCommandProposalApprove())
+	case *model.Command_CommandProposalCancel:
+		return nbce.checkProposalCancel(c.GetCommandProposalCancel())
 	default:
 		return nil, errors.New("Non-bootstrap command type not supported")
 	}
 }
 
+// getCommandType gives the name of the body type, like CommandManuscriptCreate.
+// It is stored with every balance change.
+func getCommandType(c *model.Command) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", c.Body), "*model.Command_")
+}
+
 func (nbce *nonBootstrapCommandExecution) addBalanceDeduct(u *updater) {
 	if len(u.updates) == 0 || nbce.price == int32(0) {
 		return
 	}
 	var deductUpdate singleUpdate = &singleUpdatePersonIncBalance{
-		personId:   nbce.verifiedSignerId,
-		newBalance: nbce.unmarshalledState.persons[nbce.verifiedSignerId].Balance - nbce.price,
-		timestamp:  nbce.timestamp,
+		personId:    nbce.verifiedSignerId,
+		newBalance:  nbce.unmarshalledState.persons[nbce.verifiedSignerId].Balance - nbce.price,
+		amount:      -nbce.price,
+		commandType: nbce.commandType,
+		timestamp:   nbce.timestamp,
 	}
 	// This one may be duplicate, but them both will be identical because
 	// the timestamp comes from the client.
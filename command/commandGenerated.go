@@ -193,6 +193,36 @@ func createModelCommandSettingsUpdate(orig, updated *dao.Settings) *model.Comman
 		result.PriceEditorAcceptDutyUpdate = theUpdate
 	}
 
+	if updated.PriceAuthorWithdrawManuscript != orig.PriceAuthorWithdrawManuscript {
+		oldValue := orig.PriceAuthorWithdrawManuscript
+		newValue := updated.PriceAuthorWithdrawManuscript
+		theUpdate := &model.IntUpdate{
+			OldValue: oldValue,
+			NewValue: newValue,
+		}
+		result.PriceAuthorWithdrawManuscriptUpdate = theUpdate
+	}
+
+	if updated.PriceEditorInviteReviewer != orig.PriceEditorInviteReviewer {
+		oldValue := orig.PriceEditorInviteReviewer
+		newValue := updated.PriceEditorInviteReviewer
+		theUpdate := &model.IntUpdate{
+			OldValue: oldValue,
+			NewValue: newValue,
+		}
+		result.PriceEditorInviteReviewerUpdate = theUpdate
+	}
+
+	if updated.ApprovalThreshold != orig.ApprovalThreshold {
+		oldValue := orig.ApprovalThreshold
+		newValue := updated.ApprovalThreshold
+		theUpdate := &model.IntUpdate{
+			OldValue: oldValue,
+			NewValue: newValue,
+		}
+		result.ApprovalThresholdUpdate = theUpdate
+	}
+
 	return result
 }
 
@@ -289,6 +319,16 @@ func checkModelCommandSettingsUpdate(
 			c.PriceEditorAcceptDutyUpdate.OldValue, oldSettings.PriceList.PriceEditorAcceptDuty))
 	}
 
+	if c.PriceAuthorWithdrawManuscriptUpdate != nil && c.PriceAuthorWithdrawManuscriptUpdate.OldValue != oldSettings.PriceList.PriceAuthorWithdrawManuscript {
+		return errors.New(fmt.Sprintf("PriceAuthorWithdrawManuscript mismatch. Expected %d, got %d",
+			c.PriceAuthorWithdrawManuscriptUpdate.OldValue, oldSettings.PriceList.PriceAuthorWithdrawManuscript))
+	}
+
+	if c.PriceEditorInviteReviewerUpdate != nil && c.PriceEditorInviteReviewerUpdate.OldValue != oldSettings.PriceList.PriceEditorInviteReviewer {
+		return errors.New(fmt.Sprintf("PriceEditorInviteReviewer mismatch. Expected %d, got %d",
+			c.PriceEditorInviteReviewerUpdate.OldValue, oldSettings.PriceList.PriceEditorInviteReviewer))
+	}
+
 	return nil
 }
 
@@ -476,6 +516,26 @@ func createSingleUpdatesSettingsUpdate(
 		result = append(result, toAppend)
 	}
 
+	if c.PriceAuthorWithdrawManuscriptUpdate != nil {
+		var toAppend singleUpdate = &singleUpdateSettingsUpdate{
+			newValue:   c.PriceAuthorWithdrawManuscriptUpdate.NewValue,
+			stateField: &oldSettings.PriceList.PriceAuthorWithdrawManuscript,
+			eventKey:   model.EV_KEY_PRICE_AUTHOR_WITHDRAW_MANUSCRIPT,
+			timestamp:  timestamp,
+		}
+		result = append(result, toAppend)
+	}
+
+	if c.PriceEditorInviteReviewerUpdate != nil {
+		var toAppend singleUpdate = &singleUpdateSettingsUpdate{
+			newValue:   c.PriceEditorInviteReviewerUpdate.NewValue,
+			stateField: &oldSettings.PriceList.PriceEditorInviteReviewer,
+			eventKey:   model.EV_KEY_PRICE_EDITOR_INVITE_REVIEWER,
+			timestamp:  timestamp,
+		}
+		result = append(result, toAppend)
+	}
+
 	return result
 }
 
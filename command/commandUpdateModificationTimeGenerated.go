@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"github.com/hyperledger/sawtooth-sdk-go/processor"
 	"github.com/iskendria-pub/iskendria/model"
-	"log"
 )
 
 func (nbce *nonBootstrapCommandExecution) addSingleUpdatePersonModificationTimeIfNeeded(
@@ -35,7 +34,6 @@ func (u *singleUpdatePersonModificationTime) updateState(state *unmarshalledStat
 
 func (u *singleUpdatePersonModificationTime) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_PERSON_MODIFICATION_TIME
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(eventType,
 		[]processor.Attribute{
 			{
@@ -83,7 +81,6 @@ func (u *singleUpdateJournalModificationTime) updateState(state *unmarshalledSta
 
 func (u *singleUpdateJournalModificationTime) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_JOURNAL_MODIFICATION_TIME
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(eventType,
 		[]processor.Attribute{
 			{
@@ -131,7 +128,100 @@ func (u *singleUpdateManuscriptModificationTime) updateState(state *unmarshalled
 
 func (u *singleUpdateManuscriptModificationTime) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_MANUSCRIPT_MODIFICATION_TIME
-	log.Println("Sending event of type: " + eventType)
+	return ba.AddEvent(eventType,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.id,
+			},
+		},
+		[]byte{})
+}
+
+func (nbce *nonBootstrapCommandExecution) addSingleUpdateReviewInvitationModificationTimeIfNeeded(
+	singleUpdates []singleUpdate, subjectId string) []singleUpdate {
+	if len(singleUpdates) >= 1 {
+		singleUpdates = append(singleUpdates, &singleUpdateReviewInvitationModificationTime{
+			timestamp: nbce.timestamp,
+			id:        subjectId,
+		})
+	}
+	return singleUpdates
+}
+
+type singleUpdateReviewInvitationModificationTime struct {
+	timestamp int64
+	id        string
+}
+
+var _ singleUpdate = new(singleUpdateReviewInvitationModificationTime)
+
+func (u *singleUpdateReviewInvitationModificationTime) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	state.reviewInvitations[u.id].ModifiedOn = u.timestamp
+	return []string{u.id}
+}
+
+func (u *singleUpdateReviewInvitationModificationTime) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	eventType := model.AlexandriaPrefix + model.EV_TYPE_REVIEW_INVITATION_MODIFICATION_TIME
+	return ba.AddEvent(eventType,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.id,
+			},
+		},
+		[]byte{})
+}
+
+func (nbce *nonBootstrapCommandExecution) addSingleUpdateProposalModificationTimeIfNeeded(
+	singleUpdates []singleUpdate, subjectId string) []singleUpdate {
+	if len(singleUpdates) >= 1 {
+		singleUpdates = append(singleUpdates, &singleUpdateProposalModificationTime{
+			timestamp: nbce.timestamp,
+			id:        subjectId,
+		})
+	}
+	return singleUpdates
+}
+
+type singleUpdateProposalModificationTime struct {
+	timestamp int64
+	id        string
+}
+
+var _ singleUpdate = new(singleUpdateProposalModificationTime)
+
+func (u *singleUpdateProposalModificationTime) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	state.proposals[u.id].ModifiedOn = u.timestamp
+	return []string{u.id}
+}
+
+func (u *singleUpdateProposalModificationTime) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	eventType := model.AlexandriaPrefix + model.EV_TYPE_PROPOSAL_MODIFICATION_TIME
 	return ba.AddEvent(eventType,
 		[]processor.Attribute{
 			{
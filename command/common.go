@@ -3,6 +3,7 @@ package command
 import (
 	"errors"
 	"fmt"
+	"github.com/iskendria-pub/iskendria/model"
 )
 
 type updater struct {
@@ -20,16 +21,65 @@ func formatPriceError(priceName string, expectedPrice int32) error {
 		priceName, expectedPrice))
 }
 
-func (nbce *nonBootstrapCommandExecution) readAndCheckAddresses(
-	addressesExpectedFilled []string,
-	addressesExpectedEmpty []string) error {
-	toRead := append(addressesExpectedFilled, addressesExpectedEmpty...)
+/*
+The input and output addresses of a command should be as narrow as
+possible, because Sawtooth only applies transactions in parallel if
+they do not conflict. Two transactions conflict when one of them
+writes an address that the other reads or writes. Every command reads
+the settings and the signer. The signer is written only when there is
+a price to deduct from its balance. Commands without a price therefore
+do not conflict with other commands of the same signer.
+*/
+
+func getInputAddresses(signerId string, addresses ...string) []string {
+	return uniqueAddresses(append([]string{model.GetSettingsAddress(), signerId}, addresses...))
+}
+
+func getOutputAddresses(signerId string, price int32, addresses ...string) []string {
+	if price == int32(0) {
+		return uniqueAddresses(addresses)
+	}
+	return uniqueAddresses(append([]string{signerId}, addresses...))
+}
+
+func uniqueAddresses(addresses []string) []string {
+	result := make([]string, 0, len(addresses))
+	seen := make(map[string]bool)
+	for _, a := range addresses {
+		if !seen[a] {
+			result = append(result, a)
+			seen[a] = true
+		}
+	}
+	return result
+}
+
+// readAddresses reads the addresses that were not read before. Addresses
+// that were read already may have been modified in the unmarshalled
+// state, so they should not be read again.
+func (nbce *nonBootstrapCommandExecution) readAddresses(addresses []string) error {
+	toRead := make([]string, 0, len(addresses))
+	for _, a := range uniqueAddresses(addresses) {
+		if nbce.unmarshalledState.getAddressState(a) == ADDRESS_UNKNOWN {
+			toRead = append(toRead, a)
+		}
+	}
+	if len(toRead) == 0 {
+		return nil
+	}
 	readState, err := nbce.blockchainAccess.GetState(toRead)
 	if err != nil {
 		return err
 	}
-	err = nbce.unmarshalledState.add(readState, toRead)
-	if err != nil {
+	return nbce.unmarshalledState.add(readState, toRead)
+}
+
+func (nbce *nonBootstrapCommandExecution) readAndCheckAddresses(
+	addressesExpectedFilled []string,
+	addressesExpectedEmpty []string) error {
+	toRead := make([]string, 0, len(addressesExpectedFilled)+len(addressesExpectedEmpty))
+	toRead = append(append(toRead, addressesExpectedFilled...), addressesExpectedEmpty...)
+	if err := nbce.readAddresses(toRead); err != nil {
 		return err
 	}
 	for _, a := range addressesExpectedFilled {
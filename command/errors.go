@@ -0,0 +1,68 @@
+package command
+
+import (
+	"errors"
+)
+
+/*
+ApplyModelCommand returns either an *InvalidTransactionError or an
+*InternalError. An invalid transaction can never be applied, so the
+validator should reject it. An internal error means that accessing
+the blockchain failed. The validator should retry the transaction
+in that case, because the transaction may well be valid.
+*/
+
+type InvalidTransactionError struct {
+	Msg string
+}
+
+func (e *InvalidTransactionError) Error() string {
+	return e.Msg
+}
+
+type InternalError struct {
+	Msg string
+}
+
+func (e *InternalError) Error() string {
+	return e.Msg
+}
+
+func IsInternalError(err error) bool {
+	_, isInternal := err.(*InternalError)
+	return isInternal
+}
+
+// toTypedError makes every error that is not an internal error an
+// invalid transaction error.
+func toTypedError(err error) error {
+	switch err.(type) {
+	case nil:
+		return nil
+	case *InternalError:
+		return err
+	case *InvalidTransactionError:
+		return err
+	default:
+		return &InvalidTransactionError{
+			Msg: err.Error(),
+		}
+	}
+}
+
+// addErrorContext prefixes the message of err, keeping its type.
+func addErrorContext(context string, err error) error {
+	msg := context + ": " + err.Error()
+	switch err.(type) {
+	case *InternalError:
+		return &InternalError{
+			Msg: msg,
+		}
+	case *InvalidTransactionError:
+		return &InvalidTransactionError{
+			Msg: msg,
+		}
+	default:
+		return errors.New(msg)
+	}
+}
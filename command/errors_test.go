@@ -0,0 +1,102 @@
+package command
+
+import (
+	"errors"
+	"github.com/hyperledger/sawtooth-sdk-go/processor"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
+	"github.com/iskendria-pub/iskendria/model"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+)
+
+type failingBlockchainAccess struct {
+	failGetState bool
+	failSetState bool
+	failAddEvent bool
+	delegate     BlockchainAccess
+}
+
+var _ BlockchainAccess = new(failingBlockchainAccess)
+
+var errValidatorUnavailable = errors.New("Validator unavailable")
+
+func newFailingBlockchainAccess() *failingBlockchainAccess {
+	return &failingBlockchainAccess{
+		delegate: NewBlockchainStub(
+			func(*events_pb2.Event, *log.Logger) error { return nil },
+			log.New(ioutil.Discard, "", 0)),
+	}
+}
+
+func (fba *failingBlockchainAccess) GetState(addresses []string) (map[string][]byte, error) {
+	if fba.failGetState {
+		return nil, errValidatorUnavailable
+	}
+	return fba.delegate.GetState(addresses)
+}
+
+func (fba *failingBlockchainAccess) SetState(pairs map[string][]byte) ([]string, error) {
+	if fba.failSetState {
+		return nil, errValidatorUnavailable
+	}
+	return fba.delegate.SetState(pairs)
+}
+
+func (fba *failingBlockchainAccess) AddEvent(
+	eventType string, attributes []processor.Attribute, eventData []byte) error {
+	if fba.failAddEvent {
+		return errValidatorUnavailable
+	}
+	return fba.delegate.AddEvent(eventType, attributes, eventData)
+}
+
+func applyBootstrap(ba BlockchainAccess) error {
+	cmd := GetBootstrapCommand(&Bootstrap{
+		Name:  "Major",
+		Email: "major@xxx.nl",
+	}, getRandomCryptoIdentity())
+	return ApplyModelCommand(cmd.Command, model.FamilyVersion, cmd.CryptoIdentity.PublicKeyStr, "transaction", ba)
+}
+
+func TestBlockchainAccessFailureIsInternalError(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	failingGetState := newFailingBlockchainAccess()
+	failingGetState.failGetState = true
+	failingSetState := newFailingBlockchainAccess()
+	failingSetState.failSetState = true
+	failingAddEvent := newFailingBlockchainAccess()
+	failingAddEvent.failAddEvent = true
+	for _, ba := range []*failingBlockchainAccess{failingGetState, failingSetState, failingAddEvent} {
+		err := applyBootstrap(ba)
+		if !IsInternalError(err) {
+			t.Errorf("Expected internal error, got: %v", err)
+		}
+	}
+}
+
+func TestValidationFailureIsInvalidTransactionError(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	ba := newFailingBlockchainAccess()
+	if err := applyBootstrap(ba); err != nil {
+		t.Error(err)
+		return
+	}
+	err := applyBootstrap(ba)
+	if _, isInvalid := err.(*InvalidTransactionError); !isInvalid {
+		t.Errorf("Bootstrapping twice should be an invalid transaction, got: %v", err)
+	}
+}
+
+func TestUndeclaredAddressIsInvalidTransactionError(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	ba := NewAccessCheckingDecorator(newFailingBlockchainAccess(), []string{}, []string{})
+	err := applyBootstrap(ba)
+	if _, isInvalid := err.(*InvalidTransactionError); !isInvalid {
+		t.Errorf("Reading an undeclared address should be an invalid transaction, got: %v", err)
+	}
+}
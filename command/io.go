@@ -1,10 +1,14 @@
 package command
 
 import (
+	"fmt"
 	"github.com/hyperledger/sawtooth-sdk-go/processor"
 	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
 	"github.com/hyperledger/sawtooth-sdk-go/protobuf/processor_pb2"
 	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/logging"
+	"github.com/iskendria-pub/iskendria/model"
+	"github.com/iskendria-pub/iskendria/util"
 	"log"
 )
 
@@ -78,3 +82,124 @@ func (bs *blockchainStub) AddEvent(eventType string, attributes []processor.Attr
 type EventHandler func(*events_pb2.Event, *log.Logger) error
 
 var _ EventHandler = dao.HandleEvent
+
+type accessCheckingDecorator struct {
+	readableAddresses map[string]bool
+	writableAddresses map[string]bool
+	delegate          BlockchainAccess
+}
+
+// NewAccessCheckingDecorator checks that only the input addresses are read
+// and that only the output addresses are written. The transaction processor
+// uses it to enforce the addresses declared in the transaction header.
+func NewAccessCheckingDecorator(ba BlockchainAccess, inputAddresses, outputAddresses []string) BlockchainAccess {
+	return &accessCheckingDecorator{
+		readableAddresses: util.StringSliceToSet(inputAddresses),
+		writableAddresses: util.StringSliceToSet(outputAddresses),
+		delegate:          ba,
+	}
+}
+
+func (acd *accessCheckingDecorator) GetState(addresses []string) (map[string][]byte, error) {
+	if !util.StringSetHasAll(acd.readableAddresses, addresses) {
+		toReport := getAddressesNotIn(addresses, acd.readableAddresses)
+		return nil, &InvalidTransactionError{
+			Msg: fmt.Sprintf("Some addresses were not readable: %v", toReport),
+		}
+	}
+	return acd.delegate.GetState(addresses)
+}
+
+func getAddressesNotIn(addresses []string, theSet map[string]bool) []string {
+	toReport := make([]string, 0, len(addresses))
+	for _, a := range addresses {
+		_, isIn := theSet[a]
+		if !isIn {
+			toReport = append(toReport, a)
+		}
+	}
+	return toReport
+}
+
+func (acd *accessCheckingDecorator) SetState(pairs map[string][]byte) ([]string, error) {
+	addressesToWrite := util.MapStringByteArrayToSlice(pairs)
+	if !util.StringSetHasAll(acd.writableAddresses, addressesToWrite) {
+		toReport := getAddressesNotIn(addressesToWrite, acd.writableAddresses)
+		return nil, &InvalidTransactionError{
+			Msg: fmt.Sprintf("Some addresses were not writable: %v", toReport),
+		}
+	}
+	return acd.delegate.SetState(pairs)
+}
+
+func (acd *accessCheckingDecorator) AddEvent(
+	eventType string, attributes []processor.Attribute, eventData []byte) error {
+	return acd.delegate.AddEvent(eventType, attributes, eventData)
+}
+
+// internalErrorDecorator makes the errors of the delegate internal errors,
+// unless the delegate says the transaction is invalid.
+type internalErrorDecorator struct {
+	delegate BlockchainAccess
+}
+
+func (ied *internalErrorDecorator) GetState(addresses []string) (map[string][]byte, error) {
+	result, err := ied.delegate.GetState(addresses)
+	return result, toInternalError(err)
+}
+
+func (ied *internalErrorDecorator) SetState(pairs map[string][]byte) ([]string, error) {
+	result, err := ied.delegate.SetState(pairs)
+	return result, toInternalError(err)
+}
+
+func (ied *internalErrorDecorator) AddEvent(
+	eventType string, attributes []processor.Attribute, eventData []byte) error {
+	return toInternalError(ied.delegate.AddEvent(eventType, attributes, eventData))
+}
+
+func toInternalError(err error) error {
+	switch err.(type) {
+	case nil:
+		return nil
+	case *InvalidTransactionError:
+		return err
+	case *InternalError:
+		return err
+	default:
+		return &InternalError{
+			Msg: err.Error(),
+		}
+	}
+}
+
+// loggingDecorator traces the blockchain access of a command execution.
+// The logger carries the transaction id and the signer.
+type loggingDecorator struct {
+	delegate BlockchainAccess
+	logger   *logging.Logger
+}
+
+func (ld *loggingDecorator) GetState(addresses []string) (map[string][]byte, error) {
+	ld.logger.Debug("Reading state", "addresses", addresses)
+	return ld.delegate.GetState(addresses)
+}
+
+func (ld *loggingDecorator) SetState(pairs map[string][]byte) ([]string, error) {
+	ld.logger.Debug("Writing state", "addresses", util.MapStringByteArrayToSlice(pairs))
+	return ld.delegate.SetState(pairs)
+}
+
+func (ld *loggingDecorator) AddEvent(
+	eventType string, attributes []processor.Attribute, eventData []byte) error {
+	if logging.IsEnabled(logging.LEVEL_DEBUG) {
+		eventSeq := ""
+		for _, a := range attributes {
+			if a.Key == model.EV_KEY_EVENT_SEQ {
+				eventSeq = a.Value
+			}
+		}
+		ld.logger.Debug("Sending event", "eventType", eventType, "eventSeq", eventSeq)
+	}
+	return ld.delegate.AddEvent(eventType, attributes, eventData)
+}
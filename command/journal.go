@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"github.com/hyperledger/sawtooth-sdk-go/processor"
 	"github.com/iskendria-pub/iskendria/model"
-	"log"
 	"sort"
 	"strconv"
+	"time"
 )
 
 func GetCommandJournalCreate(
@@ -17,8 +17,8 @@ func GetCommandJournalCreate(
 	price int32) (*Command, string) {
 	journalId := model.CreateJournalAddress()
 	return &Command{
-		InputAddresses:  []string{journalId, signer, model.GetSettingsAddress()},
-		OutputAddresses: []string{journalId, signer},
+		InputAddresses:  getInputAddresses(signer, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, journalId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signer,
@@ -46,8 +46,8 @@ func GetCommandJournalUpdateProperties(
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
 	return &Command{
-		InputAddresses:  []string{journalId, signer, model.GetSettingsAddress()},
-		OutputAddresses: []string{journalId, signer},
+		InputAddresses:  getInputAddresses(signer, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, journalId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signer,
@@ -83,8 +83,8 @@ func GetCommandJournalUpdateDescription(
 	price int32) *Command {
 	updatedDescriptionHash := model.HashBytes(updatedDescription)
 	return &Command{
-		InputAddresses:  []string{journalId, signer, model.GetSettingsAddress()},
-		OutputAddresses: []string{journalId, signer},
+		InputAddresses:  getInputAddresses(signer, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, journalId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signer,
@@ -110,8 +110,8 @@ func GetCommandJournalOmitDescription(
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
 	return &Command{
-		InputAddresses:  []string{journalId, signer, model.GetSettingsAddress()},
-		OutputAddresses: []string{journalId, signer},
+		InputAddresses:  getInputAddresses(signer, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, journalId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signer,
@@ -137,8 +137,8 @@ func GetCommandJournalUpdateAuthorization(
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
 	return &Command{
-		InputAddresses:  []string{journalId, signer, model.GetSettingsAddress()},
-		OutputAddresses: []string{journalId, signer},
+		InputAddresses:  getInputAddresses(signer, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, journalId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signer,
@@ -154,13 +154,65 @@ func GetCommandJournalUpdateAuthorization(
 	}
 }
 
+func GetCommandJournalUpdateReviewPolicy(
+	journalId string,
+	reviewByInvitationOnly bool,
+	signer string,
+	cryptoIdentity *CryptoIdentity,
+	price int32) *Command {
+	return &Command{
+		InputAddresses:  getInputAddresses(signer, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, journalId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signer,
+			Price:     price,
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandJournalUpdateReviewPolicy{
+				CommandJournalUpdateReviewPolicy: &model.CommandJournalUpdateReviewPolicy{
+					JournalId:              journalId,
+					ReviewByInvitationOnly: reviewByInvitationOnly,
+				},
+			},
+		},
+	}
+}
+
+// GetCommandJournalUpdateState opens, closes or archives a journal.
+// The price depends on the signer: an editor pays PriceEditorEditJournal
+// and a major who is not an editor pays
+// PriceMajorChangeJournalAuthorization.
+func GetCommandJournalUpdateState(
+	journalId string,
+	journalState model.JournalState,
+	signer string,
+	cryptoIdentity *CryptoIdentity,
+	price int32) *Command {
+	return &Command{
+		InputAddresses:  getInputAddresses(signer, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, journalId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signer,
+			Price:     price,
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandJournalUpdateState{
+				CommandJournalUpdateState: &model.CommandJournalUpdateState{
+					JournalId:    journalId,
+					JournalState: journalState,
+				},
+			},
+		},
+	}
+}
+
 func GetCommandEditorResign(
 	journalId string,
 	signer string,
 	cryptoIdentity *CryptoIdentity) *Command {
 	return &Command{
-		InputAddresses:  []string{journalId, signer, model.GetSettingsAddress()},
-		OutputAddresses: []string{journalId, signer},
+		InputAddresses:  getInputAddresses(signer, journalId),
+		OutputAddresses: getOutputAddresses(signer, int32(0), journalId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signer,
@@ -182,8 +234,8 @@ func GetCommandEditorInvite(
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
 	return &Command{
-		InputAddresses:  []string{journalId, signer, model.GetSettingsAddress()},
-		OutputAddresses: []string{journalId, signer},
+		InputAddresses:  getInputAddresses(signer, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, journalId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signer,
@@ -205,8 +257,8 @@ func GetCommandEditorAcceptDuty(
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
 	return &Command{
-		InputAddresses:  []string{journalId, signer, model.GetSettingsAddress()},
-		OutputAddresses: []string{journalId, signer},
+		InputAddresses:  getInputAddresses(signer, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, journalId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signer,
@@ -254,13 +306,8 @@ func (nbce *nonBootstrapCommandExecution) readAndCheckJournal(
 	if !model.IsJournalAddress(journalId) {
 		return errors.New("Journal id is not a journal address: " + journalId)
 	}
-	data, err := nbce.blockchainAccess.GetState([]string{journalId})
-	if err != nil {
-		return errors.New("Could not read journal address: " + journalId)
-	}
-	err = nbce.unmarshalledState.add(data, []string{journalId})
-	if err != nil {
-		return err
+	if err := nbce.readAddresses([]string{journalId}); err != nil {
+		return addErrorContext("Could not read journal address "+journalId, err)
 	}
 	if nbce.unmarshalledState.getAddressState(journalId) != expectedAddressState {
 		return reportUnexpectedJournalAddressState(expectedAddressState, journalId)
@@ -306,7 +353,6 @@ func (u *singleUpdateJournalCreate) updateState(state *unmarshalledState) []stri
 
 func (u *singleUpdateJournalCreate) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_JOURNAL_CREATE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(
 		eventType,
 		[]processor.Attribute{
@@ -360,7 +406,6 @@ func (u *singleUpdateEditorCreate) updateState(state *unmarshalledState) []strin
 
 func (u *singleUpdateEditorCreate) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_EDITOR_CREATE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(
 		eventType,
 		[]processor.Attribute{
@@ -404,6 +449,9 @@ func (nbce *nonBootstrapCommandExecution) checkJournalUpdateProperties(c *model.
 		return nil, errors.New(fmt.Sprintf(
 			"You are not editor of journal %s, or you still have to accept editorship", c.JournalId))
 	}
+	if err := nbce.checkJournalNotArchived(c.JournalId); err != nil {
+		return nil, err
+	}
 	oldJournal := nbce.unmarshalledState.journals[c.JournalId]
 	if c.TitleUpdate != nil && c.TitleUpdate.OldValue != oldJournal.Title {
 		return nil, errors.New(fmt.Sprintf("Title mismatch: expected %s, got %s",
@@ -463,7 +511,6 @@ func (u *singleUpdateJournalUpdateProperties) updateState(*unmarshalledState) (w
 func (u *singleUpdateJournalUpdateProperties) issueEvent(
 	eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_JOURNAL_UPDATE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(
 		eventType,
 		[]processor.Attribute{
@@ -545,7 +592,6 @@ func (u *singleUpdateJournalUpdateAuthorization) updateState(state *unmarshalled
 
 func (u *singleUpdateJournalUpdateAuthorization) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_JOURNAL_UPDATE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(eventType,
 		[]processor.Attribute{
 			{
@@ -571,6 +617,175 @@ func (u *singleUpdateJournalUpdateAuthorization) issueEvent(eventSeq int32, tran
 		}, []byte{})
 }
 
+func (nbce *nonBootstrapCommandExecution) checkJournalUpdateReviewPolicy(c *model.CommandJournalUpdateReviewPolicy) (
+	*updater, error) {
+	expectedPrice := nbce.unmarshalledState.settings.PriceList.PriceEditorEditJournal
+	if nbce.price != expectedPrice {
+		return nil, formatPriceError("PriceEditorEditJournal", expectedPrice)
+	}
+	if err := nbce.readAndCheckJournal(c.JournalId, ADDRESS_FILLED); err != nil {
+		return nil, err
+	}
+	if !nbce.signerIsEditor(c.JournalId, []model.EditorState{model.EditorState_editorAccepted}) {
+		return nil, errors.New("Only accepted editors can change the review policy of a journal")
+	}
+	if err := nbce.checkJournalNotArchived(c.JournalId); err != nil {
+		return nil, err
+	}
+	oldJournal := nbce.unmarshalledState.journals[c.JournalId]
+	updates := []singleUpdate{}
+	if oldJournal.ReviewByInvitationOnly != c.ReviewByInvitationOnly {
+		updates = append(updates, &singleUpdateJournalUpdateReviewPolicy{
+			journalId:              c.JournalId,
+			reviewByInvitationOnly: c.ReviewByInvitationOnly,
+			timestamp:              nbce.timestamp,
+		})
+	}
+	updates = nbce.addSingleUpdateJournalModificationTimeIfNeeded(updates, c.JournalId)
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates:           updates,
+	}, nil
+}
+
+type singleUpdateJournalUpdateReviewPolicy struct {
+	journalId              string
+	reviewByInvitationOnly bool
+	timestamp              int64
+}
+
+var _ singleUpdate = new(singleUpdateJournalUpdateReviewPolicy)
+
+func (u *singleUpdateJournalUpdateReviewPolicy) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	state.journals[u.journalId].ReviewByInvitationOnly = u.reviewByInvitationOnly
+	return []string{u.journalId}
+}
+
+func (u *singleUpdateJournalUpdateReviewPolicy) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	eventType := model.AlexandriaPrefix + model.EV_TYPE_JOURNAL_UPDATE
+	return ba.AddEvent(eventType,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.journalId,
+			},
+			{
+				Key:   model.EV_KEY_JOURNAL_REVIEW_BY_INVITATION_ONLY,
+				Value: strconv.FormatBool(u.reviewByInvitationOnly),
+			},
+		}, []byte{})
+}
+
+func (nbce *nonBootstrapCommandExecution) checkJournalUpdateState(c *model.CommandJournalUpdateState) (
+	*updater, error) {
+	if err := nbce.readAndCheckJournal(c.JournalId, ADDRESS_FILLED); err != nil {
+		return nil, err
+	}
+	if int32(c.JournalState) < model.MinJournalState || int32(c.JournalState) > model.MaxJournalState {
+		return nil, errors.New(fmt.Sprintf("JournalState out of range: %d", c.JournalState))
+	}
+	var expectedPrice int32
+	var priceName string
+	switch {
+	case nbce.signerIsEditor(c.JournalId, []model.EditorState{model.EditorState_editorAccepted}):
+		expectedPrice = nbce.unmarshalledState.settings.PriceList.PriceEditorEditJournal
+		priceName = "PriceEditorEditJournal"
+	case nbce.unmarshalledState.persons[nbce.verifiedSignerId].IsMajor:
+		expectedPrice = nbce.unmarshalledState.settings.PriceList.PriceMajorChangeJournalAuthorization
+		priceName = "PriceMajorChangeJournalAuthorization"
+	default:
+		return nil, errors.New("Only accepted editors and majors can open, close or archive a journal")
+	}
+	if nbce.price != expectedPrice {
+		return nil, formatPriceError(priceName, expectedPrice)
+	}
+	oldJournal := nbce.unmarshalledState.journals[c.JournalId]
+	updates := []singleUpdate{}
+	if oldJournal.JournalState != c.JournalState {
+		updates = append(updates, &singleUpdateJournalUpdateState{
+			journalId:    c.JournalId,
+			journalState: c.JournalState,
+			timestamp:    nbce.timestamp,
+		})
+	}
+	updates = nbce.addSingleUpdateJournalModificationTimeIfNeeded(updates, c.JournalId)
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates:           updates,
+	}, nil
+}
+
+// checkJournalAcceptsSubmissions requires that the journal was read.
+func (nbce *nonBootstrapCommandExecution) checkJournalAcceptsSubmissions(journalId string) error {
+	journalState := nbce.unmarshalledState.journals[journalId].JournalState
+	if journalState != model.JournalState_journalOpen {
+		return errors.New(fmt.Sprintf("Journal %s does not accept new manuscripts, because it is %s",
+			journalId, model.GetJournalStateString(journalState)))
+	}
+	return nil
+}
+
+// checkJournalNotArchived requires that the journal was read. An
+// archived journal is read-only.
+func (nbce *nonBootstrapCommandExecution) checkJournalNotArchived(journalId string) error {
+	if nbce.unmarshalledState.journals[journalId].JournalState == model.JournalState_journalArchived {
+		return errors.New(fmt.Sprintf("Journal %s is archived and cannot be changed", journalId))
+	}
+	return nil
+}
+
+type singleUpdateJournalUpdateState struct {
+	journalId    string
+	journalState model.JournalState
+	timestamp    int64
+}
+
+var _ singleUpdate = new(singleUpdateJournalUpdateState)
+
+func (u *singleUpdateJournalUpdateState) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	state.journals[u.journalId].JournalState = u.journalState
+	return []string{u.journalId}
+}
+
+func (u *singleUpdateJournalUpdateState) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	eventType := model.AlexandriaPrefix + model.EV_TYPE_JOURNAL_UPDATE
+	return ba.AddEvent(eventType,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.journalId,
+			},
+			{
+				Key:   model.EV_KEY_JOURNAL_STATE,
+				Value: model.GetJournalStateString(u.journalState),
+			},
+		}, []byte{})
+}
+
 func (nbce *nonBootstrapCommandExecution) checkJournalEditorResign(c *model.CommandJournalEditorResign) (
 	*updater, error) {
 	expectedPrice := int32(0)
@@ -624,7 +839,6 @@ func (u *singleUpdateEditorDelete) updateState(
 
 func (u *singleUpdateEditorDelete) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_EDITOR_DELETE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(
 		eventType,
 		[]processor.Attribute{
@@ -660,6 +874,9 @@ func (nbce *nonBootstrapCommandExecution) checkJournalEditorInvite(c *model.Comm
 	if err := nbce.readAndCheckJournal(c.JournalId, ADDRESS_FILLED); err != nil {
 		return nil, err
 	}
+	if err := nbce.checkJournalNotArchived(c.JournalId); err != nil {
+		return nil, err
+	}
 	if err := nbce.checkIsNotEditor(c.InvitedEditorId, c.JournalId); err != nil {
 		return nil, err
 	}
@@ -696,6 +913,9 @@ func (nbce *nonBootstrapCommandExecution) checkJournalEditorAcceptDuty(c *model.
 	if err := nbce.readAndCheckJournal(c.JournalId, ADDRESS_FILLED); err != nil {
 		return nil, err
 	}
+	if err := nbce.checkJournalNotArchived(c.JournalId); err != nil {
+		return nil, err
+	}
 
 	if !nbce.signerIsEditor(c.JournalId, []model.EditorState{model.EditorState_editorProposed}) {
 		return nil, errors.New("You already accepted editorship, or you are no editor at all")
@@ -761,7 +981,6 @@ func (u *singleUpdateEditorAcceptDuty) issueEvent(
 			Value: model.GetEditorStateString(model.EditorState_editorAccepted),
 		},
 	}
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(eventType, attributes, []byte{})
 }
 
@@ -769,6 +988,10 @@ type Volume struct {
 	JournalId              string
 	Issue                  string
 	LogicalPublicationTime int64
+	VolumeNumber           int32
+	IssueNumber            int32
+	Year                   int32
+	PublicationDate        string
 }
 
 func GetCommandVolumeCreate(
@@ -778,8 +1001,8 @@ func GetCommandVolumeCreate(
 	price int32) (*Command, string) {
 	volumeId := model.CreateVolumeAddress()
 	return &Command{
-		InputAddresses:  []string{model.GetSettingsAddress(), signer, volumeId, v.JournalId},
-		OutputAddresses: []string{signer, volumeId},
+		InputAddresses:  getInputAddresses(signer, volumeId, v.JournalId),
+		OutputAddresses: getOutputAddresses(signer, price, volumeId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signer,
@@ -791,6 +1014,10 @@ func GetCommandVolumeCreate(
 					JournalId:              v.JournalId,
 					Issue:                  v.Issue,
 					LogicalPublicationTime: v.LogicalPublicationTime,
+					VolumeNumber:           v.VolumeNumber,
+					IssueNumber:            v.IssueNumber,
+					Year:                   v.Year,
+					PublicationDate:        v.PublicationDate,
 				},
 			},
 		},
@@ -805,16 +1032,14 @@ func (nbce *nonBootstrapCommandExecution) checkVolumeCreate(c *model.CommandVolu
 	if err := nbce.readAndCheckJournal(c.JournalId, ADDRESS_FILLED); err != nil {
 		return nil, err
 	}
+	if err := nbce.checkJournalNotArchived(c.JournalId); err != nil {
+		return nil, err
+	}
 	if !model.IsVolumeAddress(c.VolumeId) {
 		return nil, errors.New("Volume id is not a volume address: " + c.VolumeId)
 	}
-	data, err := nbce.blockchainAccess.GetState([]string{c.VolumeId})
-	if err != nil {
-		return nil, errors.New("Could not read volume address: " + c.VolumeId)
-	}
-	err = nbce.unmarshalledState.add(data, []string{c.VolumeId})
-	if err != nil {
-		return nil, err
+	if err := nbce.readAddresses([]string{c.VolumeId}); err != nil {
+		return nil, addErrorContext("Could not read volume address "+c.VolumeId, err)
 	}
 	if nbce.unmarshalledState.getAddressState(c.VolumeId) != ADDRESS_EMPTY {
 		return nil, errors.New("Volume already exists: " + c.VolumeId)
@@ -822,39 +1047,70 @@ func (nbce *nonBootstrapCommandExecution) checkVolumeCreate(c *model.CommandVolu
 	if c.Issue == "" {
 		return nil, errors.New("Volume issue string should be filled but was empty")
 	}
+	if err := checkVolumeNumbers(c.VolumeNumber, c.IssueNumber, c.Year); err != nil {
+		return nil, err
+	}
+	if err := checkPublicationDate(c.PublicationDate); err != nil {
+		return nil, err
+	}
 	return &updater{
 		unmarshalledState: nbce.unmarshalledState,
 		updates: []singleUpdate{
 			&singleUpdateVolumeCreate{
-				volumeId:               c.VolumeId,
-				journalId:              c.JournalId,
-				issue:                  c.Issue,
-				timestamp:              nbce.timestamp,
-				logicalPublicationTime: c.LogicalPublicationTime,
+				volumeCreate: c,
+				timestamp:    nbce.timestamp,
 			},
 		},
 	}, nil
 }
 
+// checkVolumeNumbers allows zero, which means that the number is not set.
+func checkVolumeNumbers(volumeNumber, issueNumber, year int32) error {
+	if volumeNumber < 0 {
+		return errors.New(fmt.Sprintf("Volume number should not be negative, but was %d", volumeNumber))
+	}
+	if issueNumber < 0 {
+		return errors.New(fmt.Sprintf("Issue number should not be negative, but was %d", issueNumber))
+	}
+	if year < 0 {
+		return errors.New(fmt.Sprintf("Year should not be negative, but was %d", year))
+	}
+	return nil
+}
+
+// checkPublicationDate allows the empty string, which means that the
+// publication date is not set.
+func checkPublicationDate(publicationDate string) error {
+	if publicationDate == "" {
+		return nil
+	}
+	if _, err := time.Parse(model.PublicationDateLayout, publicationDate); err != nil {
+		return errors.New(fmt.Sprintf("Publication date should be formatted like %s, but was %s",
+			model.PublicationDateLayout, publicationDate))
+	}
+	return nil
+}
+
 type singleUpdateVolumeCreate struct {
-	volumeId               string
-	journalId              string
-	issue                  string
-	timestamp              int64
-	logicalPublicationTime int64
+	volumeCreate *model.CommandVolumeCreate
+	timestamp    int64
 }
 
 var _ singleUpdate = new(singleUpdateVolumeCreate)
 
 func (u *singleUpdateVolumeCreate) updateState(state *unmarshalledState) (writtenAddresses []string) {
-	state.volumes[u.volumeId] = &model.StateVolume{
-		Id:                     u.volumeId,
+	state.volumes[u.volumeCreate.VolumeId] = &model.StateVolume{
+		Id:                     u.volumeCreate.VolumeId,
 		CreatedOn:              u.timestamp,
-		JournalId:              u.journalId,
-		Issue:                  u.issue,
-		LogicalPublicationTime: u.logicalPublicationTime,
+		JournalId:              u.volumeCreate.JournalId,
+		Issue:                  u.volumeCreate.Issue,
+		LogicalPublicationTime: u.volumeCreate.LogicalPublicationTime,
+		VolumeNumber:           u.volumeCreate.VolumeNumber,
+		IssueNumber:            u.volumeCreate.IssueNumber,
+		Year:                   u.volumeCreate.Year,
+		PublicationDate:        u.volumeCreate.PublicationDate,
 	}
-	return []string{u.volumeId}
+	return []string{u.volumeCreate.VolumeId}
 }
 
 func (u *singleUpdateVolumeCreate) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
@@ -874,19 +1130,363 @@ func (u *singleUpdateVolumeCreate) issueEvent(eventSeq int32, transactionId stri
 			},
 			{
 				Key:   model.EV_KEY_ID,
-				Value: u.volumeId,
+				Value: u.volumeCreate.VolumeId,
 			},
 			{
 				Key:   model.EV_KEY_JOURNAL_ID,
-				Value: u.journalId,
+				Value: u.volumeCreate.JournalId,
 			},
 			{
 				Key:   model.EV_KEY_VOLUME_ISSUE,
-				Value: u.issue,
+				Value: u.volumeCreate.Issue,
 			},
 			{
 				Key:   model.EV_KEY_VOLUME_LOGICAL_PUBLICATION_TIME,
-				Value: fmt.Sprintf("%d", u.logicalPublicationTime),
+				Value: fmt.Sprintf("%d", u.volumeCreate.LogicalPublicationTime),
+			},
+			{
+				Key:   model.EV_KEY_VOLUME_NUMBER,
+				Value: fmt.Sprintf("%d", u.volumeCreate.VolumeNumber),
+			},
+			{
+				Key:   model.EV_KEY_VOLUME_ISSUE_NUMBER,
+				Value: fmt.Sprintf("%d", u.volumeCreate.IssueNumber),
+			},
+			{
+				Key:   model.EV_KEY_VOLUME_YEAR,
+				Value: fmt.Sprintf("%d", u.volumeCreate.Year),
+			},
+			{
+				Key:   model.EV_KEY_VOLUME_PUBLICATION_DATE,
+				Value: u.volumeCreate.PublicationDate,
 			},
 		}, []byte{})
 }
+
+type VolumeProperties struct {
+	Issue           string
+	VolumeNumber    int32
+	IssueNumber     int32
+	Year            int32
+	PublicationDate string
+}
+
+func GetCommandVolumeUpdate(
+	volumeId string,
+	journalId string,
+	orig,
+	updated *VolumeProperties,
+	signer string,
+	cryptoIdentity *CryptoIdentity,
+	price int32) *Command {
+	return &Command{
+		InputAddresses:  getInputAddresses(signer, volumeId, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, volumeId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signer,
+			Price:     price,
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandVolumeUpdate{
+				CommandVolumeUpdate: getModelCommandVolumeUpdate(volumeId, orig, updated),
+			},
+		},
+	}
+}
+
+func getModelCommandVolumeUpdate(volumeId string, orig, updated *VolumeProperties) *model.CommandVolumeUpdate {
+	result := &model.CommandVolumeUpdate{}
+	result.VolumeId = volumeId
+	if orig.Issue != updated.Issue {
+		result.IssueUpdate = &model.StringUpdate{
+			OldValue: orig.Issue,
+			NewValue: updated.Issue,
+		}
+	}
+	if orig.VolumeNumber != updated.VolumeNumber {
+		result.VolumeNumberUpdate = &model.IntUpdate{
+			OldValue: orig.VolumeNumber,
+			NewValue: updated.VolumeNumber,
+		}
+	}
+	if orig.IssueNumber != updated.IssueNumber {
+		result.IssueNumberUpdate = &model.IntUpdate{
+			OldValue: orig.IssueNumber,
+			NewValue: updated.IssueNumber,
+		}
+	}
+	if orig.Year != updated.Year {
+		result.YearUpdate = &model.IntUpdate{
+			OldValue: orig.Year,
+			NewValue: updated.Year,
+		}
+	}
+	if orig.PublicationDate != updated.PublicationDate {
+		result.PublicationDateUpdate = &model.StringUpdate{
+			OldValue: orig.PublicationDate,
+			NewValue: updated.PublicationDate,
+		}
+	}
+	return result
+}
+
+func (nbce *nonBootstrapCommandExecution) checkVolumeUpdate(c *model.CommandVolumeUpdate) (*updater, error) {
+	expectedPrice := nbce.unmarshalledState.settings.PriceList.PriceEditorEditJournal
+	if nbce.price != expectedPrice {
+		return nil, formatPriceError("PriceEditorEditJournal", expectedPrice)
+	}
+	volume, err := nbce.readAndCheckVolumeOfEditor(c.VolumeId)
+	if err != nil {
+		return nil, err
+	}
+	if c.IssueUpdate != nil && c.IssueUpdate.OldValue != volume.Issue {
+		return nil, errors.New(fmt.Sprintf("Issue mismatch: expected %s, got %s",
+			c.IssueUpdate.OldValue, volume.Issue))
+	}
+	if c.IssueUpdate != nil && c.IssueUpdate.NewValue == "" {
+		return nil, errors.New("Volume issue string should be filled but was empty")
+	}
+	if c.VolumeNumberUpdate != nil && c.VolumeNumberUpdate.OldValue != volume.VolumeNumber {
+		return nil, errors.New(fmt.Sprintf("VolumeNumber mismatch: expected %d, got %d",
+			c.VolumeNumberUpdate.OldValue, volume.VolumeNumber))
+	}
+	if c.IssueNumberUpdate != nil && c.IssueNumberUpdate.OldValue != volume.IssueNumber {
+		return nil, errors.New(fmt.Sprintf("IssueNumber mismatch: expected %d, got %d",
+			c.IssueNumberUpdate.OldValue, volume.IssueNumber))
+	}
+	if c.YearUpdate != nil && c.YearUpdate.OldValue != volume.Year {
+		return nil, errors.New(fmt.Sprintf("Year mismatch: expected %d, got %d",
+			c.YearUpdate.OldValue, volume.Year))
+	}
+	if c.PublicationDateUpdate != nil && c.PublicationDateUpdate.OldValue != volume.PublicationDate {
+		return nil, errors.New(fmt.Sprintf("PublicationDate mismatch: expected %s, got %s",
+			c.PublicationDateUpdate.OldValue, volume.PublicationDate))
+	}
+	volumeNumber, issueNumber, year, publicationDate :=
+		volume.VolumeNumber, volume.IssueNumber, volume.Year, volume.PublicationDate
+	if c.VolumeNumberUpdate != nil {
+		volumeNumber = c.VolumeNumberUpdate.NewValue
+	}
+	if c.IssueNumberUpdate != nil {
+		issueNumber = c.IssueNumberUpdate.NewValue
+	}
+	if c.YearUpdate != nil {
+		year = c.YearUpdate.NewValue
+	}
+	if c.PublicationDateUpdate != nil {
+		publicationDate = c.PublicationDateUpdate.NewValue
+	}
+	if err := checkVolumeNumbers(volumeNumber, issueNumber, year); err != nil {
+		return nil, err
+	}
+	if err := checkPublicationDate(publicationDate); err != nil {
+		return nil, err
+	}
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates:           createSingleUpdatesVolumeUpdate(c, volume, nbce.timestamp),
+	}, nil
+}
+
+// readAndCheckVolumeOfEditor reads the volume and its journal. The signer
+// should be an accepted editor of the journal, which should not be archived.
+func (nbce *nonBootstrapCommandExecution) readAndCheckVolumeOfEditor(volumeId string) (*model.StateVolume, error) {
+	if !model.IsVolumeAddress(volumeId) {
+		return nil, errors.New("Volume id is not a volume address: " + volumeId)
+	}
+	if err := nbce.readAndCheckAddresses([]string{volumeId}, []string{}); err != nil {
+		return nil, err
+	}
+	volume := nbce.unmarshalledState.volumes[volumeId]
+	if err := nbce.readAndCheckJournal(volume.JournalId, ADDRESS_FILLED); err != nil {
+		return nil, err
+	}
+	if !nbce.signerIsEditor(volume.JournalId, []model.EditorState{model.EditorState_editorAccepted}) {
+		return nil, errors.New(fmt.Sprintf(
+			"You are not editor of journal %s, or you still have to accept editorship", volume.JournalId))
+	}
+	if err := nbce.checkJournalNotArchived(volume.JournalId); err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+func createSingleUpdatesVolumeUpdate(
+	c *model.CommandVolumeUpdate, oldVolume *model.StateVolume, timestamp int64) []singleUpdate {
+	result := []singleUpdate{}
+	if c.IssueUpdate != nil {
+		result = append(result, &singleUpdateVolumeUpdateString{
+			newValue:   c.IssueUpdate.NewValue,
+			stateField: &oldVolume.Issue,
+			eventKey:   model.EV_KEY_VOLUME_ISSUE,
+			volumeId:   c.VolumeId,
+			timestamp:  timestamp,
+		})
+	}
+	if c.VolumeNumberUpdate != nil {
+		result = append(result, &singleUpdateVolumeUpdateInt{
+			newValue:   c.VolumeNumberUpdate.NewValue,
+			stateField: &oldVolume.VolumeNumber,
+			eventKey:   model.EV_KEY_VOLUME_NUMBER,
+			volumeId:   c.VolumeId,
+			timestamp:  timestamp,
+		})
+	}
+	if c.IssueNumberUpdate != nil {
+		result = append(result, &singleUpdateVolumeUpdateInt{
+			newValue:   c.IssueNumberUpdate.NewValue,
+			stateField: &oldVolume.IssueNumber,
+			eventKey:   model.EV_KEY_VOLUME_ISSUE_NUMBER,
+			volumeId:   c.VolumeId,
+			timestamp:  timestamp,
+		})
+	}
+	if c.YearUpdate != nil {
+		result = append(result, &singleUpdateVolumeUpdateInt{
+			newValue:   c.YearUpdate.NewValue,
+			stateField: &oldVolume.Year,
+			eventKey:   model.EV_KEY_VOLUME_YEAR,
+			volumeId:   c.VolumeId,
+			timestamp:  timestamp,
+		})
+	}
+	if c.PublicationDateUpdate != nil {
+		result = append(result, &singleUpdateVolumeUpdateString{
+			newValue:   c.PublicationDateUpdate.NewValue,
+			stateField: &oldVolume.PublicationDate,
+			eventKey:   model.EV_KEY_VOLUME_PUBLICATION_DATE,
+			volumeId:   c.VolumeId,
+			timestamp:  timestamp,
+		})
+	}
+	return result
+}
+
+type singleUpdateVolumeUpdateString struct {
+	newValue   string
+	stateField *string
+	eventKey   string
+	volumeId   string
+	timestamp  int64
+}
+
+var _ singleUpdate = new(singleUpdateVolumeUpdateString)
+
+func (u *singleUpdateVolumeUpdateString) updateState(*unmarshalledState) (writtenAddress []string) {
+	*u.stateField = u.newValue
+	return []string{u.volumeId}
+}
+
+func (u *singleUpdateVolumeUpdateString) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return issueVolumeUpdateEvent(u.volumeId, u.eventKey, u.newValue, u.timestamp, eventSeq, transactionId, ba)
+}
+
+type singleUpdateVolumeUpdateInt struct {
+	newValue   int32
+	stateField *int32
+	eventKey   string
+	volumeId   string
+	timestamp  int64
+}
+
+var _ singleUpdate = new(singleUpdateVolumeUpdateInt)
+
+func (u *singleUpdateVolumeUpdateInt) updateState(*unmarshalledState) (writtenAddress []string) {
+	*u.stateField = u.newValue
+	return []string{u.volumeId}
+}
+
+func (u *singleUpdateVolumeUpdateInt) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return issueVolumeUpdateEvent(
+		u.volumeId, u.eventKey, fmt.Sprintf("%d", u.newValue), u.timestamp, eventSeq, transactionId, ba)
+}
+
+func issueVolumeUpdateEvent(
+	volumeId, eventKey, value string, timestamp int64, eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return ba.AddEvent(model.AlexandriaPrefix+model.EV_TYPE_VOLUME_UPDATE,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: volumeId,
+			},
+			{
+				Key:   eventKey,
+				Value: value,
+			},
+		}, []byte{})
+}
+
+func GetCommandVolumeSeal(
+	volumeId string,
+	journalId string,
+	signer string,
+	cryptoIdentity *CryptoIdentity,
+	price int32) *Command {
+	return &Command{
+		InputAddresses:  getInputAddresses(signer, volumeId, journalId),
+		OutputAddresses: getOutputAddresses(signer, price, volumeId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signer,
+			Price:     price,
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandVolumeSeal{
+				CommandVolumeSeal: &model.CommandVolumeSeal{
+					VolumeId: volumeId,
+				},
+			},
+		},
+	}
+}
+
+func (nbce *nonBootstrapCommandExecution) checkVolumeSeal(c *model.CommandVolumeSeal) (*updater, error) {
+	expectedPrice := nbce.unmarshalledState.settings.PriceList.PriceEditorEditJournal
+	if nbce.price != expectedPrice {
+		return nil, formatPriceError("PriceEditorEditJournal", expectedPrice)
+	}
+	volume, err := nbce.readAndCheckVolumeOfEditor(c.VolumeId)
+	if err != nil {
+		return nil, err
+	}
+	if volume.IsSealed {
+		return nil, errors.New("Volume is already sealed: " + c.VolumeId)
+	}
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates: []singleUpdate{
+			&singleUpdateVolumeSeal{
+				volumeId:  c.VolumeId,
+				timestamp: nbce.timestamp,
+			},
+		},
+	}, nil
+}
+
+type singleUpdateVolumeSeal struct {
+	volumeId  string
+	timestamp int64
+}
+
+var _ singleUpdate = new(singleUpdateVolumeSeal)
+
+func (u *singleUpdateVolumeSeal) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	state.volumes[u.volumeId].IsSealed = true
+	return []string{u.volumeId}
+}
+
+func (u *singleUpdateVolumeSeal) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return issueVolumeUpdateEvent(
+		u.volumeId, model.EV_KEY_VOLUME_IS_SEALED, strconv.FormatBool(true), u.timestamp, eventSeq, transactionId, ba)
+}
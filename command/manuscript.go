@@ -1,9 +1,11 @@
 package command
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/hyperledger/sawtooth-sdk-go/processor"
+	"github.com/hyperledger/sawtooth-sdk-go/signing"
 	"github.com/iskendria-pub/iskendria/dao"
 	"github.com/iskendria-pub/iskendria/model"
 	"github.com/iskendria-pub/iskendria/util"
@@ -27,14 +29,10 @@ func GetCommandManuscriptCreate(
 	threadId := model.CreateManuscriptThreadAddress()
 	theHash := model.HashBytes(manuscriptCreate.TheManuscript)
 	return &Command{
-		InputAddresses: append(
-			manuscriptCreate.AuthorId,
-			model.GetSettingsAddress(),
-			signerId,
-			manuscriptCreate.JournalId,
-			manuscriptId,
-			threadId),
-		OutputAddresses: []string{signerId, manuscriptId, threadId},
+		InputAddresses: getInputAddresses(signerId, append(
+			[]string{manuscriptCreate.JournalId, manuscriptId, threadId},
+			manuscriptCreate.AuthorId...)...),
+		OutputAddresses: getOutputAddresses(signerId, price, manuscriptId, threadId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -75,15 +73,15 @@ func GetCommandManuscriptCreateNewVersion(
 	manuscriptId := model.CreateManuscriptAddress()
 	threadManuscriptIds := threadReferenceToAuthorIds(daoThreadReference)
 	return &Command{
-		InputAddresses: append(append(manuscriptCreateNewVersion.AuthorId,
-			model.GetSettingsAddress(),
-			signerId,
-			manuscriptCreateNewVersion.JournalId,
-			manuscriptId,
-			manuscriptCreateNewVersion.PreviousManuscriptId,
-			manuscriptCreateNewVersion.ThreadId),
-			threadManuscriptIds...),
-		OutputAddresses: []string{signerId, manuscriptId, manuscriptCreateNewVersion.ThreadId},
+		InputAddresses: getInputAddresses(signerId, append(append(
+			[]string{
+				manuscriptCreateNewVersion.JournalId,
+				manuscriptId,
+				manuscriptCreateNewVersion.PreviousManuscriptId,
+				manuscriptCreateNewVersion.ThreadId},
+			manuscriptCreateNewVersion.AuthorId...),
+			threadManuscriptIds...)...),
+		OutputAddresses: getOutputAddresses(signerId, price, manuscriptId, manuscriptCreateNewVersion.ThreadId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -123,13 +121,8 @@ func GetCommandManuscriptAcceptAuthorship(
 		authorIds[i] = a.PersonId
 	}
 	return &Command{
-		InputAddresses: append(
-			authorIds,
-			model.GetSettingsAddress(),
-			manuscript.Id,
-			manuscript.ThreadId,
-			signerId),
-		OutputAddresses: []string{manuscript.Id, manuscript.ThreadId, signerId},
+		InputAddresses:  getInputAddresses(signerId, append([]string{manuscript.Id, manuscript.ThreadId}, authorIds...)...),
+		OutputAddresses: getOutputAddresses(signerId, price, manuscript.Id),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -169,8 +162,8 @@ func GetCommandManuscriptAllowReview(
 		manuscriptIds[i] = r.Id
 	}
 	return &Command{
-		InputAddresses:  append(manuscriptIds, threadId, model.GetSettingsAddress(), signerId, journalId),
-		OutputAddresses: append(manuscriptIds, threadId, signerId),
+		InputAddresses:  getInputAddresses(signerId, append([]string{threadId, journalId}, manuscriptIds...)...),
+		OutputAddresses: getOutputAddresses(signerId, price, append([]string{threadId}, manuscriptIds...)...),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -233,9 +226,8 @@ func getCommandWriteReview(
 	reviewId := model.CreateReviewAddress()
 	hash := model.HashBytes(reviewCreate.TheReview)
 	return &Command{
-		InputAddresses: []string{
-			reviewCreate.ManuscriptId, reviewId, signerId, model.GetSettingsAddress()},
-		OutputAddresses: []string{reviewId, signerId},
+		InputAddresses:  getInputAddresses(signerId, reviewCreate.getInputAddresses(reviewId)...),
+		OutputAddresses: getOutputAddresses(signerId, price, reviewId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -247,17 +239,34 @@ func getCommandWriteReview(
 					ManuscriptId: reviewCreate.ManuscriptId,
 					Hash:         hash,
 					Judgement:    judgement,
+					InvitationId: reviewCreate.InvitationId,
 				},
 			},
 		},
 	}, reviewId
 }
 
+// ReviewCreate holds the data of a review to write. JournalId is
+// the journal of the manuscript. InvitationId is the accepted
+// invitation of the reviewer, or empty if the reviewer was not invited.
 type ReviewCreate struct {
 	ManuscriptId string
+	JournalId    string
+	InvitationId string
 	TheReview    []byte
 }
 
+func (rc *ReviewCreate) getInputAddresses(reviewId string) []string {
+	result := []string{rc.ManuscriptId, reviewId}
+	if rc.JournalId != "" {
+		result = append(result, rc.JournalId)
+	}
+	if rc.InvitationId != "" {
+		result = append(result, rc.InvitationId)
+	}
+	return result
+}
+
 func GetCommandManuscriptReject(
 	manuscriptJudge *ManuscriptJudge,
 	journalId string,
@@ -296,10 +305,10 @@ func getCommandManuscriptJudge(
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
 	return &Command{
-		InputAddresses: append(
-			manuscriptJudge.ReviewId, manuscriptJudge.ManuscriptId, signerId, model.GetSettingsAddress(), journalId),
-		OutputAddresses: append(
-			manuscriptJudge.ReviewId, manuscriptJudge.ManuscriptId, signerId),
+		InputAddresses: getInputAddresses(signerId, append(
+			[]string{manuscriptJudge.ManuscriptId, journalId}, manuscriptJudge.ReviewId...)...),
+		OutputAddresses: getOutputAddresses(signerId, price, append(
+			[]string{manuscriptJudge.ManuscriptId}, manuscriptJudge.ReviewId...)...),
 		CryptoIdentity: cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -321,24 +330,25 @@ type ManuscriptJudge struct {
 	ReviewId     []string
 }
 
+// GetCommandManuscriptAssign needs the volume the manuscript is assigned
+// to already, because its page range is removed when the manuscript moves
+// to another volume. Pass the empty string if there is none.
 func GetCommandManuscriptAssign(
 	manuscriptAssign *ManuscriptAssign,
 	journalId string,
+	previousVolumeId string,
 	signerId string,
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
+	volumeIds := []string{manuscriptAssign.VolumeId}
+	if previousVolumeId != "" {
+		volumeIds = append(volumeIds, previousVolumeId)
+	}
 	return &Command{
-		InputAddresses: []string{
-			signerId,
-			manuscriptAssign.ManuscriptId,
-			manuscriptAssign.VolumeId,
-			journalId,
-			model.GetSettingsAddress(),
-		},
-		OutputAddresses: []string{
-			signerId,
-			manuscriptAssign.ManuscriptId,
-		},
+		InputAddresses: getInputAddresses(
+			signerId, append([]string{manuscriptAssign.ManuscriptId, journalId}, volumeIds...)...),
+		OutputAddresses: getOutputAddresses(
+			signerId, price, append([]string{manuscriptAssign.ManuscriptId}, volumeIds...)...),
 		CryptoIdentity: cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -363,6 +373,37 @@ type ManuscriptAssign struct {
 	LastPage     string
 }
 
+func GetCommandManuscriptWithdraw(
+	manuscriptId string,
+	threadId string,
+	daoThreadReference []dao.ReferenceThreadItem,
+	commitMsg string,
+	signerId string,
+	cryptoIdentity *CryptoIdentity,
+	price int32) *Command {
+	manuscriptIds := make([]string, len(daoThreadReference))
+	for i, r := range daoThreadReference {
+		manuscriptIds[i] = r.Id
+	}
+	return &Command{
+		InputAddresses:  getInputAddresses(signerId, append([]string{threadId}, manuscriptIds...)...),
+		OutputAddresses: getOutputAddresses(signerId, price, manuscriptIds...),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signerId,
+			Timestamp: model.GetCurrentTime(),
+			Price:     price,
+			Body: &model.Command_CommandManuscriptWithdraw{
+				CommandManuscriptWithdraw: &model.CommandManuscriptWithdraw{
+					ManuscriptId:    manuscriptId,
+					CommitMsg:       commitMsg,
+					ThreadReference: daoThreadReferenceToCommandReferenceThread(daoThreadReference),
+				},
+			},
+		},
+	}
+}
+
 func (nbce *nonBootstrapCommandExecution) checkManuscriptCreate(c *model.CommandManuscriptCreate) (*updater, error) {
 	expectedPrice := nbce.unmarshalledState.settings.PriceList.PriceAuthorSubmitNewManuscript
 	if nbce.price != expectedPrice {
@@ -388,6 +429,9 @@ func (nbce *nonBootstrapCommandExecution) checkManuscriptCreate(c *model.Command
 	if !isSignerAuthor {
 		return nil, errors.New("A manuscript should be submitted by one of its authors")
 	}
+	if err = nbce.checkJournalAcceptsSubmissions(c.JournalId); err != nil {
+		return nil, err
+	}
 	status := getNewManuscriptStatus(len(c.AuthorId) == 1, false)
 	updates := []singleUpdate{
 		&singleUpdateManuscriptCreate{
@@ -640,10 +684,16 @@ func (nbce *nonBootstrapCommandExecution) checkManuscriptCreateNewVersion(
 	if err != nil {
 		return nil, err
 	}
+	if err = nbce.checkJournalNotArchived(previousManuscript.JournalId); err != nil {
+		return nil, err
+	}
 	manuscriptThread := nbce.unmarshalledState.manuscriptThreads[previousManuscript.ThreadId]
 	if manuscriptThread.ManuscriptId[len(manuscriptThread.ManuscriptId)-1] != c.PreviousManuscriptId {
 		return nil, errors.New("You can only add a manuscript to the end of its thread")
 	}
+	if previousManuscript.Status == model.ManuscriptStatus_withdrawn {
+		return nil, errors.New("You cannot add a manuscript to a withdrawn thread")
+	}
 	blockchainHistoricAuthors := nbce.getBlockchainSignedHistoricAuthors(manuscriptThread.Id)
 	if len(c.HistoricAuthorId) != len(blockchainHistoricAuthors) {
 		return nil, errors.New(fmt.Sprintf("Unexpected number of historic signed authors. Expected %d, got %d",
@@ -981,10 +1031,17 @@ func (nbce *nonBootstrapCommandExecution) checkManuscriptAllowReview(c *model.Co
 	if err != nil {
 		return nil, err
 	}
+	if c.ThreadReference[len(c.ThreadReference)-1].ManuscriptStatus == model.ManuscriptStatus_withdrawn {
+		return nil, errors.New("Manuscript thread was withdrawn: " + c.ThreadId)
+	}
 	err = nbce.checkManuscriptJournalHasSignerAsEditor(c.ThreadReference[0].ManuscriptId)
 	if err != nil {
 		return nil, err
 	}
+	err = nbce.checkJournalNotArchived(nbce.unmarshalledState.manuscripts[c.ThreadReference[0].ManuscriptId].JournalId)
+	if err != nil {
+		return nil, err
+	}
 	updates := []singleUpdate{
 		&singleUpdateManuscriptThreadAllowReview{
 			threadId:  c.ThreadId,
@@ -1151,6 +1208,9 @@ func (nbce *nonBootstrapCommandExecution) checkWriteReview(c *model.CommandWrite
 		return nil, errors.New(fmt.Sprintf("Reviews are not allowed yet because manuscript %s has status %s",
 			c.ManuscriptId, model.GetManuscriptStatusString(status)))
 	}
+	if err = nbce.checkWriteReviewInvitation(c); err != nil {
+		return nil, err
+	}
 	return &updater{
 		unmarshalledState: nbce.unmarshalledState,
 		updates: []singleUpdate{
@@ -1176,6 +1236,52 @@ func checkSanityWriteReview(c *model.CommandWriteReview) error {
 	if int32(c.Judgement) < model.MinJudgement || int32(c.Judgement) > model.MaxJudgement {
 		return errors.New("Invalid judgement value")
 	}
+	if c.InvitationId != "" && !model.IsReviewInvitationAddress(c.InvitationId) {
+		return errors.New("Not a review invitation address: " + c.InvitationId)
+	}
+	return nil
+}
+
+func (nbce *nonBootstrapCommandExecution) checkWriteReviewInvitation(c *model.CommandWriteReview) error {
+	if !nbce.rules.allowReviewInvitations {
+		if c.InvitationId != "" {
+			return errors.New("Reviewer invitations are not supported in family version " +
+				nbce.rules.familyVersion)
+		}
+		return nil
+	}
+	manuscript := nbce.unmarshalledState.manuscripts[c.ManuscriptId]
+	err := nbce.readAndCheckAddresses([]string{manuscript.JournalId}, []string{})
+	if err != nil {
+		return err
+	}
+	if err = nbce.checkJournalNotArchived(manuscript.JournalId); err != nil {
+		return err
+	}
+	if c.InvitationId == "" {
+		if nbce.unmarshalledState.journals[manuscript.JournalId].ReviewByInvitationOnly {
+			return errors.New("Journal only accepts reviews of invited reviewers: " + manuscript.JournalId)
+		}
+		return nil
+	}
+	err = nbce.readAndCheckAddresses([]string{c.InvitationId}, []string{})
+	if err != nil {
+		return err
+	}
+	invitation := nbce.unmarshalledState.reviewInvitations[c.InvitationId]
+	if invitation.ReviewerId != nbce.verifiedSignerId {
+		return errors.New("You are not the invited reviewer of invitation " + c.InvitationId)
+	}
+	if invitation.ThreadId != manuscript.ThreadId {
+		return errors.New(fmt.Sprintf("Invitation %s is not about manuscript %s",
+			c.InvitationId, c.ManuscriptId))
+	}
+	if invitation.InvitationState != model.ReviewInvitationState_invitationAccepted {
+		return errors.New(fmt.Sprintf("Invitation %s has state %s, expected %s",
+			c.InvitationId,
+			model.GetReviewInvitationStateString(invitation.InvitationState),
+			model.GetReviewInvitationStateString(model.ReviewInvitationState_invitationAccepted)))
+	}
 	return nil
 }
 
@@ -1186,22 +1292,27 @@ func statusAllowsReview(status model.ManuscriptStatus) bool {
 		status == model.ManuscriptStatus_assigned
 }
 
+// singleUpdateWriteReview also writes a revision of a review, then
+// previousReviewId is not empty.
 type singleUpdateWriteReview struct {
-	signerId  string
-	c         *model.CommandWriteReview
-	timestamp int64
+	signerId         string
+	c                *model.CommandWriteReview
+	previousReviewId string
+	timestamp        int64
 }
 
 var _ singleUpdate = new(singleUpdateWriteReview)
 
 func (u *singleUpdateWriteReview) updateState(state *unmarshalledState) (writtenAddresses []string) {
 	state.reviews[u.c.ReviewId] = &model.StateReview{
-		Id:             u.c.ReviewId,
-		CreatedOn:      u.timestamp,
-		ManuscriptId:   u.c.ManuscriptId,
-		ReviewAuthorId: u.signerId,
-		Hash:           u.c.Hash,
-		Judgement:      u.c.Judgement,
+		Id:               u.c.ReviewId,
+		CreatedOn:        u.timestamp,
+		ManuscriptId:     u.c.ManuscriptId,
+		ReviewAuthorId:   u.signerId,
+		Hash:             u.c.Hash,
+		Judgement:        u.c.Judgement,
+		InvitationId:     u.c.InvitationId,
+		PreviousReviewId: u.previousReviewId,
 	}
 	return []string{u.c.ReviewId}
 }
@@ -1243,6 +1354,14 @@ func (u *singleUpdateWriteReview) issueEvent(
 				Key:   model.EV_KEY_REVIEW_JUDGEMENT,
 				Value: model.GetJudgementString(u.c.Judgement),
 			},
+			{
+				Key:   model.EV_KEY_REVIEW_INVITATION_ID,
+				Value: u.c.InvitationId,
+			},
+			{
+				Key:   model.EV_KEY_REVIEW_PREVIOUS_ID,
+				Value: u.previousReviewId,
+			},
 		}, []byte{})
 }
 
@@ -1265,11 +1384,21 @@ func (nbce *nonBootstrapCommandExecution) checkManuscriptJudge(
 	if err := nbce.checkManuscriptJournalHasSignerAsEditor(c.ManuscriptId); err != nil {
 		return nil, err
 	}
+	if err := nbce.checkJournalNotArchived(nbce.unmarshalledState.manuscripts[c.ManuscriptId].JournalId); err != nil {
+		return nil, err
+	}
 	actualManuscriptStatus := nbce.unmarshalledState.manuscripts[c.ManuscriptId].Status
 	if actualManuscriptStatus != model.ManuscriptStatus_reviewable {
 		return nil, errors.New(fmt.Sprintf("Manuscript %s cannot be judged because its status is %s",
 			c.ManuscriptId, model.GetManuscriptStatusString(actualManuscriptStatus)))
 	}
+	for _, r := range c.ReviewId {
+		nextReviewId := nbce.unmarshalledState.reviews[r].NextReviewId
+		if nextReviewId != "" {
+			return nil, errors.New(fmt.Sprintf("Review %s has been revised by review %s, cite the latest revision",
+				r, nextReviewId))
+		}
+	}
 	updates := []singleUpdate{
 		&singleUpdateManuscriptUpdateStatus{
 			manuscriptId: c.ManuscriptId,
@@ -1384,11 +1513,38 @@ func (nbce *nonBootstrapCommandExecution) checkManuscriptAssign(
 	volume := nbce.unmarshalledState.volumes[c.VolumeId]
 	manuscript := nbce.unmarshalledState.manuscripts[c.ManuscriptId]
 	journal := nbce.unmarshalledState.journals[manuscript.JournalId]
+	if err := nbce.checkJournalNotArchived(journal.Id); err != nil {
+		return nil, err
+	}
 	if volume.JournalId != journal.Id {
 		return nil, errors.New(fmt.Sprintf("Volume %s does not belong to journal %s",
 			c.VolumeId, manuscript.JournalId))
 	}
+	if volume.IsSealed {
+		return nil, errors.New(fmt.Sprintf("Volume %s is sealed, no manuscripts can be assigned to it",
+			c.VolumeId))
+	}
+	// Version 1 clients did not declare the previous volume as input,
+	// so it is only read when volumes can be sealed.
+	previousVolumeId := manuscript.VolumeId
+	if (nbce.rules.allowVolumeUpdates || nbce.rules.validatePageRanges) &&
+		previousVolumeId != "" && previousVolumeId != c.VolumeId {
+		if err := nbce.readAndCheckAddresses([]string{previousVolumeId}, []string{}); err != nil {
+			return nil, err
+		}
+		if nbce.unmarshalledState.volumes[previousVolumeId].IsSealed {
+			return nil, errors.New(fmt.Sprintf("Manuscript %s cannot leave volume %s, because it is sealed",
+				c.ManuscriptId, previousVolumeId))
+		}
+	}
 	updates := nbce.getManuscriptAssignUpdates(c)
+	if nbce.rules.validatePageRanges {
+		pageRangeUpdates, err := nbce.getPageRangeUpdates(c)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, pageRangeUpdates...)
+	}
 	updates = nbce.addSingleUpdateManuscriptModificationTimeIfNeeded(updates, c.ManuscriptId)
 	return &updater{
 		unmarshalledState: nbce.unmarshalledState,
@@ -1445,6 +1601,187 @@ func (nbce *nonBootstrapCommandExecution) getManuscriptAssignUpdates(
 	}
 }
 
+// getPageRangeUpdates keeps the page range of the manuscript in its volume.
+// When the manuscript moves to another volume, its page range is removed
+// from the previous volume.
+func (nbce *nonBootstrapCommandExecution) getPageRangeUpdates(
+	c *model.CommandManuscriptAssign) ([]singleUpdate, error) {
+	volume := nbce.unmarshalledState.volumes[c.VolumeId]
+	if err := CheckPageRange(volume, c.ManuscriptId, c.FirstPage, c.LastPage); err != nil {
+		return nil, err
+	}
+	// The pages were checked by CheckPageRange.
+	firstPage, _ := parsePage(c.FirstPage)
+	lastPage, _ := parsePage(c.LastPage)
+	result := []singleUpdate{}
+	// checkManuscriptAssign has read the previous volume.
+	previousVolumeId := nbce.unmarshalledState.manuscripts[c.ManuscriptId].VolumeId
+	if previousVolumeId != "" && previousVolumeId != c.VolumeId {
+		result = append(result, &singleUpdateVolumeRemovePageRange{
+			volumeId:     previousVolumeId,
+			manuscriptId: c.ManuscriptId,
+			timestamp:    nbce.timestamp,
+		})
+	}
+	result = append(result, &singleUpdateVolumeSetPageRange{
+		volumeId:     c.VolumeId,
+		manuscriptId: c.ManuscriptId,
+		firstPage:    firstPage,
+		lastPage:     lastPage,
+		timestamp:    nbce.timestamp,
+	})
+	return result, nil
+}
+
+// CheckPageRange checks that the pages are positive numbers and that
+// they do not overlap the pages of the other manuscripts of the volume.
+func CheckPageRange(volume *model.StateVolume, manuscriptId, firstPageStr, lastPageStr string) error {
+	firstPage, err := parsePage(firstPageStr)
+	if err != nil {
+		return errors.New("FirstPage should be a positive number, but was: " + firstPageStr)
+	}
+	lastPage, err := parsePage(lastPageStr)
+	if err != nil {
+		return errors.New("LastPage should be a positive number, but was: " + lastPageStr)
+	}
+	if lastPage < firstPage {
+		return errors.New(fmt.Sprintf("LastPage %d comes before FirstPage %d", lastPage, firstPage))
+	}
+	for _, other := range volume.PageRange {
+		if other.ManuscriptId == manuscriptId {
+			continue
+		}
+		if firstPage <= other.LastPage && other.FirstPage <= lastPage {
+			return errors.New(fmt.Sprintf("Pages %d-%d overlap the pages %d-%d of manuscript %s",
+				firstPage, lastPage, other.FirstPage, other.LastPage, other.ManuscriptId))
+		}
+	}
+	return nil
+}
+
+// parsePage accepts only digits, so that every page has one spelling.
+// The page should fit in an int32.
+func parsePage(page string) (int32, error) {
+	if page == "" || page[0] == '+' || page[0] == '-' {
+		return 0, errors.New("Not a positive number: " + page)
+	}
+	result, err := strconv.ParseInt(page, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if result < 1 {
+		return 0, errors.New("Not a positive number: " + page)
+	}
+	return int32(result), nil
+}
+
+type singleUpdateVolumeSetPageRange struct {
+	volumeId     string
+	manuscriptId string
+	firstPage    int32
+	lastPage     int32
+	timestamp    int64
+}
+
+var _ singleUpdate = new(singleUpdateVolumeSetPageRange)
+
+func (u *singleUpdateVolumeSetPageRange) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	volume := state.volumes[u.volumeId]
+	volume.PageRange = append(removePageRange(volume.PageRange, u.manuscriptId), &model.PageRange{
+		ManuscriptId: u.manuscriptId,
+		FirstPage:    u.firstPage,
+		LastPage:     u.lastPage,
+	})
+	return []string{u.volumeId}
+}
+
+func (u *singleUpdateVolumeSetPageRange) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return ba.AddEvent(
+		model.AlexandriaPrefix+model.EV_TYPE_VOLUME_PAGE_RANGE,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.volumeId,
+			},
+			{
+				Key:   model.EV_KEY_MANUSCRIPT_ID,
+				Value: u.manuscriptId,
+			},
+			{
+				Key:   model.EV_KEY_MANUSCRIPT_FIRST_PAGE,
+				Value: fmt.Sprintf("%d", u.firstPage),
+			},
+			{
+				Key:   model.EV_KEY_MANUSCRIPT_LAST_PAGE,
+				Value: fmt.Sprintf("%d", u.lastPage),
+			},
+		}, []byte{})
+}
+
+type singleUpdateVolumeRemovePageRange struct {
+	volumeId     string
+	manuscriptId string
+	timestamp    int64
+}
+
+var _ singleUpdate = new(singleUpdateVolumeRemovePageRange)
+
+func (u *singleUpdateVolumeRemovePageRange) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	volume := state.volumes[u.volumeId]
+	volume.PageRange = removePageRange(volume.PageRange, u.manuscriptId)
+	return []string{u.volumeId}
+}
+
+// The event has no pages, which means that the page range was removed.
+func (u *singleUpdateVolumeRemovePageRange) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return ba.AddEvent(
+		model.AlexandriaPrefix+model.EV_TYPE_VOLUME_PAGE_RANGE,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.volumeId,
+			},
+			{
+				Key:   model.EV_KEY_MANUSCRIPT_ID,
+				Value: u.manuscriptId,
+			},
+		}, []byte{})
+}
+
+func removePageRange(pageRanges []*model.PageRange, manuscriptId string) []*model.PageRange {
+	result := make([]*model.PageRange, 0, len(pageRanges))
+	for _, pr := range pageRanges {
+		if pr.ManuscriptId != manuscriptId {
+			result = append(result, pr)
+		}
+	}
+	return result
+}
+
 type singleUpdateManuscriptUpdate struct {
 	manuscriptId string
 	field        *string
@@ -1487,3 +1824,883 @@ func (u *singleUpdateManuscriptUpdate) issueEvent(eventSeq int32, transactionId
 			},
 		}, []byte{})
 }
+
+func (nbce *nonBootstrapCommandExecution) checkManuscriptWithdraw(
+	c *model.CommandManuscriptWithdraw) (*updater, error) {
+	expectedPrice := nbce.unmarshalledState.settings.PriceList.PriceAuthorWithdrawManuscript
+	if nbce.price != expectedPrice {
+		return nil, formatPriceError("PriceAuthorWithdrawManuscript", expectedPrice)
+	}
+	if err := checkSanityManuscriptWithdraw(c); err != nil {
+		return nil, err
+	}
+	err := nbce.readAndCheckAddresses(
+		append(getManuscriptIds(c.ThreadReference), c.ManuscriptId),
+		[]string{})
+	if err != nil {
+		return nil, err
+	}
+	manuscript := nbce.unmarshalledState.manuscripts[c.ManuscriptId]
+	err = nbce.readAndCheckAddresses([]string{manuscript.ThreadId}, []string{})
+	if err != nil {
+		return nil, err
+	}
+	err = nbce.checkThreadReference(manuscript.ThreadId, c.ThreadReference)
+	if err != nil {
+		return nil, err
+	}
+	if c.ThreadReference[len(c.ThreadReference)-1].ManuscriptId != c.ManuscriptId {
+		return nil, errors.New("You can only withdraw the last manuscript of its thread")
+	}
+	if err = nbce.checkSignerIsSignedAuthor(c.ManuscriptId); err != nil {
+		return nil, err
+	}
+	updates := []singleUpdate{}
+	for _, threadReferenceItem := range c.ThreadReference {
+		switch threadReferenceItem.ManuscriptStatus {
+		case model.ManuscriptStatus_published, model.ManuscriptStatus_assigned:
+			return nil, errors.New(fmt.Sprintf("Cannot withdraw, because manuscript %s has status %s",
+				threadReferenceItem.ManuscriptId, model.GetManuscriptStatusString(threadReferenceItem.ManuscriptStatus)))
+		case model.ManuscriptStatus_withdrawn:
+			return nil, errors.New("Manuscript was already withdrawn: " + threadReferenceItem.ManuscriptId)
+		}
+		updates = append(updates,
+			&singleUpdateManuscriptUpdateStatus{
+				manuscriptId: threadReferenceItem.ManuscriptId,
+				newStatus:    model.ManuscriptStatus_withdrawn,
+				timestamp:    nbce.timestamp,
+			},
+			&singleUpdateManuscriptModificationTime{
+				id:        threadReferenceItem.ManuscriptId,
+				timestamp: nbce.timestamp,
+			})
+	}
+	updates = append(updates, &singleUpdateManuscriptUpdate{
+		manuscriptId: c.ManuscriptId,
+		field:        &manuscript.WithdrawalMsg,
+		eventKey:     model.EV_KEY_MANUSCRIPT_WITHDRAWAL_MSG,
+		value:        c.CommitMsg,
+		timestamp:    nbce.timestamp,
+	})
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates:           updates,
+	}, nil
+}
+
+func checkSanityManuscriptWithdraw(c *model.CommandManuscriptWithdraw) error {
+	if !model.IsManuscriptAddress(c.ManuscriptId) {
+		return errors.New("Not a manuscript: " + c.ManuscriptId)
+	}
+	if c.CommitMsg == "" {
+		return errors.New("The commit message is mandatory when withdrawing a manuscript")
+	}
+	if len(c.ThreadReference) == 0 {
+		return errors.New("ThreadReference should not be empty")
+	}
+	for _, item := range c.ThreadReference {
+		if !model.IsManuscriptAddress(item.ManuscriptId) {
+			return errors.New("Not a manuscript: " + item.ManuscriptId)
+		}
+		if item.ManuscriptStatus < model.ManuscriptStatus(model.MinManuscriptStatus) ||
+			item.ManuscriptStatus > model.ManuscriptStatus(model.MaxManuscriptStatus) {
+			return errors.New(fmt.Sprintf("ManuscriptStatus out of range: %d", item.ManuscriptStatus))
+		}
+	}
+	return nil
+}
+
+func (nbce *nonBootstrapCommandExecution) checkSignerIsSignedAuthor(manuscriptId string) error {
+	for _, a := range nbce.unmarshalledState.manuscripts[manuscriptId].Author {
+		if a.AuthorId == nbce.verifiedSignerId && a.DidSign {
+			return nil
+		}
+	}
+	return errors.New("You are not a signed author of manuscript " + manuscriptId)
+}
+
+func GetCommandReviewerInvite(
+	manuscriptId string,
+	journalId string,
+	reviewerId string,
+	signerId string,
+	cryptoIdentity *CryptoIdentity,
+	price int32) (*Command, string) {
+	invitationId := model.CreateReviewInvitationAddress()
+	return &Command{
+		InputAddresses:  getInputAddresses(signerId, manuscriptId, journalId, reviewerId, invitationId),
+		OutputAddresses: getOutputAddresses(signerId, price, invitationId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signerId,
+			Price:     price,
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandReviewerInvite{
+				CommandReviewerInvite: &model.CommandReviewerInvite{
+					InvitationId: invitationId,
+					ManuscriptId: manuscriptId,
+					ReviewerId:   reviewerId,
+				},
+			},
+		},
+	}, invitationId
+}
+
+func GetCommandReviewInvitationAccept(
+	invitationId string,
+	signerId string,
+	cryptoIdentity *CryptoIdentity) *Command {
+	return &Command{
+		InputAddresses:  getInputAddresses(signerId, invitationId),
+		OutputAddresses: getOutputAddresses(signerId, int32(0), invitationId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signerId,
+			Price:     int32(0),
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandReviewInvitationAccept{
+				CommandReviewInvitationAccept: &model.CommandReviewInvitationAccept{
+					InvitationId: invitationId,
+				},
+			},
+		},
+	}
+}
+
+func GetCommandReviewInvitationDecline(
+	invitationId string,
+	signerId string,
+	cryptoIdentity *CryptoIdentity) *Command {
+	return &Command{
+		InputAddresses:  getInputAddresses(signerId, invitationId),
+		OutputAddresses: getOutputAddresses(signerId, int32(0), invitationId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signerId,
+			Price:     int32(0),
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandReviewInvitationDecline{
+				CommandReviewInvitationDecline: &model.CommandReviewInvitationDecline{
+					InvitationId: invitationId,
+				},
+			},
+		},
+	}
+}
+
+func (nbce *nonBootstrapCommandExecution) checkReviewerInvite(
+	c *model.CommandReviewerInvite) (*updater, error) {
+	expectedPrice := nbce.unmarshalledState.settings.PriceList.PriceEditorInviteReviewer
+	if nbce.price != expectedPrice {
+		return nil, formatPriceError("PriceEditorInviteReviewer", expectedPrice)
+	}
+	if err := checkSanityReviewerInvite(c); err != nil {
+		return nil, err
+	}
+	err := nbce.readAndCheckAddresses(
+		[]string{c.ManuscriptId, c.ReviewerId},
+		[]string{c.InvitationId})
+	if err != nil {
+		return nil, err
+	}
+	manuscript := nbce.unmarshalledState.manuscripts[c.ManuscriptId]
+	if manuscript.Status == model.ManuscriptStatus_withdrawn {
+		return nil, errors.New("Manuscript was withdrawn: " + c.ManuscriptId)
+	}
+	err = nbce.readAndCheckAddresses([]string{manuscript.JournalId}, []string{})
+	if err != nil {
+		return nil, err
+	}
+	if err = nbce.checkJournalNotArchived(manuscript.JournalId); err != nil {
+		return nil, err
+	}
+	if !nbce.signerIsEditor(manuscript.JournalId, []model.EditorState{model.EditorState_editorAccepted}) {
+		return nil, errors.New("You are not an accepted editor of journal " + manuscript.JournalId)
+	}
+	for _, a := range manuscript.Author {
+		if a.AuthorId == c.ReviewerId {
+			return nil, errors.New("An author of the manuscript cannot be invited to review it: " + c.ReviewerId)
+		}
+	}
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates: []singleUpdate{
+			&singleUpdateReviewInvitationCreate{
+				invitationId: c.InvitationId,
+				manuscriptId: c.ManuscriptId,
+				threadId:     manuscript.ThreadId,
+				journalId:    manuscript.JournalId,
+				reviewerId:   c.ReviewerId,
+				editorId:     nbce.verifiedSignerId,
+				timestamp:    nbce.timestamp,
+			},
+		},
+	}, nil
+}
+
+func checkSanityReviewerInvite(c *model.CommandReviewerInvite) error {
+	if !model.IsReviewInvitationAddress(c.InvitationId) {
+		return errors.New("Not a review invitation address: " + c.InvitationId)
+	}
+	if !model.IsManuscriptAddress(c.ManuscriptId) {
+		return errors.New("Not a manuscript address: " + c.ManuscriptId)
+	}
+	if !model.IsPersonAddress(c.ReviewerId) {
+		return errors.New("Not a person address: " + c.ReviewerId)
+	}
+	return nil
+}
+
+type singleUpdateReviewInvitationCreate struct {
+	invitationId string
+	manuscriptId string
+	threadId     string
+	journalId    string
+	reviewerId   string
+	editorId     string
+	timestamp    int64
+}
+
+var _ singleUpdate = new(singleUpdateReviewInvitationCreate)
+
+func (u *singleUpdateReviewInvitationCreate) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	state.reviewInvitations[u.invitationId] = &model.StateReviewInvitation{
+		Id:              u.invitationId,
+		CreatedOn:       u.timestamp,
+		ModifiedOn:      u.timestamp,
+		ManuscriptId:    u.manuscriptId,
+		ThreadId:        u.threadId,
+		JournalId:       u.journalId,
+		ReviewerId:      u.reviewerId,
+		EditorId:        u.editorId,
+		InvitationState: model.ReviewInvitationState_invitationOpen,
+	}
+	return []string{u.invitationId}
+}
+
+func (u *singleUpdateReviewInvitationCreate) issueEvent(
+	eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return ba.AddEvent(
+		model.AlexandriaPrefix+model.EV_TYPE_REVIEW_INVITATION_CREATE,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.invitationId,
+			},
+			{
+				Key:   model.EV_KEY_MANUSCRIPT_ID,
+				Value: u.manuscriptId,
+			},
+			{
+				Key:   model.EV_KEY_MANUSCRIPT_THREAD_ID,
+				Value: u.threadId,
+			},
+			{
+				Key:   model.EV_KEY_JOURNAL_ID,
+				Value: u.journalId,
+			},
+			{
+				Key:   model.EV_KEY_REVIEW_INVITATION_REVIEWER_ID,
+				Value: u.reviewerId,
+			},
+			{
+				Key:   model.EV_KEY_REVIEW_INVITATION_EDITOR_ID,
+				Value: u.editorId,
+			},
+			{
+				Key:   model.EV_KEY_REVIEW_INVITATION_STATE,
+				Value: model.GetReviewInvitationStateString(model.ReviewInvitationState_invitationOpen),
+			},
+		}, []byte{})
+}
+
+// checkReviewInvitationAnswer checks accepting or declining a review
+// invitation. Like resigning as editor, this has no price.
+func (nbce *nonBootstrapCommandExecution) checkReviewInvitationAnswer(
+	invitationId string, newState model.ReviewInvitationState) (*updater, error) {
+	expectedPrice := int32(0)
+	if nbce.price != expectedPrice {
+		return nil, formatPriceError("<No price>", expectedPrice)
+	}
+	if !model.IsReviewInvitationAddress(invitationId) {
+		return nil, errors.New("Not a review invitation address: " + invitationId)
+	}
+	err := nbce.readAndCheckAddresses([]string{invitationId}, []string{})
+	if err != nil {
+		return nil, err
+	}
+	invitation := nbce.unmarshalledState.reviewInvitations[invitationId]
+	if invitation.ReviewerId != nbce.verifiedSignerId {
+		return nil, errors.New("You are not the invited reviewer of invitation " + invitationId)
+	}
+	if invitation.InvitationState != model.ReviewInvitationState_invitationOpen {
+		return nil, errors.New(fmt.Sprintf("Invitation %s was answered already, state is %s",
+			invitationId, model.GetReviewInvitationStateString(invitation.InvitationState)))
+	}
+	updates := []singleUpdate{
+		&singleUpdateReviewInvitationUpdateState{
+			invitationId: invitationId,
+			newState:     newState,
+			timestamp:    nbce.timestamp,
+		},
+	}
+	updates = nbce.addSingleUpdateReviewInvitationModificationTimeIfNeeded(updates, invitationId)
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates:           updates,
+	}, nil
+}
+
+type singleUpdateReviewInvitationUpdateState struct {
+	invitationId string
+	newState     model.ReviewInvitationState
+	timestamp    int64
+}
+
+var _ singleUpdate = new(singleUpdateReviewInvitationUpdateState)
+
+func (u *singleUpdateReviewInvitationUpdateState) updateState(
+	state *unmarshalledState) (writtenAddresses []string) {
+	state.reviewInvitations[u.invitationId].InvitationState = u.newState
+	return []string{u.invitationId}
+}
+
+func (u *singleUpdateReviewInvitationUpdateState) issueEvent(
+	eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return ba.AddEvent(
+		model.AlexandriaPrefix+model.EV_TYPE_REVIEW_INVITATION_UPDATE,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.invitationId,
+			},
+			{
+				Key:   model.EV_KEY_REVIEW_INVITATION_STATE,
+				Value: model.GetReviewInvitationStateString(u.newState),
+			},
+		}, []byte{})
+}
+
+// GetCommandWriteBlindReview creates a review that does not show its
+// author. The command is signed with a new key that is used only for this
+// review. The reviewer authorizes that key with their own key, but only
+// the hash of that authorization is in the command. The returned secret
+// is needed to reveal the review, only the reviewer should have it.
+func GetCommandWriteBlindReview(
+	reviewCreate *ReviewCreate,
+	judgement model.Judgement,
+	reviewerId string,
+	reviewer *CryptoIdentity) (*Command, *dao.BlindReviewSecret) {
+	reviewId := model.CreateReviewAddress()
+	reviewIdentity := createCryptoIdentity()
+	context := signing.CreateContext(reviewer.PrivateKey.GetAlgorithmName())
+	authorization := hex.EncodeToString(context.Sign(
+		model.GetReviewAuthorizationMessage(reviewId, reviewIdentity.PublicKeyStr), reviewer.PrivateKey))
+	secret := &dao.BlindReviewSecret{
+		ReviewId:      reviewId,
+		ReviewerId:    reviewerId,
+		Salt:          model.CreateSalt(),
+		Authorization: authorization,
+	}
+	return &Command{
+		InputAddresses: uniqueAddresses(
+			append([]string{model.GetSettingsAddress()}, reviewCreate.getInputAddresses(reviewId)...)),
+		OutputAddresses: []string{reviewId},
+		CryptoIdentity:  reviewIdentity,
+		Command: &model.Command{
+			Price:     int32(0),
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandWriteBlindReview{
+				CommandWriteBlindReview: &model.CommandWriteBlindReview{
+					ReviewId:           reviewId,
+					ManuscriptId:       reviewCreate.ManuscriptId,
+					Hash:               model.HashBytes(reviewCreate.TheReview),
+					Judgement:          judgement,
+					ReviewerCommitment: model.GetReviewerCommitment(reviewerId, secret.Salt),
+					ReviewKey:          reviewIdentity.PublicKeyStr,
+					SealedProof:        model.GetReviewSealedProof(authorization),
+				},
+			},
+		},
+	}, secret
+}
+
+func createCryptoIdentity() *CryptoIdentity {
+	context := signing.NewSecp256k1Context()
+	privateKey := context.NewRandomPrivateKey()
+	publicKey := context.GetPublicKey(privateKey)
+	return &CryptoIdentity{
+		PublicKeyStr: publicKey.AsHex(),
+		PublicKey:    publicKey,
+		PrivateKey:   privateKey,
+	}
+}
+
+// GetCommandReviewReveal reveals that the reviewer wrote a blind review.
+// The manuscript is the subject of the review.
+func GetCommandReviewReveal(
+	secret *dao.BlindReviewSecret,
+	manuscriptId string,
+	cryptoIdentity *CryptoIdentity,
+	price int32) *Command {
+	return &Command{
+		InputAddresses:  getInputAddresses(secret.ReviewerId, secret.ReviewId, manuscriptId),
+		OutputAddresses: getOutputAddresses(secret.ReviewerId, price, secret.ReviewId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    secret.ReviewerId,
+			Price:     price,
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandReviewReveal{
+				CommandReviewReveal: &model.CommandReviewReveal{
+					ReviewId:      secret.ReviewId,
+					Salt:          secret.Salt,
+					Authorization: secret.Authorization,
+				},
+			},
+		},
+	}
+}
+
+func (nbce *nonBootstrapCommandExecution) checkWriteBlindReview(c *model.CommandWriteBlindReview) (
+	*updater, error) {
+	if err := checkSanityWriteBlindReview(c); err != nil {
+		return nil, err
+	}
+	err := nbce.readAndCheckAddresses(
+		[]string{c.ManuscriptId},
+		[]string{c.ReviewId})
+	if err != nil {
+		return nil, err
+	}
+	manuscript := nbce.unmarshalledState.manuscripts[c.ManuscriptId]
+	if !statusAllowsReview(manuscript.Status) {
+		return nil, errors.New(fmt.Sprintf("Reviews are not allowed yet because manuscript %s has status %s",
+			c.ManuscriptId, model.GetManuscriptStatusString(manuscript.Status)))
+	}
+	err = nbce.readAndCheckAddresses([]string{manuscript.JournalId}, []string{})
+	if err != nil {
+		return nil, err
+	}
+	if err = nbce.checkJournalNotArchived(manuscript.JournalId); err != nil {
+		return nil, err
+	}
+	if nbce.unmarshalledState.journals[manuscript.JournalId].ReviewByInvitationOnly {
+		return nil, errors.New(
+			"Journal only accepts reviews of invited reviewers, which cannot be blind: " + manuscript.JournalId)
+	}
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates: []singleUpdate{
+			&singleUpdateWriteBlindReview{
+				c:         c,
+				timestamp: nbce.timestamp,
+			},
+		},
+	}, nil
+}
+
+func checkSanityWriteBlindReview(c *model.CommandWriteBlindReview) error {
+	if !model.IsReviewAddress(c.ReviewId) {
+		return errors.New("Not a review address: " + c.ReviewId)
+	}
+	if !model.IsManuscriptAddress(c.ManuscriptId) {
+		return errors.New("Not a manuscript address: " + c.ManuscriptId)
+	}
+	if c.Hash == "" {
+		return errors.New("Hash should not be omitted")
+	}
+	if int32(c.Judgement) < model.MinJudgement || int32(c.Judgement) > model.MaxJudgement {
+		return errors.New("Invalid judgement value")
+	}
+	if c.ReviewerCommitment == "" {
+		return errors.New("ReviewerCommitment should not be omitted")
+	}
+	if c.SealedProof == "" {
+		return errors.New("SealedProof should not be omitted")
+	}
+	return nil
+}
+
+type singleUpdateWriteBlindReview struct {
+	c         *model.CommandWriteBlindReview
+	timestamp int64
+}
+
+var _ singleUpdate = new(singleUpdateWriteBlindReview)
+
+func (u *singleUpdateWriteBlindReview) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	state.reviews[u.c.ReviewId] = &model.StateReview{
+		Id:                 u.c.ReviewId,
+		CreatedOn:          u.timestamp,
+		ManuscriptId:       u.c.ManuscriptId,
+		Hash:               u.c.Hash,
+		Judgement:          u.c.Judgement,
+		IsBlind:            true,
+		ReviewerCommitment: u.c.ReviewerCommitment,
+		ReviewKey:          u.c.ReviewKey,
+		SealedProof:        u.c.SealedProof,
+	}
+	return []string{u.c.ReviewId}
+}
+
+func (u *singleUpdateWriteBlindReview) issueEvent(
+	eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return ba.AddEvent(
+		model.AlexandriaPrefix+model.EV_TYPE_REVIEW_CREATE,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.c.ReviewId,
+			},
+			{
+				Key:   model.EV_KEY_MANUSCRIPT_ID,
+				Value: u.c.ManuscriptId,
+			},
+			{
+				Key:   model.EV_KEY_REVIEW_HASH,
+				Value: u.c.Hash,
+			},
+			{
+				Key:   model.EV_KEY_REVIEW_JUDGEMENT,
+				Value: model.GetJudgementString(u.c.Judgement),
+			},
+			{
+				Key:   model.EV_KEY_REVIEW_IS_BLIND,
+				Value: "true",
+			},
+		}, []byte{})
+}
+
+func (nbce *nonBootstrapCommandExecution) checkReviewReveal(c *model.CommandReviewReveal) (*updater, error) {
+	expectedPrice := nbce.unmarshalledState.settings.PriceList.PriceReviewerSubmit
+	if nbce.price != expectedPrice {
+		return nil, formatPriceError("PriceReviewerSubmit", expectedPrice)
+	}
+	if !model.IsReviewAddress(c.ReviewId) {
+		return nil, errors.New("Not a review address: " + c.ReviewId)
+	}
+	err := nbce.readAndCheckAddresses([]string{c.ReviewId}, []string{})
+	if err != nil {
+		return nil, err
+	}
+	review := nbce.unmarshalledState.reviews[c.ReviewId]
+	if !review.IsBlind {
+		return nil, errors.New("Review is not blind: " + c.ReviewId)
+	}
+	if review.ReviewAuthorId != "" {
+		return nil, errors.New("Review was revealed already: " + c.ReviewId)
+	}
+	err = nbce.readAndCheckAddresses([]string{review.ManuscriptId}, []string{})
+	if err != nil {
+		return nil, err
+	}
+	status := nbce.unmarshalledState.manuscripts[review.ManuscriptId].Status
+	if !statusIsJudged(status) {
+		return nil, errors.New(fmt.Sprintf(
+			"A blind review can only be revealed after judging the manuscript, manuscript %s has status %s",
+			review.ManuscriptId, model.GetManuscriptStatusString(status)))
+	}
+	if model.GetReviewerCommitment(nbce.verifiedSignerId, c.Salt) != review.ReviewerCommitment {
+		return nil, errors.New("You are not the author of review " + c.ReviewId)
+	}
+	if model.GetReviewSealedProof(c.Authorization) != review.SealedProof {
+		return nil, errors.New("Authorization does not match the sealed proof of review " + c.ReviewId)
+	}
+	err = checkReviewAuthorization(
+		c.Authorization,
+		model.GetReviewAuthorizationMessage(c.ReviewId, review.ReviewKey),
+		nbce.unmarshalledState.persons[nbce.verifiedSignerId].PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates: []singleUpdate{
+			&singleUpdateReviewReveal{
+				reviewId:       c.ReviewId,
+				reviewAuthorId: nbce.verifiedSignerId,
+				timestamp:      nbce.timestamp,
+			},
+		},
+	}, nil
+}
+
+func statusIsJudged(status model.ManuscriptStatus) bool {
+	return status == model.ManuscriptStatus_rejected ||
+		status == model.ManuscriptStatus_published ||
+		status == model.ManuscriptStatus_assigned
+}
+
+func checkReviewAuthorization(authorization string, message []byte, publicKeyHex string) error {
+	signature, err := hex.DecodeString(authorization)
+	if err != nil {
+		return errors.New("Authorization is not hexadecimal: " + err.Error())
+	}
+	publicKeyBytes, err := hex.DecodeString(publicKeyHex)
+	if err != nil {
+		return errors.New("Public key of signer is not hexadecimal: " + err.Error())
+	}
+	context := signing.NewSecp256k1Context()
+	if !context.Verify(signature, message, signing.NewSecp256k1PublicKey(publicKeyBytes)) {
+		return errors.New("Authorization is not signed with your key")
+	}
+	return nil
+}
+
+type singleUpdateReviewReveal struct {
+	reviewId       string
+	reviewAuthorId string
+	timestamp      int64
+}
+
+var _ singleUpdate = new(singleUpdateReviewReveal)
+
+func (u *singleUpdateReviewReveal) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	state.reviews[u.reviewId].ReviewAuthorId = u.reviewAuthorId
+	return []string{u.reviewId}
+}
+
+func (u *singleUpdateReviewReveal) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return ba.AddEvent(
+		model.AlexandriaPrefix+model.EV_TYPE_REVIEW_REVEAL,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.reviewId,
+			},
+			{
+				Key:   model.EV_KEY_REVIEW_AUTHOR_ID,
+				Value: u.reviewAuthorId,
+			},
+		}, []byte{})
+}
+
+// GetCommandReviewRevise replaces a review by a new revision. The
+// revision can be about a later version of the manuscript.
+func GetCommandReviewRevise(
+	reviewRevise *ReviewRevise,
+	judgement model.Judgement,
+	signerId string,
+	cryptoIdentity *CryptoIdentity,
+	price int32) (*Command, string) {
+	reviewId := model.CreateReviewAddress()
+	hash := model.HashBytes(reviewRevise.TheReview)
+	return &Command{
+		InputAddresses: getInputAddresses(signerId,
+			reviewId,
+			reviewRevise.PreviousReviewId,
+			reviewRevise.ManuscriptId,
+			reviewRevise.PreviousManuscriptId,
+			reviewRevise.JournalId),
+		OutputAddresses: getOutputAddresses(signerId, price, reviewId, reviewRevise.PreviousReviewId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signerId,
+			Price:     price,
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandReviewRevise{
+				CommandReviewRevise: &model.CommandReviewRevise{
+					ReviewId:         reviewId,
+					PreviousReviewId: reviewRevise.PreviousReviewId,
+					ManuscriptId:     reviewRevise.ManuscriptId,
+					Hash:             hash,
+					Judgement:        judgement,
+				},
+			},
+		},
+	}, reviewId
+}
+
+// ReviewRevise holds the data of a new revision of a review.
+// PreviousManuscriptId is the manuscript of the previous revision,
+// ManuscriptId is the manuscript of the new revision. JournalId is
+// the journal of these manuscripts.
+type ReviewRevise struct {
+	PreviousReviewId     string
+	PreviousManuscriptId string
+	ManuscriptId         string
+	JournalId            string
+	TheReview            []byte
+}
+
+func (nbce *nonBootstrapCommandExecution) checkReviewRevise(c *model.CommandReviewRevise) (*updater, error) {
+	expectedPrice := nbce.unmarshalledState.settings.PriceList.PriceReviewerSubmit
+	if nbce.price != expectedPrice {
+		return nil, formatPriceError("PriceReviewerSubmit", expectedPrice)
+	}
+	if err := checkSanityReviewRevise(c); err != nil {
+		return nil, err
+	}
+	err := nbce.readAndCheckAddresses(
+		[]string{c.PreviousReviewId, c.ManuscriptId},
+		[]string{c.ReviewId})
+	if err != nil {
+		return nil, err
+	}
+	previousReview := nbce.unmarshalledState.reviews[c.PreviousReviewId]
+	if previousReview.IsBlind {
+		return nil, errors.New("A blind review cannot be revised: " + c.PreviousReviewId)
+	}
+	if previousReview.ReviewAuthorId != nbce.verifiedSignerId {
+		return nil, errors.New("You are not the author of review " + c.PreviousReviewId)
+	}
+	if previousReview.NextReviewId != "" {
+		return nil, errors.New(fmt.Sprintf("Review %s has been revised already by review %s",
+			c.PreviousReviewId, previousReview.NextReviewId))
+	}
+	if previousReview.IsUsedByEditor {
+		return nil, errors.New(fmt.Sprintf(
+			"Review %s cannot be revised because the editor used it to judge the manuscript",
+			c.PreviousReviewId))
+	}
+	err = nbce.readAndCheckAddresses([]string{previousReview.ManuscriptId}, []string{})
+	if err != nil {
+		return nil, err
+	}
+	manuscript := nbce.unmarshalledState.manuscripts[c.ManuscriptId]
+	if manuscript.ThreadId != nbce.unmarshalledState.manuscripts[previousReview.ManuscriptId].ThreadId {
+		return nil, errors.New(fmt.Sprintf("Manuscript %s is not a version of reviewed manuscript %s",
+			c.ManuscriptId, previousReview.ManuscriptId))
+	}
+	if !statusAllowsReview(manuscript.Status) {
+		return nil, errors.New(fmt.Sprintf("Reviews are not allowed yet because manuscript %s has status %s",
+			c.ManuscriptId, model.GetManuscriptStatusString(manuscript.Status)))
+	}
+	err = nbce.readAndCheckAddresses([]string{manuscript.JournalId}, []string{})
+	if err != nil {
+		return nil, err
+	}
+	if err = nbce.checkJournalNotArchived(manuscript.JournalId); err != nil {
+		return nil, err
+	}
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates: []singleUpdate{
+			&singleUpdateWriteReview{
+				signerId: nbce.verifiedSignerId,
+				c: &model.CommandWriteReview{
+					ReviewId:     c.ReviewId,
+					ManuscriptId: c.ManuscriptId,
+					Hash:         c.Hash,
+					Judgement:    c.Judgement,
+					InvitationId: previousReview.InvitationId,
+				},
+				previousReviewId: c.PreviousReviewId,
+				timestamp:        nbce.timestamp,
+			},
+			&singleUpdateReviewSupersede{
+				reviewId:     c.PreviousReviewId,
+				nextReviewId: c.ReviewId,
+				timestamp:    nbce.timestamp,
+			},
+		},
+	}, nil
+}
+
+func checkSanityReviewRevise(c *model.CommandReviewRevise) error {
+	if !model.IsReviewAddress(c.ReviewId) {
+		return errors.New("Not a review address: " + c.ReviewId)
+	}
+	if !model.IsReviewAddress(c.PreviousReviewId) {
+		return errors.New("Not a review address: " + c.PreviousReviewId)
+	}
+	if !model.IsManuscriptAddress(c.ManuscriptId) {
+		return errors.New("Not a manuscript address: " + c.ManuscriptId)
+	}
+	if c.Hash == "" {
+		return errors.New("Hash should not be omitted")
+	}
+	if int32(c.Judgement) < model.MinJudgement || int32(c.Judgement) > model.MaxJudgement {
+		return errors.New("Invalid judgement value")
+	}
+	return nil
+}
+
+type singleUpdateReviewSupersede struct {
+	reviewId     string
+	nextReviewId string
+	timestamp    int64
+}
+
+var _ singleUpdate = new(singleUpdateReviewSupersede)
+
+func (u *singleUpdateReviewSupersede) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	state.reviews[u.reviewId].NextReviewId = u.nextReviewId
+	return []string{u.reviewId}
+}
+
+func (u *singleUpdateReviewSupersede) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	return ba.AddEvent(
+		model.AlexandriaPrefix+model.EV_TYPE_REVIEW_SUPERSEDE,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.reviewId,
+			},
+			{
+				Key:   model.EV_KEY_REVIEW_NEXT_ID,
+				Value: u.nextReviewId,
+			},
+		}, []byte{})
+}
@@ -154,3 +154,39 @@ func TestGetBlockchainSignedHistoricAuthors(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckPageRange(t *testing.T) {
+	volume := &model.StateVolume{
+		PageRange: []*model.PageRange{
+			{
+				ManuscriptId: "other",
+				FirstPage:    10,
+				LastPage:     20,
+			},
+		},
+	}
+	cases := []struct {
+		firstPage     string
+		lastPage      string
+		expectedValid bool
+	}{
+		{"1", "9", true},
+		{"21", "2147483647", true},
+		{"0", "5", false},
+		{"+1", "5", false},
+		{"-1", "5", false},
+		{"1", "+5", false},
+		{"", "5", false},
+		{"1", "3000000000", false},
+		{"3000000000", "3000000001", false},
+		{"5", "4", false},
+		{"5", "15", false},
+		{"1 ", "5", false},
+	}
+	for _, c := range cases {
+		err := CheckPageRange(volume, "manuscript", c.firstPage, c.lastPage)
+		if (err == nil) != c.expectedValid {
+			t.Errorf("Pages %q-%q: expected valid %v, got error %v", c.firstPage, c.lastPage, c.expectedValid, err)
+		}
+	}
+}
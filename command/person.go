@@ -6,7 +6,7 @@ import (
 	"github.com/hyperledger/sawtooth-sdk-go/processor"
 	"github.com/iskendria-pub/iskendria/dao"
 	"github.com/iskendria-pub/iskendria/model"
-	"log"
+	"math"
 	"strconv"
 )
 
@@ -23,8 +23,8 @@ func GetPersonCreateCommand(
 	price int32) (*Command, string) {
 	personId := model.CreatePersonAddress()
 	return &Command{
-		InputAddresses:  []string{personId, signerId, model.GetSettingsAddress()},
-		OutputAddresses: []string{personId, signerId},
+		InputAddresses:  getInputAddresses(signerId, personId),
+		OutputAddresses: getOutputAddresses(signerId, price, personId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -50,8 +50,8 @@ func GetPersonUpdatePropertiesCommand(
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
 	return &Command{
-		InputAddresses:  []string{model.GetSettingsAddress(), personId, signerId},
-		OutputAddresses: []string{personId, signerId},
+		InputAddresses:  getInputAddresses(signerId, personId),
+		OutputAddresses: getOutputAddresses(signerId, price, personId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -73,8 +73,8 @@ func GetCommandPersonUpdateBiography(
 	price int32) *Command {
 	updatedDescriptionHash := model.HashBytes(updatedBiography)
 	return &Command{
-		InputAddresses:  []string{model.GetSettingsAddress(), personId, signerId},
-		OutputAddresses: []string{personId, signerId},
+		InputAddresses:  getInputAddresses(signerId, personId),
+		OutputAddresses: getOutputAddresses(signerId, price, personId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -100,8 +100,8 @@ func GetCommandPersonOmitBiography(
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
 	return &Command{
-		InputAddresses:  []string{model.GetSettingsAddress(), personId, signerId},
-		OutputAddresses: []string{personId, signerId},
+		InputAddresses:  getInputAddresses(signerId, personId),
+		OutputAddresses: getOutputAddresses(signerId, price, personId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -175,8 +175,9 @@ func getGenericPersonUpdateAuthorizationCommand(
 	price int32,
 	update *authorizationUpdate) *Command {
 	return &Command{
-		InputAddresses:  []string{model.GetSettingsAddress(), signerId, personId},
-		OutputAddresses: []string{personId, signerId},
+		InputAddresses: getInputAddresses(signerId, personId),
+		// From family version 2, the settings hold the major count
+		OutputAddresses: getOutputAddresses(signerId, price, personId, model.GetSettingsAddress()),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -205,8 +206,8 @@ func GetPersonUpdateIncBalanceCommand(
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
 	return &Command{
-		InputAddresses:  []string{model.GetSettingsAddress(), signerId, personId},
-		OutputAddresses: []string{personId, signerId},
+		InputAddresses:  getInputAddresses(signerId, personId),
+		OutputAddresses: getOutputAddresses(signerId, price, personId),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Signer:    signerId,
@@ -230,12 +231,7 @@ func (nbce *nonBootstrapCommandExecution) checkPersonCreate(c *model.CommandPers
 	if err := checkSanityPersonCreate(c); err != nil {
 		return nil, err
 	}
-	readData, err := nbce.blockchainAccess.GetState([]string{c.NewPersonId})
-	if err != nil {
-		return nil, err
-	}
-	err = nbce.unmarshalledState.add(readData, []string{c.NewPersonId})
-	if err != nil {
+	if err := nbce.readAddresses([]string{c.NewPersonId}); err != nil {
 		return nil, err
 	}
 	if nbce.unmarshalledState.getAddressState(c.NewPersonId) != ADDRESS_EMPTY {
@@ -300,7 +296,6 @@ func (u *singleUpdatePersonCreate) updateState(state *unmarshalledState) (writte
 
 func (u *singleUpdatePersonCreate) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_PERSON_CREATE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(
 		eventType,
 		[]processor.Attribute{
@@ -380,18 +375,11 @@ func (nbce *nonBootstrapCommandExecution) checkPersonUpdatePropertiesAuthorized(
 
 func (nbce *nonBootstrapCommandExecution) readPersonBeingUpdatedIfNotPresent(
 	c *model.CommandPersonUpdateProperties) error {
-	if nbce.verifiedSignerId != c.PersonId {
-		readData, err := nbce.blockchainAccess.GetState([]string{c.PersonId})
-		if err != nil {
-			return err
-		}
-		err = nbce.unmarshalledState.add(readData, []string{c.PersonId})
-		if err != nil {
-			return err
-		}
-		if nbce.unmarshalledState.getAddressState(c.PersonId) != ADDRESS_FILLED {
-			return errors.New("Person being updated does not exist: " + c.PersonId)
-		}
+	if err := nbce.readAddresses([]string{c.PersonId}); err != nil {
+		return err
+	}
+	if nbce.unmarshalledState.getAddressState(c.PersonId) != ADDRESS_FILLED {
+		return errors.New("Person being updated does not exist: " + c.PersonId)
 	}
 	return nil
 }
@@ -414,7 +402,6 @@ func (su singleUpdatePersonPropertyUpdate) updateState(_ *unmarshalledState) (wr
 func (su singleUpdatePersonPropertyUpdate) issueEvent(
 	eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_PERSON_UPDATE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(eventType,
 		[]processor.Attribute{
 			{
@@ -446,30 +433,79 @@ func (nbce *nonBootstrapCommandExecution) checkPersonUpdateAuthorization(
 	if nbce.price != expectedPrice {
 		return nil, formatPriceError("PriceMajorChangePersonAuthorization", expectedPrice)
 	}
+	if c.MakeMajor != model.BoolUpdate_UNMODIFIED {
+		if err := nbce.checkNoApprovalNeeded("Changing major status"); err != nil {
+			return nil, err
+		}
+	}
+	return nbce.checkPersonUpdateAuthorizationWithoutPrice(c)
+}
+
+// checkPersonUpdateAuthorizationWithoutPrice is also used to execute an
+// approved proposal.
+func (nbce *nonBootstrapCommandExecution) checkPersonUpdateAuthorizationWithoutPrice(
+	c *model.CommandPersonUpdateAuthorization) (*updater, error) {
 	if !nbce.unmarshalledState.persons[nbce.verifiedSignerId].IsMajor {
 		return nil, errors.New("Only majors can do this")
 	}
-	data, err := nbce.blockchainAccess.GetState([]string{c.PersonId})
-	if err != nil {
-		return nil, errors.New("Could not read person address: " + c.PersonId)
-	}
-	err = nbce.unmarshalledState.add(data, []string{c.PersonId})
-	if err != nil {
-		return nil, errors.New(fmt.Sprintf("Could not unmarshall person %s, error %s",
-			c.PersonId, err))
+	if err := nbce.readAddresses([]string{c.PersonId}); err != nil {
+		return nil, addErrorContext("Could not read person "+c.PersonId, err)
 	}
 	if nbce.unmarshalledState.getAddressState(c.PersonId) != ADDRESS_FILLED {
 		return nil, errors.New(fmt.Sprintf("Person to modify does not exist: " + c.PersonId))
 	}
 	oldPerson := nbce.unmarshalledState.persons[c.PersonId]
+	majorCountUpdates, err := nbce.createSingleUpdatesMajorCount(c, oldPerson)
+	if err != nil {
+		return nil, err
+	}
 	singleUpdates := createSingleUpdatesPersonUpdateAuthorization(c, oldPerson, nbce.timestamp)
 	singleUpdates = nbce.addSingleUpdatePersonModificationTimeIfNeeded(singleUpdates, oldPerson.Id)
+	singleUpdates = append(singleUpdates, majorCountUpdates...)
 	return &updater{
 		unmarshalledState: nbce.unmarshalledState,
 		updates:           singleUpdates,
 	}, nil
 }
 
+// Once the majors are counted, see countMajors, the major count in the
+// settings follows the actual changes of IsMajor. A revocation that
+// would leave fewer majors than the approval threshold is refused,
+// because no proposal could be executed anymore after it. Until the
+// majors are counted, the approval threshold is zero and there is
+// nothing to check. The major count is bookkeeping, so the modification
+// time of the settings is not updated.
+func (nbce *nonBootstrapCommandExecution) createSingleUpdatesMajorCount(
+	c *model.CommandPersonUpdateAuthorization,
+	oldPerson *model.StatePerson) ([]singleUpdate, error) {
+	settings := nbce.unmarshalledState.settings
+	if !nbce.rules.countMajors || settings.MajorCount == int32(0) {
+		return []singleUpdate{}, nil
+	}
+	newMajorCount := settings.MajorCount
+	switch {
+	case c.MakeMajor == model.BoolUpdate_MAKE_TRUE && !oldPerson.IsMajor:
+		newMajorCount++
+	case c.MakeMajor == model.BoolUpdate_MAKE_FALSE && oldPerson.IsMajor:
+		if newMajorCount-1 < settings.ApprovalThreshold {
+			return nil, errors.New(fmt.Sprintf(
+				"Cannot revoke major, the remaining %d majors would be below the approval threshold %d",
+				newMajorCount-1, settings.ApprovalThreshold))
+		}
+		newMajorCount--
+	default:
+		return []singleUpdate{}, nil
+	}
+	return []singleUpdate{
+		&singleUpdateSettingsUpdate{
+			stateField: &settings.MajorCount,
+			newValue:   newMajorCount,
+			eventKey:   model.EV_KEY_MAJOR_COUNT,
+			timestamp:  nbce.timestamp,
+		},
+	}, nil
+}
+
 func createSingleUpdatesPersonUpdateAuthorization(
 	c *model.CommandPersonUpdateAuthorization,
 	oldPerson *model.StatePerson,
@@ -540,7 +576,6 @@ func (u *singleUpdatePersonAuthorizationUpdate) updateState(*unmarshalledState)
 func (u *singleUpdatePersonAuthorizationUpdate) issueEvent(
 	eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_PERSON_UPDATE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(
 		eventType,
 		[]processor.Attribute{
@@ -572,17 +607,21 @@ func (nbce *nonBootstrapCommandExecution) checkPersonUpdateIncBalance(c *model.C
 	if nbce.price != int32(0) {
 		return nil, formatPriceError("-", int32(0))
 	}
+	if err := nbce.checkNoApprovalNeeded("Incrementing a balance"); err != nil {
+		return nil, err
+	}
+	return nbce.checkPersonUpdateIncBalanceWithoutPrice(c)
+}
+
+// checkPersonUpdateIncBalanceWithoutPrice is also used to execute an
+// approved proposal.
+func (nbce *nonBootstrapCommandExecution) checkPersonUpdateIncBalanceWithoutPrice(
+	c *model.CommandPersonUpdateBalanceIncrement) (*updater, error) {
 	if !nbce.unmarshalledState.persons[nbce.verifiedSignerId].IsMajor {
 		return nil, errors.New("Only majors can increment someone's balance")
 	}
-	data, err := nbce.blockchainAccess.GetState([]string{c.PersonId})
-	if err != nil {
-		return nil, errors.New(fmt.Sprintf(
-			"Could not read person %s: %s", c.PersonId, err.Error()))
-	}
-	err = nbce.unmarshalledState.add(data, []string{c.PersonId})
-	if err != nil {
-		return nil, errors.New("Could not unmarshall person: " + c.PersonId)
+	if err := nbce.readAddresses([]string{c.PersonId}); err != nil {
+		return nil, addErrorContext("Could not read person "+c.PersonId, err)
 	}
 	if nbce.unmarshalledState.getAddressState(c.PersonId) != ADDRESS_FILLED {
 		return nil, errors.New("The person whose balance is to be updated does not exist: " + c.PersonId)
@@ -590,9 +629,11 @@ func (nbce *nonBootstrapCommandExecution) checkPersonUpdateIncBalance(c *model.C
 	oldPerson := nbce.unmarshalledState.persons[c.PersonId]
 	singleUpdates := []singleUpdate{
 		&singleUpdatePersonIncBalance{
-			personId:   c.PersonId,
-			newBalance: oldPerson.Balance + c.BalanceIncrement,
-			timestamp:  nbce.timestamp,
+			personId:    c.PersonId,
+			newBalance:  oldPerson.Balance + c.BalanceIncrement,
+			amount:      c.BalanceIncrement,
+			commandType: nbce.commandType,
+			timestamp:   nbce.timestamp,
 		},
 	}
 	singleUpdates = nbce.addSingleUpdatePersonModificationTimeIfNeeded(singleUpdates, oldPerson.Id)
@@ -602,10 +643,92 @@ func (nbce *nonBootstrapCommandExecution) checkPersonUpdateIncBalance(c *model.C
 	}, nil
 }
 
+func GetCommandBalanceTransfer(
+	toPersonId string,
+	amount int32,
+	signerId string,
+	cryptoIdentity *CryptoIdentity,
+	price int32) *Command {
+	return &Command{
+		InputAddresses:  getInputAddresses(signerId, toPersonId),
+		OutputAddresses: getOutputAddresses(signerId, price, signerId, toPersonId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signerId,
+			Price:     price,
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandBalanceTransfer{
+				CommandBalanceTransfer: &model.CommandBalanceTransfer{
+					ToPersonId: toPersonId,
+					Amount:     amount,
+				},
+			},
+		},
+	}
+}
+
+// checkBalanceTransfer requires price zero, because both balances are set
+// to values that are calculated here. See addBalanceDeduct.
+func (nbce *nonBootstrapCommandExecution) checkBalanceTransfer(c *model.CommandBalanceTransfer) (
+	*updater, error) {
+	if nbce.price != int32(0) {
+		return nil, formatPriceError("-", int32(0))
+	}
+	if c.Amount <= int32(0) {
+		return nil, errors.New(fmt.Sprintf("The amount to transfer should be positive, but was %d", c.Amount))
+	}
+	if c.ToPersonId == nbce.verifiedSignerId {
+		return nil, errors.New("You cannot transfer to yourself")
+	}
+	if !model.IsPersonAddress(c.ToPersonId) {
+		return nil, errors.New("Not a person: " + c.ToPersonId)
+	}
+	if err := nbce.readAddresses([]string{c.ToPersonId}); err != nil {
+		return nil, addErrorContext("Could not read person "+c.ToPersonId, err)
+	}
+	if nbce.unmarshalledState.getAddressState(c.ToPersonId) != ADDRESS_FILLED {
+		return nil, errors.New("The person to transfer to does not exist: " + c.ToPersonId)
+	}
+	payer := nbce.unmarshalledState.persons[nbce.verifiedSignerId]
+	receiver := nbce.unmarshalledState.persons[c.ToPersonId]
+	if payer.Balance < c.Amount {
+		return nil, errors.New(fmt.Sprintf("Insufficient balance, got %d need %d", payer.Balance, c.Amount))
+	}
+	if receiver.Balance > math.MaxInt32-c.Amount {
+		return nil, errors.New(fmt.Sprintf("The balance of person %s would become too large", c.ToPersonId))
+	}
+	singleUpdates := []singleUpdate{
+		&singleUpdatePersonIncBalance{
+			personId:    payer.Id,
+			newBalance:  payer.Balance - c.Amount,
+			amount:      -c.Amount,
+			commandType: nbce.commandType,
+			timestamp:   nbce.timestamp,
+		},
+		&singleUpdatePersonIncBalance{
+			personId:    receiver.Id,
+			newBalance:  receiver.Balance + c.Amount,
+			amount:      c.Amount,
+			commandType: nbce.commandType,
+			timestamp:   nbce.timestamp,
+		},
+	}
+	singleUpdates = nbce.addSingleUpdatePersonModificationTimeIfNeeded(singleUpdates, payer.Id)
+	singleUpdates = nbce.addSingleUpdatePersonModificationTimeIfNeeded(singleUpdates, receiver.Id)
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates:           singleUpdates,
+	}, nil
+}
+
+// The amount and the command type are not needed to update the state.
+// They are put in the event so that clients can keep a balance ledger.
 type singleUpdatePersonIncBalance struct {
-	personId   string
-	newBalance int32
-	timestamp  int64
+	personId    string
+	newBalance  int32
+	amount      int32
+	commandType string
+	timestamp   int64
 }
 
 var _ singleUpdate = new(singleUpdatePersonIncBalance)
@@ -617,7 +740,6 @@ func (u *singleUpdatePersonIncBalance) updateState(state *unmarshalledState) (wr
 
 func (u *singleUpdatePersonIncBalance) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_PERSON_UPDATE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(
 		eventType,
 		[]processor.Attribute{
@@ -641,6 +763,14 @@ func (u *singleUpdatePersonIncBalance) issueEvent(eventSeq int32, transactionId
 				Key:   model.EV_KEY_PERSON_BALANCE,
 				Value: fmt.Sprintf("%d", u.newBalance),
 			},
+			{
+				Key:   model.EV_KEY_PERSON_BALANCE_AMOUNT,
+				Value: fmt.Sprintf("%d", u.amount),
+			},
+			{
+				Key:   model.EV_KEY_PERSON_COMMAND_TYPE,
+				Value: u.commandType,
+			},
 		},
 		[]byte{})
 }
@@ -0,0 +1,549 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hyperledger/sawtooth-sdk-go/processor"
+	"github.com/iskendria-pub/iskendria/model"
+	"reflect"
+	"strings"
+)
+
+// GetCommandProposalCreate wraps a governance command in a proposal. The
+// governed command is created with its usual constructor, for example
+// GetSettingsUpdateCommand. Its signer, its price and its addresses are
+// those of the proposal. The second return value is the id of the new
+// proposal.
+func GetCommandProposalCreate(governed *Command, expiresOn int64) (*Command, string, error) {
+	proposedCommand, err := GetProposedCommand(governed.Command)
+	if err != nil {
+		return nil, "", err
+	}
+	proposalId := model.CreateProposalAddress()
+	return &Command{
+		InputAddresses:  uniqueAddresses(append(governed.InputAddresses, proposalId)),
+		OutputAddresses: uniqueAddresses(append(governed.OutputAddresses, proposalId)),
+		CryptoIdentity:  governed.CryptoIdentity,
+		Command: &model.Command{
+			Signer:    governed.Command.Signer,
+			Price:     governed.Command.Price,
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandProposalCreate{
+				CommandProposalCreate: &model.CommandProposalCreate{
+					ProposalId:      proposalId,
+					ProposedCommand: proposedCommand,
+					ExpiresOn:       expiresOn,
+				},
+			},
+		},
+	}, proposalId, nil
+}
+
+func GetProposedCommand(c *model.Command) (*model.ProposedCommand, error) {
+	switch body := c.Body.(type) {
+	case *model.Command_CommandSettingsUpdate:
+		return &model.ProposedCommand{
+			Body: &model.ProposedCommand_CommandSettingsUpdate{
+				CommandSettingsUpdate: body.CommandSettingsUpdate,
+			},
+		}, nil
+	case *model.Command_CommandUpdateAuthorization:
+		return &model.ProposedCommand{
+			Body: &model.ProposedCommand_CommandUpdateAuthorization{
+				CommandUpdateAuthorization: body.CommandUpdateAuthorization,
+			},
+		}, nil
+	case *model.Command_CommandPersonUpdateBalanceIncrement:
+		return &model.ProposedCommand{
+			Body: &model.ProposedCommand_CommandPersonUpdateBalanceIncrement{
+				CommandPersonUpdateBalanceIncrement: body.CommandPersonUpdateBalanceIncrement,
+			},
+		}, nil
+	}
+	return nil, errors.New("Only settings updates, authorization updates and balance increments can be proposed")
+}
+
+// GetCommandProposalApprove needs the target of the proposal, see
+// dao.Proposal. The approval that reaches the approval threshold executes
+// the proposed command, which writes the settings or the target person.
+// Changing a major also writes the major count in the settings.
+func GetCommandProposalApprove(
+	proposalId string,
+	targetId string,
+	signer string,
+	cryptoIdentity *CryptoIdentity) *Command {
+	governedAddress := targetId
+	if governedAddress == "" {
+		governedAddress = model.GetSettingsAddress()
+	}
+	return &Command{
+		InputAddresses:  getInputAddresses(signer, proposalId, governedAddress),
+		OutputAddresses: getOutputAddresses(signer, int32(0), proposalId, governedAddress, model.GetSettingsAddress()),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signer,
+			Price:     int32(0),
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandProposalApprove{
+				CommandProposalApprove: &model.CommandProposalApprove{
+					ProposalId: proposalId,
+				},
+			},
+		},
+	}
+}
+
+func GetCommandProposalCancel(
+	proposalId string,
+	signer string,
+	cryptoIdentity *CryptoIdentity) *Command {
+	return &Command{
+		InputAddresses:  getInputAddresses(signer, proposalId),
+		OutputAddresses: getOutputAddresses(signer, int32(0), proposalId),
+		CryptoIdentity:  cryptoIdentity,
+		Command: &model.Command{
+			Signer:    signer,
+			Price:     int32(0),
+			Timestamp: model.GetCurrentTime(),
+			Body: &model.Command_CommandProposalCancel{
+				CommandProposalCancel: &model.CommandProposalCancel{
+					ProposalId: proposalId,
+				},
+			},
+		},
+	}
+}
+
+// checkNoApprovalNeeded is called when a governance command is issued
+// directly. When the approval threshold is two or more, such a command
+// has to be proposed and approved by enough majors. Only family version 2
+// can set the threshold, so older versions are not affected.
+func (nbce *nonBootstrapCommandExecution) checkNoApprovalNeeded(what string) error {
+	threshold := nbce.unmarshalledState.settings.ApprovalThreshold
+	if threshold >= 2 {
+		return errors.New(fmt.Sprintf(
+			"%s needs the approval of %d majors, please create a proposal", what, threshold))
+	}
+	return nil
+}
+
+// A threshold of zero means that no approvals are required. The proposer
+// approves by creating the proposal, so that case is the same as one.
+func (nbce *nonBootstrapCommandExecution) getEffectiveApprovalThreshold() int {
+	threshold := int(nbce.unmarshalledState.settings.ApprovalThreshold)
+	if threshold < 1 {
+		return 1
+	}
+	return threshold
+}
+
+// checkProposedCommand checks the proposed command as if the signer
+// issued it directly, but without the price and without the approval
+// threshold. Balance changes are attributed to the proposed command, not
+// to the proposal.
+func (nbce *nonBootstrapCommandExecution) checkProposedCommand(pc *model.ProposedCommand) (*updater, error) {
+	governed := *nbce
+	governed.commandType = getProposedCommandType(pc)
+	switch body := pc.Body.(type) {
+	case *model.ProposedCommand_CommandSettingsUpdate:
+		return governed.checkSettingsUpdateWithoutPrice(body.CommandSettingsUpdate)
+	case *model.ProposedCommand_CommandUpdateAuthorization:
+		return governed.checkPersonUpdateAuthorizationWithoutPrice(body.CommandUpdateAuthorization)
+	case *model.ProposedCommand_CommandPersonUpdateBalanceIncrement:
+		return governed.checkPersonUpdateIncBalanceWithoutPrice(body.CommandPersonUpdateBalanceIncrement)
+	}
+	return nil, errors.New("Proposal does not contain a command that can be proposed")
+}
+
+// getProposedCommandType gives the same names as getCommandType.
+func getProposedCommandType(pc *model.ProposedCommand) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", pc.Body), "*model.ProposedCommand_")
+}
+
+func (nbce *nonBootstrapCommandExecution) getProposedCommandPrice(pc *model.ProposedCommand) (
+	priceName string, price int32) {
+	priceList := nbce.unmarshalledState.settings.PriceList
+	switch pc.Body.(type) {
+	case *model.ProposedCommand_CommandSettingsUpdate:
+		return "PriceMajorEditSettings", priceList.PriceMajorEditSettings
+	case *model.ProposedCommand_CommandUpdateAuthorization:
+		return "PriceMajorChangePersonAuthorization", priceList.PriceMajorChangePersonAuthorization
+	}
+	return "-", int32(0)
+}
+
+func getProposedCommandTargetId(pc *model.ProposedCommand) string {
+	switch body := pc.Body.(type) {
+	case *model.ProposedCommand_CommandUpdateAuthorization:
+		return body.CommandUpdateAuthorization.PersonId
+	case *model.ProposedCommand_CommandPersonUpdateBalanceIncrement:
+		return body.CommandPersonUpdateBalanceIncrement.PersonId
+	}
+	return ""
+}
+
+// describeProposedCommand gives the text that majors read before they
+// approve a proposal.
+func describeProposedCommand(pc *model.ProposedCommand) string {
+	switch body := pc.Body.(type) {
+	case *model.ProposedCommand_CommandSettingsUpdate:
+		return "Update settings: " + describeSettingsUpdate(body.CommandSettingsUpdate)
+	case *model.ProposedCommand_CommandUpdateAuthorization:
+		c := body.CommandUpdateAuthorization
+		changes := []string{}
+		changes = appendBoolUpdateDescription(changes, c.MakeMajor, "make major", "revoke major")
+		changes = appendBoolUpdateDescription(changes, c.MakeSigned, "make signed", "make unsigned")
+		return fmt.Sprintf("Person %s: %s", c.PersonId, strings.Join(changes, ", "))
+	case *model.ProposedCommand_CommandPersonUpdateBalanceIncrement:
+		c := body.CommandPersonUpdateBalanceIncrement
+		return fmt.Sprintf("Increment balance of person %s by %d", c.PersonId, c.BalanceIncrement)
+	}
+	return ""
+}
+
+func describeSettingsUpdate(c *model.CommandSettingsUpdate) string {
+	changes := []string{}
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := strings.TrimSuffix(v.Type().Field(i).Name, "Update")
+		switch u := v.Field(i).Interface().(type) {
+		case *model.IntUpdate:
+			if u != nil {
+				changes = append(changes, fmt.Sprintf("%s %d -> %d", name, u.OldValue, u.NewValue))
+			}
+		case *model.StringUpdate:
+			if u != nil {
+				changes = append(changes, fmt.Sprintf("%s %q -> %q", name, u.OldValue, u.NewValue))
+			}
+		}
+	}
+	return strings.Join(changes, ", ")
+}
+
+func appendBoolUpdateDescription(changes []string, u model.BoolUpdate, makeTrue, makeFalse string) []string {
+	switch u {
+	case model.BoolUpdate_MAKE_TRUE:
+		return append(changes, makeTrue)
+	case model.BoolUpdate_MAKE_FALSE:
+		return append(changes, makeFalse)
+	}
+	return changes
+}
+
+// checkProposalCreate requires the price of the proposed command. When no
+// other approvals are needed, the proposed command is executed right away.
+func (nbce *nonBootstrapCommandExecution) checkProposalCreate(c *model.CommandProposalCreate) (*updater, error) {
+	if c.ProposedCommand == nil || c.ProposedCommand.Body == nil {
+		return nil, errors.New("A proposal needs a proposed command")
+	}
+	priceName, expectedPrice := nbce.getProposedCommandPrice(c.ProposedCommand)
+	if nbce.price != expectedPrice {
+		return nil, formatPriceError(priceName, expectedPrice)
+	}
+	if !nbce.unmarshalledState.persons[nbce.verifiedSignerId].IsMajor {
+		return nil, errors.New("Only majors can create proposals")
+	}
+	if err := nbce.readAndCheckProposal(c.ProposalId, ADDRESS_EMPTY); err != nil {
+		return nil, err
+	}
+	if c.ExpiresOn <= nbce.timestamp {
+		return nil, errors.New("The expiration time of a proposal should be in the future")
+	}
+	executed, err := nbce.checkProposedCommand(c.ProposedCommand)
+	if err != nil {
+		return nil, addErrorContext("Invalid proposed command", err)
+	}
+	if len(executed.updates) == 0 {
+		return nil, errors.New("The proposed command does not change anything")
+	}
+	singleUpdates := []singleUpdate{
+		&singleUpdateProposalCreate{
+			proposalCreate: c,
+			proposerId:     nbce.verifiedSignerId,
+			timestamp:      nbce.timestamp,
+		},
+	}
+	if nbce.getEffectiveApprovalThreshold() <= 1 {
+		singleUpdates = append(singleUpdates, executed.updates...)
+		singleUpdates = append(singleUpdates, &singleUpdateProposalUpdateState{
+			proposalId:    c.ProposalId,
+			proposalState: model.ProposalState_proposalExecuted,
+			timestamp:     nbce.timestamp,
+		})
+	}
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates:           singleUpdates,
+	}, nil
+}
+
+// checkProposalApprove executes the proposed command when the approval
+// reaches the approval threshold. The proposed command is checked again
+// against the current state. If it is no longer valid, for example
+// because a settings update was based on old values, the approval is
+// rejected and the proposer can cancel the proposal.
+func (nbce *nonBootstrapCommandExecution) checkProposalApprove(c *model.CommandProposalApprove) (*updater, error) {
+	if nbce.price != int32(0) {
+		return nil, formatPriceError("-", int32(0))
+	}
+	if !nbce.unmarshalledState.persons[nbce.verifiedSignerId].IsMajor {
+		return nil, errors.New("Only majors can approve proposals")
+	}
+	proposal, err := nbce.readOpenProposal(c.ProposalId)
+	if err != nil {
+		return nil, err
+	}
+	// The timestamp is chosen by the client, so the expiration can be
+	// bypassed by backdating the approval. An approval can at least
+	// not be older than the proposal.
+	if nbce.timestamp < proposal.CreatedOn {
+		return nil, errors.New("An approval cannot be older than proposal " + c.ProposalId)
+	}
+	if proposal.ExpiresOn <= nbce.timestamp {
+		return nil, errors.New("Proposal has expired: " + c.ProposalId)
+	}
+	for _, approverId := range proposal.ApproverId {
+		if approverId == nbce.verifiedSignerId {
+			return nil, errors.New("You already approved proposal " + c.ProposalId)
+		}
+	}
+	singleUpdates := []singleUpdate{
+		&singleUpdateProposalApprove{
+			proposalId: c.ProposalId,
+			approverId: nbce.verifiedSignerId,
+			timestamp:  nbce.timestamp,
+		},
+	}
+	if len(proposal.ApproverId)+1 >= nbce.getEffectiveApprovalThreshold() {
+		executed, err := nbce.checkProposedCommand(proposal.ProposedCommand)
+		if err != nil {
+			return nil, addErrorContext("Cannot execute proposal "+c.ProposalId, err)
+		}
+		singleUpdates = append(singleUpdates, executed.updates...)
+		singleUpdates = append(singleUpdates, &singleUpdateProposalUpdateState{
+			proposalId:    c.ProposalId,
+			proposalState: model.ProposalState_proposalExecuted,
+			timestamp:     nbce.timestamp,
+		})
+	}
+	singleUpdates = nbce.addSingleUpdateProposalModificationTimeIfNeeded(singleUpdates, c.ProposalId)
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates:           singleUpdates,
+	}, nil
+}
+
+// checkProposalCancel allows the proposer to cancel an open proposal at
+// any time, also after it has expired, so that it does not stay open
+// forever.
+func (nbce *nonBootstrapCommandExecution) checkProposalCancel(c *model.CommandProposalCancel) (*updater, error) {
+	if nbce.price != int32(0) {
+		return nil, formatPriceError("-", int32(0))
+	}
+	proposal, err := nbce.readOpenProposal(c.ProposalId)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.ProposerId != nbce.verifiedSignerId {
+		return nil, errors.New("Only the proposer can cancel a proposal")
+	}
+	singleUpdates := []singleUpdate{
+		&singleUpdateProposalUpdateState{
+			proposalId:    c.ProposalId,
+			proposalState: model.ProposalState_proposalCancelled,
+			timestamp:     nbce.timestamp,
+		},
+	}
+	singleUpdates = nbce.addSingleUpdateProposalModificationTimeIfNeeded(singleUpdates, c.ProposalId)
+	return &updater{
+		unmarshalledState: nbce.unmarshalledState,
+		updates:           singleUpdates,
+	}, nil
+}
+
+func (nbce *nonBootstrapCommandExecution) readAndCheckProposal(
+	proposalId string, expectedAddressState addressState) error {
+	if !model.IsProposalAddress(proposalId) {
+		return errors.New("Proposal id is not a proposal address: " + proposalId)
+	}
+	if err := nbce.readAddresses([]string{proposalId}); err != nil {
+		return addErrorContext("Could not read proposal address "+proposalId, err)
+	}
+	if nbce.unmarshalledState.getAddressState(proposalId) != expectedAddressState {
+		switch expectedAddressState {
+		case ADDRESS_EMPTY:
+			return errors.New("Proposal already exists: " + proposalId)
+		case ADDRESS_FILLED:
+			return errors.New("Proposal does not exist: " + proposalId)
+		}
+		return errors.New("Internal error, proposal was not read: " + proposalId)
+	}
+	return nil
+}
+
+func (nbce *nonBootstrapCommandExecution) readOpenProposal(proposalId string) (*model.StateProposal, error) {
+	if err := nbce.readAndCheckProposal(proposalId, ADDRESS_FILLED); err != nil {
+		return nil, err
+	}
+	proposal := nbce.unmarshalledState.proposals[proposalId]
+	if proposal.ProposalState != model.ProposalState_proposalOpen {
+		return nil, errors.New(fmt.Sprintf("Proposal %s is not open, but %s",
+			proposalId, model.GetProposalStateString(proposal.ProposalState)))
+	}
+	return proposal, nil
+}
+
+type singleUpdateProposalCreate struct {
+	proposalCreate *model.CommandProposalCreate
+	proposerId     string
+	timestamp      int64
+}
+
+var _ singleUpdate = new(singleUpdateProposalCreate)
+
+func (u *singleUpdateProposalCreate) updateState(state *unmarshalledState) []string {
+	proposalId := u.proposalCreate.ProposalId
+	state.proposals[proposalId] = &model.StateProposal{
+		Id:              proposalId,
+		CreatedOn:       u.timestamp,
+		ModifiedOn:      u.timestamp,
+		ProposerId:      u.proposerId,
+		ProposedCommand: u.proposalCreate.ProposedCommand,
+		ApproverId:      []string{u.proposerId},
+		ExpiresOn:       u.proposalCreate.ExpiresOn,
+		ProposalState:   model.ProposalState_proposalOpen,
+	}
+	return []string{proposalId}
+}
+
+func (u *singleUpdateProposalCreate) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	eventType := model.AlexandriaPrefix + model.EV_TYPE_PROPOSAL_CREATE
+	proposedCommand := u.proposalCreate.ProposedCommand
+	return ba.AddEvent(
+		eventType,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.proposalCreate.ProposalId,
+			},
+			{
+				Key:   model.EV_KEY_PROPOSAL_PROPOSER_ID,
+				Value: u.proposerId,
+			},
+			{
+				Key:   model.EV_KEY_PROPOSAL_COMMAND_TYPE,
+				Value: getProposedCommandType(proposedCommand),
+			},
+			{
+				Key:   model.EV_KEY_PROPOSAL_TARGET_ID,
+				Value: getProposedCommandTargetId(proposedCommand),
+			},
+			{
+				Key:   model.EV_KEY_PROPOSAL_DESCRIPTION,
+				Value: describeProposedCommand(proposedCommand),
+			},
+			{
+				Key:   model.EV_KEY_PROPOSAL_EXPIRES_ON,
+				Value: fmt.Sprintf("%d", u.proposalCreate.ExpiresOn),
+			},
+			{
+				Key:   model.EV_KEY_PROPOSAL_STATE,
+				Value: model.GetProposalStateString(model.ProposalState_proposalOpen),
+			},
+		}, []byte{})
+}
+
+type singleUpdateProposalApprove struct {
+	proposalId string
+	approverId string
+	timestamp  int64
+}
+
+var _ singleUpdate = new(singleUpdateProposalApprove)
+
+func (u *singleUpdateProposalApprove) updateState(state *unmarshalledState) []string {
+	proposal := state.proposals[u.proposalId]
+	proposal.ApproverId = append(proposal.ApproverId, u.approverId)
+	return []string{u.proposalId}
+}
+
+func (u *singleUpdateProposalApprove) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	eventType := model.AlexandriaPrefix + model.EV_TYPE_PROPOSAL_APPROVE
+	return ba.AddEvent(
+		eventType,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.proposalId,
+			},
+			{
+				Key:   model.EV_KEY_PROPOSAL_APPROVER_ID,
+				Value: u.approverId,
+			},
+		}, []byte{})
+}
+
+type singleUpdateProposalUpdateState struct {
+	proposalId    string
+	proposalState model.ProposalState
+	timestamp     int64
+}
+
+var _ singleUpdate = new(singleUpdateProposalUpdateState)
+
+func (u *singleUpdateProposalUpdateState) updateState(state *unmarshalledState) []string {
+	state.proposals[u.proposalId].ProposalState = u.proposalState
+	return []string{u.proposalId}
+}
+
+func (u *singleUpdateProposalUpdateState) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	eventType := model.AlexandriaPrefix + model.EV_TYPE_PROPOSAL_UPDATE
+	return ba.AddEvent(
+		eventType,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: u.proposalId,
+			},
+			{
+				Key:   model.EV_KEY_PROPOSAL_STATE,
+				Value: model.GetProposalStateString(u.proposalState),
+			},
+		}, []byte{})
+}
@@ -0,0 +1,217 @@
+package command
+
+import (
+	"fmt"
+	"github.com/hyperledger/sawtooth-sdk-go/processor"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
+	"github.com/hyperledger/sawtooth-sdk-go/signing"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+Sawtooth's parallel scheduler applies transactions at the same time
+when their declared addresses do not conflict. The benchmark below
+mimics this. Commands are put in waves and the commands of a wave are
+applied concurrently. Accessing state takes some time, like the round
+trip from the transaction processor to the validator.
+*/
+
+const stateAccessLatency = time.Millisecond
+
+const numBenchmarkPersons = 20
+
+func TestFreeCommandDoesNotWriteSigner(t *testing.T) {
+	signerId := "signer"
+	free := GetPersonUpdateIncBalanceCommand("person", int32(10), signerId, nil, int32(0))
+	if len(free.OutputAddresses) != 1 || free.OutputAddresses[0] != "person" {
+		t.Errorf("Free command should only write the person, got %v", free.OutputAddresses)
+	}
+	paid := GetPersonUpdateSetMajorCommand("person", signerId, nil, int32(1))
+	if len(paid.OutputAddresses) != 3 {
+		t.Errorf("Paid command should write the person, the settings and the signer, got %v", paid.OutputAddresses)
+	}
+}
+
+func TestBalanceIncrementsAreScheduledInParallel(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	_, commands, err := getBalanceIncrementWorkload()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if numWaves := len(getWaves(commands)); numWaves != 1 {
+		t.Errorf("Expected balance increments of different persons in one wave, got %d waves", numWaves)
+	}
+	for _, c := range commands {
+		c.OutputAddresses = append(c.OutputAddresses, c.Command.Signer)
+	}
+	if numWaves := len(getWaves(commands)); numWaves != numBenchmarkPersons {
+		t.Errorf("Expected that writing the signer serializes all commands, got %d waves", numWaves)
+	}
+}
+
+func BenchmarkParallelScheduling(b *testing.B) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	b.Run("narrowAddresses", func(b *testing.B) {
+		benchmarkBalanceIncrements(b, func(*Command) {})
+	})
+	b.Run("signerAlwaysWritten", func(b *testing.B) {
+		benchmarkBalanceIncrements(b, func(c *Command) {
+			c.OutputAddresses = append(c.OutputAddresses, c.Command.Signer)
+		})
+	})
+}
+
+func benchmarkBalanceIncrements(b *testing.B, modifyAddresses func(*Command)) {
+	ba, commands, err := getBalanceIncrementWorkload()
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, c := range commands {
+		modifyAddresses(c)
+	}
+	waves := getWaves(commands)
+	b.ReportMetric(float64(len(waves)), "waves")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, wave := range waves {
+			if err := applyWave(wave, ba); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// A major increments the balances of different persons. The increments
+// have price zero.
+func getBalanceIncrementWorkload() (BlockchainAccess, []*Command, error) {
+	ba := &latencyBlockchainAccess{
+		delegate: NewBlockchainStub(
+			func(*events_pb2.Event, *log.Logger) error { return nil },
+			log.New(ioutil.Discard, "", 0)),
+	}
+	major := getRandomCryptoIdentity()
+	bootstrap := GetBootstrapCommand(&Bootstrap{
+		Name:  "Major",
+		Email: "major@xxx.nl",
+	}, major)
+	if err := RunCommandForTest(bootstrap, "bootstrap", ba); err != nil {
+		return nil, nil, err
+	}
+	majorId := bootstrap.Command.Signer
+	commands := make([]*Command, numBenchmarkPersons)
+	for i := range commands {
+		personCreate, personId := GetPersonCreateCommand(&PersonCreate{
+			PublicKey: getRandomCryptoIdentity().PublicKeyStr,
+			Name:      fmt.Sprintf("Person %d", i),
+			Email:     "person@xxx.nl",
+		}, majorId, major, int32(0))
+		if err := RunCommandForTest(personCreate, fmt.Sprintf("personCreate%d", i), ba); err != nil {
+			return nil, nil, err
+		}
+		commands[i] = GetPersonUpdateIncBalanceCommand(personId, int32(1), majorId, major, int32(0))
+	}
+	return ba, commands, nil
+}
+
+func getRandomCryptoIdentity() *CryptoIdentity {
+	context := signing.NewSecp256k1Context()
+	privateKey := context.NewRandomPrivateKey()
+	publicKey := context.GetPublicKey(privateKey)
+	return &CryptoIdentity{
+		PublicKeyStr: publicKey.AsHex(),
+		PublicKey:    publicKey,
+		PrivateKey:   privateKey,
+	}
+}
+
+// A command goes in the wave after the last wave with a conflicting command.
+func getWaves(commands []*Command) [][]*Command {
+	waves := [][]*Command{}
+	waveOfCommand := make([]int, len(commands))
+	for i, c := range commands {
+		wave := 0
+		for j := 0; j < i; j++ {
+			if isConflict(commands[j], c) && waveOfCommand[j] >= wave {
+				wave = waveOfCommand[j] + 1
+			}
+		}
+		waveOfCommand[i] = wave
+		if wave == len(waves) {
+			waves = append(waves, []*Command{})
+		}
+		waves[wave] = append(waves[wave], c)
+	}
+	return waves
+}
+
+func isConflict(earlier, later *Command) bool {
+	return isOverlap(earlier.OutputAddresses, later.InputAddresses) ||
+		isOverlap(earlier.OutputAddresses, later.OutputAddresses) ||
+		isOverlap(earlier.InputAddresses, later.OutputAddresses)
+}
+
+func isOverlap(first, second []string) bool {
+	for _, f := range first {
+		for _, s := range second {
+			if f == s {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func applyWave(wave []*Command, ba BlockchainAccess) error {
+	errs := make([]error, len(wave))
+	wg := sync.WaitGroup{}
+	for i, c := range wave {
+		wg.Add(1)
+		go func(i int, c *Command) {
+			defer wg.Done()
+			errs[i] = RunCommandForTest(c, fmt.Sprintf("transaction%d", i), ba)
+		}(i, c)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type latencyBlockchainAccess struct {
+	mux      sync.Mutex
+	delegate BlockchainAccess
+}
+
+var _ BlockchainAccess = new(latencyBlockchainAccess)
+
+func (lba *latencyBlockchainAccess) GetState(addresses []string) (map[string][]byte, error) {
+	time.Sleep(stateAccessLatency)
+	lba.mux.Lock()
+	defer lba.mux.Unlock()
+	return lba.delegate.GetState(addresses)
+}
+
+func (lba *latencyBlockchainAccess) SetState(pairs map[string][]byte) ([]string, error) {
+	time.Sleep(stateAccessLatency)
+	lba.mux.Lock()
+	defer lba.mux.Unlock()
+	return lba.delegate.SetState(pairs)
+}
+
+func (lba *latencyBlockchainAccess) AddEvent(
+	eventType string, attributes []processor.Attribute, eventData []byte) error {
+	lba.mux.Lock()
+	defer lba.mux.Unlock()
+	return lba.delegate.AddEvent(eventType, attributes, eventData)
+}
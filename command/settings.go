@@ -6,7 +6,6 @@ import (
 	"github.com/hyperledger/sawtooth-sdk-go/processor"
 	"github.com/iskendria-pub/iskendria/dao"
 	"github.com/iskendria-pub/iskendria/model"
-	"log"
 )
 
 type Bootstrap struct {
@@ -28,6 +27,8 @@ type Bootstrap struct {
 	PriceEditorEditJournal               int32
 	PriceEditorAddColleague              int32
 	PriceEditorAcceptDuty                int32
+	PriceAuthorWithdrawManuscript        int32
+	PriceEditorInviteReviewer            int32
 	Name                                 string
 	Email                                string
 }
@@ -63,6 +64,8 @@ func GetBootstrapCommand(bootstrap *Bootstrap, cryptoIdentity *CryptoIdentity) *
 						PriceEditorEditJournal:               bootstrap.PriceEditorEditJournal,
 						PriceEditorAddColleague:              bootstrap.PriceEditorAddColleague,
 						PriceEditorAcceptDuty:                bootstrap.PriceEditorAcceptDuty,
+						PriceAuthorWithdrawManuscript:        bootstrap.PriceAuthorWithdrawManuscript,
+						PriceEditorInviteReviewer:            bootstrap.PriceEditorInviteReviewer,
 					},
 					FirstMajor: &model.CommandPersonCreate{
 						NewPersonId: personId,
@@ -76,27 +79,48 @@ func GetBootstrapCommand(bootstrap *Bootstrap, cryptoIdentity *CryptoIdentity) *
 	}
 }
 
+// GetSettingsUpdateCommand needs the ids of all majors, see
+// dao.GetMajorIds. They are only sent when the approval threshold is
+// updated while the majors have not been counted yet.
 func GetSettingsUpdateCommand(
 	orig,
 	updated *dao.Settings,
+	majorIds []string,
 	signerId string,
 	cryptoIdentity *CryptoIdentity,
 	price int32) *Command {
+	c := createCommandSettingsUpdate(orig, updated, majorIds)
 	return &Command{
-		InputAddresses:  []string{model.GetSettingsAddress(), signerId},
-		OutputAddresses: []string{model.GetSettingsAddress(), signerId},
+		InputAddresses:  getInputAddresses(signerId, c.MajorIds...),
+		OutputAddresses: getOutputAddresses(signerId, price, model.GetSettingsAddress()),
 		CryptoIdentity:  cryptoIdentity,
 		Command: &model.Command{
 			Price:     price,
 			Signer:    signerId,
 			Timestamp: model.GetCurrentTime(),
 			Body: &model.Command_CommandSettingsUpdate{
-				CommandSettingsUpdate: createModelCommandSettingsUpdate(orig, updated),
+				CommandSettingsUpdate: c,
 			},
 		},
 	}
 }
 
+// The minimum family version is a string and the major ids are not
+// a setting, so they are not handled by the generated code.
+func createCommandSettingsUpdate(orig, updated *dao.Settings, majorIds []string) *model.CommandSettingsUpdate {
+	result := createModelCommandSettingsUpdate(orig, updated)
+	if updated.MinimumFamilyVersion != orig.MinimumFamilyVersion {
+		result.MinimumFamilyVersionUpdate = &model.StringUpdate{
+			OldValue: orig.MinimumFamilyVersion,
+			NewValue: updated.MinimumFamilyVersion,
+		}
+	}
+	if result.ApprovalThresholdUpdate != nil && orig.MajorCount == int32(0) {
+		result.MajorIds = majorIds
+	}
+	return result
+}
+
 type singleUpdateSettingsCreate struct {
 	timestamp int64
 	priceList *model.PriceList
@@ -127,6 +151,8 @@ func (u *singleUpdateSettingsCreate) updateState(state *unmarshalledState) (writ
 			PriceEditorEditJournal:               u.priceList.PriceEditorEditJournal,
 			PriceEditorAddColleague:              u.priceList.PriceEditorAddColleague,
 			PriceEditorAcceptDuty:                u.priceList.PriceEditorAcceptDuty,
+			PriceAuthorWithdrawManuscript:        u.priceList.PriceAuthorWithdrawManuscript,
+			PriceEditorInviteReviewer:            u.priceList.PriceEditorInviteReviewer,
 		},
 	}
 	return []string{model.GetSettingsAddress()}
@@ -134,7 +160,6 @@ func (u *singleUpdateSettingsCreate) updateState(state *unmarshalledState) (writ
 
 func (u *singleUpdateSettingsCreate) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_SETTINGS_CREATE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(
 		eventType,
 		[]processor.Attribute{
@@ -222,6 +247,14 @@ func (u *singleUpdateSettingsCreate) issueEvent(eventSeq int32, transactionId st
 				Key:   model.EV_KEY_PRICE_EDITOR_ACCEPT_DUTY,
 				Value: fmt.Sprintf("%d", u.priceList.PriceEditorAcceptDuty),
 			},
+			{
+				Key:   model.EV_KEY_PRICE_AUTHOR_WITHDRAW_MANUSCRIPT,
+				Value: fmt.Sprintf("%d", u.priceList.PriceAuthorWithdrawManuscript),
+			},
+			{
+				Key:   model.EV_KEY_PRICE_EDITOR_INVITE_REVIEWER,
+				Value: fmt.Sprintf("%d", u.priceList.PriceEditorInviteReviewer),
+			},
 		},
 		[]byte{})
 }
@@ -231,6 +264,16 @@ func (nbce *nonBootstrapCommandExecution) checkSettingsUpdate(c *model.CommandSe
 	if nbce.price != expectedPrice {
 		return nil, formatPriceError("PriceMajorEditSettings", expectedPrice)
 	}
+	if err := nbce.checkNoApprovalNeeded("Updating settings"); err != nil {
+		return nil, err
+	}
+	return nbce.checkSettingsUpdateWithoutPrice(c)
+}
+
+// checkSettingsUpdateWithoutPrice is also used to execute an approved
+// proposal. The price of a proposal is checked when it is created.
+func (nbce *nonBootstrapCommandExecution) checkSettingsUpdateWithoutPrice(c *model.CommandSettingsUpdate) (
+	*updater, error) {
 	if !nbce.unmarshalledState.persons[nbce.verifiedSignerId].IsMajor {
 		return nil, errors.New("Only majors can update settings")
 	}
@@ -238,7 +281,39 @@ func (nbce *nonBootstrapCommandExecution) checkSettingsUpdate(c *model.CommandSe
 	if err := checkModelCommandSettingsUpdate(c, oldSettings); err != nil {
 		return nil, err
 	}
+	majorCount, err := nbce.countMajors(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkApprovalThresholdUpdate(c.ApprovalThresholdUpdate, oldSettings, majorCount); err != nil {
+		return nil, err
+	}
+	if err := checkMinimumFamilyVersionUpdate(c.MinimumFamilyVersionUpdate, oldSettings); err != nil {
+		return nil, err
+	}
 	singleUpdates := createSingleUpdatesSettingsUpdate(c, oldSettings, nbce.timestamp)
+	if majorCount != oldSettings.MajorCount {
+		singleUpdates = append(singleUpdates, &singleUpdateSettingsUpdate{
+			stateField: &oldSettings.MajorCount,
+			newValue:   majorCount,
+			eventKey:   model.EV_KEY_MAJOR_COUNT,
+			timestamp:  nbce.timestamp,
+		})
+	}
+	if c.ApprovalThresholdUpdate != nil {
+		singleUpdates = append(singleUpdates, &singleUpdateSettingsUpdate{
+			stateField: &oldSettings.ApprovalThreshold,
+			newValue:   c.ApprovalThresholdUpdate.NewValue,
+			eventKey:   model.EV_KEY_APPROVAL_THRESHOLD,
+			timestamp:  nbce.timestamp,
+		})
+	}
+	if c.MinimumFamilyVersionUpdate != nil {
+		singleUpdates = append(singleUpdates, &singleUpdateSettingsMinimumFamilyVersion{
+			newValue:  c.MinimumFamilyVersionUpdate.NewValue,
+			timestamp: nbce.timestamp,
+		})
+	}
 	singleUpdates = nbce.addSingleUpdateSettingsModificationTimeIfNeeded(singleUpdates)
 	return &updater{
 		unmarshalledState: nbce.unmarshalledState,
@@ -246,6 +321,95 @@ func (nbce *nonBootstrapCommandExecution) checkSettingsUpdate(c *model.CommandSe
 	}, nil
 }
 
+/*
+countMajors returns the number of majors that bounds the approval
+threshold. Blockchains do not count their majors from the bootstrap,
+because family version 1 does not maintain the count. The blockchain
+cannot enumerate the persons, so the first approval threshold update
+lists all majors. Every listed person should be a major. A major that
+is not listed is not counted, which only makes the bounds stricter.
+After that, authorization updates of family version 2 maintain the
+count, see createSingleUpdatesMajorCount. A major count of zero thus
+means that the majors have not been counted.
+*/
+func (nbce *nonBootstrapCommandExecution) countMajors(c *model.CommandSettingsUpdate) (int32, error) {
+	oldMajorCount := nbce.unmarshalledState.settings.MajorCount
+	if len(c.MajorIds) == 0 {
+		if c.ApprovalThresholdUpdate != nil && oldMajorCount == int32(0) {
+			return 0, errors.New("The majors have not been counted, so all majors should be listed")
+		}
+		return oldMajorCount, nil
+	}
+	if c.ApprovalThresholdUpdate == nil {
+		return 0, errors.New("Majors can only be listed with an approval threshold update")
+	}
+	if oldMajorCount != int32(0) {
+		return 0, errors.New(fmt.Sprintf("The majors have already been counted, there are %d", oldMajorCount))
+	}
+	seen := make(map[string]bool)
+	for _, id := range c.MajorIds {
+		if !model.IsPersonAddress(id) {
+			return 0, errors.New("Not a person: " + id)
+		}
+		if seen[id] {
+			return 0, errors.New("Major listed twice: " + id)
+		}
+		seen[id] = true
+	}
+	if err := nbce.readAndCheckAddresses(c.MajorIds, []string{}); err != nil {
+		return 0, err
+	}
+	for _, id := range c.MajorIds {
+		if !nbce.unmarshalledState.persons[id].IsMajor {
+			return 0, errors.New("Listed person is not a major: " + id)
+		}
+	}
+	return int32(len(c.MajorIds)), nil
+}
+
+// The approval threshold is not in the price list, so it is not handled
+// by the generated code.
+func checkApprovalThresholdUpdate(u *model.IntUpdate, oldSettings *model.StateSettings, majorCount int32) error {
+	if u == nil {
+		return nil
+	}
+	if u.OldValue != oldSettings.ApprovalThreshold {
+		return errors.New(fmt.Sprintf("ApprovalThreshold mismatch. Expected %d, got %d",
+			u.OldValue, oldSettings.ApprovalThreshold))
+	}
+	if u.NewValue < 0 {
+		return errors.New(fmt.Sprintf("The approval threshold cannot be negative, got %d", u.NewValue))
+	}
+	// With a threshold above the number of majors, no proposal
+	// could be executed anymore, including one to lower the threshold.
+	if u.NewValue > majorCount {
+		return errors.New(fmt.Sprintf("The approval threshold %d exceeds the number of majors %d",
+			u.NewValue, majorCount))
+	}
+	return nil
+}
+
+// The minimum family version can only be raised. Lowering it would
+// allow transactions that bypass the rules of newer family versions again.
+func checkMinimumFamilyVersionUpdate(u *model.StringUpdate, oldSettings *model.StateSettings) error {
+	if u == nil {
+		return nil
+	}
+	if u.OldValue != oldSettings.MinimumFamilyVersion {
+		return errors.New(fmt.Sprintf("MinimumFamilyVersion mismatch. Expected %s, got %s",
+			u.OldValue, oldSettings.MinimumFamilyVersion))
+	}
+	if !model.IsSupportedFamilyVersion(u.NewValue) {
+		return errors.New(fmt.Sprintf("Unsupported family version: %s, supported are %v",
+			u.NewValue, model.FamilyVersions))
+	}
+	if model.IsFamilyVersionBelow(u.NewValue, oldSettings.MinimumFamilyVersion) {
+		return errors.New(fmt.Sprintf("The minimum family version cannot be lowered from %s to %s",
+			oldSettings.MinimumFamilyVersion, u.NewValue))
+	}
+	return nil
+}
+
 func (nbce *nonBootstrapCommandExecution) addSingleUpdateSettingsModificationTimeIfNeeded(
 	singleUpdates []singleUpdate) []singleUpdate {
 	if len(singleUpdates) >= 1 {
@@ -272,7 +436,6 @@ func (u *singleUpdateSettingsUpdate) updateState(_ *unmarshalledState) (writtenA
 
 func (u *singleUpdateSettingsUpdate) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_SETTINGS_UPDATE
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(eventType,
 		[]processor.Attribute{
 			{
@@ -295,6 +458,43 @@ func (u *singleUpdateSettingsUpdate) issueEvent(eventSeq int32, transactionId st
 		[]byte{})
 }
 
+type singleUpdateSettingsMinimumFamilyVersion struct {
+	newValue  string
+	timestamp int64
+}
+
+var _ singleUpdate = new(singleUpdateSettingsMinimumFamilyVersion)
+
+func (u *singleUpdateSettingsMinimumFamilyVersion) updateState(state *unmarshalledState) (writtenAddresses []string) {
+	state.settings.MinimumFamilyVersion = u.newValue
+	return []string{model.GetSettingsAddress()}
+}
+
+func (u *singleUpdateSettingsMinimumFamilyVersion) issueEvent(
+	eventSeq int32, transactionId string, ba BlockchainAccess) error {
+	eventType := model.AlexandriaPrefix + model.EV_TYPE_SETTINGS_UPDATE
+	return ba.AddEvent(eventType,
+		[]processor.Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: fmt.Sprintf("%d", eventSeq),
+			},
+			{
+				Key:   model.EV_KEY_TIMESTAMP,
+				Value: fmt.Sprintf("%d", u.timestamp),
+			},
+			{
+				Key:   model.EV_KEY_MINIMUM_FAMILY_VERSION,
+				Value: u.newValue,
+			},
+		},
+		[]byte{})
+}
+
 type singleUpdateSettingsModificationTime struct {
 	timestamp int64
 }
@@ -308,7 +508,6 @@ func (u *singleUpdateSettingsModificationTime) updateState(state *unmarshalledSt
 
 func (u *singleUpdateSettingsModificationTime) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + model.EV_TYPE_SETTINGS_MODIFICATION_TIME
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(
 		eventType,
 		[]processor.Attribute{
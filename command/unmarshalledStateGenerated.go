@@ -17,6 +17,8 @@ type unmarshalledState struct {
 	manuscripts       map[string]*model.StateManuscript
 	manuscriptThreads map[string]*model.StateManuscriptThread
 	reviews           map[string]*model.StateReview
+	reviewInvitations map[string]*model.StateReviewInvitation
+	proposals         map[string]*model.StateProposal
 }
 
 func newUnmarshalledState() *unmarshalledState {
@@ -29,6 +31,8 @@ func newUnmarshalledState() *unmarshalledState {
 		manuscripts:       make(map[string]*model.StateManuscript),
 		manuscriptThreads: make(map[string]*model.StateManuscriptThread),
 		reviews:           make(map[string]*model.StateReview),
+		reviewInvitations: make(map[string]*model.StateReviewInvitation),
+		proposals:         make(map[string]*model.StateProposal),
 	}
 }
 
@@ -72,6 +76,16 @@ func (us *unmarshalledState) getAddressState(address string) addressState {
 		if found {
 			return ADDRESS_FILLED
 		}
+	case model.IsReviewInvitationAddress(address):
+		_, found := us.reviewInvitations[address]
+		if found {
+			return ADDRESS_FILLED
+		}
+	case model.IsProposalAddress(address):
+		_, found := us.proposals[address]
+		if found {
+			return ADDRESS_FILLED
+		}
 	}
 	return ADDRESS_UNKNOWN
 }
@@ -109,6 +123,10 @@ func (us *unmarshalledState) addAvailable(address string, contents []byte) error
 		err = us.addManuscriptThread(address, contents)
 	case model.IsReviewAddress(address):
 		err = us.addReview(address, contents)
+	case model.IsReviewInvitationAddress(address):
+		err = us.addReviewInvitation(address, contents)
+	case model.IsProposalAddress(address):
+		err = us.addProposal(address, contents)
 	}
 	return err
 }
@@ -177,6 +195,24 @@ func (us *unmarshalledState) addReview(theId string, contents []byte) error {
 	us.reviews[theId] = modelContainer
 	return nil
 }
+func (us *unmarshalledState) addReviewInvitation(theId string, contents []byte) error {
+	modelContainer := &model.StateReviewInvitation{}
+	err := proto.Unmarshal(contents, modelContainer)
+	if err != nil {
+		return err
+	}
+	us.reviewInvitations[theId] = modelContainer
+	return nil
+}
+func (us *unmarshalledState) addProposal(theId string, contents []byte) error {
+	modelContainer := &model.StateProposal{}
+	err := proto.Unmarshal(contents, modelContainer)
+	if err != nil {
+		return err
+	}
+	us.proposals[theId] = modelContainer
+	return nil
+}
 func (us *unmarshalledState) read(addresses []string) (map[string][]byte, error) {
 	result := make(map[string][]byte)
 	var err error
@@ -196,6 +232,10 @@ func (us *unmarshalledState) read(addresses []string) (map[string][]byte, error)
 			err = us.readManuscriptThread(address, result)
 		case model.IsReviewAddress(address):
 			err = us.readReview(address, result)
+		case model.IsReviewInvitationAddress(address):
+			err = us.readReviewInvitation(address, result)
+		case model.IsProposalAddress(address):
+			err = us.readProposal(address, result)
 		}
 		if err != nil {
 			return result, err
@@ -261,3 +301,19 @@ func (us *unmarshalledState) readReview(theId string, result map[string][]byte)
 	result[theId] = marshalled
 	return nil
 }
+func (us *unmarshalledState) readReviewInvitation(theId string, result map[string][]byte) error {
+	marshalled, err := proto.Marshal(us.reviewInvitations[theId])
+	if err != nil {
+		return err
+	}
+	result[theId] = marshalled
+	return nil
+}
+func (us *unmarshalledState) readProposal(theId string, result map[string][]byte) error {
+	marshalled, err := proto.Marshal(us.proposals[theId])
+	if err != nil {
+		return err
+	}
+	result[theId] = marshalled
+	return nil
+}
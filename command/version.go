@@ -0,0 +1,133 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/iskendria-pub/iskendria/model"
+)
+
+/*
+The rules of all family versions are kept side by side, because the
+blockchain has to be replayable: a transaction is applied with the
+rules of the family version in its header. The differences between
+the versions are the fields of ruleSet. To change a rule incompatibly,
+add a family version to the model and a rule set here that differs
+from the previous one only in that rule.
+
+Accepting a family version forever would allow everyone to bypass the
+rules of newer versions by sending old transactions. Therefore the
+settings hold a minimum family version that majors can raise. A
+transaction of an older family version is rejected, but the
+transactions that were accepted before the minimum was raised remain
+valid when the blockchain is replayed.
+*/
+
+type ruleSet struct {
+	familyVersion string
+	// Parses the transaction payload. All versions share the schema of
+	// model.Command so far.
+	parsePayload func([]byte) (*model.Command, error)
+	// Version 1 accepts a command that changes nothing without charging
+	// its price. From version 2, such a command is rejected.
+	rejectCommandWithoutUpdates bool
+	// Authors can withdraw a manuscript from version 2.
+	allowManuscriptWithdraw bool
+	// Editors can invite reviewers from version 2. Only then a review
+	// reads the journal of its manuscript, so a journal that accepts
+	// reviews only from invited reviewers can only enforce this for
+	// reviews of version 2 and later. Reviews of version 1 are stopped
+	// by raising the minimum family version.
+	allowReviewInvitations bool
+	// Reviews can be blind from version 2. The author of a blind
+	// review can reveal it after the manuscript is judged.
+	allowBlindReviews bool
+	// Reviewers can revise a review from version 2, until the editor
+	// has used it to judge a manuscript.
+	allowReviewRevisions bool
+	// Journals can be closed and archived from version 2. Older
+	// journals stay open, so the checks on the journal state need no
+	// rule of their own.
+	allowJournalStates bool
+	// Volumes can be updated and sealed from version 2. The check that
+	// an assigned manuscript does not leave a sealed volume reads the
+	// previous volume, which version 1 clients did not declare as input.
+	// That check is therefore only done with this rule.
+	allowVolumeUpdates bool
+	// From version 2, the pages of an assigned manuscript should be
+	// numbers that do not overlap the pages of the other manuscripts of
+	// the volume. The volume keeps the page ranges for this check, so
+	// manuscripts assigned by older versions are not checked against.
+	validatePageRanges bool
+	// Persons can transfer balance to other persons from version 2.
+	allowBalanceTransfers bool
+	// Governance commands can be proposed and approved by multiple majors
+	// from version 2. Only version 2 can set the approval threshold, so
+	// the threshold needs no rule of its own.
+	allowProposals bool
+	// Majors can raise the minimum family version from version 2.
+	allowMinimumFamilyVersion bool
+	// From version 2, changing a major updates the major count in the
+	// settings, and a major cannot be revoked when fewer majors than the
+	// approval threshold would remain. Version 1 clients did not declare
+	// the settings as output of an authorization update.
+	countMajors bool
+}
+
+var ruleSets = map[string]*ruleSet{
+	model.FamilyVersion1: {
+		familyVersion: model.FamilyVersion1,
+		parsePayload:  parseCommand,
+	},
+	model.FamilyVersion2: {
+		familyVersion:               model.FamilyVersion2,
+		parsePayload:                parseCommand,
+		rejectCommandWithoutUpdates: true,
+		allowManuscriptWithdraw:     true,
+		allowReviewInvitations:      true,
+		allowBlindReviews:           true,
+		allowReviewRevisions:        true,
+		allowJournalStates:          true,
+		allowVolumeUpdates:          true,
+		validatePageRanges:          true,
+		allowBalanceTransfers:       true,
+		allowProposals:              true,
+		allowMinimumFamilyVersion:   true,
+		countMajors:                 true,
+	},
+}
+
+func init() {
+	for _, v := range model.FamilyVersions {
+		if _, ok := ruleSets[v]; !ok {
+			panic("No rule set for family version " + v)
+		}
+	}
+}
+
+func getRuleSet(familyVersion string) (*ruleSet, error) {
+	result, ok := ruleSets[familyVersion]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Unsupported family version: %s, supported are %v",
+			familyVersion, model.FamilyVersions))
+	}
+	return result, nil
+}
+
+// ParsePayload parses a transaction payload according to the schema of
+// the family version.
+func ParsePayload(familyVersion string, payload []byte) (*model.Command, error) {
+	rules, err := getRuleSet(familyVersion)
+	if err != nil {
+		return nil, err
+	}
+	return rules.parsePayload(payload)
+}
+
+func parseCommand(payload []byte) (*model.Command, error) {
+	result := &model.Command{}
+	if err := proto.Unmarshal(payload, result); err != nil {
+		return nil, errors.New("Could not parse transaction: " + err.Error())
+	}
+	return result, nil
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/iskendria-pub/iskendria/logging"
+	"github.com/iskendria-pub/iskendria/model"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+)
+
+/*
+This package holds the configuration shared by all executables.
+The settings are read from the following sources. Later sources
+override earlier ones:
+
+* The defaults of the executable.
+* A JSON configuration file, given by flag -config or environment
+variable ALEXANDRIA_CONFIG. The keys are the JSON names of the
+fields of Config.
+* Environment variables. For backward compatibility, ALEXANDRIA_IP
+sets the host of both the REST API and the ZMQ endpoint, using the
+default Sawtooth ports.
+* Command-line flags.
+*/
+
+type Config struct {
+	// URL of the Sawtooth REST API, like http://localhost:8008.
+	// HTTPS is supported.
+	RestUrl string `json:"restUrl"`
+	// URL of the validator's component endpoint, like tcp://localhost:4004.
+	// It is used for event subscriptions and by the transaction processor.
+	ZmqUrl        string `json:"zmqUrl"`
+	DatabaseFile  string `json:"databaseFile"`
+	EventLogFile  string `json:"eventLogFile"`
+	PortalAddress string `json:"portalAddress"`
+	DocumentDir   string `json:"documentDir"`
+	// Address of the HTTP endpoint with the metrics and the readiness
+	// probe of the transaction processor, like localhost:9101.
+	// The endpoint is disabled when it is empty.
+	MetricsAddress string `json:"metricsAddress"`
+	// Lowest level of the lines that are logged: debug, info, warn
+	// or error. Debug shows the trace of every transaction and event.
+	LogLevel string `json:"logLevel"`
+	// Family version of the transactions that the client creates. The
+	// transaction processor applies them with the rules of that version.
+	FamilyVersion string `json:"familyVersion"`
+}
+
+const (
+	defaultRestPort = "8008"
+	defaultZmqPort  = "4004"
+)
+
+const (
+	envVarConfig         = "ALEXANDRIA_CONFIG"
+	envVarIp             = "ALEXANDRIA_IP"
+	envVarRestUrl        = "ALEXANDRIA_REST_URL"
+	envVarZmqUrl         = "ALEXANDRIA_ZMQ_URL"
+	envVarDatabaseFile   = "ALEXANDRIA_DATABASE_FILE"
+	envVarEventLogFile   = "ALEXANDRIA_EVENT_LOG_FILE"
+	envVarPortalAddress  = "ALEXANDRIA_PORTAL_ADDRESS"
+	envVarDocumentDir    = "ALEXANDRIA_DOCUMENT_DIR"
+	envVarMetricsAddress = "ALEXANDRIA_METRICS_ADDRESS"
+	envVarLogLevel       = "ALEXANDRIA_LOG_LEVEL"
+	envVarFamilyVersion  = "ALEXANDRIA_FAMILY_VERSION"
+)
+
+// Current is the configuration in use. It is set by Load.
+var Current = GetDefaults()
+
+func GetDefaults() *Config {
+	return &Config{
+		RestUrl:       "http://localhost:" + defaultRestPort,
+		ZmqUrl:        "tcp://localhost:" + defaultZmqPort,
+		DatabaseFile:  "iskendria.db",
+		EventLogFile:  "./events.log",
+		PortalAddress: ":8080",
+		DocumentDir:   "./documents",
+		LogLevel:      "info",
+		FamilyVersion: model.FamilyVersion,
+	}
+}
+
+// Load reads the configuration from all sources and sets Current.
+// It also sets the log level. The command-line arguments that remain
+// after parsing the flags are returned.
+func Load(defaults *Config) ([]string, error) {
+	result, args, err := load(defaults, os.Args[0], os.Args[1:], os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+	Current = result
+	level, _ := logging.ParseLevel(Current.LogLevel)
+	logging.SetLevel(level)
+	return args, nil
+}
+
+func load(defaults *Config, name string, arguments []string, getenv func(string) string) (*Config, []string, error) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := flags.String("config", "", "JSON configuration file")
+	flagValues := &Config{}
+	flags.StringVar(&flagValues.RestUrl, "restUrl", "", "URL of the Sawtooth REST API")
+	flags.StringVar(&flagValues.ZmqUrl, "zmqUrl", "", "URL of the validator ZMQ endpoint")
+	flags.StringVar(&flagValues.DatabaseFile, "databaseFile", "", "Path of the local database")
+	flags.StringVar(&flagValues.EventLogFile, "eventLogFile", "", "Path of the event log file")
+	flags.StringVar(&flagValues.PortalAddress, "portalAddress", "", "Address the portal listens on")
+	flags.StringVar(&flagValues.DocumentDir, "documentDir", "", "Directory of the portal documents")
+	flags.StringVar(&flagValues.MetricsAddress, "metricsAddress", "", "Address the metrics endpoint listens on")
+	flags.StringVar(&flagValues.LogLevel, "logLevel", "", "Lowest level that is logged: debug, info, warn or error")
+	flags.StringVar(&flagValues.FamilyVersion, "familyVersion", "", "Family version of the transactions that are sent")
+	if err := flags.Parse(arguments); err != nil {
+		return nil, nil, err
+	}
+	result := *defaults
+	if *configFile == "" {
+		*configFile = getenv(envVarConfig)
+	}
+	if *configFile != "" {
+		if err := readConfigFile(*configFile, &result); err != nil {
+			return nil, nil, err
+		}
+	}
+	if ip := getenv(envVarIp); ip != "" {
+		result.RestUrl = "http://" + ip + ":" + defaultRestPort
+		result.ZmqUrl = "tcp://" + ip + ":" + defaultZmqPort
+	}
+	override(&result, &Config{
+		RestUrl:        getenv(envVarRestUrl),
+		ZmqUrl:         getenv(envVarZmqUrl),
+		DatabaseFile:   getenv(envVarDatabaseFile),
+		EventLogFile:   getenv(envVarEventLogFile),
+		PortalAddress:  getenv(envVarPortalAddress),
+		DocumentDir:    getenv(envVarDocumentDir),
+		MetricsAddress: getenv(envVarMetricsAddress),
+		LogLevel:       getenv(envVarLogLevel),
+		FamilyVersion:  getenv(envVarFamilyVersion),
+	})
+	override(&result, flagValues)
+	result.RestUrl = strings.TrimSuffix(result.RestUrl, "/")
+	if err := result.check(); err != nil {
+		return nil, nil, err
+	}
+	return &result, flags.Args(), nil
+}
+
+func readConfigFile(fname string, result *Config) error {
+	data, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Could not read configuration file %s: %s", fname, err.Error()))
+	}
+	err = json.Unmarshal(data, result)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Could not parse configuration file %s: %s", fname, err.Error()))
+	}
+	return nil
+}
+
+// Only the non-empty fields of overrides are applied.
+func override(result *Config, overrides *Config) {
+	if overrides.RestUrl != "" {
+		result.RestUrl = overrides.RestUrl
+	}
+	if overrides.ZmqUrl != "" {
+		result.ZmqUrl = overrides.ZmqUrl
+	}
+	if overrides.DatabaseFile != "" {
+		result.DatabaseFile = overrides.DatabaseFile
+	}
+	if overrides.EventLogFile != "" {
+		result.EventLogFile = overrides.EventLogFile
+	}
+	if overrides.PortalAddress != "" {
+		result.PortalAddress = overrides.PortalAddress
+	}
+	if overrides.DocumentDir != "" {
+		result.DocumentDir = overrides.DocumentDir
+	}
+	if overrides.MetricsAddress != "" {
+		result.MetricsAddress = overrides.MetricsAddress
+	}
+	if overrides.LogLevel != "" {
+		result.LogLevel = overrides.LogLevel
+	}
+	if overrides.FamilyVersion != "" {
+		result.FamilyVersion = overrides.FamilyVersion
+	}
+}
+
+func (c *Config) check() error {
+	restUrl, err := url.Parse(c.RestUrl)
+	if err != nil || (restUrl.Scheme != "http" && restUrl.Scheme != "https") || restUrl.Host == "" {
+		return errors.New("The REST URL should be an http or https URL, but is: " + c.RestUrl)
+	}
+	zmqUrl, err := url.Parse(c.ZmqUrl)
+	if err != nil || zmqUrl.Scheme != "tcp" || zmqUrl.Host == "" {
+		return errors.New("The ZMQ URL should be a tcp URL, but is: " + c.ZmqUrl)
+	}
+	if _, err = logging.ParseLevel(c.LogLevel); err != nil {
+		return err
+	}
+	if !model.IsSupportedFamilyVersion(c.FamilyVersion) {
+		return errors.New(fmt.Sprintf("Unsupported family version %s, supported are %v",
+			c.FamilyVersion, model.FamilyVersions))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestPrecedence(t *testing.T) {
+	configFile := "testConfig.json"
+	err := ioutil.WriteFile(configFile, []byte(`{
+  "restUrl": "https://rest.example.com/",
+  "zmqUrl": "tcp://validator.example.com:4004",
+  "databaseFile": "fromFile.db",
+  "eventLogFile": "fromFile.log"
+}`), 0644)
+	if err != nil {
+		t.Error("Could not write configuration file: " + err.Error())
+		return
+	}
+	defer func() { _ = os.Remove(configFile) }()
+	env := map[string]string{
+		envVarConfig:        configFile,
+		envVarDatabaseFile:  "fromEnv.db",
+		envVarEventLogFile:  "fromEnv.log",
+		envVarFamilyVersion: "1.0",
+	}
+	defaults := GetDefaults()
+	defaults.DocumentDir = "defaultDocuments"
+	actual, args, err := load(defaults, "test", []string{"-eventLogFile", "fromFlag.log", "script"},
+		func(key string) string { return env[key] })
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := Config{
+		RestUrl:       "https://rest.example.com",
+		ZmqUrl:        "tcp://validator.example.com:4004",
+		DatabaseFile:  "fromEnv.db",
+		EventLogFile:  "fromFlag.log",
+		PortalAddress: ":8080",
+		DocumentDir:   "defaultDocuments",
+		LogLevel:      "info",
+		FamilyVersion: "1.0",
+	}
+	if *actual != expected {
+		t.Errorf("Configuration mismatch, expected %v, got %v", expected, *actual)
+	}
+	if len(args) != 1 || args[0] != "script" {
+		t.Errorf("Expected remaining argument script, got %v", args)
+	}
+}
+
+func TestLegacyIp(t *testing.T) {
+	env := map[string]string{
+		envVarIp:     "validator",
+		envVarZmqUrl: "tcp://events:4004",
+	}
+	actual, _, err := load(GetDefaults(), "test", []string{}, func(key string) string { return env[key] })
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if actual.RestUrl != "http://validator:8008" {
+		t.Error("REST URL not derived from legacy ip: " + actual.RestUrl)
+	}
+	if actual.ZmqUrl != "tcp://events:4004" {
+		t.Error("ZMQ URL should override legacy ip: " + actual.ZmqUrl)
+	}
+}
+
+func TestInvalidUrl(t *testing.T) {
+	_, _, err := load(GetDefaults(), "test", []string{"-restUrl", "tcp://validator:8008"},
+		func(string) string { return "" })
+	if err == nil {
+		t.Error("Expected error for REST URL with scheme tcp")
+	}
+}
+
+func TestInvalidLogLevel(t *testing.T) {
+	_, _, err := load(GetDefaults(), "test", []string{"-logLevel", "verbose"},
+		func(string) string { return "" })
+	if err == nil {
+		t.Error("Expected error for unknown log level")
+	}
+}
+
+func TestUnsupportedFamilyVersion(t *testing.T) {
+	_, _, err := load(GetDefaults(), "test", []string{"-familyVersion", "0.9"},
+		func(string) string { return "" })
+	if err == nil {
+		t.Error("Expected error for unsupported family version")
+	}
+}
@@ -0,0 +1,62 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type Batch struct {
+	Seq           int32
+	BatchId       string `db:"batchid"`
+	Signer        string
+	CommandType   string `db:"commandtype"`
+	TargetIds     string `db:"targetids"`
+	SubmitTime    int64  `db:"submittime"`
+	Status        string
+	StatusMessage string `db:"statusmessage"`
+	SignerKey     string `db:"signerkey"`
+}
+
+// AddBatch saves a submitted batch and returns its sequence number.
+// Field Seq of the argument is ignored.
+func AddBatch(batch *Batch) (int32, error) {
+	result, err := db.Exec("INSERT INTO batch "+
+		"(batchid, signer, commandtype, targetids, submittime, status, statusmessage, signerkey) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		batch.BatchId, batch.Signer, batch.CommandType, batch.TargetIds,
+		batch.SubmitTime, batch.Status, batch.StatusMessage, batch.SignerKey)
+	if err != nil {
+		return 0, err
+	}
+	seq, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int32(seq), nil
+}
+
+func GetBatch(seq int32) (*Batch, error) {
+	result := new(Batch)
+	err := db.QueryRowx("SELECT * FROM batch WHERE seq = ?", seq).StructScan(result)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBatchesOfKey returns the batches that were signed with the given
+// public key. Batches sent before the key was recorded have an empty
+// signer key and are not returned.
+func GetBatchesOfKey(signerKey string) ([]*Batch, error) {
+	result := []*Batch{}
+	err := db.Select(&result, "SELECT * FROM batch WHERE signerkey = ? ORDER BY seq", signerKey)
+	return result, err
+}
+
+func UpdateBatchStatus(batchId, status, statusMessage string) error {
+	_, err := db.Exec("UPDATE batch SET status = ?, statusmessage = ? WHERE batchid = ?",
+		status, statusMessage, batchId)
+	return err
+}
@@ -0,0 +1,113 @@
+package dao
+
+import (
+	"log"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestBatchHistory(t *testing.T) {
+	logger := log.New(os.Stdout, "testBatchHistory", log.Flags())
+	Init("testBatchHistory.db", logger)
+	defer ShutdownAndDelete(logger)
+	batch := &Batch{
+		BatchId:     "batchId",
+		Signer:      "signer",
+		CommandType: "CommandJournalCreate",
+		TargetIds:   "journalId",
+		SubmitTime:  1100000000,
+		Status:      "PENDING",
+		SignerKey:   "signerKey",
+	}
+	seq, err := AddBatch(batch)
+	if err != nil {
+		t.Error("Could not add batch: " + err.Error())
+		return
+	}
+	err = UpdateBatchStatus("batchId", "INVALID", "Insufficient balance")
+	if err != nil {
+		t.Error("Could not update batch status: " + err.Error())
+		return
+	}
+	// The batch history is not obtained from events and should survive a reset.
+	Reset(logger)
+	actual, err := GetBatch(seq)
+	if err != nil {
+		t.Error("Could not get batch: " + err.Error())
+		return
+	}
+	expected := &Batch{
+		Seq:           seq,
+		BatchId:       "batchId",
+		Signer:        "signer",
+		CommandType:   "CommandJournalCreate",
+		TargetIds:     "journalId",
+		SubmitTime:    1100000000,
+		Status:        "INVALID",
+		StatusMessage: "Insufficient balance",
+		SignerKey:     "signerKey",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Batch mismatch, expected %v, got %v", expected, actual)
+	}
+	own, err := GetBatchesOfKey("signerKey")
+	if err != nil {
+		t.Error("Could not get batches: " + err.Error())
+		return
+	}
+	if len(own) != 1 {
+		t.Errorf("Expected one batch, got %d", len(own))
+	}
+	other, err := GetBatchesOfKey("otherKey")
+	if err != nil {
+		t.Error("Could not get batches: " + err.Error())
+		return
+	}
+	if len(other) != 0 {
+		t.Errorf("Expected no batches of another key, got %d", len(other))
+	}
+}
+
+func TestBatchTableWithoutSignerKey(t *testing.T) {
+	logger := log.New(os.Stdout, "testBatchTableWithoutSignerKey", log.Flags())
+	Init("testBatchTableWithoutSignerKey.db", logger)
+	defer ShutdownAndDelete(logger)
+	if _, err := db.Exec("DROP TABLE batch"); err != nil {
+		t.Error(err)
+		return
+	}
+	_, err := db.Exec(`CREATE TABLE batch (
+	seq integer primary key autoincrement,
+	batchid varchar not null,
+	signer varchar not null,
+	commandtype varchar not null,
+	targetids varchar not null,
+	submittime integer not null,
+	status varchar not null,
+	statusmessage varchar not null
+)`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = db.Exec("INSERT INTO batch (batchid, signer, commandtype, targetids, submittime, status, statusmessage) " +
+		"VALUES ('oldBatch', 'signer', 'CommandJournalCreate', '', 1100000000, 'COMMITTED', '')")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	Shutdown(logger)
+	Init("testBatchTableWithoutSignerKey.db", logger)
+	if _, err = AddBatch(&Batch{BatchId: "newBatch", SignerKey: "signerKey"}); err != nil {
+		t.Error("Could not add batch after upgrade: " + err.Error())
+	}
+	batches, err := GetBatchesOfKey("signerKey")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(batches) != 1 || batches[0].BatchId != "newBatch" {
+		t.Errorf("Expected only the new batch, got %v", batches)
+	}
+}
@@ -0,0 +1,39 @@
+package dao
+
+import (
+	"database/sql"
+)
+
+type BlindReviewSecret struct {
+	ReviewId      string `db:"reviewid"`
+	ReviewerId    string `db:"reviewerid"`
+	Salt          string
+	Authorization string
+}
+
+func AddBlindReviewSecret(secret *BlindReviewSecret) error {
+	_, err := db.Exec("INSERT INTO blindreviewsecret (reviewid, reviewerid, salt, authorization) "+
+		"VALUES (?, ?, ?, ?)",
+		secret.ReviewId, secret.ReviewerId, secret.Salt, secret.Authorization)
+	return err
+}
+
+// GetBlindReviewSecret returns nil if the review was not written
+// on this computer.
+func GetBlindReviewSecret(reviewId string) (*BlindReviewSecret, error) {
+	result := new(BlindReviewSecret)
+	err := db.QueryRowx("SELECT * FROM blindreviewsecret WHERE reviewid = ?", reviewId).StructScan(result)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func GetBlindReviewSecretsOfReviewer(reviewerId string) ([]*BlindReviewSecret, error) {
+	result := []*BlindReviewSecret{}
+	err := db.Select(&result, "SELECT * FROM blindreviewsecret WHERE reviewerid = ? ORDER BY reviewid", reviewerId)
+	return result, err
+}
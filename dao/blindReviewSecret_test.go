@@ -0,0 +1,60 @@
+package dao
+
+import (
+	"log"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestBlindReviewSecret(t *testing.T) {
+	logger := log.New(os.Stdout, "testBlindReviewSecret", log.Flags())
+	Init("testBlindReviewSecret.db", logger)
+	defer ShutdownAndDelete(logger)
+	secret := &BlindReviewSecret{
+		ReviewId:      "reviewId",
+		ReviewerId:    "reviewerId",
+		Salt:          "salt",
+		Authorization: "authorization",
+	}
+	if err := AddBlindReviewSecret(secret); err != nil {
+		t.Error("Could not add blind review secret: " + err.Error())
+		return
+	}
+	// The secrets are not obtained from events and should survive a reset
+	// and a change of the database schema.
+	Reset(logger)
+	if _, err := db.Exec("PRAGMA user_version = 0"); err != nil {
+		t.Error("Could not change the schema version: " + err.Error())
+		return
+	}
+	Shutdown(logger)
+	Init("testBlindReviewSecret.db", logger)
+	if !isInitialized() {
+		t.Error("Expected that the tables filled from events were recreated")
+	}
+	actual, err := GetBlindReviewSecret("reviewId")
+	if err != nil {
+		t.Error("Could not get blind review secret: " + err.Error())
+		return
+	}
+	if !reflect.DeepEqual(actual, secret) {
+		t.Errorf("Secret mismatch, expected %v, got %v", secret, actual)
+	}
+	missing, err := GetBlindReviewSecret("otherReviewId")
+	if err != nil {
+		t.Error("Could not get blind review secret: " + err.Error())
+		return
+	}
+	if missing != nil {
+		t.Error("Expected no secret for a review that was not written here")
+	}
+	secrets, err := GetBlindReviewSecretsOfReviewer("reviewerId")
+	if err != nil {
+		t.Error("Could not get blind review secrets: " + err.Error())
+		return
+	}
+	if len(secrets) != 1 {
+		t.Errorf("Expected one secret, got %d", len(secrets))
+	}
+}
@@ -0,0 +1,197 @@
+package dao
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/iskendria-pub/iskendria/model"
+	"github.com/jmoiron/sqlx"
+)
+
+const THE_METADATA_ID = "1"
+
+// Forks deeper than this number of blocks cannot be rolled back.
+const UNDO_DEPTH = 100
+
+type appliedBlock struct {
+	BlockId         string `db:"blockid"`
+	PreviousBlockId string `db:"previousblockid"`
+	Seq             int64
+}
+
+// GetLastBlockId returns the last block of which all events have
+// been applied, or the empty string when no block was applied yet.
+func GetLastBlockId() (string, error) {
+	var lastBlockId string
+	err := db.QueryRowx("SELECT lastblockid FROM metadata WHERE id = ?", THE_METADATA_ID).Scan(&lastBlockId)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return lastBlockId, err
+}
+
+// registerBlock makes blockId the head of the applied branch. When the
+// block is already the head, its events are being replayed and true is
+// returned.
+func registerBlock(blockId, previousBlockId string) (bool, error) {
+	tx, err := db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	alreadyApplied, err := registerBlockInTransaction(tx, blockId, previousBlockId)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	return alreadyApplied, tx.Commit()
+}
+
+func registerBlockInTransaction(tx *sqlx.Tx, blockId, previousBlockId string) (bool, error) {
+	head, err := getHeadBlock(tx)
+	if err != nil {
+		return false, err
+	}
+	if head != nil && head.BlockId == blockId {
+		return true, nil
+	}
+	seq := int64(0)
+	if head != nil {
+		if head.BlockId != previousBlockId {
+			if err = rollbackToBlock(tx, head, previousBlockId); err != nil {
+				return false, err
+			}
+		}
+		previousBlock, err := getAppliedBlock(tx, previousBlockId)
+		if err != nil {
+			return false, err
+		}
+		seq = previousBlock.Seq + 1
+	}
+	_, err = tx.Exec("INSERT INTO appliedblock (blockid, previousblockid, seq) VALUES (?, ?, ?)",
+		blockId, previousBlockId, seq)
+	if err != nil {
+		return false, err
+	}
+	if err = pruneUndoLog(tx, seq-UNDO_DEPTH); err != nil {
+		return false, err
+	}
+	_, err = tx.Exec("INSERT OR REPLACE INTO metadata (id, lastblockid) VALUES (?, ?)",
+		THE_METADATA_ID, previousBlockId)
+	return false, err
+}
+
+func getHeadBlock(tx *sqlx.Tx) (*appliedBlock, error) {
+	result := new(appliedBlock)
+	err := tx.QueryRowx("SELECT * FROM appliedblock ORDER BY seq DESC LIMIT 1").StructScan(result)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func getAppliedBlock(tx *sqlx.Tx, blockId string) (*appliedBlock, error) {
+	result := new(appliedBlock)
+	err := tx.QueryRowx("SELECT * FROM appliedblock WHERE blockid = ?", blockId).StructScan(result)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(fmt.Sprintf(
+			"Fork from block %s that is not in the undo log, cannot roll back", blockId))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func rollbackToBlock(tx *sqlx.Tx, head *appliedBlock, forkBlockId string) error {
+	if _, err := getAppliedBlock(tx, forkBlockId); err != nil {
+		return err
+	}
+	current := head
+	for current.BlockId != forkBlockId {
+		if err := rollbackBlock(tx, current.BlockId); err != nil {
+			return err
+		}
+		var err error
+		current, err = getAppliedBlock(tx, current.PreviousBlockId)
+		if err != nil {
+			return err
+		}
+	}
+	// The undo statements themselves caused new entries in the undo log.
+	_, err := tx.Exec("DELETE FROM undolog WHERE blockid = ''")
+	return err
+}
+
+func rollbackBlock(tx *sqlx.Tx, blockId string) error {
+	statements := []string{}
+	err := tx.Select(&statements, "SELECT statement FROM undolog WHERE blockid = ? ORDER BY seq DESC", blockId)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err = tx.Exec(statement); err != nil {
+			return errors.New(fmt.Sprintf("Could not roll back block %s, statement %s failed: %s",
+				blockId, statement, err.Error()))
+		}
+	}
+	for _, stmt := range []string{
+		"DELETE FROM undolog WHERE blockid = ?",
+		"DELETE FROM appliedtransaction WHERE blockid = ?",
+		"DELETE FROM appliedblock WHERE blockid = ?",
+	} {
+		if _, err = tx.Exec(stmt, blockId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pruneUndoLog(tx *sqlx.Tx, minSeq int64) error {
+	_, err := tx.Exec("DELETE FROM appliedblock WHERE seq < ?", minSeq)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM undolog WHERE blockid <> '' AND blockid NOT IN (SELECT blockid FROM appliedblock)")
+	return err
+}
+
+func assignUndoLogToBlock(tx *sqlx.Tx, blockId string) error {
+	_, err := tx.Exec("UPDATE undolog SET blockid = ? WHERE blockid = ''", blockId)
+	return err
+}
+
+func isTransactionApplied(tx *sqlx.Tx, transactionId string) (bool, error) {
+	var count int32
+	err := tx.QueryRowx("SELECT COUNT(*) FROM appliedtransaction WHERE transactionid = ?",
+		transactionId).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count >= 1, nil
+}
+
+func addAppliedTransaction(tx *sqlx.Tx, transactionId, blockId string) error {
+	_, err := tx.Exec("INSERT OR REPLACE INTO appliedtransaction (transactionid, blockid) VALUES (?, ?)",
+		transactionId, blockId)
+	return err
+}
+
+func isInitialized() bool {
+	var count int32
+	err := db.QueryRowx("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'undolog'").Scan(&count)
+	if err != nil {
+		return false
+	}
+	if count == 0 {
+		return false
+	}
+	var version int32
+	err = db.QueryRowx("PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return false
+	}
+	return version == model.DatabaseSchemaVersion
+}
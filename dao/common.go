@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
 	"github.com/jmoiron/sqlx"
+	"github.com/iskendria-pub/iskendria/logging"
 	"github.com/iskendria-pub/iskendria/model"
 	"log"
 	"strconv"
@@ -112,15 +113,25 @@ type contextImpl struct {
 	transaction         *sqlx.Tx
 	seenEvents          map[int32]bool
 	expectedNumEvents   int32
+	replaying           bool
+	skipTransaction     bool
 }
 
-func createContext() {
+func createContext(logger *log.Logger) {
 	contextImpl := &contextImpl{}
 	contextImpl.stateNoBlock = &contextStateNoBlock{parent: contextImpl}
 	contextImpl.stateNoTransaction = &contextStateNoTransaction{parent: contextImpl}
 	contextImpl.stateInTransaction = &contextStateInTransaction{parent: contextImpl}
 	contextImpl.stateKnownNumEvents = &contextStateKnownNumEvents{parent: contextImpl}
 	contextImpl.state = contextImpl.stateNoBlock
+	lastBlockId, err := GetLastBlockId()
+	if err != nil {
+		logging.New(logger).Fatal("Could not read last block id", "error", err)
+	}
+	if lastBlockId != "" {
+		contextImpl.blockId = lastBlockId
+		contextImpl.state = contextImpl.stateNoTransaction
+	}
 	theContext = contextImpl
 }
 
@@ -137,20 +148,28 @@ func (c *contextImpl) visitDataManipulation(
 	return c.state.visitDataManipulation(transactionId, eventSeq, dataManipulation)
 }
 
-func (c *contextImpl) changeStateNewBlock(blockId string) {
+// The previous block is complete when a new block starts. After a restart,
+// the last block can be received again. Transactions of that block that
+// were already applied are skipped. When the new block is on another branch,
+// the orphaned blocks are rolled back first.
+func (c *contextImpl) changeStateNewBlock(blockId, previousBlockId string) error {
+	alreadyApplied, err := registerBlock(blockId, previousBlockId)
+	if err != nil {
+		return err
+	}
 	c.blockId = blockId
+	c.replaying = alreadyApplied
 	c.changeStateNoTransaction()
+	return nil
 }
 
 func (c *contextImpl) changeStateNoTransaction() {
-	c.transactionId = ""
 	if c.transaction != nil {
-		err := c.transaction.Commit()
-		if err != nil {
-			panic("Could not commit transaction: " + err.Error())
-		}
+		c.finishTransaction()
 		c.transaction = nil
 	}
+	c.transactionId = ""
+	c.skipTransaction = false
 	c.seenEvents = make(map[int32]bool)
 	c.expectedNumEvents = 0
 	c.state = c.stateNoTransaction
@@ -163,11 +182,39 @@ func (c *contextImpl) changeStateInTransaction(transactionId string) {
 	if err != nil {
 		panic("Could not start transaction: " + err.Error())
 	}
+	if c.replaying {
+		c.skipTransaction, err = isTransactionApplied(c.transaction, transactionId)
+		if err != nil {
+			panic("Could not check whether transaction was applied: " + err.Error())
+		}
+	}
 	c.seenEvents = make(map[int32]bool)
 	c.expectedNumEvents = 0
 	c.state = c.stateInTransaction
 }
 
+func (c *contextImpl) finishTransaction() {
+	if c.skipTransaction {
+		err := c.transaction.Rollback()
+		if err != nil {
+			panic("Could not rollback transaction: " + err.Error())
+		}
+		return
+	}
+	err := addAppliedTransaction(c.transaction, c.transactionId, c.blockId)
+	if err != nil {
+		panic("Could not register applied transaction: " + err.Error())
+	}
+	err = assignUndoLogToBlock(c.transaction, c.blockId)
+	if err != nil {
+		panic("Could not update undo log: " + err.Error())
+	}
+	err = c.transaction.Commit()
+	if err != nil {
+		panic("Could not commit transaction: " + err.Error())
+	}
+}
+
 func (c *contextImpl) changeStateKnownNumEvents(numEvents int32) {
 	c.expectedNumEvents = numEvents
 	c.state = c.stateKnownNumEvents
@@ -178,6 +225,10 @@ func (c *contextImpl) checkAndApply(
 	if err := c.check(transactionId, eventSeq); err != nil {
 		return err
 	}
+	if c.skipTransaction {
+		c.seenEvents[eventSeq] = true
+		return nil
+	}
 	if err := dm.apply(c.transaction); err != nil {
 		return errors.New(fmt.Sprintf("Error executing event for transaction id %s, error %s, eventSeq = %d",
 			err, transactionId, eventSeq))
@@ -214,8 +265,7 @@ type contextStateNoBlock struct {
 }
 
 func (csnb *contextStateNoBlock) visitSawtoothBlockCommit(currentBlockId, previousBlockId string) error {
-	csnb.parent.changeStateNewBlock(currentBlockId)
-	return nil
+	return csnb.parent.changeStateNewBlock(currentBlockId, previousBlockId)
 }
 
 func (csnb *contextStateNoBlock) visitTransactionControl(transactionId string, eventSeq, numEvents int32) error {
@@ -232,12 +282,7 @@ type contextStateNoTransaction struct {
 }
 
 func (csnt *contextStateNoTransaction) visitSawtoothBlockCommit(currentBlockId, previousBlockId string) error {
-	if csnt.parent.blockId != previousBlockId {
-		return errors.New(fmt.Sprintf("Fork detected, expected previous %s but was %s, new = %s",
-			csnt.parent.blockId, previousBlockId, currentBlockId))
-	}
-	csnt.parent.changeStateNewBlock(currentBlockId)
-	return nil
+	return csnt.parent.changeStateNewBlock(currentBlockId, previousBlockId)
 }
 
 func (csnt *contextStateNoTransaction) visitTransactionControl(transactionId string, eventSeq, numEvents int32) error {
@@ -317,6 +362,6 @@ func GetPlaceHolders(n int) string {
 	return strings.Join(placeHolders, ", ")
 }
 
-func logAttribute(a *events_pb2.Event_Attribute, logger *log.Logger) {
-	logger.Printf("Attribute %s = %s\n", a.Key, a.Value)
+func logAttribute(a *events_pb2.Event_Attribute, logger *logging.Logger) {
+	logger.Debug("Attribute", "key", a.Key, "value", a.Value)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/iskendria-pub/iskendria/logging"
 	"github.com/iskendria-pub/iskendria/model"
 	"github.com/iskendria-pub/iskendria/util"
 	"log"
@@ -38,22 +39,68 @@ var AllEventTypes = []string{
 	model.AlexandriaPrefix + model.EV_TYPE_MANUSCRIPT_THREAD_UPDATE,
 	model.AlexandriaPrefix + model.EV_TYPE_REVIEW_CREATE,
 	model.AlexandriaPrefix + model.EV_TYPE_REVIEW_USE_BY_EDITOR,
+	model.AlexandriaPrefix + model.EV_TYPE_REVIEW_REVEAL,
+	model.AlexandriaPrefix + model.EV_TYPE_REVIEW_SUPERSEDE,
+	model.AlexandriaPrefix + model.EV_TYPE_REVIEW_INVITATION_CREATE,
+	model.AlexandriaPrefix + model.EV_TYPE_REVIEW_INVITATION_UPDATE,
+	model.AlexandriaPrefix + model.EV_TYPE_REVIEW_INVITATION_MODIFICATION_TIME,
+	model.AlexandriaPrefix + model.EV_TYPE_PROPOSAL_CREATE,
+	model.AlexandriaPrefix + model.EV_TYPE_PROPOSAL_APPROVE,
+	model.AlexandriaPrefix + model.EV_TYPE_PROPOSAL_UPDATE,
+	model.AlexandriaPrefix + model.EV_TYPE_PROPOSAL_MODIFICATION_TIME,
 }
 
+// Init opens the database, keeping the data of a previous run. When the
+// database was not created with block tracking or has another schema
+// version, the tables filled from events are recreated. They are filled
+// again from the first block. The local tables are kept.
 func Init(fname string, logger *log.Logger) {
-	var err error
 	DbFileName = fname
-	util.RemoveFileIfExists(fname, logger)
-	db, err = sqlx.Open("sqlite3", fname)
+	openDb(logger)
+	if !isInitialized() {
+		dropEventTables(logger)
+		createTables(logger)
+	}
+	createLocalTables(logger)
+	createContext(logger)
+}
+
+// Reset removes all data that was obtained from events, so that the
+// database can be rebuilt from the first block.
+func Reset(logger *log.Logger) {
+	tables := append([]string{}, undoLoggedTables...)
+	tables = append(tables, "metadata", "appliedtransaction", "appliedblock", "undolog")
+	for _, table := range tables {
+		_, err := db.Exec("DELETE FROM " + table)
+		if err != nil {
+			logging.New(logger).Fatal("Could not clear table", "table", table, "error", err)
+		}
+	}
+	createContext(logger)
+}
+
+// ResetContext discards the events of a transaction that was not
+// completely received. Call it before resubscribing to events.
+func ResetContext(logger *log.Logger) {
+	c := theContext.(*contextImpl)
+	if c.transaction != nil {
+		if err := c.transaction.Rollback(); err != nil {
+			logging.New(logger).Warn("Could not rollback partially received transaction", "error", err)
+		}
+	}
+	createContext(logger)
+}
+
+func openDb(logger *log.Logger) {
+	var err error
+	db, err = sqlx.Open("sqlite3", DbFileName)
 	if err != nil {
-		logger.Fatal("Could not open sqlite3 database: " + err.Error())
+		logging.New(logger).Fatal("Could not open sqlite3 database", "file", DbFileName, "error", err)
 	}
 	err = db.Ping()
 	if err != nil {
-		logger.Fatal("Could not ping database: " + err.Error())
+		logging.New(logger).Fatal("Could not ping database", "file", DbFileName, "error", err)
 	}
-	createTables(logger)
-	createContext()
 }
 
 func Shutdown(logger *log.Logger) {
@@ -68,8 +115,13 @@ func ShutdownAndDelete(logger *log.Logger) {
 
 func createTables(logger *log.Logger) {
 	tableCreateStatements := []string{
+		model.TableCreateMetadata,
+		model.TableCreateAppliedTransaction,
+		model.TableCreateAppliedBlock,
+		model.TableCreateUndoLog,
 		model.TableCreateSettings,
 		model.TableCreatePerson,
+		model.TableCreateBalanceChange,
 		model.TableCreateJournal,
 		model.TableCreateEditor,
 		model.TableCreateVolume,
@@ -77,31 +129,103 @@ func createTables(logger *log.Logger) {
 		model.IndexCreateManuscript,
 		model.TableCreateAuthor,
 		model.TableCreateReview,
+		model.TableCreateReviewInvitation,
+		model.TableCreateProposal,
+		model.TableCreateProposalApproval,
+	}
+	for _, stmt := range tableCreateStatements {
+		_, err := db.Exec(stmt)
+		if err != nil {
+			logging.New(logger).Fatal("Table create statement failed", "statement", stmt, "error", err)
+		}
+	}
+	createUndoTriggers(logger)
+	_, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d", model.DatabaseSchemaVersion))
+	if err != nil {
+		logging.New(logger).Fatal("Could not set database schema version", "error", err)
+	}
+}
+
+// dropEventTables drops all tables except the local tables. Indexes and
+// triggers are dropped with their tables.
+func dropEventTables(logger *log.Logger) {
+	localTables := map[string]bool{
+		"batch":             true,
+		"blindreviewsecret": true,
+	}
+	var tables []string
+	err := db.Select(&tables, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'")
+	if err != nil {
+		logging.New(logger).Fatal("Could not read the tables of the database", "error", err)
+	}
+	for _, table := range tables {
+		if localTables[table] {
+			continue
+		}
+		if _, err = db.Exec("DROP TABLE " + table); err != nil {
+			logging.New(logger).Fatal("Could not drop table", "table", table, "error", err)
+		}
+	}
+}
+
+// Local tables are not filled from events. They are created
+// when they do not exist yet, also in existing databases.
+func createLocalTables(logger *log.Logger) {
+	tableCreateStatements := []string{
+		model.TableCreateBatch,
+		model.TableCreateBlindReviewSecret,
 	}
 	for _, stmt := range tableCreateStatements {
 		_, err := db.Exec(stmt)
 		if err != nil {
-			logger.Fatal(fmt.Sprintf("Table create statement failed: %s, error: %s",
-				stmt, err))
+			logging.New(logger).Fatal("Table create statement failed", "statement", stmt, "error", err)
 		}
 	}
+	addColumnIfMissing("batch", "signerkey", "varchar not null default ''", logger)
+}
+
+// addColumnIfMissing upgrades a local table that was created by an older
+// version. Local tables cannot be recreated, because their data is not
+// obtained from events.
+func addColumnIfMissing(table, column, definition string, logger *log.Logger) {
+	var count int
+	err := db.Get(&count, "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column)
+	if err != nil {
+		logging.New(logger).Fatal("Could not read table info", "table", table, "error", err)
+	}
+	if count >= 1 {
+		return
+	}
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	if err != nil {
+		logging.New(logger).Fatal("Could not add column", "table", table, "column", column, "error", err)
+	}
 }
 
+// HandleEvent applies an event to the database. The lines logged about
+// the event carry its transaction id and sequence number, which together
+// identify the event, or the block id of a block commit.
 func HandleEvent(input *events_pb2.Event, logger *log.Logger) error {
-	ev, err := parseEvent(input, logger)
+	eventLogger := getEventLogger(input, logger)
+	ev, err := parseEvent(input, eventLogger)
 	if err != nil {
+		eventLogger.Debug("Could not parse event", "error", err)
+		return err
+	}
+	if err = ev.accept(theContext); err != nil {
+		eventLogger.Debug("Could not apply event", "error", err)
 		return err
 	}
-	return ev.accept(theContext)
+	eventLogger.Debug("Applied event")
+	return nil
 }
 
 type EventHandler func(*events_pb2.Event, *log.Logger) error
 
 var _ EventHandler = HandleEvent
 
-func parseEvent(input *events_pb2.Event, logger *log.Logger) (event, error) {
-	logEvent(input, logger)
-	defer logger.Printf("Done parsing event of type %s\n", input.EventType)
+func parseEvent(input *events_pb2.Event, logger *logging.Logger) (event, error) {
+	logger.Debug("Parsing event")
 	switch simplifyEventType(input.EventType) {
 	case model.SAWTOOTH_BLOCK_COMMIT:
 		return createSawtoothBlockCommitEvent(input)
@@ -129,6 +253,10 @@ func parseEvent(input *events_pb2.Event, logger *log.Logger) (event, error) {
 		return createPersonCreateEvent(input)
 	case model.EV_TYPE_VOLUME_CREATE:
 		return createVolumeCreateEvent(input)
+	case model.EV_TYPE_VOLUME_UPDATE:
+		return createVolumeUpdateEvent(input)
+	case model.EV_TYPE_VOLUME_PAGE_RANGE:
+		return createVolumePageRangeEvent(input)
 	case model.EV_TYPE_PERSON_UPDATE:
 		return createPersonUpdateEvent(input)
 	case model.EV_TYPE_PERSON_MODIFICATION_TIME:
@@ -149,24 +277,42 @@ func parseEvent(input *events_pb2.Event, logger *log.Logger) (event, error) {
 		return createReviewCreateEvent(input)
 	case model.EV_TYPE_REVIEW_USE_BY_EDITOR:
 		return createReviewUseByEditorEvent(input)
+	case model.EV_TYPE_REVIEW_REVEAL:
+		return createReviewRevealEvent(input)
+	case model.EV_TYPE_REVIEW_SUPERSEDE:
+		return createReviewSupersedeEvent(input)
+	case model.EV_TYPE_REVIEW_INVITATION_CREATE:
+		return createReviewInvitationCreateEvent(input)
+	case model.EV_TYPE_REVIEW_INVITATION_UPDATE:
+		return createReviewInvitationUpdateEvent(input)
+	case model.EV_TYPE_REVIEW_INVITATION_MODIFICATION_TIME:
+		return createReviewInvitationModificationTimeEvent(input)
+	case model.EV_TYPE_PROPOSAL_CREATE:
+		return createProposalCreateEvent(input)
+	case model.EV_TYPE_PROPOSAL_APPROVE:
+		return createProposalApproveEvent(input)
+	case model.EV_TYPE_PROPOSAL_UPDATE:
+		return createProposalUpdateEvent(input)
+	case model.EV_TYPE_PROPOSAL_MODIFICATION_TIME:
+		return createProposalModificationTimeEvent(input)
 	default:
 		return nil, errors.New("Unknown event type: " + input.EventType)
 	}
 }
 
-func logEvent(ev *events_pb2.Event, logger *log.Logger) {
-	var transactionId string
-	var eventSeq string
+func getEventLogger(ev *events_pb2.Event, logger *log.Logger) *logging.Logger {
+	keyValues := []interface{}{"eventType", ev.EventType}
 	for _, a := range ev.Attributes {
 		switch a.Key {
 		case model.EV_KEY_TRANSACTION_ID:
-			transactionId = a.Value
+			keyValues = append(keyValues, "transactionId", a.Value)
 		case model.EV_KEY_EVENT_SEQ:
-			eventSeq = a.Value
+			keyValues = append(keyValues, "eventSeq", a.Value)
+		case model.SAWTOOTH_CURRENT_BLOCK_ID:
+			keyValues = append(keyValues, "blockId", a.Value)
 		}
 	}
-	logger.Printf("Parsing event with type %s, transactionId = %s, eventSeq = %s\n",
-		ev.EventType, transactionId, eventSeq)
+	return logging.New(logger).With(keyValues...)
 }
 
 func simplifyEventType(orig string) string {
@@ -183,3 +183,89 @@ func (dm *dataManipulationManuscriptModificationTime) apply(tx *sqlx.Tx) error {
 		dm.timestamp, dm.id))
 	return err
 }
+
+func createReviewInvitationModificationTimeEvent(input *events_pb2.Event) (event, error) {
+	dm := new(dataManipulationReviewInvitationModificationTime)
+
+	result := &dataManipulationEvent{
+		dataManipulation: dm,
+	}
+	var err error
+	var i64 int64
+	for _, a := range input.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		case model.EV_KEY_TIMESTAMP:
+			i64, err = strconv.ParseInt(a.Value, 10, 64)
+			dm.timestamp = i64
+		case model.EV_KEY_ID:
+			dm.id = a.Value
+		default:
+			err = errors.New("createReviewInvitationModificationTimeEvent: Unknown event attribute: " + a.Key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type dataManipulationReviewInvitationModificationTime struct {
+	id        string
+	timestamp int64
+}
+
+var _ dataManipulation = new(dataManipulationReviewInvitationModificationTime)
+
+func (dm *dataManipulationReviewInvitationModificationTime) apply(tx *sqlx.Tx) error {
+	_, err := tx.Exec(fmt.Sprintf("UPDATE reviewinvitation SET modifiedon = %d WHERE id = \"%s\"",
+		dm.timestamp, dm.id))
+	return err
+}
+
+func createProposalModificationTimeEvent(input *events_pb2.Event) (event, error) {
+	dm := new(dataManipulationProposalModificationTime)
+
+	result := &dataManipulationEvent{
+		dataManipulation: dm,
+	}
+	var err error
+	var i64 int64
+	for _, a := range input.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		case model.EV_KEY_TIMESTAMP:
+			i64, err = strconv.ParseInt(a.Value, 10, 64)
+			dm.timestamp = i64
+		case model.EV_KEY_ID:
+			dm.id = a.Value
+		default:
+			err = errors.New("createProposalModificationTimeEvent: Unknown event attribute: " + a.Key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type dataManipulationProposalModificationTime struct {
+	id        string
+	timestamp int64
+}
+
+var _ dataManipulation = new(dataManipulationProposalModificationTime)
+
+func (dm *dataManipulationProposalModificationTime) apply(tx *sqlx.Tx) error {
+	_, err := tx.Exec(fmt.Sprintf("UPDATE proposal SET modifiedon = %d WHERE id = \"%s\"",
+		dm.timestamp, dm.id))
+	return err
+}
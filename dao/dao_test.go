@@ -113,7 +113,9 @@ func applyEventsHappy(events []*events_pb2.Event, personId string, t *testing.T)
 		actualSettings.PriceEditorCreateVolume != int32(15) ||
 		actualSettings.PriceEditorEditJournal != int32(16) ||
 		actualSettings.PriceEditorAddColleague != int32(17) ||
-		actualSettings.PriceEditorAcceptDuty != int32(18) {
+		actualSettings.PriceEditorAcceptDuty != int32(18) ||
+		actualSettings.PriceAuthorWithdrawManuscript != int32(19) ||
+		actualSettings.PriceEditorInviteReviewer != int32(20) {
 		t.Error("Price mismatch")
 	}
 	if actualPerson.Id != personId {
@@ -167,6 +169,292 @@ func applyEventsHappy(events []*events_pb2.Event, personId string, t *testing.T)
 	}
 }
 
+/*
+The events of the second block are applied partially before the database
+is closed. After reopening, the second block is replayed. The transaction
+that was already applied should be skipped, the other one applied.
+*/
+func TestResumeFromLastBlock(t *testing.T) {
+	logger := log.New(os.Stdout, "testResume", log.Flags())
+	dbFile := "testResume.db"
+	util.RemoveFileIfExists(dbFile, logger)
+	firstPersonId := model.CreatePersonAddress()
+	secondPersonId := model.CreatePersonAddress()
+	firstSession := []*events_pb2.Event{
+		getBlockCommitEvent(firstBlock, ""),
+		getTransactionControlEvent("firstTransaction", 0, 2),
+		getCreateSettingsEvent("firstTransaction", 1),
+		getBlockCommitEvent(secondBlock, firstBlock),
+		getTransactionControlEvent("secondTransaction", 0, 2),
+		getCreatePersonEvent("secondTransaction", 1, firstPersonId),
+	}
+	secondSession := []*events_pb2.Event{
+		getBlockCommitEvent(secondBlock, firstBlock),
+		getTransactionControlEvent("secondTransaction", 0, 2),
+		getCreatePersonEvent("secondTransaction", 1, firstPersonId),
+		getTransactionControlEvent("thirdTransaction", 0, 2),
+		getCreatePersonEvent("thirdTransaction", 1, secondPersonId),
+		getBlockCommitEvent(thirdBlock, secondBlock),
+	}
+	Init(dbFile, logger)
+	handleEventsForTest(firstSession, logger, t)
+	checkLastBlockId(firstBlock, t)
+	Shutdown(logger)
+	Init(dbFile, logger)
+	defer ShutdownAndDelete(logger)
+	checkLastBlockId(firstBlock, t)
+	handleEventsForTest(secondSession, logger, t)
+	checkLastBlockId(secondBlock, t)
+	for _, personId := range []string{firstPersonId, secondPersonId} {
+		person, err := GetPersonById(personId)
+		if err != nil {
+			t.Error("Could not get person: " + err.Error())
+			return
+		}
+		if person == nil {
+			t.Error("Person was not present in db: " + personId)
+		}
+	}
+}
+
+/*
+The second and third block are orphaned by a fork that starts after
+the first block. The person created in the first block is renamed in
+the orphaned branch and another person is created there. After the
+fork, the rename and the creation should be rolled back and the
+transaction of the new branch should be applied.
+*/
+func TestForkRollsBackOrphanedBlocks(t *testing.T) {
+	logger := log.New(os.Stdout, "testFork", log.Flags())
+	dbFile := "testFork.db"
+	util.RemoveFileIfExists(dbFile, logger)
+	Init(dbFile, logger)
+	defer ShutdownAndDelete(logger)
+	firstPersonId := model.CreatePersonAddress()
+	orphanedPersonId := model.CreatePersonAddress()
+	newBranchPersonId := model.CreatePersonAddress()
+	const forkBlock = "fork block"
+	events := []*events_pb2.Event{
+		getBlockCommitEvent(firstBlock, ""),
+		getTransactionControlEvent("firstTransaction", 0, 3),
+		getCreateSettingsEvent("firstTransaction", 1),
+		getCreatePersonEvent("firstTransaction", 2, firstPersonId),
+		getBlockCommitEvent(secondBlock, firstBlock),
+		getTransactionControlEvent("orphanedTransaction", 0, 3),
+		getUpdatePersonNameEvent("orphanedTransaction", 1, firstPersonId, "Orphaned"),
+		getCreatePersonEvent("orphanedTransaction", 2, orphanedPersonId),
+		getBlockCommitEvent(thirdBlock, secondBlock),
+		getBlockCommitEvent(forkBlock, firstBlock),
+		getTransactionControlEvent("newBranchTransaction", 0, 2),
+		getCreatePersonEvent("newBranchTransaction", 1, newBranchPersonId),
+	}
+	handleEventsForTest(events, logger, t)
+	checkLastBlockId(firstBlock, t)
+	firstPerson, err := GetPersonById(firstPersonId)
+	if err != nil {
+		t.Error("Could not get person: " + err.Error())
+		return
+	}
+	if firstPerson == nil || firstPerson.Name != "Martijn" {
+		t.Error("Update of orphaned block was not rolled back")
+	}
+	orphanedPerson, err := GetPersonById(orphanedPersonId)
+	if err != nil {
+		t.Error("Could not get person: " + err.Error())
+		return
+	}
+	if orphanedPerson != nil {
+		t.Error("Person created in orphaned block was not removed")
+	}
+	newBranchPerson, err := GetPersonById(newBranchPersonId)
+	if err != nil {
+		t.Error("Could not get person: " + err.Error())
+		return
+	}
+	if newBranchPerson == nil {
+		t.Error("Person created in new branch was not present")
+	}
+}
+
+/*
+The connection fails while a transaction is partially received. After
+resetting the context, the block is received again from the start.
+*/
+func TestResetContextDuringTransaction(t *testing.T) {
+	logger := log.New(os.Stdout, "testResetContext", log.Flags())
+	dbFile := "testResetContext.db"
+	util.RemoveFileIfExists(dbFile, logger)
+	Init(dbFile, logger)
+	defer ShutdownAndDelete(logger)
+	personId := model.CreatePersonAddress()
+	handleEventsForTest([]*events_pb2.Event{
+		getBlockCommitEvent(firstBlock, ""),
+		getTransactionControlEvent(theTransactionId, 0, 3),
+		getCreateSettingsEvent(theTransactionId, 1),
+	}, logger, t)
+	ResetContext(logger)
+	handleEventsForTest([]*events_pb2.Event{
+		getBlockCommitEvent(firstBlock, ""),
+		getTransactionControlEvent(theTransactionId, 0, 3),
+		getCreateSettingsEvent(theTransactionId, 1),
+		getCreatePersonEvent(theTransactionId, 2, personId),
+	}, logger, t)
+	person, err := GetPersonById(personId)
+	if err != nil {
+		t.Error("Could not get person: " + err.Error())
+		return
+	}
+	if person == nil {
+		t.Error("Person was not present in db: " + personId)
+	}
+}
+
+func TestForkFromUnknownBlock(t *testing.T) {
+	logger := log.New(os.Stdout, "testForkUnknown", log.Flags())
+	dbFile := "testForkUnknown.db"
+	util.RemoveFileIfExists(dbFile, logger)
+	Init(dbFile, logger)
+	defer ShutdownAndDelete(logger)
+	handleEventsForTest([]*events_pb2.Event{
+		getBlockCommitEvent(firstBlock, ""),
+		getBlockCommitEvent(secondBlock, firstBlock),
+	}, logger, t)
+	err := HandleEvent(getBlockCommitEvent(thirdBlock, "unknown block"), logger)
+	if err == nil {
+		t.Error("Expected error for fork from unknown block")
+	}
+}
+
+/*
+The first balance update is issued before the balance ledger existed,
+so it has no amount and no command type. Its amount is calculated from
+the previous balance and it is reported as unattributed.
+*/
+func TestBalanceLedger(t *testing.T) {
+	logger := log.New(os.Stdout, "testBalanceLedger", log.Flags())
+	dbFile := "testBalanceLedger.db"
+	util.RemoveFileIfExists(dbFile, logger)
+	Init(dbFile, logger)
+	defer ShutdownAndDelete(logger)
+	personId := model.CreatePersonAddress()
+	handleEventsForTest([]*events_pb2.Event{
+		getBlockCommitEvent(firstBlock, ""),
+		getTransactionControlEvent("firstTransaction", 0, 3),
+		getCreateSettingsEvent("firstTransaction", 1),
+		getCreatePersonEvent("firstTransaction", 2, personId),
+		getTransactionControlEvent("legacyTransaction", 0, 2),
+		getUpdatePersonBalanceEvent("legacyTransaction", 1, personId, 50),
+		getTransactionControlEvent("incrementTransaction", 0, 2),
+		getUpdatePersonBalanceEvent("incrementTransaction", 1, personId, 80,
+			&events_pb2.Event_Attribute{Key: model.EV_KEY_PERSON_BALANCE_AMOUNT, Value: "30"},
+			&events_pb2.Event_Attribute{Key: model.EV_KEY_PERSON_COMMAND_TYPE, Value: model.COMMAND_TYPE_BALANCE_INCREMENT}),
+		getTransactionControlEvent("paymentTransaction", 0, 2),
+		getUpdatePersonBalanceEvent("paymentTransaction", 1, personId, 75,
+			&events_pb2.Event_Attribute{Key: model.EV_KEY_PERSON_BALANCE_AMOUNT, Value: "-5"},
+			&events_pb2.Event_Attribute{Key: model.EV_KEY_PERSON_COMMAND_TYPE, Value: "CommandManuscriptCreate"}),
+	}, logger, t)
+	history, err := GetBalanceHistory(personId)
+	if err != nil {
+		t.Error("Could not get balance history: " + err.Error())
+		return
+	}
+	expectedAmounts := []int32{50, 30, -5}
+	expectedBalances := []int32{50, 80, 75}
+	if len(history) != len(expectedAmounts) {
+		t.Error(fmt.Sprintf("Expected %d balance changes, got %d", len(expectedAmounts), len(history)))
+		return
+	}
+	for i, c := range history {
+		if c.Amount != expectedAmounts[i] || c.Balance != expectedBalances[i] {
+			t.Error(fmt.Sprintf("Balance change %d mismatch: %v", i, c))
+		}
+	}
+	if history[2].CommandType != "CommandManuscriptCreate" || history[2].TransactionId != "paymentTransaction" {
+		t.Error(fmt.Sprintf("Balance change not attributed to its command: %v", history[2]))
+	}
+	summary, err := GetCreditSummary(personId)
+	if err != nil {
+		t.Error("Could not get credit summary: " + err.Error())
+		return
+	}
+	if summary.Issued != 30 || summary.Paid != 5 || summary.Unattributed != 50 || summary.Balance != 75 {
+		t.Error(fmt.Sprintf("Credit summary mismatch: %v", summary))
+	}
+	if !summary.IsReconciled() {
+		t.Error("Expected credit summary to be reconciled")
+	}
+}
+
+func handleEventsForTest(events []*events_pb2.Event, logger *log.Logger, t *testing.T) {
+	for _, ev := range events {
+		if err := HandleEvent(ev, logger); err != nil {
+			t.Error(fmt.Sprintf("Error handling event: error %s, event %v", err, ev))
+		}
+	}
+}
+
+func checkLastBlockId(expected string, t *testing.T) {
+	actual, err := GetLastBlockId()
+	if err != nil {
+		t.Error("Could not get last block id: " + err.Error())
+		return
+	}
+	if actual != expected {
+		t.Error(fmt.Sprintf("Last block id mismatch, expected %s, got %s", expected, actual))
+	}
+}
+
+func getUpdatePersonNameEvent(transactionId string, eventSeq int32, personId, name string) *events_pb2.Event {
+	return &events_pb2.Event{
+		EventType: model.EV_TYPE_PERSON_UPDATE,
+		Attributes: []*events_pb2.Event_Attribute{
+			{
+				Key:   model.EV_KEY_TRANSACTION_ID,
+				Value: transactionId,
+			},
+			{
+				Key:   model.EV_KEY_EVENT_SEQ,
+				Value: strconv.FormatInt(int64(eventSeq), 10),
+			},
+			{
+				Key:   model.EV_KEY_ID,
+				Value: personId,
+			},
+			{
+				Key:   model.EV_KEY_PERSON_NAME,
+				Value: name,
+			},
+		},
+	}
+}
+
+func getUpdatePersonBalanceEvent(
+	transactionId string, eventSeq int32, personId string, balance int32,
+	extraAttributes ...*events_pb2.Event_Attribute) *events_pb2.Event {
+	attributes := []*events_pb2.Event_Attribute{
+		{
+			Key:   model.EV_KEY_TRANSACTION_ID,
+			Value: transactionId,
+		},
+		{
+			Key:   model.EV_KEY_EVENT_SEQ,
+			Value: strconv.FormatInt(int64(eventSeq), 10),
+		},
+		{
+			Key:   model.EV_KEY_ID,
+			Value: personId,
+		},
+		{
+			Key:   model.EV_KEY_PERSON_BALANCE,
+			Value: strconv.FormatInt(int64(balance), 10),
+		},
+	}
+	return &events_pb2.Event{
+		EventType:  model.EV_TYPE_PERSON_UPDATE,
+		Attributes: append(attributes, extraAttributes...),
+	}
+}
+
 func getBlockCommitEvent(current, previous string) *events_pb2.Event {
 	return &events_pb2.Event{
 		EventType: model.SAWTOOTH_BLOCK_COMMIT,
@@ -291,6 +579,14 @@ func getCreateSettingsEvent(transactionId string, eventSeq int32) *events_pb2.Ev
 				Key:   model.EV_KEY_PRICE_EDITOR_ACCEPT_DUTY,
 				Value: "18",
 			},
+			{
+				Key:   model.EV_KEY_PRICE_AUTHOR_WITHDRAW_MANUSCRIPT,
+				Value: "19",
+			},
+			{
+				Key:   model.EV_KEY_PRICE_EDITOR_INVITE_REVIEWER,
+				Value: "20",
+			},
 		},
 	}
 }
@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"github.com/iskendria-pub/iskendria/model"
+	"github.com/jmoiron/sqlx"
+	"sort"
+	"strings"
+)
+
+// DumpEventTables returns the rows of the tables that are filled from
+// events, by table name. A row is formatted as column=value pairs. The
+// rows of each table are sorted, so that the dumps of two databases can
+// be compared.
+func DumpEventTables() (map[string][]string, error) {
+	return dumpEventTables(db)
+}
+
+// DumpEventTablesOfFile is DumpEventTables for a database that is not
+// opened with Init. The file is opened read-only and is not upgraded,
+// so that the database of a running client can be dumped without
+// changing it.
+func DumpEventTablesOfFile(fname string) (map[string][]string, error) {
+	other, err := sqlx.Open("sqlite3", "file:"+fname+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = other.Close() }()
+	var version int32
+	if err = other.QueryRowx("PRAGMA user_version").Scan(&version); err != nil {
+		return nil, err
+	}
+	if version != model.DatabaseSchemaVersion {
+		return nil, errors.New(fmt.Sprintf("Database %s has schema version %d, expected %d",
+			fname, version, model.DatabaseSchemaVersion))
+	}
+	return dumpEventTables(other)
+}
+
+func dumpEventTables(q sqlx.Queryer) (map[string][]string, error) {
+	result := make(map[string][]string)
+	for _, table := range undoLoggedTables {
+		rows, err := dumpTable(q, table)
+		if err != nil {
+			return nil, err
+		}
+		result[table] = rows
+	}
+	return result, nil
+}
+
+func dumpTable(q sqlx.Queryer, table string) ([]string, error) {
+	columns, err := getColumnNames(q, table)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.Queryx(fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), table))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	result := []string{}
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return nil, err
+		}
+		fields := make([]string, len(columns))
+		for i, c := range columns {
+			fields[i] = fmt.Sprintf("%s=%s", c, formatDumpedValue(values[i]))
+		}
+		result = append(result, strings.Join(fields, ", "))
+	}
+	sort.Strings(result)
+	return result, rows.Err()
+}
+
+func formatDumpedValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return fmt.Sprintf("%q", string(v))
+	case string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -5,14 +5,14 @@ import (
 	"fmt"
 	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
 	"github.com/jmoiron/sqlx"
+	"github.com/iskendria-pub/iskendria/logging"
 	"github.com/iskendria-pub/iskendria/model"
-	"log"
 	"sort"
 	"strconv"
 	"strings"
 )
 
-func createJournalCreateEvent(ev *events_pb2.Event, logger *log.Logger) (event, error) {
+func createJournalCreateEvent(ev *events_pb2.Event, logger *logging.Logger) (event, error) {
 	dm := &dataManipulationJournalCreate{}
 	result := &dataManipulationEvent{
 		dataManipulation: dm,
@@ -54,12 +54,13 @@ type dataManipulationJournalCreate struct {
 var _ dataManipulation = new(dataManipulationJournalCreate)
 
 func (dm *dataManipulationJournalCreate) apply(tx *sqlx.Tx) error {
-	_, err := tx.Exec(fmt.Sprintf("INSERT INTO journal VALUES (%s)", GetPlaceHolders(6)),
-		dm.journalId, dm.timestamp, dm.timestamp, dm.title, false, dm.descriptionHash)
+	_, err := tx.Exec(fmt.Sprintf("INSERT INTO journal VALUES (%s)", GetPlaceHolders(8)),
+		dm.journalId, dm.timestamp, dm.timestamp, dm.title, false, dm.descriptionHash, false,
+		model.GetJournalStateString(model.JournalState_journalOpen))
 	return err
 }
 
-func createEditorCreateEvent(ev *events_pb2.Event, logger *log.Logger) (event, error) {
+func createEditorCreateEvent(ev *events_pb2.Event, logger *logging.Logger) (event, error) {
 	dm := &dataManipulationEditorCreate{}
 	result := &dataManipulationEvent{
 		dataManipulation: dm,
@@ -105,6 +106,8 @@ func (dm *dataManipulationEditorCreate) apply(tx *sqlx.Tx) error {
 func createJournalUpdateEvent(ev *events_pb2.Event) (event, error) {
 	dmProperties := &dataManipulationJournalUpdateProperties{}
 	dmAuthorization := &dataManipulationJournalUpdateAuthorization{}
+	dmReviewPolicy := &dataManipulationJournalUpdateReviewPolicy{}
+	dmState := &dataManipulationJournalUpdateState{}
 	result := &dataManipulationEvent{}
 	var err error
 	var i64 int64
@@ -119,6 +122,8 @@ func createJournalUpdateEvent(ev *events_pb2.Event) (event, error) {
 		case model.EV_KEY_ID:
 			dmProperties.id = a.Value
 			dmAuthorization.id = a.Value
+			dmReviewPolicy.id = a.Value
+			dmState.id = a.Value
 		case model.EV_KEY_JOURNAL_TITLE, model.EV_KEY_JOURNAL_DESCRIPTION_HASH:
 			result.dataManipulation = dmProperties
 			dmProperties.field = a.Key
@@ -127,6 +132,13 @@ func createJournalUpdateEvent(ev *events_pb2.Event) (event, error) {
 			b, err = strconv.ParseBool(a.Value)
 			result.dataManipulation = dmAuthorization
 			dmAuthorization.newIsSigned = b
+		case model.EV_KEY_JOURNAL_REVIEW_BY_INVITATION_ONLY:
+			b, err = strconv.ParseBool(a.Value)
+			result.dataManipulation = dmReviewPolicy
+			dmReviewPolicy.newReviewByInvitationOnly = b
+		case model.EV_KEY_JOURNAL_STATE:
+			result.dataManipulation = dmState
+			dmState.newJournalState = a.Value
 		}
 		if err != nil {
 			return nil, err
@@ -163,7 +175,33 @@ func (dm *dataManipulationJournalUpdateAuthorization) apply(tx *sqlx.Tx) error {
 	return err
 }
 
-func createEditorDeleteEvent(ev *events_pb2.Event, logger *log.Logger) (event, error) {
+type dataManipulationJournalUpdateReviewPolicy struct {
+	id                        string
+	newReviewByInvitationOnly bool
+}
+
+var _ dataManipulation = new(dataManipulationJournalUpdateReviewPolicy)
+
+func (dm *dataManipulationJournalUpdateReviewPolicy) apply(tx *sqlx.Tx) error {
+	_, err := tx.Exec("UPDATE journal SET reviewbyinvitationonly = ? WHERE journalId = ?",
+		dm.newReviewByInvitationOnly, dm.id)
+	return err
+}
+
+type dataManipulationJournalUpdateState struct {
+	id              string
+	newJournalState string
+}
+
+var _ dataManipulation = new(dataManipulationJournalUpdateState)
+
+func (dm *dataManipulationJournalUpdateState) apply(tx *sqlx.Tx) error {
+	_, err := tx.Exec("UPDATE journal SET journalstate = ? WHERE journalId = ?",
+		dm.newJournalState, dm.id)
+	return err
+}
+
+func createEditorDeleteEvent(ev *events_pb2.Event, logger *logging.Logger) (event, error) {
 	dm := &dataManipulationEditorDelete{}
 	result := &dataManipulationEvent{
 		dataManipulation: dm,
@@ -203,7 +241,7 @@ func (dm *dataManipulationEditorDelete) apply(tx *sqlx.Tx) error {
 	return err
 }
 
-func createEditorUpdateEvent(ev *events_pb2.Event, logger *log.Logger) (event, error) {
+func createEditorUpdateEvent(ev *events_pb2.Event, logger *logging.Logger) (event, error) {
 	dm := &dataManipulationEditorUpdate{}
 	result := &dataManipulationEvent{
 		dataManipulation: dm,
@@ -322,13 +360,15 @@ func getJournalsWithEditorsForQuery(tx *sqlx.Tx, query string) ([]*Journal, erro
 }
 
 type Journal struct {
-	JournalId       string
-	CreatedOn       int64
-	ModifiedOn      int64
-	Title           string
-	IsSigned        bool
-	Descriptionhash string
-	AcceptedEditors []*Editor
+	JournalId              string
+	CreatedOn              int64
+	ModifiedOn             int64
+	Title                  string
+	IsSigned               bool
+	Descriptionhash        string
+	ReviewByInvitationOnly bool
+	JournalState           string
+	AcceptedEditors        []*Editor
 }
 
 type Editor struct {
@@ -338,15 +378,17 @@ type Editor struct {
 }
 
 type JournalEditorCombination struct {
-	JournalId       string
-	CreatedOn       int64
-	ModifiedOn      int64
-	Title           string
-	IsSigned        bool
-	Descriptionhash string
-	PersonId        string
-	PersonName      string
-	PersonIsSigned  bool
+	JournalId              string
+	CreatedOn              int64
+	ModifiedOn             int64
+	Title                  string
+	IsSigned               bool
+	Descriptionhash        string
+	ReviewByInvitationOnly bool
+	JournalState           string
+	PersonId               string
+	PersonName             string
+	PersonIsSigned         bool
 }
 
 func getJournalEditorCombinationsQuery() string {
@@ -358,6 +400,8 @@ SELECT
   journal.title,
   journal.issigned,
   journal.descriptionhash,
+  journal.reviewbyinvitationonly,
+  journal.journalstate,
   editor.personid,
   person.name AS personname,
   person.issigned AS personissigned
@@ -390,6 +434,8 @@ func journalEditorCombinationToJournal(jec *JournalEditorCombination) *Journal {
 	journal.Title = jec.Title
 	journal.IsSigned = jec.IsSigned
 	journal.Descriptionhash = jec.Descriptionhash
+	journal.ReviewByInvitationOnly = jec.ReviewByInvitationOnly
+	journal.JournalState = jec.JournalState
 	journal.AcceptedEditors = []*Editor{
 		{
 			PersonId:       jec.PersonId,
@@ -421,7 +467,9 @@ SELECT
   modifiedon,
   title,
   issigned,
-  descriptionhash
+  descriptionhash,
+  reviewbyinvitationonly,
+  journalstate
 FROM journal
 WHERE journalId NOT IN (
   SELECT journalId FROM editor
@@ -430,22 +478,26 @@ WHERE journalId NOT IN (
 }
 
 type JournalExcludingEditors struct {
-	JournalId       string
-	CreatedOn       int64
-	ModifiedOn      int64
-	Title           string
-	IsSigned        bool
-	Descriptionhash string
+	JournalId              string
+	CreatedOn              int64
+	ModifiedOn             int64
+	Title                  string
+	IsSigned               bool
+	Descriptionhash        string
+	ReviewByInvitationOnly bool
+	JournalState           string
 }
 
 func journalExcludingEditorsToJournal(jwe *JournalExcludingEditors) *Journal {
 	return &Journal{
-		JournalId:       jwe.JournalId,
-		CreatedOn:       jwe.CreatedOn,
-		ModifiedOn:      jwe.ModifiedOn,
-		Title:           jwe.Title,
-		IsSigned:        jwe.IsSigned,
-		Descriptionhash: jwe.Descriptionhash,
+		JournalId:              jwe.JournalId,
+		CreatedOn:              jwe.CreatedOn,
+		ModifiedOn:             jwe.ModifiedOn,
+		Title:                  jwe.Title,
+		IsSigned:               jwe.IsSigned,
+		Descriptionhash:        jwe.Descriptionhash,
+		ReviewByInvitationOnly: jwe.ReviewByInvitationOnly,
+		JournalState:           jwe.JournalState,
 	}
 }
 
@@ -546,13 +598,15 @@ func VerifyJournalDescription(journalId string, data []byte) error {
 }
 
 type JournalIncludingProposedEditors struct {
-	JournalId       string
-	CreatedOn       int64
-	ModifiedOn      int64
-	Title           string
-	IsSigned        bool
-	Descriptionhash string
-	AllEditors      []*EditorWithState
+	JournalId              string
+	CreatedOn              int64
+	ModifiedOn             int64
+	Title                  string
+	IsSigned               bool
+	Descriptionhash        string
+	ReviewByInvitationOnly bool
+	JournalState           string
+	AllEditors             []*EditorWithState
 }
 
 type EditorWithState struct {
@@ -597,12 +651,14 @@ func GetJournalIncludingProposedEditors(journalId string) (*JournalIncludingProp
 func journalExcludingEditorsToJournalIncludingProposedEditors(
 	jwe *JournalExcludingEditors) *JournalIncludingProposedEditors {
 	return &JournalIncludingProposedEditors{
-		JournalId:       jwe.JournalId,
-		CreatedOn:       jwe.CreatedOn,
-		ModifiedOn:      jwe.ModifiedOn,
-		Title:           jwe.Title,
-		IsSigned:        jwe.IsSigned,
-		Descriptionhash: jwe.Descriptionhash,
+		JournalId:              jwe.JournalId,
+		CreatedOn:              jwe.CreatedOn,
+		ModifiedOn:             jwe.ModifiedOn,
+		Title:                  jwe.Title,
+		IsSigned:               jwe.IsSigned,
+		Descriptionhash:        jwe.Descriptionhash,
+		ReviewByInvitationOnly: jwe.ReviewByInvitationOnly,
+		JournalState:           jwe.JournalState,
 	}
 }
 
@@ -645,6 +701,17 @@ func createVolumeCreateEvent(ev *events_pb2.Event) (event, error) {
 		case model.EV_KEY_VOLUME_LOGICAL_PUBLICATION_TIME:
 			i64, err = strconv.ParseInt(a.Value, 10, 64)
 			dm.logicalPublicationTime = i64
+		case model.EV_KEY_VOLUME_NUMBER:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			dm.volumeNumber = int32(i64)
+		case model.EV_KEY_VOLUME_ISSUE_NUMBER:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			dm.issueNumber = int32(i64)
+		case model.EV_KEY_VOLUME_YEAR:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			dm.year = int32(i64)
+		case model.EV_KEY_VOLUME_PUBLICATION_DATE:
+			dm.publicationDate = a.Value
 		}
 		if err != nil {
 			return nil, err
@@ -659,16 +726,96 @@ type dataManipulationVolumeCreate struct {
 	createdOn              int64
 	issue                  string
 	logicalPublicationTime int64
+	volumeNumber           int32
+	issueNumber            int32
+	year                   int32
+	publicationDate        string
 }
 
 var _ dataManipulation = new(dataManipulationVolumeCreate)
 
 func (dm *dataManipulationVolumeCreate) apply(tx *sqlx.Tx) error {
-	_, err := tx.Exec("INSERT INTO volume VALUES(?, ?, ?, ?, ?)",
-		dm.volumeId, dm.createdOn, dm.journalId, dm.issue, dm.logicalPublicationTime)
+	_, err := tx.Exec("INSERT INTO volume VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		dm.volumeId, dm.createdOn, dm.journalId, dm.issue, dm.logicalPublicationTime,
+		dm.volumeNumber, dm.issueNumber, dm.year, dm.publicationDate, false)
+	return err
+}
+
+func createVolumeUpdateEvent(ev *events_pb2.Event) (event, error) {
+	dm := &dataManipulationVolumeUpdate{}
+	result := &dataManipulationEvent{dataManipulation: dm}
+	var i64 int64
+	var err error
+	for _, a := range ev.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		case model.EV_KEY_ID:
+			dm.volumeId = a.Value
+		case model.EV_KEY_VOLUME_ISSUE, model.EV_KEY_VOLUME_PUBLICATION_DATE:
+			dm.field = a.Key
+			dm.newValue = a.Value
+		case model.EV_KEY_VOLUME_NUMBER, model.EV_KEY_VOLUME_ISSUE_NUMBER, model.EV_KEY_VOLUME_YEAR:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			dm.field = a.Key
+			dm.newValue = int32(i64)
+		case model.EV_KEY_VOLUME_IS_SEALED:
+			dm.field = a.Key
+			dm.newValue, err = strconv.ParseBool(a.Value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type dataManipulationVolumeUpdate struct {
+	volumeId string
+	field    string
+	newValue interface{}
+}
+
+var _ dataManipulation = new(dataManipulationVolumeUpdate)
+
+func (dm *dataManipulationVolumeUpdate) apply(tx *sqlx.Tx) error {
+	_, err := tx.Exec(fmt.Sprintf("UPDATE volume SET %s = ? WHERE volumeId = ?", dm.field),
+		dm.newValue, dm.volumeId)
 	return err
 }
 
+func createVolumePageRangeEvent(ev *events_pb2.Event) (event, error) {
+	result := &dataManipulationEvent{dataManipulation: &dataManipulationNothing{}}
+	var i64 int64
+	var err error
+	for _, a := range ev.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// dataManipulationNothing is for events that only have to be counted,
+// because the database already has their data.
+type dataManipulationNothing struct{}
+
+var _ dataManipulation = new(dataManipulationNothing)
+
+func (dm *dataManipulationNothing) apply(*sqlx.Tx) error {
+	return nil
+}
+
 func GetVolumesOfJournal(journalId string) ([]Volume, error) {
 	tx, err := db.Beginx()
 	if err != nil {
@@ -687,6 +834,11 @@ type Volume struct {
 	JournalId              string
 	Issue                  string
 	LogicalPublicationTime int64
+	VolumeNumber           int32
+	IssueNumber            int32
+	Year                   int32
+	PublicationDate        string
+	IsSealed               bool
 }
 
 func GetVolume(volumeId string) (*Volume, error) {
@@ -250,8 +250,8 @@ func doTestGetAllJournals(_ *log.Logger, t *testing.T) {
 }
 
 func insertJournal(id string, isSigned bool, tx *sqlx.Tx, t *testing.T) {
-	_, err := tx.Exec(fmt.Sprintf("INSERT INTO journal VALUES (%s)", GetPlaceHolders(6)),
-		id, 0, 0, "", isSigned, "")
+	_, err := tx.Exec(fmt.Sprintf("INSERT INTO journal VALUES (%s)", GetPlaceHolders(8)),
+		id, 0, 0, "", isSigned, "", false, "OPEN")
 	if err != nil {
 		t.Error(err)
 	}
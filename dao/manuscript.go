@@ -67,7 +67,7 @@ type dataManipulationManuscriptCreate struct {
 var _ dataManipulation = new(dataManipulationManuscriptCreate)
 
 func (dm *dataManipulationManuscriptCreate) apply(tx *sqlx.Tx) error {
-	_, err := tx.Exec(fmt.Sprintf("INSERT INTO manuscript VALUES (%s)", GetPlaceHolders(14)),
+	_, err := tx.Exec(fmt.Sprintf("INSERT INTO manuscript VALUES (%s)", GetPlaceHolders(15)),
 		dm.id,
 		dm.timestamp,
 		dm.timestamp,
@@ -81,6 +81,7 @@ func (dm *dataManipulationManuscriptCreate) apply(tx *sqlx.Tx) error {
 		"",
 		"",
 		"",
+		"",
 		false)
 	return err
 }
@@ -156,7 +157,8 @@ func createManuscriptUpdateEvent(ev *events_pb2.Event) (event, error) {
 			model.EV_KEY_MANUSCRIPT_STATUS,
 			model.EV_KEY_VOLUME_ID,
 			model.EV_KEY_MANUSCRIPT_FIRST_PAGE,
-			model.EV_KEY_MANUSCRIPT_LAST_PAGE:
+			model.EV_KEY_MANUSCRIPT_LAST_PAGE,
+			model.EV_KEY_MANUSCRIPT_WITHDRAWAL_MSG:
 			dm.field = strings.ToLower(a.Key)
 			dm.newValue = a.Value
 		}
@@ -290,6 +292,12 @@ func createReviewCreateEvent(ev *events_pb2.Event) (event, error) {
 			dm.hash = a.Value
 		case model.EV_KEY_REVIEW_JUDGEMENT:
 			dm.judgement = a.Value
+		case model.EV_KEY_REVIEW_INVITATION_ID:
+			dm.invitationId = a.Value
+		case model.EV_KEY_REVIEW_IS_BLIND:
+			dm.isBlind, err = strconv.ParseBool(a.Value)
+		case model.EV_KEY_REVIEW_PREVIOUS_ID:
+			dm.previousReviewId = a.Value
 		}
 		if err != nil {
 			return nil, err
@@ -299,18 +307,21 @@ func createReviewCreateEvent(ev *events_pb2.Event) (event, error) {
 }
 
 type dataManipulationReviewCreate struct {
-	id             string
-	timestamp      int64
-	manuscriptId   string
-	reviewAuthorId string
-	hash           string
-	judgement      string
+	id               string
+	timestamp        int64
+	manuscriptId     string
+	reviewAuthorId   string
+	hash             string
+	judgement        string
+	invitationId     string
+	isBlind          bool
+	previousReviewId string
 }
 
 var _ dataManipulation = new(dataManipulationReviewCreate)
 
 func (dm *dataManipulationReviewCreate) apply(tx *sqlx.Tx) error {
-	query := fmt.Sprintf("INSERT INTO review VALUES (%s)", GetPlaceHolders(7))
+	query := fmt.Sprintf("INSERT INTO review VALUES (%s)", GetPlaceHolders(11))
 	_, err := tx.Exec(query,
 		dm.id,
 		dm.timestamp,
@@ -318,7 +329,11 @@ func (dm *dataManipulationReviewCreate) apply(tx *sqlx.Tx) error {
 		dm.reviewAuthorId,
 		dm.hash,
 		dm.judgement,
-		false)
+		false,
+		dm.invitationId,
+		dm.isBlind,
+		dm.previousReviewId,
+		"")
 	return err
 }
 
@@ -358,6 +373,84 @@ func (dm *dataManipulationReviewUseByEditor) apply(tx *sqlx.Tx) error {
 	return err
 }
 
+func createReviewRevealEvent(ev *events_pb2.Event) (event, error) {
+	dm := &dataManipulationReviewReveal{}
+	result := &dataManipulationEvent{
+		dataManipulation: dm,
+	}
+	var i64 int64
+	var err error
+	for _, a := range ev.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		case model.EV_KEY_ID:
+			dm.reviewId = a.Value
+		case model.EV_KEY_REVIEW_AUTHOR_ID:
+			dm.reviewAuthorId = a.Value
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type dataManipulationReviewReveal struct {
+	reviewId       string
+	reviewAuthorId string
+}
+
+var _ dataManipulation = new(dataManipulationReviewReveal)
+
+func (dm *dataManipulationReviewReveal) apply(tx *sqlx.Tx) error {
+	_, err := tx.Exec("UPDATE review SET reviewauthorid = ? WHERE id = ?",
+		dm.reviewAuthorId, dm.reviewId)
+	return err
+}
+
+func createReviewSupersedeEvent(ev *events_pb2.Event) (event, error) {
+	dm := &dataManipulationReviewSupersede{}
+	result := &dataManipulationEvent{
+		dataManipulation: dm,
+	}
+	var i64 int64
+	var err error
+	for _, a := range ev.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		case model.EV_KEY_ID:
+			dm.reviewId = a.Value
+		case model.EV_KEY_REVIEW_NEXT_ID:
+			dm.nextReviewId = a.Value
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type dataManipulationReviewSupersede struct {
+	reviewId     string
+	nextReviewId string
+}
+
+var _ dataManipulation = new(dataManipulationReviewSupersede)
+
+func (dm *dataManipulationReviewSupersede) apply(tx *sqlx.Tx) error {
+	_, err := tx.Exec("UPDATE review SET nextreviewid = ? WHERE id = ?",
+		dm.nextReviewId, dm.reviewId)
+	return err
+}
+
 func GetManuscript(manuscriptId string) (*Manuscript, error) {
 	tx, err := db.Beginx()
 	if err != nil {
@@ -393,6 +486,7 @@ type Manuscript struct {
 	VolumeId      string
 	FirstPage     string
 	LastPage      string
+	WithdrawalMsg string
 	IsReviewable  bool
 	Authors       []*Author
 }
@@ -419,6 +513,7 @@ type ManuscriptAuthorCombination struct {
 	VolumeId      string
 	FirstPage     string
 	LastPage      string
+	WithdrawalMsg string
 	IsReviewable  bool
 	PersonId      string
 	DidSign       bool
@@ -442,6 +537,7 @@ SELECT
 	manuscript.volumeid,
 	manuscript.firstpage,
 	manuscript.lastpage,
+	manuscript.withdrawalmsg,
 	manuscript.isreviewable,
 	author.personid,
 	author.didsign,
@@ -474,6 +570,7 @@ func combinationsToManuscript(combinations *[]ManuscriptAuthorCombination) *Manu
 		result.VolumeId = c.VolumeId
 		result.FirstPage = c.FirstPage
 		result.LastPage = c.LastPage
+		result.WithdrawalMsg = c.WithdrawalMsg
 		result.IsReviewable = c.IsReviewable
 		result.Authors[i] = &Author{
 			ManuscriptId: c.Id,
@@ -511,7 +608,7 @@ FROM manuscript
 WHERE
   volumeid = ?
 ORDER BY
-  firstpage
+  CAST(firstpage AS INTEGER), firstpage
 `
 }
 
@@ -668,13 +765,17 @@ func getReviewFromTransaction(tx *sqlx.Tx, reviewId string) (*Review, error) {
 }
 
 type Review struct {
-	Id             string
-	CreatedOn      int64
-	ManuscriptId   string
-	ReviewAuthorId string
-	Hash           string
-	Judgement      string
-	IsUsedByEditor bool
+	Id               string
+	CreatedOn        int64
+	ManuscriptId     string
+	ReviewAuthorId   string
+	Hash             string
+	Judgement        string
+	IsUsedByEditor   bool
+	InvitationId     string
+	IsBlind          bool
+	PreviousReviewId string
+	NextReviewId     string
 }
 
 func GetManuscriptView(manuscriptId string) (*ManuscriptView, error) {
@@ -726,19 +827,26 @@ type ManuscriptView struct {
 	Reviews    []*ExtendedReview
 }
 
+// The person fields of an ExtendedReview are empty for a blind review
+// that was not revealed. A manuscript view only has the latest revision
+// of each review about the manuscript, PreviousReviewId gives access to
+// the earlier revisions.
 type ExtendedReview struct {
-	Id             string
-	CreatedOn      int64
-	ManuscriptId   string
-	ReviewAuthorId string
-	Hash           string
-	Judgement      string
-	IsUsedByEditor bool
-	PersonId       string
-	PersonIsSigned bool
-	PersonName     string
+	Id               string
+	CreatedOn        int64
+	ManuscriptId     string
+	ReviewAuthorId   string
+	Hash             string
+	Judgement        string
+	IsUsedByEditor   bool
+	IsBlind          bool
+	PreviousReviewId string
+	PersonId         string
+	PersonIsSigned   bool
+	PersonName       string
 }
 
+
 func getExtendedReviewQuery() string {
 	return `
 SELECT
@@ -749,12 +857,16 @@ SELECT
     review.hash,
     review.judgement,
     review.isusedbyeditor,
-    person.id AS personid,
-    person.issigned AS personissigned,
-    person.name AS personname
-FROM review, person
-WHERE review.reviewauthorid = person.id
-AND review.manuscriptid = ?
+    review.isblind,
+    review.previousreviewid,
+    COALESCE(person.id, '') AS personid,
+    COALESCE(person.issigned, false) AS personissigned,
+    COALESCE(person.name, '') AS personname
+FROM review
+  LEFT JOIN person ON review.reviewauthorid = person.id
+  LEFT JOIN review AS next ON review.nextreviewid = next.id
+WHERE review.manuscriptid = ?
+  AND (next.id IS NULL OR next.manuscriptid <> review.manuscriptid)
 ORDER BY review.createdon
 `
 }
@@ -770,21 +882,44 @@ func GetReviewDetailsView(reviewId string) (*ReviewView, error) {
 	if err != nil {
 		return nil, err
 	}
-	result.ReviewAuthor, err = getPersonByIdFromTransaction(result.Review.ReviewAuthorId, tx)
+	if result.Review.ReviewAuthorId != "" {
+		result.ReviewAuthor, err = getPersonByIdFromTransaction(result.Review.ReviewAuthorId, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.Manuscript, err = getManuscriptFromTransaction(tx, result.Review.ManuscriptId)
 	if err != nil {
 		return nil, err
 	}
-	result.Manuscript, err = getManuscriptFromTransaction(tx, result.Review.ManuscriptId)
+	result.EarlierRevisions, err = getEarlierReviewRevisionsFromTransaction(tx, result.Review)
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
+// ReviewView has no ReviewAuthor when the review is blind and was not
+// revealed. EarlierRevisions are ordered from the newest to the oldest.
+// A later revision is referenced by Review.NextReviewId.
 type ReviewView struct {
-	Review       *Review
-	ReviewAuthor *Person
-	Manuscript   *Manuscript
+	Review           *Review
+	ReviewAuthor     *Person
+	Manuscript       *Manuscript
+	EarlierRevisions []*Review
+}
+
+func getEarlierReviewRevisionsFromTransaction(tx *sqlx.Tx, review *Review) ([]*Review, error) {
+	result := make([]*Review, 0)
+	for review.PreviousReviewId != "" {
+		var err error
+		review, err = getReviewFromTransaction(tx, review.PreviousReviewId)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, review)
+	}
+	return result, nil
 }
 
 type ReviewEditor struct {
@@ -810,3 +945,185 @@ func VerifyReview(reviewId string, data []byte) error {
 	}
 	return nil
 }
+
+func createReviewInvitationCreateEvent(ev *events_pb2.Event) (event, error) {
+	dm := &dataManipulationReviewInvitationCreate{}
+	result := &dataManipulationEvent{
+		dataManipulation: dm,
+	}
+	var err error
+	var i64 int64
+	for _, a := range ev.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		case model.EV_KEY_TIMESTAMP:
+			i64, err = strconv.ParseInt(a.Value, 10, 64)
+			dm.timestamp = i64
+		case model.EV_KEY_ID:
+			dm.id = a.Value
+		case model.EV_KEY_MANUSCRIPT_ID:
+			dm.manuscriptId = a.Value
+		case model.EV_KEY_MANUSCRIPT_THREAD_ID:
+			dm.threadId = a.Value
+		case model.EV_KEY_JOURNAL_ID:
+			dm.journalId = a.Value
+		case model.EV_KEY_REVIEW_INVITATION_REVIEWER_ID:
+			dm.reviewerId = a.Value
+		case model.EV_KEY_REVIEW_INVITATION_EDITOR_ID:
+			dm.editorId = a.Value
+		case model.EV_KEY_REVIEW_INVITATION_STATE:
+			dm.invitationState = a.Value
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type dataManipulationReviewInvitationCreate struct {
+	id              string
+	timestamp       int64
+	manuscriptId    string
+	threadId        string
+	journalId       string
+	reviewerId      string
+	editorId        string
+	invitationState string
+}
+
+var _ dataManipulation = new(dataManipulationReviewInvitationCreate)
+
+func (dm *dataManipulationReviewInvitationCreate) apply(tx *sqlx.Tx) error {
+	query := fmt.Sprintf("INSERT INTO reviewinvitation VALUES (%s)", GetPlaceHolders(9))
+	_, err := tx.Exec(query,
+		dm.id,
+		dm.timestamp,
+		dm.timestamp,
+		dm.manuscriptId,
+		dm.threadId,
+		dm.journalId,
+		dm.reviewerId,
+		dm.editorId,
+		dm.invitationState)
+	return err
+}
+
+func createReviewInvitationUpdateEvent(ev *events_pb2.Event) (event, error) {
+	dm := &dataManipulationReviewInvitationUpdate{}
+	result := &dataManipulationEvent{
+		dataManipulation: dm,
+	}
+	var i64 int64
+	var err error
+	for _, a := range ev.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		case model.EV_KEY_ID:
+			dm.id = a.Value
+		case model.EV_KEY_REVIEW_INVITATION_STATE:
+			dm.newInvitationState = a.Value
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type dataManipulationReviewInvitationUpdate struct {
+	id                 string
+	newInvitationState string
+}
+
+var _ dataManipulation = new(dataManipulationReviewInvitationUpdate)
+
+func (dm *dataManipulationReviewInvitationUpdate) apply(tx *sqlx.Tx) error {
+	_, err := tx.Exec("UPDATE reviewinvitation SET invitationstate = ? WHERE id = ?",
+		dm.newInvitationState, dm.id)
+	return err
+}
+
+func GetReviewInvitation(invitationId string) (*ReviewInvitation, error) {
+	tx, err := db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Commit() }()
+	result := ReviewInvitation{}
+	err = tx.Get(&result, "SELECT * FROM reviewinvitation WHERE id = ?", invitationId)
+	return &result, err
+}
+
+type ReviewInvitation struct {
+	Id              string
+	CreatedOn       int64
+	ModifiedOn      int64
+	ManuscriptId    string
+	ThreadId        string
+	JournalId       string
+	ReviewerId      string
+	EditorId        string
+	InvitationState string
+}
+
+// GetOpenReviewInvitations returns the invitations to review that the
+// person did not accept or decline yet, newest first.
+func GetOpenReviewInvitations(reviewerId string) ([]*ReviewInvitationView, error) {
+	tx, err := db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Commit() }()
+	rawResult := make([]ReviewInvitationView, 0)
+	err = tx.Select(&rawResult, getQueryOpenReviewInvitations(), reviewerId,
+		model.GetReviewInvitationStateString(model.ReviewInvitationState_invitationOpen))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ReviewInvitationView, len(rawResult))
+	for i := range rawResult {
+		result[i] = &rawResult[i]
+	}
+	return result, nil
+}
+
+type ReviewInvitationView struct {
+	Id              string
+	CreatedOn       int64
+	ManuscriptId    string
+	ManuscriptTitle string
+	JournalId       string
+	JournalTitle    string
+	EditorId        string
+	EditorName      string
+}
+
+func getQueryOpenReviewInvitations() string {
+	return `
+SELECT
+  reviewinvitation.id,
+  reviewinvitation.createdon,
+  reviewinvitation.manuscriptid,
+  manuscript.title AS manuscripttitle,
+  reviewinvitation.journalid,
+  journal.title AS journaltitle,
+  reviewinvitation.editorid,
+  person.name AS editorname
+FROM reviewinvitation, manuscript, journal, person
+WHERE reviewinvitation.manuscriptid = manuscript.id
+  AND reviewinvitation.journalid = journal.journalid
+  AND reviewinvitation.editorid = person.id
+  AND reviewinvitation.reviewerid = ?
+  AND reviewinvitation.invitationstate = ?
+ORDER BY reviewinvitation.createdon DESC, reviewinvitation.id
+`
+}
@@ -43,6 +43,12 @@ func SearchPersonByKey(key string) ([]*Person, error) {
 	return result, nil
 }
 
+func GetMajorIds() ([]string, error) {
+	result := make([]string, 0)
+	err := db.Select(&result, "SELECT id FROM person WHERE ismajor = 1 ORDER BY id")
+	return result, err
+}
+
 func GetPersonById(id string) (*Person, error) {
 	tx, err := db.Beginx()
 	if err != nil {
@@ -224,11 +230,14 @@ func createPersonUpdateEvent(ev *events_pb2.Event) (event, error) {
 		switch a.Key {
 		case model.EV_KEY_TRANSACTION_ID:
 			result.transactionId = a.Value
+			dmBalance.transactionId = a.Value
 		case model.EV_KEY_EVENT_SEQ:
 			i64, err = strconv.ParseInt(a.Value, 10, 32)
 			result.eventSeq = int32(i64)
+			dmBalance.eventSeq = int32(i64)
 		case model.EV_KEY_TIMESTAMP:
-			// Nothing to do.
+			i64, err = strconv.ParseInt(a.Value, 10, 64)
+			dmBalance.timestamp = i64
 		case model.EV_KEY_ID:
 			dmProperties.id = a.Value
 			dmAuthorization.id = a.Value
@@ -249,6 +258,12 @@ func createPersonUpdateEvent(ev *events_pb2.Event) (event, error) {
 			result.dataManipulation = dmBalance
 			i64, err = strconv.ParseInt(a.Value, 10, 32)
 			dmBalance.newValue = int32(i64)
+		case model.EV_KEY_PERSON_BALANCE_AMOUNT:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			dmBalance.amount = int32(i64)
+			dmBalance.hasAmount = true
+		case model.EV_KEY_PERSON_COMMAND_TYPE:
+			dmBalance.commandType = a.Value
 		default:
 			err = errors.New("createPersonUpdateEvent: unknown attribute " + a.Key)
 		}
@@ -289,16 +304,136 @@ func (dm *dataManipulationPersonUpdateAuthorization) apply(tx *sqlx.Tx) error {
 	return err
 }
 
+// Events issued before the balance ledger existed have no amount
+// and no command type. The amount is then calculated from the
+// balance before the update.
 type dataManipulationPersonUpdateBalance struct {
-	id       string
-	newValue int32
+	transactionId string
+	eventSeq      int32
+	timestamp     int64
+	id            string
+	newValue      int32
+	amount        int32
+	hasAmount     bool
+	commandType   string
 }
 
 var _ dataManipulation = new(dataManipulationPersonUpdateBalance)
 
 func (dm *dataManipulationPersonUpdateBalance) apply(tx *sqlx.Tx) error {
+	amount := dm.amount
+	if !dm.hasAmount {
+		var oldBalance int32
+		if err := tx.Get(&oldBalance, "SELECT balance FROM person WHERE id = ?", dm.id); err != nil {
+			return err
+		}
+		amount = dm.newValue - oldBalance
+	}
 	query := fmt.Sprintf("UPDATE person SET %s = %d WHERE id = \"%s\"",
 		strings.ToLower(model.EV_KEY_PERSON_BALANCE), dm.newValue, dm.id)
 	_, err := tx.Exec(query)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(fmt.Sprintf("INSERT INTO balancechange VALUES (%s)", GetPlaceHolders(7)),
+		dm.transactionId, dm.eventSeq, dm.id, amount, dm.newValue, dm.commandType, dm.timestamp)
 	return err
 }
+
+type BalanceChange struct {
+	TransactionId string `db:"transactionid"`
+	EventSeq      int32  `db:"eventseq"`
+	PersonId      string `db:"personid"`
+	Amount        int32
+	Balance       int32
+	CommandType   string `db:"commandtype"`
+	Timestamp     int64
+}
+
+// GetBalanceHistory returns the balance changes of a person, oldest first.
+func GetBalanceHistory(personId string) ([]*BalanceChange, error) {
+	changes := make([]BalanceChange, 0)
+	err := db.Select(&changes, "SELECT * FROM balancechange WHERE personid = ? ORDER BY rowid", personId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*BalanceChange, len(changes))
+	for i := 0; i < len(changes); i++ {
+		result[i] = &changes[i]
+	}
+	return result, nil
+}
+
+// CreditSummary totals the balance changes of a person by their
+// cause. Paid is what the person paid for commands. Unattributed
+// holds changes without a command type, see TableCreateBalanceChange.
+// When the ledger is complete, Issued + TransferredIn - TransferredOut
+// - Paid + Unattributed equals Balance.
+type CreditSummary struct {
+	PersonId       string `db:"personid"`
+	Name           string
+	Balance        int32
+	Issued         int64
+	TransferredIn  int64 `db:"transferredin"`
+	TransferredOut int64 `db:"transferredout"`
+	Paid           int64
+	Unattributed   int64
+}
+
+func (cs *CreditSummary) IsReconciled() bool {
+	return cs.Issued+cs.TransferredIn-cs.TransferredOut-cs.Paid+cs.Unattributed == int64(cs.Balance)
+}
+
+const creditSummaryQuery = `
+SELECT
+  person.id AS personid,
+  person.name,
+  person.balance,
+  COALESCE(SUM(CASE WHEN balancechange.commandtype = ? THEN balancechange.amount ELSE 0 END), 0) AS issued,
+  COALESCE(SUM(CASE WHEN balancechange.commandtype = ? AND balancechange.amount > 0
+    THEN balancechange.amount ELSE 0 END), 0) AS transferredin,
+  COALESCE(SUM(CASE WHEN balancechange.commandtype = ? AND balancechange.amount < 0
+    THEN -balancechange.amount ELSE 0 END), 0) AS transferredout,
+  COALESCE(SUM(CASE WHEN balancechange.commandtype NOT IN (?, ?, '')
+    THEN -balancechange.amount ELSE 0 END), 0) AS paid,
+  COALESCE(SUM(CASE WHEN balancechange.commandtype = '' THEN balancechange.amount ELSE 0 END), 0) AS unattributed
+FROM person LEFT JOIN balancechange ON balancechange.personid = person.id
+`
+
+func getCreditSummaryQueryArgs() []interface{} {
+	return []interface{}{
+		model.COMMAND_TYPE_BALANCE_INCREMENT,
+		model.COMMAND_TYPE_BALANCE_TRANSFER,
+		model.COMMAND_TYPE_BALANCE_TRANSFER,
+		model.COMMAND_TYPE_BALANCE_INCREMENT,
+		model.COMMAND_TYPE_BALANCE_TRANSFER,
+	}
+}
+
+func GetCreditSummary(personId string) (*CreditSummary, error) {
+	var result = new(CreditSummary)
+	args := append(getCreditSummaryQueryArgs(), personId)
+	err := db.QueryRowx(creditSummaryQuery+"WHERE person.id = ?\nGROUP BY person.id", args...).StructScan(result)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCreditReport returns the credit summaries of all persons.
+func GetCreditReport() ([]*CreditSummary, error) {
+	summaries := make([]CreditSummary, 0)
+	err := db.Select(&summaries, creditSummaryQuery+"GROUP BY person.id\nORDER BY person.name, person.id",
+		getCreditSummaryQueryArgs()...)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*CreditSummary, len(summaries))
+	for i := 0; i < len(summaries); i++ {
+		result[i] = &summaries[i]
+	}
+	return result, nil
+}
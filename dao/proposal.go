@@ -0,0 +1,239 @@
+package dao
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
+	"github.com/iskendria-pub/iskendria/model"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+type Proposal struct {
+	Id            string
+	CreatedOn     int64  `db:"createdon"`
+	ModifiedOn    int64  `db:"modifiedon"`
+	ProposerId    string `db:"proposerid"`
+	CommandType   string `db:"commandtype"`
+	TargetId      string `db:"targetid"`
+	Description   string
+	ExpiresOn     int64  `db:"expireson"`
+	ProposalState string `db:"proposalstate"`
+}
+
+type ProposalApproval struct {
+	ProposalId   string `db:"proposalid"`
+	ApproverId   string `db:"approverid"`
+	ApproverName string `db:"approvername"`
+	CreatedOn    int64  `db:"createdon"`
+}
+
+func GetProposal(proposalId string) (*Proposal, error) {
+	var proposal = new(Proposal)
+	err := db.QueryRowx("SELECT * FROM proposal WHERE id = ?", proposalId).StructScan(proposal)
+	if err == nil {
+		return proposal, nil
+	}
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// GetOpenProposals returns the proposals that can still be approved
+// at the given time, oldest first.
+func GetOpenProposals(now int64) ([]*Proposal, error) {
+	proposals := make([]Proposal, 0)
+	err := db.Select(&proposals,
+		"SELECT * FROM proposal WHERE proposalstate = ? AND expireson > ? ORDER BY createdon, id",
+		model.GetProposalStateString(model.ProposalState_proposalOpen), now)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Proposal, len(proposals))
+	for i := 0; i < len(proposals); i++ {
+		result[i] = &proposals[i]
+	}
+	return result, nil
+}
+
+// GetProposalApprovals returns the approvals in the order they were
+// given, the proposer first.
+func GetProposalApprovals(proposalId string) ([]*ProposalApproval, error) {
+	approvals := make([]ProposalApproval, 0)
+	err := db.Select(&approvals, `
+SELECT
+  proposalapproval.proposalid,
+  proposalapproval.approverid,
+  person.name AS approvername,
+  proposalapproval.createdon
+FROM proposalapproval, person
+WHERE proposalapproval.approverid = person.id
+  AND proposalapproval.proposalid = ?
+ORDER BY proposalapproval.rowid`, proposalId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ProposalApproval, len(approvals))
+	for i := 0; i < len(approvals); i++ {
+		result[i] = &approvals[i]
+	}
+	return result, nil
+}
+
+func createProposalCreateEvent(ev *events_pb2.Event) (event, error) {
+	dm := &dataManipulationProposalCreate{}
+	result := &dataManipulationEvent{
+		dataManipulation: dm,
+	}
+	var err error
+	var i64 int64
+	for _, a := range ev.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		case model.EV_KEY_TIMESTAMP:
+			i64, err = strconv.ParseInt(a.Value, 10, 64)
+			dm.timestamp = i64
+		case model.EV_KEY_ID:
+			dm.id = a.Value
+		case model.EV_KEY_PROPOSAL_PROPOSER_ID:
+			dm.proposerId = a.Value
+		case model.EV_KEY_PROPOSAL_COMMAND_TYPE:
+			dm.commandType = a.Value
+		case model.EV_KEY_PROPOSAL_TARGET_ID:
+			dm.targetId = a.Value
+		case model.EV_KEY_PROPOSAL_DESCRIPTION:
+			dm.description = a.Value
+		case model.EV_KEY_PROPOSAL_EXPIRES_ON:
+			i64, err = strconv.ParseInt(a.Value, 10, 64)
+			dm.expiresOn = i64
+		case model.EV_KEY_PROPOSAL_STATE:
+			dm.proposalState = a.Value
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type dataManipulationProposalCreate struct {
+	id            string
+	timestamp     int64
+	proposerId    string
+	commandType   string
+	targetId      string
+	description   string
+	expiresOn     int64
+	proposalState string
+}
+
+var _ dataManipulation = new(dataManipulationProposalCreate)
+
+// The proposer approves the proposal by creating it.
+func (dm *dataManipulationProposalCreate) apply(tx *sqlx.Tx) error {
+	_, err := tx.Exec(fmt.Sprintf("INSERT INTO proposal VALUES (%s)", GetPlaceHolders(9)),
+		dm.id,
+		dm.timestamp,
+		dm.timestamp,
+		dm.proposerId,
+		dm.commandType,
+		dm.targetId,
+		dm.description,
+		dm.expiresOn,
+		dm.proposalState)
+	if err != nil {
+		return err
+	}
+	return insertProposalApproval(tx, dm.id, dm.proposerId, dm.timestamp)
+}
+
+func insertProposalApproval(tx *sqlx.Tx, proposalId, approverId string, timestamp int64) error {
+	_, err := tx.Exec(fmt.Sprintf("INSERT INTO proposalapproval VALUES (%s)", GetPlaceHolders(3)),
+		proposalId, approverId, timestamp)
+	return err
+}
+
+func createProposalApproveEvent(ev *events_pb2.Event) (event, error) {
+	dm := &dataManipulationProposalApprove{}
+	result := &dataManipulationEvent{
+		dataManipulation: dm,
+	}
+	var err error
+	var i64 int64
+	for _, a := range ev.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		case model.EV_KEY_TIMESTAMP:
+			i64, err = strconv.ParseInt(a.Value, 10, 64)
+			dm.timestamp = i64
+		case model.EV_KEY_ID:
+			dm.id = a.Value
+		case model.EV_KEY_PROPOSAL_APPROVER_ID:
+			dm.approverId = a.Value
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type dataManipulationProposalApprove struct {
+	id         string
+	approverId string
+	timestamp  int64
+}
+
+var _ dataManipulation = new(dataManipulationProposalApprove)
+
+func (dm *dataManipulationProposalApprove) apply(tx *sqlx.Tx) error {
+	return insertProposalApproval(tx, dm.id, dm.approverId, dm.timestamp)
+}
+
+func createProposalUpdateEvent(ev *events_pb2.Event) (event, error) {
+	dm := &dataManipulationProposalUpdate{}
+	result := &dataManipulationEvent{
+		dataManipulation: dm,
+	}
+	var err error
+	var i64 int64
+	for _, a := range ev.Attributes {
+		switch a.Key {
+		case model.EV_KEY_TRANSACTION_ID:
+			result.transactionId = a.Value
+		case model.EV_KEY_EVENT_SEQ:
+			i64, err = strconv.ParseInt(a.Value, 10, 32)
+			result.eventSeq = int32(i64)
+		case model.EV_KEY_ID:
+			dm.id = a.Value
+		case model.EV_KEY_PROPOSAL_STATE:
+			dm.newProposalState = a.Value
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type dataManipulationProposalUpdate struct {
+	id               string
+	newProposalState string
+}
+
+var _ dataManipulation = new(dataManipulationProposalUpdate)
+
+func (dm *dataManipulationProposalUpdate) apply(tx *sqlx.Tx) error {
+	_, err := tx.Exec("UPDATE proposal SET proposalstate = ? WHERE id = ?",
+		dm.newProposalState, dm.id)
+	return err
+}
@@ -33,6 +33,11 @@ type Settings struct {
 	PriceEditorEditJournal               int32 `db:"priceeditoreditjournal"`
 	PriceEditorAddColleague              int32 `db:"priceeditoraddcolleague"`
 	PriceEditorAcceptDuty                int32 `db:"priceeditoracceptduty"`
+	PriceAuthorWithdrawManuscript        int32 `db:"priceauthorwithdrawmanuscript"`
+	PriceEditorInviteReviewer            int32 `db:"priceeditorinvitereviewer"`
+	ApprovalThreshold                    int32 `db:"approvalthreshold"`
+	MinimumFamilyVersion                 string `db:"minimumfamilyversion"`
+	MajorCount                           int32 `db:"majorcount"`
 }
 
 func GetSettings() (*Settings, error) {
@@ -117,6 +122,12 @@ func createSettingsCreateEvent(event *events_pb2.Event) (event, error) {
 		case model.EV_KEY_PRICE_EDITOR_ACCEPT_DUTY:
 			i64, err = strconv.ParseInt(attribute.Value, 10, 32)
 			dataManipulation.priceEditorAcceptDuty = int32(i64)
+		case model.EV_KEY_PRICE_AUTHOR_WITHDRAW_MANUSCRIPT:
+			i64, err = strconv.ParseInt(attribute.Value, 10, 32)
+			dataManipulation.priceAuthorWithdrawManuscript = int32(i64)
+		case model.EV_KEY_PRICE_EDITOR_INVITE_REVIEWER:
+			i64, err = strconv.ParseInt(attribute.Value, 10, 32)
+			dataManipulation.priceEditorInviteReviewer = int32(i64)
 		}
 		if err != nil {
 			return nil, err
@@ -149,12 +160,14 @@ type dataManipulationSettingsCreate struct {
 	priceEditorEditJournal               int32
 	priceEditorAddColleague              int32
 	priceEditorAcceptDuty                int32
+	priceAuthorWithdrawManuscript        int32
+	priceEditorInviteReviewer            int32
 }
 
 var _ dataManipulation = new(dataManipulationSettingsCreate)
 
 func (dmsc *dataManipulationSettingsCreate) apply(tx *sqlx.Tx) error {
-	_, err := tx.Exec(fmt.Sprintf("INSERT INTO settings VALUES (%s)", GetPlaceHolders(21)),
+	_, err := tx.Exec(fmt.Sprintf("INSERT INTO settings VALUES (%s)", GetPlaceHolders(26)),
 		// id, createdOn, modifiedOn
 		THE_SETTINGS_ID, dmsc.timestamp, dmsc.timestamp,
 		// prices
@@ -175,7 +188,12 @@ func (dmsc *dataManipulationSettingsCreate) apply(tx *sqlx.Tx) error {
 		dmsc.priceEditorCreateVolume,
 		dmsc.priceEditorEditJournal,
 		dmsc.priceEditorAddColleague,
-		dmsc.priceEditorAcceptDuty)
+		dmsc.priceEditorAcceptDuty,
+		dmsc.priceAuthorWithdrawManuscript,
+		dmsc.priceEditorInviteReviewer,
+		// The approval threshold, the minimum family version and
+		// the major count are not part of the bootstrap
+		int32(0), "", int32(0))
 	return err
 }
 
@@ -203,10 +221,15 @@ func createSettingsUpdateEvent(input *events_pb2.Event) (event, error) {
 			model.EV_KEY_PRICE_EDITOR_REJECT_MANUSCRIPT, model.EV_KEY_PRICE_EDITOR_PUBLISH_MANUSCRIPT,
 			model.EV_KEY_PRICE_EDITOR_ASSIGN_MANUSCRIPT, model.EV_KEY_PRICE_EDITOR_CREATE_JOURNAL,
 			model.EV_KEY_PRICE_EDITOR_CREATE_VOLUME, model.EV_KEY_PRICE_EDITOR_EDIT_JOURNAL,
-			model.EV_KEY_PRICE_EDITOR_ADD_COLLEAGUE, model.EV_KEY_PRICE_EDITOR_ACCEPT_DUTY:
+			model.EV_KEY_PRICE_EDITOR_ADD_COLLEAGUE, model.EV_KEY_PRICE_EDITOR_ACCEPT_DUTY,
+			model.EV_KEY_PRICE_AUTHOR_WITHDRAW_MANUSCRIPT, model.EV_KEY_PRICE_EDITOR_INVITE_REVIEWER,
+			model.EV_KEY_APPROVAL_THRESHOLD, model.EV_KEY_MAJOR_COUNT:
 			i64, err = strconv.ParseInt(a.Value, 10, 32)
 			dm.field = strings.ToLower(a.Key)
 			dm.newValue = int32(i64)
+		case model.EV_KEY_MINIMUM_FAMILY_VERSION:
+			dm.field = strings.ToLower(a.Key)
+			dm.newValue = a.Value
 		default:
 			err = errors.New("createSettingsUpdateEvent: Unknown event attribute: " + a.Key)
 		}
@@ -219,13 +242,13 @@ func createSettingsUpdateEvent(input *events_pb2.Event) (event, error) {
 
 type dataManipulationSettingsUpdate struct {
 	field    string
-	newValue int32
+	newValue interface{}
 }
 
 var _ dataManipulation = new(dataManipulationSettingsUpdate)
 
 func (dm *dataManipulationSettingsUpdate) apply(tx *sqlx.Tx) error {
-	_, err := tx.Exec(fmt.Sprintf("UPDATE settings SET %s = %d WHERE Id = %s",
-		dm.field, dm.newValue, THE_SETTINGS_ID))
+	_, err := tx.Exec(fmt.Sprintf("UPDATE settings SET %s = ? WHERE Id = ?", dm.field),
+		dm.newValue, THE_SETTINGS_ID)
 	return err
 }
@@ -80,6 +80,14 @@ var theExpectations = []expectation{
 		g:        func(s *Settings) int32 { return s.PriceEditorAcceptDuty },
 		expected: 1800,
 	},
+	{
+		g:        func(s *Settings) int32 { return s.PriceAuthorWithdrawManuscript },
+		expected: 1900,
+	},
+	{
+		g:        func(s *Settings) int32 { return s.PriceEditorInviteReviewer },
+		expected: 2000,
+	},
 }
 
 type expectation struct {
@@ -109,6 +117,8 @@ var settingsCreate = &dataManipulationSettingsCreate{
 	priceEditorEditJournal:               1600,
 	priceEditorAddColleague:              1700,
 	priceEditorAcceptDuty:                1800,
+	priceAuthorWithdrawManuscript:        1900,
+	priceEditorInviteReviewer:            2000,
 }
 
 func TestGetSettings(t *testing.T) {
@@ -0,0 +1,92 @@
+package dao
+
+import (
+	"fmt"
+	"github.com/iskendria-pub/iskendria/logging"
+	"github.com/jmoiron/sqlx"
+	"log"
+	"strings"
+)
+
+// The tables that are filled from blockchain events. Changes of these
+// tables are recorded in the undo log, so that they can be rolled back
+// when a block is orphaned.
+var undoLoggedTables = []string{
+	"settings",
+	"person",
+	"balancechange",
+	"journal",
+	"editor",
+	"volume",
+	"manuscript",
+	"author",
+	"review",
+	"reviewinvitation",
+	"proposal",
+	"proposalapproval",
+}
+
+func createUndoTriggers(logger *log.Logger) {
+	for _, table := range undoLoggedTables {
+		columns, err := getColumnNames(db, table)
+		if err != nil {
+			logging.New(logger).Fatal("Could not get columns of table", "table", table, "error", err)
+		}
+		for _, stmt := range getUndoTriggerStatements(table, columns) {
+			_, err := db.Exec(stmt)
+			if err != nil {
+				logging.New(logger).Fatal("Trigger create statement failed", "statement", stmt, "error", err)
+			}
+		}
+	}
+}
+
+func getColumnNames(q sqlx.Queryer, table string) ([]string, error) {
+	rows, err := q.Queryx(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	result := []string{}
+	for rows.Next() {
+		row := make(map[string]interface{})
+		if err = rows.MapScan(row); err != nil {
+			return nil, err
+		}
+		result = append(result, fmt.Sprintf("%s", row["name"]))
+	}
+	return result, rows.Err()
+}
+
+// Each trigger writes the SQL statement that reverts the change.
+// Rows are identified by their rowid, so that the undo statements
+// do not depend on the primary keys of the tables.
+func getUndoTriggerStatements(table string, columns []string) []string {
+	setClauses := make([]string, len(columns))
+	quotedValues := make([]string, len(columns))
+	for i, c := range columns {
+		setClauses[i] = fmt.Sprintf("%s = ' || quote(old.%s) || '", c, c)
+		quotedValues[i] = fmt.Sprintf("' || quote(old.%s) || '", c)
+	}
+	undoInsert := fmt.Sprintf(
+		"'DELETE FROM %s WHERE rowid = ' || new.rowid", table)
+	undoUpdate := fmt.Sprintf(
+		"'UPDATE %s SET %s WHERE rowid = ' || old.rowid",
+		table, strings.Join(setClauses, ", "))
+	undoDelete := fmt.Sprintf(
+		"'INSERT INTO %s (rowid, %s) VALUES (' || old.rowid || ', %s)'",
+		table, strings.Join(columns, ", "), strings.Join(quotedValues, ", "))
+	return []string{
+		getUndoTriggerStatement(table, "insert", undoInsert),
+		getUndoTriggerStatement(table, "update", undoUpdate),
+		getUndoTriggerStatement(table, "delete", undoDelete),
+	}
+}
+
+func getUndoTriggerStatement(table, operation, undoExpression string) string {
+	return fmt.Sprintf(`
+CREATE TRIGGER undo_%s_%s AFTER %s ON %s
+BEGIN
+	INSERT INTO undolog (statement) VALUES (%s);
+END`, table, operation, strings.ToUpper(operation), table, undoExpression)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/iskendria-pub/iskendria/blockchain"
+	"github.com/iskendria-pub/iskendria/config"
+	"github.com/iskendria-pub/iskendria/model"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+)
+
+/*
+The decode tool prints protobuf records of Iskendria as JSON. Build
+it with go build -o iskendria-decode ./decode. Usage:
+
+  iskendria-decode state <address>
+      Reads the address through the REST API and prints its state.
+  iskendria-decode state <address> <file>
+      Prints the state in the file, which holds the raw protobuf bytes.
+  iskendria-decode payload [file]
+      Prints the command in a base64 encoded transaction payload, as
+      shown by /blocks.
+
+A file name - means standard input, which is also used when the
+payload file is omitted. The configuration flags come before the
+subcommand.
+*/
+
+const usage = `Usage:
+  iskendria-decode state <address> [file]
+  iskendria-decode payload [file]`
+
+func main() {
+	args, err := config.Load(config.GetDefaults())
+	if err != nil {
+		log.Fatal(err)
+	}
+	message, err := decode(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	result, err := model.ToJson(message)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	fmt.Println(result)
+}
+
+func decode(args []string) (proto.Message, error) {
+	if len(args) == 0 {
+		return nil, errors.New(usage)
+	}
+	switch {
+	case args[0] == "state" && len(args) == 2:
+		data, err := blockchain.GetState(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return model.UnmarshalState(args[1], data)
+	case args[0] == "state" && len(args) == 3:
+		data, err := readInput(args[2])
+		if err != nil {
+			return nil, err
+		}
+		return model.UnmarshalState(args[1], data)
+	case args[0] == "payload" && len(args) <= 2:
+		fname := "-"
+		if len(args) == 2 {
+			fname = args[1]
+		}
+		data, err := readInput(fname)
+		if err != nil {
+			return nil, err
+		}
+		return model.UnmarshalBase64Payload(strings.TrimSpace(string(data)))
+	}
+	return nil, errors.New(usage)
+}
+
+func readInput(fname string) ([]byte, error) {
+	if fname == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(fname)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/base64"
+	"github.com/golang/protobuf/proto"
+	"github.com/iskendria-pub/iskendria/model"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDecodeStateFile(t *testing.T) {
+	address := model.CreatePersonAddress()
+	data, err := proto.Marshal(&model.StatePerson{
+		Id:   address,
+		Name: "Person",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fname := "testDecodeState.bin"
+	if err = ioutil.WriteFile(fname, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(fname) }()
+	message, err := decode([]string{"state", address, fname})
+	if err != nil {
+		t.Fatal(err)
+	}
+	person, isPerson := message.(*model.StatePerson)
+	if !isPerson || person.Name != "Person" {
+		t.Errorf("Expected the person, got %v", message)
+	}
+	result, err := model.ToJson(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result, `"name": "Person"`) {
+		t.Error("Unexpected JSON: " + result)
+	}
+}
+
+func TestDecodeStateOfEveryAddressType(t *testing.T) {
+	addresses := []string{
+		model.GetSettingsAddress(),
+		model.CreatePersonAddress(),
+		model.CreateManuscriptAddress(),
+		model.CreateManuscriptThreadAddress(),
+		model.CreateJournalAddress(),
+		model.CreateVolumeAddress(),
+		model.CreateReviewAddress(),
+	}
+	seenTypes := make(map[string]bool)
+	for _, address := range addresses {
+		message, err := model.UnmarshalState(address, []byte{})
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		seenTypes[proto.MessageName(message)] = true
+	}
+	if len(seenTypes) != len(addresses) {
+		t.Errorf("Expected a different message type for every address type, got %v", seenTypes)
+	}
+	if _, err := model.UnmarshalState(model.Namespace+"99"+strings.Repeat("0", 62), []byte{}); err == nil {
+		t.Error("Expected error for unknown type code")
+	}
+}
+
+func TestDecodePayload(t *testing.T) {
+	payload, err := proto.Marshal(&model.Command{
+		Signer:    "signer",
+		Timestamp: 12345,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fname := "testDecodePayload.txt"
+	if err = ioutil.WriteFile(fname, []byte(base64.StdEncoding.EncodeToString(payload)+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(fname) }()
+	message, err := decode([]string{"payload", fname})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, isCommand := message.(*model.Command); !isCommand || c.Signer != "signer" || c.Timestamp != 12345 {
+		t.Errorf("Unexpected command: %v", message)
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/sawtooth-sdk-go/processor"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/batch_pb2"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/processor_pb2"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/transaction_pb2"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/model"
+	"github.com/iskendria-pub/iskendria/processor/handler"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+The chain holds the state and the blocks of the devnet. Every batch
+that is valid becomes a block of its own, so batches are committed
+as soon as they are submitted. Nothing is persisted. After a restart
+the chain has a new genesis block, which makes the clients rebuild
+their databases.
+
+Signatures are not checked. The devnet does check that transactions
+only read their input addresses and only write their output addresses,
+like the validator does.
+*/
+
+const (
+	BATCH_STATUS_COMMITTED = "COMMITTED"
+	BATCH_STATUS_INVALID   = "INVALID"
+)
+
+const (
+	sawtoothBlockNum      = "block_num"
+	sawtoothStateRootHash = "state_root_hash"
+)
+
+type block struct {
+	id         string
+	previousId string
+	num        int
+	batchIds   []string
+	batches    []*batch_pb2.Batch
+	events     []*events_pb2.Event
+}
+
+type batchResult struct {
+	status              string
+	invalidTransactions []*invalidTransaction
+}
+
+type invalidTransaction struct {
+	id      string
+	message string
+}
+
+type chain struct {
+	mux          sync.Mutex
+	state        map[string][]byte
+	blocks       []*block
+	batchResults map[string]*batchResult
+	handler      *handler.AlexandriaHandler
+	logger       *log.Logger
+}
+
+func newChain(logger *log.Logger) *chain {
+	genesis := &block{
+		id:       model.HashBytes([]byte(fmt.Sprintf("genesis %d", time.Now().UnixNano()))),
+		num:      0,
+		batchIds: []string{},
+		batches:  []*batch_pb2.Batch{},
+		events:   []*events_pb2.Event{},
+	}
+	genesis.events = append(genesis.events, getBlockCommitEvent(genesis))
+	return &chain{
+		state:        make(map[string][]byte),
+		blocks:       []*block{genesis},
+		batchResults: make(map[string]*batchResult),
+		handler:      &handler.AlexandriaHandler{},
+		logger:       logger,
+	}
+}
+
+func getBlockCommitEvent(b *block) *events_pb2.Event {
+	return &events_pb2.Event{
+		EventType: model.SAWTOOTH_BLOCK_COMMIT,
+		Attributes: []*events_pb2.Event_Attribute{
+			{Key: model.SAWTOOTH_CURRENT_BLOCK_ID, Value: b.id},
+			{Key: sawtoothBlockNum, Value: strconv.Itoa(b.num)},
+			{Key: sawtoothStateRootHash, Value: ""},
+			{Key: model.SAWTOOTH_PREVIOUS_BLOCK_ID, Value: b.previousId},
+		},
+	}
+}
+
+func (c *chain) applyBatch(batch *batch_pb2.Batch) *batchResult {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if result, found := c.batchResults[batch.HeaderSignature]; found {
+		return result
+	}
+	access := &transactionAccess{
+		committed: c.state,
+		pending:   make(map[string][]byte),
+		events:    []*events_pb2.Event{},
+	}
+	for _, transaction := range batch.Transactions {
+		if err := c.applyTransaction(transaction, access); err != nil {
+			c.logger.Printf("Batch %s is invalid, transaction %s failed: %s\n",
+				batch.HeaderSignature, transaction.HeaderSignature, err.Error())
+			result := &batchResult{
+				status: BATCH_STATUS_INVALID,
+				invalidTransactions: []*invalidTransaction{
+					{
+						id:      transaction.HeaderSignature,
+						message: getInvalidTransactionMessage(err),
+					},
+				},
+			}
+			c.batchResults[batch.HeaderSignature] = result
+			return result
+		}
+	}
+	for address, data := range access.pending {
+		c.state[address] = data
+	}
+	head := c.blocks[len(c.blocks)-1]
+	newBlock := &block{
+		id:         model.HashBytes([]byte(head.id + batch.HeaderSignature)),
+		previousId: head.id,
+		num:        head.num + 1,
+		batchIds:   []string{batch.HeaderSignature},
+		batches:    []*batch_pb2.Batch{batch},
+	}
+	newBlock.events = append([]*events_pb2.Event{getBlockCommitEvent(newBlock)}, access.events...)
+	c.blocks = append(c.blocks, newBlock)
+	c.logger.Printf("Committed batch %s in block %d: %s\n", batch.HeaderSignature, newBlock.num, newBlock.id)
+	result := &batchResult{
+		status:              BATCH_STATUS_COMMITTED,
+		invalidTransactions: []*invalidTransaction{},
+	}
+	c.batchResults[batch.HeaderSignature] = result
+	return result
+}
+
+func (c *chain) applyTransaction(transaction *transaction_pb2.Transaction, access *transactionAccess) error {
+	header := &transaction_pb2.TransactionHeader{}
+	if err := proto.Unmarshal(transaction.Header, header); err != nil {
+		return errors.New("Could not parse transaction header: " + err.Error())
+	}
+	if header.FamilyName != c.handler.FamilyName() {
+		return errors.New("Unknown transaction family: " + header.FamilyName)
+	}
+	if !isSupportedFamilyVersion(header.FamilyVersion, c.handler.FamilyVersions()) {
+		return errors.New("Unsupported family version: " + header.FamilyVersion)
+	}
+	if header.PayloadSha512 != model.HashBytes(transaction.Payload) {
+		return errors.New("Payload does not match its hash in the transaction header")
+	}
+	access.inputs = header.Inputs
+	access.outputs = header.Outputs
+	return c.handler.ApplyRequest(&processor_pb2.TpProcessRequest{
+		Header:    header,
+		Payload:   transaction.Payload,
+		Signature: transaction.HeaderSignature,
+	}, access)
+}
+
+func isSupportedFamilyVersion(familyVersion string, supported []string) bool {
+	for _, s := range supported {
+		if s == familyVersion {
+			return true
+		}
+	}
+	return false
+}
+
+func getInvalidTransactionMessage(err error) string {
+	if invalidTransactionError, ok := err.(*processor.InvalidTransactionError); ok {
+		return invalidTransactionError.Msg
+	}
+	return err.Error()
+}
+
+func (c *chain) getBatchResult(batchId string) (*batchResult, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	result, found := c.batchResults[batchId]
+	return result, found
+}
+
+func (c *chain) getBlocks() []*block {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	result := make([]*block, len(c.blocks))
+	copy(result, c.blocks)
+	return result
+}
+
+// getBlocksAfter returns the blocks after the block with the given id.
+// The boolean result is false when the block is unknown.
+func (c *chain) getBlocksAfter(blockId string) ([]*block, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	for i, b := range c.blocks {
+		if b.id == blockId {
+			result := make([]*block, len(c.blocks)-i-1)
+			copy(result, c.blocks[i+1:])
+			return result, true
+		}
+	}
+	return nil, false
+}
+
+func (c *chain) getState(address string) ([]byte, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	data, found := c.state[address]
+	return data, found
+}
+
+func (c *chain) getStateWithPrefix(prefix string) map[string][]byte {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	result := make(map[string][]byte)
+	for address, data := range c.state {
+		if strings.HasPrefix(address, prefix) {
+			result[address] = data
+		}
+	}
+	return result
+}
+
+// The transactionAccess gives a transaction access to the state,
+// like the context of the validator. Changes are pending until
+// the whole batch has been applied.
+type transactionAccess struct {
+	committed map[string][]byte
+	pending   map[string][]byte
+	inputs    []string
+	outputs   []string
+	events    []*events_pb2.Event
+}
+
+var _ command.BlockchainAccess = new(transactionAccess)
+
+func (ta *transactionAccess) GetState(addresses []string) (map[string][]byte, error) {
+	result := make(map[string][]byte)
+	for _, address := range addresses {
+		if !isAuthorized(address, ta.inputs) {
+			return nil, errors.New("Tried to read address that is not in the inputs: " + address)
+		}
+		if data, found := ta.pending[address]; found {
+			result[address] = data
+		} else if data, found := ta.committed[address]; found {
+			result[address] = data
+		}
+	}
+	return result, nil
+}
+
+func (ta *transactionAccess) SetState(pairs map[string][]byte) ([]string, error) {
+	result := make([]string, 0, len(pairs))
+	for address := range pairs {
+		if !isAuthorized(address, ta.outputs) {
+			return nil, errors.New("Tried to write address that is not in the outputs: " + address)
+		}
+	}
+	for address, data := range pairs {
+		ta.pending[address] = data
+		result = append(result, address)
+	}
+	return result, nil
+}
+
+func (ta *transactionAccess) AddEvent(eventType string, attributes []processor.Attribute, eventData []byte) error {
+	eventAttributes := make([]*events_pb2.Event_Attribute, len(attributes))
+	for i, a := range attributes {
+		eventAttributes[i] = &events_pb2.Event_Attribute{
+			Key:   a.Key,
+			Value: a.Value,
+		}
+	}
+	ta.events = append(ta.events, &events_pb2.Event{
+		EventType:  eventType,
+		Attributes: eventAttributes,
+		Data:       eventData,
+	})
+	return nil
+}
+
+// Like with the validator, the inputs and outputs of a transaction
+// may be address prefixes.
+func isAuthorized(address string, allowed []string) bool {
+	for _, a := range allowed {
+		if strings.HasPrefix(address, a) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/hyperledger/sawtooth-sdk-go/signing"
+	"github.com/iskendria-pub/iskendria/blockchain"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/config"
+	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/model"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSubmitBatches(t *testing.T) {
+	logger := log.New(os.Stdout, "devnet.TestSubmitBatches", log.Flags())
+	dao.Init("testDevnet.db", logger)
+	defer dao.ShutdownAndDelete(logger)
+	theChain := newChain(logger)
+	server := httptest.NewServer((&restApi{chain: theChain}).getRouter())
+	defer server.Close()
+	config.Current.RestUrl = server.URL
+	outputter := func(string) {}
+	bootstrapCommand := getBootstrapCommand()
+	batchId, err := blockchain.SendCommands([]*command.Command{bootstrapCommand}, outputter)
+	if err != nil {
+		t.Error("Could not send bootstrap: " + err.Error())
+		return
+	}
+	checkBatchStatus(batchId, blockchain.BATCH_STATUS_COMMITTED, "", t)
+	if _, found := theChain.getState(model.GetSettingsAddress()); !found {
+		t.Error("Bootstrap did not write the settings")
+	}
+	blocks := theChain.getBlocks()
+	if len(blocks) != 2 {
+		t.Errorf("Expected genesis block and bootstrap block, got %d blocks", len(blocks))
+		return
+	}
+	events := blocks[1].events
+	if events[0].EventType != model.SAWTOOTH_BLOCK_COMMIT || len(events) == 1 {
+		t.Error("Expected block commit event followed by the events of the bootstrap")
+	}
+	batchId, err = blockchain.SendCommands([]*command.Command{getBootstrapCommand()}, outputter)
+	if err != nil {
+		t.Error("Could not send second bootstrap: " + err.Error())
+		return
+	}
+	checkBatchStatus(batchId, blockchain.BATCH_STATUS_INVALID, "bootstrapped already", t)
+	if len(theChain.getBlocks()) != 2 {
+		t.Error("Invalid batch should not produce a block")
+	}
+}
+
+func TestTransactionCannotWriteOutsideOutputs(t *testing.T) {
+	logger := log.New(os.Stdout, "devnet.TestTransactionCannotWriteOutsideOutputs", log.Flags())
+	dao.Init("testDevnet.db", logger)
+	defer dao.ShutdownAndDelete(logger)
+	theChain := newChain(logger)
+	server := httptest.NewServer((&restApi{chain: theChain}).getRouter())
+	defer server.Close()
+	config.Current.RestUrl = server.URL
+	bootstrapCommand := getBootstrapCommand()
+	bootstrapCommand.OutputAddresses = bootstrapCommand.OutputAddresses[:1]
+	batchId, err := blockchain.SendCommands([]*command.Command{bootstrapCommand}, func(string) {})
+	if err != nil {
+		t.Error("Could not send bootstrap: " + err.Error())
+		return
+	}
+	checkBatchStatus(batchId, blockchain.BATCH_STATUS_INVALID, "not writable", t)
+	if _, found := theChain.getState(model.GetSettingsAddress()); found {
+		t.Error("Invalid batch should not change the state")
+	}
+}
+
+func TestReadAllBlocksAndState(t *testing.T) {
+	logger := log.New(os.Stdout, "devnet.TestReadAllBlocksAndState", log.Flags())
+	dao.Init("testDevnet.db", logger)
+	defer dao.ShutdownAndDelete(logger)
+	theChain := newChain(logger)
+	server := httptest.NewServer((&restApi{chain: theChain}).getRouter())
+	defer server.Close()
+	config.Current.RestUrl = server.URL
+	batchId, err := blockchain.SendCommands([]*command.Command{getBootstrapCommand()}, func(string) {})
+	if err != nil {
+		t.Error("Could not send bootstrap: " + err.Error())
+		return
+	}
+	checkBatchStatus(batchId, blockchain.BATCH_STATUS_COMMITTED, "", t)
+	blocks, err := blockchain.GetAllBlocks()
+	if err != nil {
+		t.Error("Could not read blocks: " + err.Error())
+		return
+	}
+	if len(blocks) != 2 || len(blocks[0].Transactions) != 0 || len(blocks[1].Transactions) != 1 {
+		t.Errorf("Expected genesis block and bootstrap block, got %v", blocks)
+		return
+	}
+	if blocks[1].PreviousBlockId != blocks[0].BlockId || blocks[1].Transactions[0].FamilyName != model.FamilyName {
+		t.Errorf("Bootstrap block mismatch: %v", blocks[1])
+	}
+	state, err := blockchain.GetAllState(model.Namespace)
+	if err != nil {
+		t.Error("Could not read state: " + err.Error())
+		return
+	}
+	if len(state) != 2 {
+		t.Errorf("Expected settings and first major, got %d addresses", len(state))
+	}
+	response, err := http.Get(server.URL + "/blocks?reverse&limit=1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer func() { _ = response.Body.Close() }()
+	parsedResponse := &restBlocksResponse{}
+	if err = json.NewDecoder(response.Body).Decode(parsedResponse); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(parsedResponse.Data) != 1 || parsedResponse.Paging.NextPosition != blocks[1].BlockId {
+		t.Error("Expected a page with the genesis block that refers to the bootstrap block")
+	}
+}
+
+func getBootstrapCommand() *command.Command {
+	context := signing.NewSecp256k1Context()
+	privateKey := context.NewRandomPrivateKey()
+	publicKey := context.GetPublicKey(privateKey)
+	return command.GetBootstrapCommand(&command.Bootstrap{
+		PriceMajorEditSettings: 10,
+		PricePersonEdit:        10,
+		Name:                   "Devnet Major",
+		Email:                  "devnet@xxx.nl",
+	}, &command.CryptoIdentity{
+		PublicKeyStr: publicKey.AsHex(),
+		PublicKey:    publicKey,
+		PrivateKey:   privateKey,
+	})
+}
+
+func checkBatchStatus(batchId string, expectedStatus blockchain.BatchStatusCode, expectedMessage string, t *testing.T) {
+	status, err := blockchain.GetBatchStatus(batchId)
+	if err != nil {
+		t.Error("Could not get batch status: " + err.Error())
+		return
+	}
+	if status.StatusCode != expectedStatus {
+		t.Errorf("Expected batch status %s, got %s", expectedStatus.String(), status.StatusCode.String())
+	}
+	if expectedMessage == "" {
+		return
+	}
+	if len(status.InvalidTransactions) != 1 ||
+		!strings.Contains(status.InvalidTransactions[0].Message, expectedMessage) {
+		t.Errorf("Expected invalid transaction with message %s, got %v", expectedMessage, status.InvalidTransactions)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"github.com/iskendria-pub/iskendria/config"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+/*
+The devnet is a stand-in for a Sawtooth network with the Iskendria
+transaction processor. It serves the REST API and the event
+subscriptions on the ports of the configured REST and ZMQ URLs, so
+the client, the major tool and the portal can run against it with the
+same configuration. The devnet does not need a validator or Docker.
+It is meant for development only.
+*/
+
+func main() {
+	if _, err := config.Load(config.GetDefaults()); err != nil {
+		log.Fatal(err)
+	}
+	logger := log.New(os.Stdout, "devnet", log.Flags())
+	restAddress, err := getRestListenAddress(config.Current.RestUrl)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	zmqBindUrl, err := getZmqBindUrl(config.Current.ZmqUrl)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	theChain := newChain(logger)
+	go func() {
+		logger.Fatal(newEventServer(theChain, logger).run(zmqBindUrl))
+	}()
+	api := &restApi{
+		chain: theChain,
+	}
+	logger.Printf("Serving REST API on %s and events on %s\n", restAddress, zmqBindUrl)
+	logger.Fatal(http.ListenAndServe(restAddress, api.getRouter()))
+}
+
+// The devnet listens on all interfaces, using the port of the URL.
+func getRestListenAddress(restUrl string) (string, error) {
+	parsed, err := url.Parse(restUrl)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Scheme != "http" {
+		return "", errors.New("The devnet only serves plain http, cannot serve " + restUrl)
+	}
+	port := parsed.Port()
+	if port == "" {
+		port = "80"
+	}
+	return ":" + port, nil
+}
+
+func getZmqBindUrl(zmqUrl string) (string, error) {
+	parsed, err := url.Parse(zmqUrl)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Port() == "" {
+		return "", errors.New("The ZMQ URL has no port: " + zmqUrl)
+	}
+	return "tcp://*:" + parsed.Port(), nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/sawtooth-sdk-go/messaging"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/client_event_pb2"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/validator_pb2"
+	"github.com/pebbe/zmq4"
+	"log"
+	"time"
+)
+
+/*
+The event server implements the client event subscription protocol
+of the validator. A subscriber first gets the events of the blocks
+after its last known block and then the events of new blocks. The
+events of a block are sent as one message, starting with the block
+commit event. Event filters are not supported.
+
+ZMQ sockets are not thread safe. Therefore one goroutine does all
+the work, polling for requests and checking for new blocks in turns.
+*/
+
+const eventPollInterval = 100 * time.Millisecond
+
+type subscriber struct {
+	eventTypes      map[string]bool
+	lastSentBlockId string
+}
+
+type eventServer struct {
+	chain       *chain
+	connection  *messaging.ZmqConnection
+	subscribers map[string]*subscriber
+	logger      *log.Logger
+}
+
+func newEventServer(theChain *chain, logger *log.Logger) *eventServer {
+	return &eventServer{
+		chain:       theChain,
+		subscribers: make(map[string]*subscriber),
+		logger:      logger,
+	}
+}
+
+func (es *eventServer) run(bindUrl string) error {
+	ctx, err := zmq4.NewContext()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = ctx.Term() }()
+	es.connection, err = messaging.NewConnection(ctx, zmq4.ROUTER, bindUrl, true)
+	if err != nil {
+		return err
+	}
+	defer es.connection.Close()
+	poller := zmq4.NewPoller()
+	poller.Add(es.connection.Socket(), zmq4.POLLIN)
+	for {
+		polled, err := poller.Poll(eventPollInterval)
+		if err != nil {
+			return err
+		}
+		if len(polled) >= 1 {
+			identity, message, err := es.connection.RecvMsg()
+			if err != nil {
+				return err
+			}
+			if err = es.handleMessage(identity, message); err != nil {
+				es.logger.Printf("Could not handle message of type %s from %s: %s\n",
+					message.MessageType.String(), identity, err.Error())
+			}
+		}
+		es.sendNewBlocks()
+	}
+}
+
+func (es *eventServer) handleMessage(identity string, message *validator_pb2.Message) error {
+	switch message.MessageType {
+	case validator_pb2.Message_CLIENT_EVENTS_SUBSCRIBE_REQUEST:
+		return es.handleSubscribe(identity, message)
+	case validator_pb2.Message_CLIENT_EVENTS_UNSUBSCRIBE_REQUEST:
+		return es.handleUnsubscribe(identity, message)
+	case validator_pb2.Message_PING_REQUEST:
+		return es.respond(identity, message, validator_pb2.Message_PING_RESPONSE, nil)
+	default:
+		return errors.New("Unsupported message type")
+	}
+}
+
+func (es *eventServer) handleSubscribe(identity string, message *validator_pb2.Message) error {
+	request := &client_event_pb2.ClientEventsSubscribeRequest{}
+	if err := proto.Unmarshal(message.Content, request); err != nil {
+		return err
+	}
+	lastKnownBlockId, found := es.getLastKnownBlockId(request.LastKnownBlockIds)
+	response := &client_event_pb2.ClientEventsSubscribeResponse{
+		Status: client_event_pb2.ClientEventsSubscribeResponse_OK,
+	}
+	if !found {
+		response.Status = client_event_pb2.ClientEventsSubscribeResponse_UNKNOWN_BLOCK
+		response.ResponseMessage = "None of the last known blocks is known to the devnet"
+	}
+	if err := es.respond(identity, message, validator_pb2.Message_CLIENT_EVENTS_SUBSCRIBE_RESPONSE, response); err != nil {
+		return err
+	}
+	if !found {
+		es.logger.Printf("Refused subscription of %s, unknown blocks %v\n", identity, request.LastKnownBlockIds)
+		return nil
+	}
+	eventTypes := make(map[string]bool)
+	for _, s := range request.Subscriptions {
+		eventTypes[s.EventType] = true
+	}
+	es.subscribers[identity] = &subscriber{
+		eventTypes:      eventTypes,
+		lastSentBlockId: lastKnownBlockId,
+	}
+	es.logger.Printf("Subscribed %s from block %s\n", identity, lastKnownBlockId)
+	return nil
+}
+
+// Without last known blocks, a subscriber only gets the events of new blocks.
+func (es *eventServer) getLastKnownBlockId(lastKnownBlockIds []string) (string, bool) {
+	if len(lastKnownBlockIds) == 0 {
+		blocks := es.chain.getBlocks()
+		return blocks[len(blocks)-1].id, true
+	}
+	for _, blockId := range lastKnownBlockIds {
+		if _, found := es.chain.getBlocksAfter(blockId); found {
+			return blockId, true
+		}
+	}
+	return "", false
+}
+
+func (es *eventServer) handleUnsubscribe(identity string, message *validator_pb2.Message) error {
+	delete(es.subscribers, identity)
+	es.logger.Printf("Unsubscribed %s\n", identity)
+	return es.respond(identity, message, validator_pb2.Message_CLIENT_EVENTS_UNSUBSCRIBE_RESPONSE,
+		&client_event_pb2.ClientEventsUnsubscribeResponse{
+			Status: client_event_pb2.ClientEventsUnsubscribeResponse_OK,
+		})
+}
+
+func (es *eventServer) respond(
+	identity string,
+	request *validator_pb2.Message,
+	messageType validator_pb2.Message_MessageType,
+	response proto.Message) error {
+	var content []byte
+	if response != nil {
+		var err error
+		content, err = proto.Marshal(response)
+		if err != nil {
+			return err
+		}
+	}
+	return es.connection.SendMsgTo(identity, &validator_pb2.Message{
+		MessageType:   messageType,
+		CorrelationId: request.CorrelationId,
+		Content:       content,
+	})
+}
+
+func (es *eventServer) sendNewBlocks() {
+	for identity, s := range es.subscribers {
+		blocks, found := es.chain.getBlocksAfter(s.lastSentBlockId)
+		if !found {
+			continue
+		}
+		for _, b := range blocks {
+			if err := es.sendBlock(identity, s, b); err != nil {
+				es.logger.Printf("Could not send events of block %s to %s, unsubscribing: %s\n",
+					b.id, identity, err.Error())
+				delete(es.subscribers, identity)
+				break
+			}
+			s.lastSentBlockId = b.id
+		}
+	}
+}
+
+func (es *eventServer) sendBlock(identity string, s *subscriber, b *block) error {
+	eventList := &events_pb2.EventList{
+		Events: []*events_pb2.Event{},
+	}
+	for _, ev := range b.events {
+		if s.eventTypes[ev.EventType] {
+			eventList.Events = append(eventList.Events, ev)
+		}
+	}
+	content, err := proto.Marshal(eventList)
+	if err != nil {
+		return err
+	}
+	_, err = es.connection.SendNewMsgTo(identity, validator_pb2.Message_CLIENT_EVENTS, content)
+	return err
+}
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/batch_pb2"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/transaction_pb2"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+The REST API of the devnet is the subset of the Sawtooth REST API
+that is used by package blockchain. Batches are applied when they
+are posted, so the wait parameter of /batch_statuses is accepted
+but not needed.
+
+Lists of blocks and state entries are paged. Query parameter start
+is the id of the first block or the first address of the page, like
+next_position in the paging of the previous page.
+*/
+
+const BATCH_STATUS_UNKNOWN = "UNKNOWN"
+
+const (
+	DEFAULT_PAGE_LIMIT = 100
+	MAX_PAGE_LIMIT     = 1000
+)
+
+type restBlocksResponse struct {
+	Head   string       `json:"head"`
+	Link   string       `json:"link"`
+	Paging *restPaging  `json:"paging"`
+	Data   []*restBlock `json:"data"`
+}
+
+type restPaging struct {
+	Start        string `json:"start,omitempty"`
+	Limit        int    `json:"limit"`
+	NextPosition string `json:"next_position,omitempty"`
+	Next         string `json:"next,omitempty"`
+}
+
+type restBlock struct {
+	HeaderSignature string           `json:"header_signature"`
+	Header          *restBlockHeader `json:"header"`
+	Batches         []*restBatch     `json:"batches"`
+}
+
+type restBlockHeader struct {
+	BlockNum        string   `json:"block_num"`
+	PreviousBlockId string   `json:"previous_block_id"`
+	BatchIds        []string `json:"batch_ids"`
+}
+
+type restBatch struct {
+	HeaderSignature string             `json:"header_signature"`
+	Header          *restBatchHeader   `json:"header"`
+	Transactions    []*restTransaction `json:"transactions"`
+}
+
+type restBatchHeader struct {
+	SignerPublicKey string   `json:"signer_public_key"`
+	TransactionIds  []string `json:"transaction_ids"`
+}
+
+type restTransaction struct {
+	HeaderSignature string                 `json:"header_signature"`
+	Header          *restTransactionHeader `json:"header"`
+	Payload         []byte                 `json:"payload"`
+}
+
+type restTransactionHeader struct {
+	BatcherPublicKey string   `json:"batcher_public_key"`
+	Dependencies     []string `json:"dependencies"`
+	FamilyName       string   `json:"family_name"`
+	FamilyVersion    string   `json:"family_version"`
+	Inputs           []string `json:"inputs"`
+	Nonce            string   `json:"nonce"`
+	Outputs          []string `json:"outputs"`
+	PayloadSha512    string   `json:"payload_sha512"`
+	SignerPublicKey  string   `json:"signer_public_key"`
+}
+
+type restBatchStatusesResponse struct {
+	Data []*restBatchStatus `json:"data"`
+	Link string             `json:"link"`
+}
+
+type restBatchStatus struct {
+	Id                  string                    `json:"id"`
+	Status              string                    `json:"status"`
+	InvalidTransactions []*restInvalidTransaction `json:"invalid_transactions"`
+}
+
+type restInvalidTransaction struct {
+	Id      string `json:"id"`
+	Message string `json:"message"`
+}
+
+type restBatchesResponse struct {
+	Link string `json:"link"`
+}
+
+type restStateResponse struct {
+	Data []byte `json:"data"`
+	Head string `json:"head"`
+	Link string `json:"link"`
+}
+
+type restStateListResponse struct {
+	Data   []*restStateEntry `json:"data"`
+	Head   string            `json:"head"`
+	Link   string            `json:"link"`
+	Paging *restPaging       `json:"paging"`
+}
+
+type restStateEntry struct {
+	Address string `json:"address"`
+	Data    []byte `json:"data"`
+}
+
+type restErrorResponse struct {
+	Error *restError `json:"error"`
+}
+
+type restError struct {
+	Code    int    `json:"code"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+type restApi struct {
+	chain *chain
+}
+
+func (ra *restApi) getRouter() *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/batches", ra.postBatches).Methods(http.MethodPost)
+	r.HandleFunc("/batch_statuses", ra.getBatchStatuses).Methods(http.MethodGet)
+	r.HandleFunc("/blocks", ra.getBlocks).Methods(http.MethodGet)
+	r.HandleFunc("/state", ra.getStateList).Methods(http.MethodGet)
+	r.HandleFunc("/state/{address}", ra.getState).Methods(http.MethodGet)
+	return r
+}
+
+func (ra *restApi) postBatches(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Could not read request body: "+err.Error())
+		return
+	}
+	batchList := &batch_pb2.BatchList{}
+	if err = proto.Unmarshal(body, batchList); err != nil {
+		writeError(w, http.StatusBadRequest, "Could not parse batch list: "+err.Error())
+		return
+	}
+	if len(batchList.Batches) == 0 {
+		writeError(w, http.StatusBadRequest, "The batch list has no batches")
+		return
+	}
+	batchIds := make([]string, len(batchList.Batches))
+	for i, batch := range batchList.Batches {
+		ra.chain.applyBatch(batch)
+		batchIds[i] = batch.HeaderSignature
+	}
+	writeJson(w, http.StatusAccepted, &restBatchesResponse{
+		Link: getLink(r, "/batch_statuses?id="+strings.Join(batchIds, ",")),
+	})
+}
+
+func (ra *restApi) getBatchStatuses(w http.ResponseWriter, r *http.Request) {
+	idParameter := r.URL.Query().Get("id")
+	if idParameter == "" {
+		writeError(w, http.StatusBadRequest, "Query parameter id is required")
+		return
+	}
+	response := &restBatchStatusesResponse{
+		Data: []*restBatchStatus{},
+		Link: getLink(r, r.URL.RequestURI()),
+	}
+	for _, batchId := range strings.Split(idParameter, ",") {
+		status := &restBatchStatus{
+			Id:                  batchId,
+			Status:              BATCH_STATUS_UNKNOWN,
+			InvalidTransactions: []*restInvalidTransaction{},
+		}
+		if result, found := ra.chain.getBatchResult(batchId); found {
+			status.Status = result.status
+			for _, it := range result.invalidTransactions {
+				status.InvalidTransactions = append(status.InvalidTransactions, &restInvalidTransaction{
+					Id:      it.id,
+					Message: it.message,
+				})
+			}
+		}
+		response.Data = append(response.Data, status)
+	}
+	writeJson(w, http.StatusOK, response)
+}
+
+// Blocks are listed newest first, or oldest first with
+// query parameter reverse.
+func (ra *restApi) getBlocks(w http.ResponseWriter, r *http.Request) {
+	blocks := ra.chain.getBlocks()
+	_, reverse := r.URL.Query()["reverse"]
+	ordered := make([]*block, len(blocks))
+	ids := make([]string, len(blocks))
+	for i, b := range blocks {
+		index := len(blocks) - 1 - i
+		if reverse {
+			index = i
+		}
+		ordered[index] = b
+		ids[index] = b.id
+	}
+	first, last, paging, err := getPage(r, ids)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	response := &restBlocksResponse{
+		Head:   blocks[len(blocks)-1].id,
+		Link:   getLink(r, r.URL.RequestURI()),
+		Paging: paging,
+		Data:   make([]*restBlock, 0, last-first),
+	}
+	for _, b := range ordered[first:last] {
+		restBlock, err := getRestBlock(b)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		response.Data = append(response.Data, restBlock)
+	}
+	writeJson(w, http.StatusOK, response)
+}
+
+func getRestBlock(b *block) (*restBlock, error) {
+	result := &restBlock{
+		HeaderSignature: b.id,
+		Header: &restBlockHeader{
+			BlockNum:        strconv.Itoa(b.num),
+			PreviousBlockId: b.previousId,
+			BatchIds:        b.batchIds,
+		},
+		Batches: make([]*restBatch, len(b.batches)),
+	}
+	for i, batch := range b.batches {
+		restBatch, err := getRestBatch(batch)
+		if err != nil {
+			return nil, err
+		}
+		result.Batches[i] = restBatch
+	}
+	return result, nil
+}
+
+func getRestBatch(batch *batch_pb2.Batch) (*restBatch, error) {
+	header := &batch_pb2.BatchHeader{}
+	if err := proto.Unmarshal(batch.Header, header); err != nil {
+		return nil, errors.New("Could not parse header of batch " + batch.HeaderSignature)
+	}
+	result := &restBatch{
+		HeaderSignature: batch.HeaderSignature,
+		Header: &restBatchHeader{
+			SignerPublicKey: header.SignerPublicKey,
+			TransactionIds:  header.TransactionIds,
+		},
+		Transactions: make([]*restTransaction, len(batch.Transactions)),
+	}
+	for i, transaction := range batch.Transactions {
+		header := &transaction_pb2.TransactionHeader{}
+		if err := proto.Unmarshal(transaction.Header, header); err != nil {
+			return nil, errors.New("Could not parse header of transaction " + transaction.HeaderSignature)
+		}
+		result.Transactions[i] = &restTransaction{
+			HeaderSignature: transaction.HeaderSignature,
+			Header: &restTransactionHeader{
+				BatcherPublicKey: header.BatcherPublicKey,
+				Dependencies:     header.Dependencies,
+				FamilyName:       header.FamilyName,
+				FamilyVersion:    header.FamilyVersion,
+				Inputs:           header.Inputs,
+				Nonce:            header.Nonce,
+				Outputs:          header.Outputs,
+				PayloadSha512:    header.PayloadSha512,
+				SignerPublicKey:  header.SignerPublicKey,
+			},
+			Payload: transaction.Payload,
+		}
+	}
+	return result, nil
+}
+
+// getPage gives the range of the keys that is on the requested page.
+func getPage(r *http.Request, keys []string) (int, int, *restPaging, error) {
+	query := r.URL.Query()
+	limit := DEFAULT_PAGE_LIMIT
+	if limitParameter := query.Get("limit"); limitParameter != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParameter)
+		if err != nil || limit < 1 || limit > MAX_PAGE_LIMIT {
+			return 0, 0, nil, errors.New(fmt.Sprintf(
+				"Query parameter limit should be a number from 1 to %d, got %s", MAX_PAGE_LIMIT, limitParameter))
+		}
+	}
+	paging := &restPaging{
+		Start: query.Get("start"),
+		Limit: limit,
+	}
+	first := 0
+	if paging.Start != "" {
+		first = indexOf(keys, paging.Start)
+		if first < 0 {
+			return 0, 0, nil, errors.New("Paging start not found: " + paging.Start)
+		}
+	}
+	last := first + limit
+	if last >= len(keys) {
+		return first, len(keys), paging, nil
+	}
+	paging.NextPosition = keys[last]
+	query.Set("start", paging.NextPosition)
+	query.Set("limit", strconv.Itoa(limit))
+	paging.Next = getLink(r, r.URL.Path+"?"+query.Encode())
+	return first, last, paging, nil
+}
+
+func indexOf(keys []string, key string) int {
+	for i, k := range keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (ra *restApi) getState(w http.ResponseWriter, r *http.Request) {
+	address := mux.Vars(r)["address"]
+	data, found := ra.chain.getState(address)
+	if !found {
+		writeError(w, http.StatusNotFound, "There is no state data at address "+address)
+		return
+	}
+	writeJson(w, http.StatusOK, &restStateResponse{
+		Data: data,
+		Head: ra.getHead(),
+		Link: getLink(r, r.URL.RequestURI()),
+	})
+}
+
+func (ra *restApi) getStateList(w http.ResponseWriter, r *http.Request) {
+	entries := ra.chain.getStateWithPrefix(r.URL.Query().Get("address"))
+	addresses := make([]string, 0, len(entries))
+	for address := range entries {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	first, last, paging, err := getPage(r, addresses)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	response := &restStateListResponse{
+		Data:   make([]*restStateEntry, 0, last-first),
+		Head:   ra.getHead(),
+		Link:   getLink(r, r.URL.RequestURI()),
+		Paging: paging,
+	}
+	for _, address := range addresses[first:last] {
+		response.Data = append(response.Data, &restStateEntry{
+			Address: address,
+			Data:    entries[address],
+		})
+	}
+	writeJson(w, http.StatusOK, response)
+}
+
+func (ra *restApi) getHead() string {
+	blocks := ra.chain.getBlocks()
+	return blocks[len(blocks)-1].id
+}
+
+func getLink(r *http.Request, path string) string {
+	return fmt.Sprintf("http://%s%s", r.Host, path)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJson(w, status, &restErrorResponse{
+		Error: &restError{
+			Code:    status,
+			Title:   http.StatusText(status),
+			Message: message,
+		},
+	})
+}
+
+func writeJson(w http.ResponseWriter, status int, response interface{}) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Could not serialize response: " + err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
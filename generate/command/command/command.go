@@ -188,6 +188,9 @@ func getDaoSettingsUpdatePropertiesFields() []string {
 		"PriceEditorEditJournal",
 		"PriceEditorAddColleague",
 		"PriceEditorAcceptDuty",
+		"PriceAuthorWithdrawManuscript",
+		"PriceEditorInviteReviewer",
+		"ApprovalThreshold",
 	}
 }
 
@@ -274,6 +277,14 @@ func getCommandSettingsUpdatePropertiesFields() []*modelCommandCheck {
 			CommandField: "PriceEditorAcceptDuty",
 			EventKey:     "EV_KEY_PRICE_EDITOR_ACCEPT_DUTY",
 		},
+		{
+			CommandField: "PriceAuthorWithdrawManuscript",
+			EventKey:     "EV_KEY_PRICE_AUTHOR_WITHDRAW_MANUSCRIPT",
+		},
+		{
+			CommandField: "PriceEditorInviteReviewer",
+			EventKey:     "EV_KEY_PRICE_EDITOR_INVITE_REVIEWER",
+		},
 	}
 }
 
@@ -17,7 +17,6 @@ import (
 	"fmt"
 	"github.com/hyperledger/sawtooth-sdk-go/processor"
 	"github.com/iskendria-pub/iskendria/model"
-	"log"
 )
 
 {{range .}}
@@ -46,7 +45,6 @@ func (u *singleUpdate{{.Tag}}ModificationTime) updateState(state *unmarshalledSt
 
 func (u *singleUpdate{{.Tag}}ModificationTime) issueEvent(eventSeq int32, transactionId string, ba BlockchainAccess) error {
 	eventType := model.AlexandriaPrefix + {{.EventType}}
-	log.Println("Sending event of type: " + eventType)
 	return ba.AddEvent(eventType,
 		[]processor.Attribute{
 			{
@@ -93,6 +91,16 @@ func main() {
 			EventType:      "model.EV_TYPE_MANUSCRIPT_MODIFICATION_TIME",
 			StateContainer: "manuscripts",
 		},
+		{
+			Tag:            "ReviewInvitation",
+			EventType:      "model.EV_TYPE_REVIEW_INVITATION_MODIFICATION_TIME",
+			StateContainer: "reviewInvitations",
+		},
+		{
+			Tag:            "Proposal",
+			EventType:      "model.EV_TYPE_PROPOSAL_MODIFICATION_TIME",
+			StateContainer: "proposals",
+		},
 	}
 	tmpl, err := template.New("templateUpdateModificationTime").Parse(templateUpdateModificationTime)
 	if err != nil {
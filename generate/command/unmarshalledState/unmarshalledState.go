@@ -192,6 +192,18 @@ func main() {
 			ModelStateField:            "StateReview",
 			ModelAddressTypeChecker:    "IsReviewAddress",
 		},
+		{
+			Tag:                        "ReviewInvitation",
+			UnmarshalledContainerField: "reviewInvitations",
+			ModelStateField:            "StateReviewInvitation",
+			ModelAddressTypeChecker:    "IsReviewInvitationAddress",
+		},
+		{
+			Tag:                        "Proposal",
+			UnmarshalledContainerField: "proposals",
+			ModelStateField:            "StateProposal",
+			ModelAddressTypeChecker:    "IsProposalAddress",
+		},
 	}
 	tmpl, err := template.New("templateUnmarshalledState").Parse(templateUnmarshalledState)
 	if err != nil {
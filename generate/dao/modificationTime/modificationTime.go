@@ -101,6 +101,18 @@ func main() {
 			Tag:        "Manuscript",
 			Table:      "manuscript",
 		},
+		{
+			IdColumn:   "id",
+			IsSettings: false,
+			Tag:        "ReviewInvitation",
+			Table:      "reviewinvitation",
+		},
+		{
+			IdColumn:   "id",
+			IsSettings: false,
+			Tag:        "Proposal",
+			Table:      "proposal",
+		},
 	}
 	tmpl, err := template.New("templateModificationTime").Parse(templateModificationTime)
 	if err != nil {
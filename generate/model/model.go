@@ -76,13 +76,15 @@ func Is{{.AddressTypeTitle}}Address(address string) bool {
 func main() {
 	c := &Config{
 		EnumNames: []string{
-			"ManuscriptStatus", "Judgement", "ManuscriptJudgement",
+			"ManuscriptStatus", "Judgement", "ManuscriptJudgement", "JournalState",
 		},
 		AddressDefs: []AddressDef{
 			getAddressDef("Journal", "20"),
 			getAddressDef("Manuscript", "10"),
 			getAddressDef("ManuscriptThread", "18"),
 			getAddressDef("Review", "30"),
+			getAddressDef("ReviewInvitation", "38"),
+			getAddressDef("Proposal", "48"),
 			getAddressDef("Person", "01"),
 		},
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/iskendria-pub/iskendria/util"
 	"log"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -430,6 +431,395 @@ func doTestPersonUpdateIncBalance(t *testing.T) {
 	}
 }
 
+func TestBalanceTransfer(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestBalanceTransfer", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(personCreate *command.PersonCreate, t *testing.T) {
+		doTestPersonCreate(personCreate, t)
+		payerKey := cliIskendria.LoggedIn().PublicKeyStr
+		payerId := getPersonByKey(payerKey, t).Id
+		receiverId := getPersonByKey(personCreate.PublicKey, t).Id
+		payerBalance := SUFFICIENT_BALANCE - priceMajorCreatePerson
+		receiverBalance := SUFFICIENT_BALANCE
+		theAmount := int32(30)
+		cmd := command.GetCommandBalanceTransfer(receiverId, theAmount, payerId, cliIskendria.LoggedIn(), int32(0))
+		err := command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdBalanceTransferV1", blockchainAccess)
+		if err == nil {
+			t.Error("Expected version 1 to reject transferring balance")
+		}
+		doTestBalanceTransferRejected(payerId, theAmount, payerId, "transactionIdBalanceTransferSelf", t)
+		doTestBalanceTransferRejected(receiverId, int32(0), payerId, "transactionIdBalanceTransferZero", t)
+		doTestBalanceTransferRejected(receiverId, int32(-5), payerId, "transactionIdBalanceTransferNegative", t)
+		doTestBalanceTransferRejected(
+			receiverId, payerBalance+1, payerId, "transactionIdBalanceTransferInsufficient", t)
+		checkStateBalanceOfKey(payerBalance, payerKey, t)
+		checkDaoBalanceOfKey(payerBalance, payerKey, t)
+		err = command.RunCommandForTest(cmd, "transactionIdBalanceTransfer", blockchainAccess)
+		if err != nil {
+			t.Error("Could not transfer balance: " + err.Error())
+		}
+		checkStateBalanceOfKey(payerBalance-theAmount, payerKey, t)
+		checkDaoBalanceOfKey(payerBalance-theAmount, payerKey, t)
+		checkStateBalanceOfKey(receiverBalance+theAmount, personCreate.PublicKey, t)
+		checkDaoBalanceOfKey(receiverBalance+theAmount, personCreate.PublicKey, t)
+	}
+	withNewPersonCreate(f, t)
+}
+
+func doTestBalanceTransferRejected(toPersonId string, amount int32, signerId, transactionId string, t *testing.T) {
+	cmd := command.GetCommandBalanceTransfer(toPersonId, amount, signerId, cliIskendria.LoggedIn(), int32(0))
+	err := command.RunCommandForTest(cmd, transactionId, blockchainAccess)
+	if err == nil {
+		t.Error(fmt.Sprintf("Expected transfer of %d to %s to be rejected", amount, toPersonId))
+	}
+}
+
+func TestBalanceLedger(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestBalanceLedger", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(personCreate *command.PersonCreate, t *testing.T) {
+		doTestPersonCreate(personCreate, t)
+		payerId := getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id
+		receiverId := getPersonByKey(personCreate.PublicKey, t).Id
+		theAmount := int32(20)
+		cmd := command.GetCommandBalanceTransfer(receiverId, theAmount, payerId, cliIskendria.LoggedIn(), int32(0))
+		if err := command.RunCommandForTest(cmd, "transactionIdBalanceLedgerTransfer", blockchainAccess); err != nil {
+			t.Error("Could not transfer balance: " + err.Error())
+		}
+		payerHistory, err := dao.GetBalanceHistory(payerId)
+		if err != nil {
+			t.Error("Could not get balance history: " + err.Error())
+			return
+		}
+		expectedPayerAmounts := []int32{SUFFICIENT_BALANCE, -priceMajorCreatePerson, -theAmount}
+		expectedPayerCommandTypes := []string{
+			model.COMMAND_TYPE_BALANCE_INCREMENT, "PersonCreate", model.COMMAND_TYPE_BALANCE_TRANSFER}
+		if len(payerHistory) != len(expectedPayerAmounts) {
+			t.Error(fmt.Sprintf("Expected %d balance changes, got %d", len(expectedPayerAmounts), len(payerHistory)))
+			return
+		}
+		for i, c := range payerHistory {
+			if c.Amount != expectedPayerAmounts[i] || c.CommandType != expectedPayerCommandTypes[i] {
+				t.Error(fmt.Sprintf("Balance change %d mismatch: %v", i, c))
+			}
+		}
+		if payerHistory[2].Balance != SUFFICIENT_BALANCE-priceMajorCreatePerson-theAmount {
+			t.Error(fmt.Sprintf("Resulting balance mismatch: %v", payerHistory[2]))
+		}
+		report, err := dao.GetCreditReport()
+		if err != nil {
+			t.Error("Could not get credit report: " + err.Error())
+			return
+		}
+		if len(report) != 2 {
+			t.Error(fmt.Sprintf("Expected credit summaries of two persons, got %d", len(report)))
+		}
+		for _, summary := range report {
+			if !summary.IsReconciled() {
+				t.Error(fmt.Sprintf("Credit summary not reconciled: %v", summary))
+			}
+			if summary.PersonId == receiverId && summary.TransferredIn != int64(theAmount) {
+				t.Error(fmt.Sprintf("Transfer not reported for receiver: %v", summary))
+			}
+			if summary.PersonId == payerId && summary.Paid != int64(priceMajorCreatePerson) {
+				t.Error(fmt.Sprintf("Payment not reported for payer: %v", summary))
+			}
+		}
+	}
+	withNewPersonCreate(f, t)
+}
+
+func TestProposals(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestProposals", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(personCreate *command.PersonCreate, t *testing.T) {
+		doTestPersonCreate(personCreate, t)
+		major := cliIskendria.LoggedIn()
+		majorId := getPersonByKey(major.PublicKeyStr, t).Id
+		secondMajorId := getPersonByKey(personCreate.PublicKey, t).Id
+		cmd := command.GetPersonUpdateSetMajorCommand(
+			secondMajorId, majorId, major, priceMajorChangePersonAuthorization)
+		if err := command.RunCommandForTest(cmd, "transactionIdProposalsSetMajor", blockchainAccess); err != nil {
+			t.Error("Could not make second major: " + err.Error())
+		}
+		// The majors are counted by the first approval threshold update
+		checkMajorCount(int32(0), t)
+		orig := getSettings(t)
+		tooHigh := *orig
+		tooHigh.ApprovalThreshold = int32(3)
+		cmd = command.GetSettingsUpdateCommand(orig, &tooHigh, getMajorIds(t), majorId, major, priceMajorEditSettings)
+		if err := command.RunCommandForTest(cmd, "transactionIdProposalsThresholdTooHigh", blockchainAccess); err == nil {
+			t.Error("Expected that the approval threshold cannot exceed the number of majors")
+		}
+		doTestSetApprovalThreshold(int32(2), majorId, t)
+		checkMajorCount(int32(2), t)
+		cmd = command.GetPersonUpdateIncBalanceCommand(secondMajorId, int32(50), majorId, major, int32(0))
+		if err := command.RunCommandForTest(cmd, "transactionIdProposalsDirect", blockchainAccess); err == nil {
+			t.Error("Expected that incrementing a balance needs a proposal")
+		}
+		governed := command.GetPersonUpdateIncBalanceCommand(secondMajorId, int32(50), majorId, major, int32(0))
+		cmd, _, err := command.GetCommandProposalCreate(governed, model.GetCurrentTime()-1)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if err = command.RunCommandForTest(cmd, "transactionIdProposalsExpired", blockchainAccess); err == nil {
+			t.Error("Expected that a proposal cannot expire in the past")
+		}
+		cmd, proposalId, err := command.GetCommandProposalCreate(governed, model.GetCurrentTime()+3600)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdProposalsV1", blockchainAccess)
+		if err == nil {
+			t.Error("Expected version 1 to reject proposals")
+		}
+		if err = command.RunCommandForTest(cmd, "transactionIdProposalsCreate", blockchainAccess); err != nil {
+			t.Error("Could not create proposal: " + err.Error())
+		}
+		checkStateProposal(getStateProposal(proposalId, t), majorId, []string{majorId},
+			model.ProposalState_proposalOpen, t)
+		checkStateBalanceOfKey(SUFFICIENT_BALANCE, personCreate.PublicKey, t)
+		checkDaoOpenProposals([]string{proposalId}, t)
+		cmd = command.GetCommandProposalApprove(proposalId, secondMajorId, majorId, major)
+		if err = command.RunCommandForTest(cmd, "transactionIdProposalsApproveTwice", blockchainAccess); err == nil {
+			t.Error("Expected that the proposer cannot approve twice")
+		}
+		if err = cliIskendria.Login(personPublicKeyFile, personPrivateKeyFile); err != nil {
+			t.Error("Could not login as second major")
+		}
+		secondMajor := cliIskendria.LoggedIn()
+		cmd = command.GetCommandProposalApprove(proposalId, secondMajorId, secondMajorId, secondMajor)
+		cmd.Command.Timestamp = getStateProposal(proposalId, t).CreatedOn - 1
+		if err = command.RunCommandForTest(cmd, "transactionIdProposalsApproveBackdated", blockchainAccess); err == nil {
+			t.Error("Expected that an approval cannot be older than the proposal")
+		}
+		cmd = command.GetCommandProposalApprove(proposalId, secondMajorId, secondMajorId, secondMajor)
+		if err = command.RunCommandForTest(cmd, "transactionIdProposalsApprove", blockchainAccess); err != nil {
+			t.Error("Could not approve proposal: " + err.Error())
+		}
+		checkStateProposal(getStateProposal(proposalId, t), majorId, []string{majorId, secondMajorId},
+			model.ProposalState_proposalExecuted, t)
+		checkStateBalanceOfKey(SUFFICIENT_BALANCE+50, personCreate.PublicKey, t)
+		checkDaoBalanceOfKey(SUFFICIENT_BALANCE+50, personCreate.PublicKey, t)
+		checkDaoOpenProposals([]string{}, t)
+		checkDaoProposal(proposalId, model.ProposalState_proposalExecuted, []string{majorName, personCreate.Name}, t)
+		history, err := dao.GetBalanceHistory(secondMajorId)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		last := history[len(history)-1]
+		if last.Amount != int32(50) || last.CommandType != model.COMMAND_TYPE_BALANCE_INCREMENT {
+			t.Error(fmt.Sprintf("Executed proposal not in balance history as issued: %v", last))
+		}
+		if err = command.RunCommandForTest(cmd, "transactionIdProposalsApproveExecuted", blockchainAccess); err == nil {
+			t.Error("Expected that an executed proposal cannot be approved")
+		}
+		governed = command.GetPersonUpdateUnsetMajorCommand(
+			majorId, secondMajorId, secondMajor, priceMajorChangePersonAuthorization)
+		cmd, _, err = command.GetCommandProposalCreate(governed, model.GetCurrentTime()+3600)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = command.RunCommandForTest(cmd, "transactionIdProposalsCreateUnsetMajor", blockchainAccess)
+		if err == nil || !strings.Contains(err.Error(), "below the approval threshold") {
+			t.Error(fmt.Sprintf("Expected that revoking a major below the approval threshold is refused, got %v", err))
+		}
+		checkMajorCount(int32(2), t)
+		majorBalance := getStatePerson(majorId, t).Balance
+		governed = command.GetPersonUpdateIncBalanceCommand(majorId, int32(50), secondMajorId, secondMajor, int32(0))
+		cmd, proposalId, err = command.GetCommandProposalCreate(governed, model.GetCurrentTime()+3600)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if err = command.RunCommandForTest(cmd, "transactionIdProposalsCreateToCancel", blockchainAccess); err != nil {
+			t.Error("Could not create proposal: " + err.Error())
+		}
+		cmd = command.GetCommandProposalCancel(proposalId, majorId, major)
+		if err = command.RunCommandForTest(cmd, "transactionIdProposalsCancelOther", blockchainAccess); err == nil {
+			t.Error("Expected that only the proposer can cancel a proposal")
+		}
+		cmd = command.GetCommandProposalCancel(proposalId, secondMajorId, secondMajor)
+		if err = command.RunCommandForTest(cmd, "transactionIdProposalsCancel", blockchainAccess); err != nil {
+			t.Error("Could not cancel proposal: " + err.Error())
+		}
+		checkDaoProposal(proposalId, model.ProposalState_proposalCancelled, []string{personCreate.Name}, t)
+		cmd = command.GetCommandProposalApprove(proposalId, majorId, majorId, major)
+		if err = command.RunCommandForTest(cmd, "transactionIdProposalsApproveCancelled", blockchainAccess); err == nil {
+			t.Error("Expected that a cancelled proposal cannot be approved")
+		}
+		if getStatePerson(majorId, t).Balance != majorBalance {
+			t.Error("A cancelled proposal should not be executed")
+		}
+	}
+	withNewPersonCreate(f, t)
+}
+
+func TestMajorCount(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestMajorCount", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(personCreate *command.PersonCreate, t *testing.T) {
+		doTestPersonCreate(personCreate, t)
+		major := cliIskendria.LoggedIn()
+		majorId := getPersonByKey(major.PublicKeyStr, t).Id
+		personId := getPersonByKey(personCreate.PublicKey, t).Id
+		// Version 1 clients only declared the person and the signer as
+		// outputs. Replaying their transactions should still work.
+		cmd := command.GetPersonUpdateSetMajorCommand(personId, majorId, major, priceMajorChangePersonAuthorization)
+		cmd.OutputAddresses = []string{majorId, personId}
+		err := command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdMajorCountSetMajorV1", blockchainAccess)
+		if err != nil {
+			t.Error("Could not replay a version 1 set major: " + err.Error())
+		}
+		if !getStatePerson(personId, t).IsMajor {
+			t.Error("Version 1 set major was not applied")
+		}
+		cmd = command.GetPersonUpdateUnsetMajorCommand(personId, majorId, major, priceMajorChangePersonAuthorization)
+		cmd.OutputAddresses = []string{majorId, personId}
+		err = command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdMajorCountUnsetMajorV1", blockchainAccess)
+		if err != nil {
+			t.Error("Could not replay a version 1 unset major: " + err.Error())
+		}
+		if getStatePerson(personId, t).IsMajor {
+			t.Error("Version 1 unset major was not applied")
+		}
+		checkMajorCount(int32(0), t)
+		orig := getSettings(t)
+		updated := *orig
+		updated.ApprovalThreshold = int32(1)
+		cmd = command.GetSettingsUpdateCommand(orig, &updated, nil, majorId, major, priceMajorEditSettings)
+		if err = command.RunCommandForTest(cmd, "transactionIdMajorCountNotListed", blockchainAccess); err == nil {
+			t.Error("Expected that the majors should be listed to count them")
+		}
+		cmd = command.GetSettingsUpdateCommand(
+			orig, &updated, []string{majorId, personId}, majorId, major, priceMajorEditSettings)
+		if err = command.RunCommandForTest(cmd, "transactionIdMajorCountNotMajor", blockchainAccess); err == nil {
+			t.Error("Expected that only majors can be listed")
+		}
+		doTestSetApprovalThreshold(int32(1), majorId, t)
+		checkMajorCount(int32(1), t)
+		cmd = command.GetPersonUpdateSetMajorCommand(personId, majorId, major, priceMajorChangePersonAuthorization)
+		if err = command.RunCommandForTest(cmd, "transactionIdMajorCountSetMajor", blockchainAccess); err != nil {
+			t.Error("Could not set major: " + err.Error())
+		}
+		checkMajorCount(int32(2), t)
+		cmd = command.GetPersonUpdateUnsetMajorCommand(personId, majorId, major, priceMajorChangePersonAuthorization)
+		if err = command.RunCommandForTest(cmd, "transactionIdMajorCountUnsetMajor", blockchainAccess); err != nil {
+			t.Error("Could not unset major: " + err.Error())
+		}
+		checkMajorCount(int32(1), t)
+		cmd = command.GetPersonUpdateUnsetMajorCommand(majorId, majorId, major, priceMajorChangePersonAuthorization)
+		if err = command.RunCommandForTest(cmd, "transactionIdMajorCountUnsetLast", blockchainAccess); err == nil {
+			t.Error("Expected that the last major cannot be revoked with approval threshold 1")
+		}
+		checkMajorCount(int32(1), t)
+	}
+	withNewPersonCreate(f, t)
+}
+
+func doTestSetApprovalThreshold(threshold int32, majorId string, t *testing.T) {
+	orig := getSettings(t)
+	updated := *orig
+	updated.ApprovalThreshold = threshold
+	cmd := command.GetSettingsUpdateCommand(orig, &updated, getMajorIds(t), majorId, cliIskendria.LoggedIn(), priceMajorEditSettings)
+	err := command.RunCommandForTestWithFamilyVersion(
+		cmd, model.FamilyVersion1, "transactionIdApprovalThresholdV1", blockchainAccess)
+	if err == nil {
+		t.Error("Expected version 1 to reject updating the approval threshold")
+	}
+	if err = command.RunCommandForTest(cmd, "transactionIdApprovalThreshold", blockchainAccess); err != nil {
+		t.Error("Could not update approval threshold: " + err.Error())
+	}
+	if getStateSettings(t).ApprovalThreshold != threshold {
+		t.Error("ApprovalThreshold mismatch in state")
+	}
+	if getSettings(t).ApprovalThreshold != threshold {
+		t.Error("ApprovalThreshold mismatch in database")
+	}
+}
+
+func getStateProposal(proposalId string, t *testing.T) *model.StateProposal {
+	data, err := blockchainAccess.GetState([]string{proposalId})
+	if err != nil {
+		t.Error("Cannot read proposal address: " + err.Error())
+	}
+	proposal := &model.StateProposal{}
+	if err = proto.Unmarshal(data[proposalId], proposal); err != nil {
+		t.Error("Could not unmarshal proposal")
+	}
+	return proposal
+}
+
+func checkStateProposal(
+	proposal *model.StateProposal,
+	expectedProposerId string,
+	expectedApproverIds []string,
+	expectedState model.ProposalState,
+	t *testing.T) {
+	if proposal.ProposerId != expectedProposerId {
+		t.Error("ProposerId mismatch")
+	}
+	if fmt.Sprintf("%v", proposal.ApproverId) != fmt.Sprintf("%v", expectedApproverIds) {
+		t.Error(fmt.Sprintf("ApproverId mismatch, expected %v, got %v", expectedApproverIds, proposal.ApproverId))
+	}
+	if proposal.ProposalState != expectedState {
+		t.Error("ProposalState mismatch")
+	}
+}
+
+func checkDaoOpenProposals(expectedIds []string, t *testing.T) {
+	proposals, err := dao.GetOpenProposals(model.GetCurrentTime())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(proposals) != len(expectedIds) {
+		t.Error(fmt.Sprintf("Expected %d open proposals, got %d", len(expectedIds), len(proposals)))
+		return
+	}
+	for i, p := range proposals {
+		if p.Id != expectedIds[i] {
+			t.Error("Open proposal id mismatch")
+		}
+		if p.Description == "" {
+			t.Error("Open proposal has no description")
+		}
+	}
+}
+
+func checkDaoProposal(
+	proposalId string, expectedState model.ProposalState, expectedApproverNames []string, t *testing.T) {
+	proposal, err := dao.GetProposal(proposalId)
+	if err != nil || proposal == nil {
+		t.Error("Could not read proposal " + proposalId)
+		return
+	}
+	if proposal.ProposalState != model.GetProposalStateString(expectedState) {
+		t.Error(fmt.Sprintf("ProposalState mismatch, expected %s, got %s",
+			model.GetProposalStateString(expectedState), proposal.ProposalState))
+	}
+	approvals, err := dao.GetProposalApprovals(proposalId)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	names := make([]string, len(approvals))
+	for i, a := range approvals {
+		names[i] = a.ApproverName
+	}
+	if fmt.Sprintf("%v", names) != fmt.Sprintf("%v", expectedApproverNames) {
+		t.Error(fmt.Sprintf("Approvers mismatch, expected %v, got %v", expectedApproverNames, names))
+	}
+}
+
 func TestSettingsUpdate(t *testing.T) {
 	logger = log.New(os.Stdout, "integration.TestSettingsUpdate", log.Flags())
 	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
@@ -450,6 +840,7 @@ func doTestSettingsUpdate(t *testing.T) {
 	cmd := command.GetSettingsUpdateCommand(
 		origSettings,
 		settingsUpdate,
+		getMajorIds(t),
 		signer.Id,
 		cliIskendria.LoggedIn(),
 		origSettings.PriceMajorEditSettings)
@@ -482,6 +873,8 @@ func getSettingsUpdate() *dao.Settings {
 		PriceEditorEditJournal:               216,
 		PriceEditorAddColleague:              217,
 		PriceEditorAcceptDuty:                218,
+		PriceAuthorWithdrawManuscript:        219,
+		PriceEditorInviteReviewer:            220,
 	}
 }
 
@@ -546,6 +939,12 @@ func checkUpdatedStateSettings(settings *model.StateSettings, t *testing.T) {
 	if settings.PriceList.PriceEditorAcceptDuty != 218 {
 		t.Error("PriceEditorAcceptDuty mismatch")
 	}
+	if settings.PriceList.PriceAuthorWithdrawManuscript != 219 {
+		t.Error("PriceAuthorWithdrawManuscript mismatch")
+	}
+	if settings.PriceList.PriceEditorInviteReviewer != 220 {
+		t.Error("PriceEditorInviteReviewer mismatch")
+	}
 
 }
 func checkUpdatedDaoSettings(updated *dao.Settings, t *testing.T) {
@@ -603,6 +1002,68 @@ func checkUpdatedDaoSettings(updated *dao.Settings, t *testing.T) {
 	if updated.PriceEditorAcceptDuty != int32(218) {
 		t.Error("PriceEditorAcceptDuty mismatch")
 	}
+	if updated.PriceAuthorWithdrawManuscript != int32(219) {
+		t.Error("PriceAuthorWithdrawManuscript mismatch")
+	}
+	if updated.PriceEditorInviteReviewer != int32(220) {
+		t.Error("PriceEditorInviteReviewer mismatch")
+	}
+}
+
+func TestMinimumFamilyVersion(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestMinimumFamilyVersion", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(t *testing.T) {
+		doTestBootstrap(t)
+		majorId := getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id
+		doTestMinimumFamilyVersionRejected("0.9", majorId, "transactionIdMinimumFamilyVersionUnknown", t)
+		doTestSetMinimumFamilyVersion(model.FamilyVersion2, majorId, t)
+		cmd := command.GetPersonUpdateIncBalanceCommand(majorId, int32(10), majorId, cliIskendria.LoggedIn(), int32(0))
+		err := command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdMinimumFamilyVersionV1Command", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a version 1 command is rejected after raising the minimum family version")
+		}
+		if err = command.RunCommandForTest(cmd, "transactionIdMinimumFamilyVersionV2Command", blockchainAccess); err != nil {
+			t.Error("Could not run version 2 command: " + err.Error())
+		}
+		doTestMinimumFamilyVersionRejected(model.FamilyVersion1, majorId, "transactionIdMinimumFamilyVersionLower", t)
+	}
+	withLoggedInWithNewKey(f, t)
+}
+
+func doTestSetMinimumFamilyVersion(minimumFamilyVersion, majorId string, t *testing.T) {
+	orig := getSettings(t)
+	updated := *orig
+	updated.MinimumFamilyVersion = minimumFamilyVersion
+	cmd := command.GetSettingsUpdateCommand(orig, &updated, getMajorIds(t), majorId, cliIskendria.LoggedIn(), priceMajorEditSettings)
+	err := command.RunCommandForTestWithFamilyVersion(
+		cmd, model.FamilyVersion1, "transactionIdMinimumFamilyVersionV1", blockchainAccess)
+	if err == nil {
+		t.Error("Expected version 1 to reject updating the minimum family version")
+	}
+	if err = command.RunCommandForTest(cmd, "transactionIdMinimumFamilyVersion", blockchainAccess); err != nil {
+		t.Error("Could not update minimum family version: " + err.Error())
+	}
+	if getStateSettings(t).MinimumFamilyVersion != minimumFamilyVersion {
+		t.Error("MinimumFamilyVersion mismatch in state")
+	}
+	if getSettings(t).MinimumFamilyVersion != minimumFamilyVersion {
+		t.Error("MinimumFamilyVersion mismatch in database")
+	}
+}
+
+func doTestMinimumFamilyVersionRejected(minimumFamilyVersion, majorId, transactionId string, t *testing.T) {
+	orig := getSettings(t)
+	updated := *orig
+	updated.MinimumFamilyVersion = minimumFamilyVersion
+	cmd := command.GetSettingsUpdateCommand(orig, &updated, getMajorIds(t), majorId, cliIskendria.LoggedIn(), priceMajorEditSettings)
+	if err := command.RunCommandForTest(cmd, transactionId, blockchainAccess); err == nil {
+		t.Error("Expected that the minimum family version cannot be set to " + minimumFamilyVersion)
+	}
+	if getSettings(t).MinimumFamilyVersion != orig.MinimumFamilyVersion {
+		t.Error("MinimumFamilyVersion should not have changed")
+	}
 }
 
 func TestJournalCreate(t *testing.T) {
@@ -677,6 +1138,37 @@ func TestJournalUpdateAuthorization(t *testing.T) {
 	withNewJournalCreate(f, t)
 }
 
+// A version 1 transaction that changes nothing was accepted, and it was
+// not charged. Replaying it should still give that outcome.
+func TestUnchangedJournalAuthorizationByFamilyVersion(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestUnchangedJournalAuthorizationByFamilyVersion", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(journal *command.Journal, personCreate *command.PersonCreate, initialBalance int32, t *testing.T) {
+		doTestJournalCreate(journal, personCreate, initialBalance, t)
+		journalId := getTheOnlyDaoJournal(t).JournalId
+		cmd := command.GetCommandJournalUpdateAuthorization(
+			journalId,
+			false,
+			getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id,
+			cliIskendria.LoggedIn(),
+			priceMajorChangeJournalAuthorization)
+		err := command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdJournalUpdateAuthorizationV1", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		err = command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion2, "transactionIdJournalUpdateAuthorizationV2", blockchainAccess)
+		if err == nil {
+			t.Error("Expected version 2 to reject a command that changes nothing")
+		}
+		expectedBalance := initialBalance - priceEditorCreateJournal
+		checkDaoBalanceOfKey(expectedBalance, cliIskendria.LoggedIn().PublicKeyStr, t)
+		checkStateBalanceOfKey(expectedBalance, cliIskendria.LoggedIn().PublicKeyStr, t)
+	}
+	withNewJournalCreate(f, t)
+}
+
 func checkDaoJournalUpdatedAuthorization(journal *dao.Journal, t *testing.T) {
 	if journal.IsSigned != true {
 		t.Error("Setting IsSigned of journal was not done")
@@ -689,6 +1181,163 @@ func checkStateJournalUpdatedAuthorization(journal *model.StateJournal, t *testi
 	}
 }
 
+func TestJournalUpdateState(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestJournalUpdateState", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(
+		manuscriptCreate *command.ManuscriptCreate,
+		journal *command.Journal,
+		personCreate *command.PersonCreate,
+		initialBalance int32,
+		t *testing.T) {
+		journalId := manuscriptCreate.JournalId
+		editorId := getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id
+		cmd := command.GetCommandJournalUpdateState(
+			journalId, model.JournalState_journalClosed, editorId, cliIskendria.LoggedIn(), priceEditorEditJournal)
+		err := command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdJournalCloseV1", blockchainAccess)
+		if err == nil {
+			t.Error("Expected version 1 to reject closing a journal")
+		}
+		other, err := cliIskendria.ReadCryptoIdentity(personPublicKeyFile, personPrivateKeyFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmd = command.GetCommandJournalUpdateState(
+			journalId, model.JournalState_journalClosed, getPersonByKey(personCreate.PublicKey, t).Id, other, int32(0))
+		err = command.RunCommandForTest(cmd, "transactionIdJournalCloseByOther", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that only editors and majors can close a journal")
+		}
+		doTestJournalUpdateState(journalId, model.JournalState_journalClosed, "transactionIdJournalClose", t)
+		cmd, _ = command.GetCommandManuscriptCreate(
+			manuscriptCreate, editorId, cliIskendria.LoggedIn(), priceAuthorSubmitNewManuscript)
+		err = command.RunCommandForTest(cmd, "transactionIdManuscriptCreateClosed", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a closed journal does not accept manuscripts")
+		}
+		doTestJournalUpdateState(journalId, model.JournalState_journalArchived, "transactionIdJournalArchive", t)
+		cmd, _ = command.GetCommandVolumeCreate(&command.Volume{
+			JournalId:              journalId,
+			Issue:                  "My issue",
+			LogicalPublicationTime: THE_LOGICAL_PUBLICATION_TIME,
+		}, editorId, cliIskendria.LoggedIn(), priceEditorCreateVolume)
+		err = command.RunCommandForTest(cmd, "transactionIdVolumeCreateArchived", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that an archived journal does not accept volumes")
+		}
+		cmd = command.GetCommandEditorInvite(
+			journalId, getPersonByKey(personCreate.PublicKey, t).Id, editorId, cliIskendria.LoggedIn(), priceEditorAddColleague)
+		err = command.RunCommandForTest(cmd, "transactionIdEditorInviteArchived", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that an archived journal does not accept editor invitations")
+		}
+		doTestJournalUpdateState(journalId, model.JournalState_journalOpen, "transactionIdJournalOpen", t)
+		expectedBalance := initialBalance - 3*priceEditorEditJournal
+		checkDaoBalanceOfKey(expectedBalance, cliIskendria.LoggedIn().PublicKeyStr, t)
+		checkStateBalanceOfKey(expectedBalance, cliIskendria.LoggedIn().PublicKeyStr, t)
+		doTestManuscriptCreate(manuscriptCreate, personCreate, expectedBalance, t)
+	}
+	withNewManuscriptCreate(f, 2, t)
+}
+
+func doTestJournalUpdateState(journalId string, journalState model.JournalState, transactionId string, t *testing.T) {
+	cmd := command.GetCommandJournalUpdateState(
+		journalId,
+		journalState,
+		getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id,
+		cliIskendria.LoggedIn(),
+		priceEditorEditJournal)
+	err := command.RunCommandForTest(cmd, transactionId, blockchainAccess)
+	if err != nil {
+		t.Error(err)
+	}
+	if actual := getStateJournal(journalId, t).JournalState; actual != journalState {
+		t.Error(fmt.Sprintf("Expected journal state %s in the blockchain, got %s", journalState, actual))
+	}
+	expected := model.GetJournalStateString(journalState)
+	if actual := getTheOnlyDaoJournal(t).JournalState; actual != expected {
+		t.Error(fmt.Sprintf("Expected journal state %s in the database, got %s", expected, actual))
+	}
+}
+
+func TestArchivedJournalBlocksManuscripts(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestArchivedJournalBlocksManuscripts", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(initialReview *dao.Review, initialManuscript *dao.Manuscript, initialBalance int32, t *testing.T) {
+		journalId := initialManuscript.JournalId
+		signer := cliIskendria.LoggedIn()
+		signerId := getPersonByKey(signer.PublicKeyStr, t).Id
+		cmd, volumeId := command.GetCommandVolumeCreate(&command.Volume{
+			JournalId: journalId,
+			Issue:     "2019-01-01",
+		}, signerId, signer, priceEditorCreateVolume)
+		if err := command.RunCommandForTest(cmd, "transactionIdVolumeCreateBeforeArchive", blockchainAccess); err != nil {
+			t.Error(err)
+		}
+		doTestJournalUpdateState(journalId, model.JournalState_journalArchived, "transactionIdJournalArchive", t)
+		threadReference, err := dao.GetReferenceThread(initialManuscript.ThreadId)
+		if err != nil {
+			t.Error(err)
+		}
+		cmd = command.GetCommandManuscriptAllowReview(
+			initialManuscript.ThreadId, threadReference, journalId, signerId, signer, priceEditorAllowManuscriptReview)
+		checkRejectedBecauseArchived(cmd, "allowing reviews", t)
+		reviewCreate := &command.ReviewCreate{
+			ManuscriptId: initialManuscript.Id,
+			JournalId:    journalId,
+			TheReview:    []byte("Review of archived journal"),
+		}
+		cmd, _ = command.GetCommandWritePositiveReview(reviewCreate, signerId, signer, priceReviewerSubmit)
+		checkRejectedBecauseArchived(cmd, "a review", t)
+		cmd, _ = command.GetCommandWriteBlindReview(reviewCreate, model.Judgement_POSITIVE, signerId, signer)
+		checkRejectedBecauseArchived(cmd, "a blind review", t)
+		cmd, _ = command.GetCommandReviewRevise(&command.ReviewRevise{
+			PreviousReviewId:     initialReview.Id,
+			PreviousManuscriptId: initialManuscript.Id,
+			ManuscriptId:         initialManuscript.Id,
+			JournalId:            journalId,
+			TheReview:            []byte("Revised review of archived journal"),
+		}, model.Judgement_NEGATIVE, signerId, signer, priceReviewerSubmit)
+		checkRejectedBecauseArchived(cmd, "a review revision", t)
+		cmd, _ = command.GetCommandReviewerInvite(
+			initialManuscript.Id, journalId, signerId, signerId, signer, priceEditorInviteReviewer)
+		checkRejectedBecauseArchived(cmd, "a reviewer invitation", t)
+		manuscriptJudge := &command.ManuscriptJudge{
+			ManuscriptId: initialManuscript.Id,
+			ReviewId:     []string{initialReview.Id},
+		}
+		cmd = command.GetCommandManuscriptPublish(
+			manuscriptJudge, journalId, signerId, signer, priceEditorPublishManuscript)
+		checkRejectedBecauseArchived(cmd, "a judgement", t)
+		manuscriptAssign := &command.ManuscriptAssign{
+			ManuscriptId: initialManuscript.Id,
+			VolumeId:     volumeId,
+			FirstPage:    "3",
+			LastPage:     "5",
+		}
+		checkRejectedBecauseArchived(command.GetCommandManuscriptAssign(
+			manuscriptAssign, journalId, "", signerId, signer, priceEditorAssignManuscript), "an assignment", t)
+		doTestJournalUpdateState(journalId, model.JournalState_journalOpen, "transactionIdJournalOpen", t)
+		if err = command.RunCommandForTest(cmd, "transactionIdPublishReopened", blockchainAccess); err != nil {
+			t.Error(err)
+		}
+	}
+	withReviewCreated(f, t)
+}
+
+func checkRejectedBecauseArchived(cmd *command.Command, what string, t *testing.T) {
+	err := command.RunCommandForTest(cmd, "transactionIdArchivedJournal", blockchainAccess)
+	if err == nil {
+		t.Error("Expected that an archived journal does not accept " + what)
+		return
+	}
+	if !strings.Contains(err.Error(), "is archived") {
+		t.Error(fmt.Sprintf("Expected that %s is rejected because the journal is archived, got: %s",
+			what, err.Error()))
+	}
+}
+
 func TestJournalEditorResign(t *testing.T) {
 	logger = log.New(os.Stdout, "integration.TestJournalEditorResign", log.Flags())
 	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
@@ -1457,6 +2106,7 @@ func TestManuscriptAssign(t *testing.T) {
 		cmd = command.GetCommandManuscriptAssign(
 			manuscriptAssign,
 			initialManuscript.JournalId,
+			"",
 			getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id,
 			cliIskendria.LoggedIn(),
 			priceEditorAssignManuscript)
@@ -1528,3 +2178,753 @@ func checkDaoAssignedManuscript(
 		t.Error("LastPage mismatch")
 	}
 }
+
+func TestVolumeMetadataAndPageRanges(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestVolumeMetadataAndPageRanges", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(
+		manuscriptCreate *command.ManuscriptCreate,
+		journal *command.Journal,
+		personCreate *command.PersonCreate,
+		initialBalance int32,
+		t *testing.T) {
+		journalId := manuscriptCreate.JournalId
+		editorId := getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id
+		cmd := command.GetPersonUpdateIncBalanceCommand(
+			editorId, SUFFICIENT_BALANCE, editorId, cliIskendria.LoggedIn(), int32(0))
+		if err := command.RunCommandForTest(cmd, "transactionIdIncBalance", blockchainAccess); err != nil {
+			t.Error(err)
+		}
+		initialBalance += SUFFICIENT_BALANCE
+		firstManuscriptId := doTestCreateManuscriptForVolume(manuscriptCreate, "transactionIdFirstManuscript", t)
+		secondManuscriptId := doTestCreateManuscriptForVolume(manuscriptCreate, "transactionIdSecondManuscript", t)
+		volume := &command.Volume{
+			JournalId:       journalId,
+			Issue:           "Spring issue",
+			VolumeNumber:    int32(2),
+			IssueNumber:     int32(1),
+			Year:            int32(2019),
+			PublicationDate: "01-05-2019",
+		}
+		cmd, _ = command.GetCommandVolumeCreate(volume, editorId, cliIskendria.LoggedIn(), priceEditorCreateVolume)
+		if err := command.RunCommandForTest(cmd, "transactionIdVolumeCreateBadDate", blockchainAccess); err == nil {
+			t.Error("Expected that a badly formatted publication date is rejected")
+		}
+		volume.PublicationDate = "2019-05-01"
+		cmd, volumeId := command.GetCommandVolumeCreate(volume, editorId, cliIskendria.LoggedIn(), priceEditorCreateVolume)
+		if err := command.RunCommandForTest(cmd, "transactionIdVolumeCreate", blockchainAccess); err != nil {
+			t.Error(err)
+		}
+		cmd, otherVolumeId := command.GetCommandVolumeCreate(&command.Volume{
+			JournalId: journalId,
+			Issue:     "Summer issue",
+		}, editorId, cliIskendria.LoggedIn(), priceEditorCreateVolume)
+		if err := command.RunCommandForTest(cmd, "transactionIdOtherVolumeCreate", blockchainAccess); err != nil {
+			t.Error(err)
+		}
+		doTestAssignPages(firstManuscriptId, volumeId, "", "10", "12", "transactionIdAssignFirst", true, t)
+		doTestAssignPages(secondManuscriptId, volumeId, "", "x", "12", "transactionIdAssignNotNumeric", false, t)
+		doTestAssignPages(secondManuscriptId, volumeId, "", "12", "14", "transactionIdAssignOverlap", false, t)
+		doTestAssignPages(secondManuscriptId, volumeId, "", "9", "2", "transactionIdAssignReversed", false, t)
+		doTestAssignPages(secondManuscriptId, otherVolumeId, "", "1", "4", "transactionIdAssignOther", true, t)
+		doTestAssignPages(secondManuscriptId, volumeId, otherVolumeId, "2", "9", "transactionIdAssignMove", true, t)
+		if numRanges := len(getStateVolume(otherVolumeId, t).PageRange); numRanges != 0 {
+			t.Error(fmt.Sprintf("Expected that the moved manuscript left the other volume, but it has %d page ranges",
+				numRanges))
+		}
+		volumeView, err := dao.GetVolumeView(volumeId)
+		if err != nil {
+			t.Error(err)
+		}
+		if len(volumeView.Manuscripts) != 2 ||
+			volumeView.Manuscripts[0].Id != secondManuscriptId ||
+			volumeView.Manuscripts[1].Id != firstManuscriptId {
+			t.Error("Expected the manuscripts of the volume to be ordered by their first page")
+		}
+		cmd = command.GetCommandVolumeUpdate(
+			volumeId,
+			journalId,
+			&command.VolumeProperties{Issue: "Spring issue", VolumeNumber: 2, IssueNumber: 1, Year: 2019, PublicationDate: "2019-05-01"},
+			&command.VolumeProperties{Issue: "Spring issue", VolumeNumber: 2, IssueNumber: 1, Year: 2020, PublicationDate: "2020-05-01"},
+			editorId,
+			cliIskendria.LoggedIn(),
+			priceEditorEditJournal)
+		if err := command.RunCommandForTest(cmd, "transactionIdVolumeUpdate", blockchainAccess); err != nil {
+			t.Error(err)
+		}
+		seal := command.GetCommandVolumeSeal(volumeId, journalId, editorId, cliIskendria.LoggedIn(), priceEditorEditJournal)
+		err = command.RunCommandForTestWithFamilyVersion(
+			seal, model.FamilyVersion1, "transactionIdVolumeSealV1", blockchainAccess)
+		if err == nil {
+			t.Error("Expected version 1 to reject sealing a volume")
+		}
+		if err := command.RunCommandForTest(seal, "transactionIdVolumeSeal", blockchainAccess); err != nil {
+			t.Error(err)
+		}
+		doTestAssignPages(firstManuscriptId, volumeId, volumeId, "20", "22", "transactionIdAssignSealed", false, t)
+		doTestAssignPages(secondManuscriptId, otherVolumeId, volumeId, "1", "4", "transactionIdLeaveSealed", false, t)
+		stateVolume := getStateVolume(volumeId, t)
+		if stateVolume.Year != 2020 || stateVolume.PublicationDate != "2020-05-01" || !stateVolume.IsSealed {
+			t.Error("Volume update or seal was not done in the blockchain")
+		}
+		if len(stateVolume.PageRange) != 2 {
+			t.Error(fmt.Sprintf("Expected two page ranges, got %d", len(stateVolume.PageRange)))
+		}
+		daoVolume, err := dao.GetVolume(volumeId)
+		if err != nil {
+			t.Error(err)
+		}
+		if daoVolume.VolumeNumber != 2 || daoVolume.IssueNumber != 1 || daoVolume.Year != 2020 ||
+			daoVolume.PublicationDate != "2020-05-01" || !daoVolume.IsSealed {
+			t.Error("Volume update or seal was not done in the database")
+		}
+		expectedBalance := initialBalance -
+			2*priceAuthorSubmitNewManuscript -
+			2*priceEditorCreateVolume -
+			3*priceEditorAssignManuscript -
+			2*priceEditorEditJournal
+		checkStateBalanceOfKey(expectedBalance, cliIskendria.LoggedIn().PublicKeyStr, t)
+		checkDaoBalanceOfKey(expectedBalance, cliIskendria.LoggedIn().PublicKeyStr, t)
+		thirdManuscriptId := doTestCreateManuscriptForVolume(manuscriptCreate, "transactionIdThirdManuscript", t)
+		cmd, thirdVolumeId := command.GetCommandVolumeCreate(&command.Volume{
+			JournalId: journalId,
+			Issue:     "Autumn issue",
+		}, editorId, cliIskendria.LoggedIn(), priceEditorCreateVolume)
+		if err := command.RunCommandForTest(cmd, "transactionIdThirdVolumeCreate", blockchainAccess); err != nil {
+			t.Error(err)
+		}
+		doTestAssignPages(thirdManuscriptId, otherVolumeId, "", "1", "4", "transactionIdAssignThird", true, t)
+		// Version 1 clients did not declare the previous volume, so
+		// replaying their reassignments should not read it.
+		cmd = command.GetCommandManuscriptAssign(
+			&command.ManuscriptAssign{ManuscriptId: thirdManuscriptId, VolumeId: thirdVolumeId, FirstPage: "1", LastPage: "4"},
+			journalId, "", editorId, cliIskendria.LoggedIn(), priceEditorAssignManuscript)
+		err = command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdReassignV1", blockchainAccess)
+		if err != nil {
+			t.Error("Could not replay a version 1 reassignment: " + err.Error())
+		}
+	}
+	withNewManuscriptCreate(f, 1, t)
+}
+
+func doTestCreateManuscriptForVolume(
+	manuscriptCreate *command.ManuscriptCreate, transactionId string, t *testing.T) string {
+	cmd, manuscriptId := command.GetCommandManuscriptCreate(
+		manuscriptCreate,
+		getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id,
+		cliIskendria.LoggedIn(),
+		priceAuthorSubmitNewManuscript)
+	if err := command.RunCommandForTest(cmd, transactionId, blockchainAccess); err != nil {
+		t.Error(err)
+	}
+	return manuscriptId
+}
+
+func doTestAssignPages(
+	manuscriptId, volumeId, previousVolumeId, firstPage, lastPage, transactionId string,
+	expectSuccess bool,
+	t *testing.T) {
+	cmd := command.GetCommandManuscriptAssign(
+		&command.ManuscriptAssign{
+			ManuscriptId: manuscriptId,
+			VolumeId:     volumeId,
+			FirstPage:    firstPage,
+			LastPage:     lastPage,
+		},
+		getTheOnlyDaoJournal(t).JournalId,
+		previousVolumeId,
+		getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id,
+		cliIskendria.LoggedIn(),
+		priceEditorAssignManuscript)
+	err := command.RunCommandForTest(cmd, transactionId, blockchainAccess)
+	if expectSuccess && err != nil {
+		t.Error(err)
+	}
+	if !expectSuccess && err == nil {
+		t.Error(fmt.Sprintf("Expected that pages %s-%s are rejected for volume %s", firstPage, lastPage, volumeId))
+	}
+}
+
+func TestManuscriptWithdraw(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestManuscriptWithdraw", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(initialReview *dao.Review, initialManuscript *dao.Manuscript, initialBalance int32, t *testing.T) {
+		threadReference, err := dao.GetReferenceThread(initialManuscript.ThreadId)
+		if err != nil {
+			t.Error(err)
+		}
+		signerId := getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id
+		cmd := command.GetCommandManuscriptWithdraw(
+			initialManuscript.Id,
+			initialManuscript.ThreadId,
+			threadReference,
+			"Found an error in the proof",
+			signerId,
+			cliIskendria.LoggedIn(),
+			priceAuthorWithdrawManuscript)
+		err = command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdManuscriptWithdrawV1", blockchainAccess)
+		if err == nil {
+			t.Error("Expected version 1 to reject withdrawing a manuscript")
+		}
+		err = command.RunCommandForTest(cmd, "transactionIdManuscriptWithdraw", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		checkStateWithdrawnManuscript(getStateManuscript(initialManuscript.Id), t)
+		daoManuscript, err := dao.GetManuscript(initialManuscript.Id)
+		if err != nil {
+			t.Error(err)
+		}
+		checkDaoWithdrawnManuscript(daoManuscript, t)
+		cmdWriteReview, _ := command.GetCommandWritePositiveReview(
+			&command.ReviewCreate{
+				ManuscriptId: initialManuscript.Id,
+				JournalId:    initialManuscript.JournalId,
+				TheReview:    []byte("Late review"),
+			},
+			signerId,
+			cliIskendria.LoggedIn(),
+			priceReviewerSubmit)
+		err = command.RunCommandForTest(cmdWriteReview, "transactionIdWriteReviewAfterWithdraw", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a withdrawn manuscript cannot be reviewed")
+		}
+		cmd = command.GetCommandManuscriptPublish(
+			&command.ManuscriptJudge{
+				ManuscriptId: initialManuscript.Id,
+				ReviewId:     []string{initialReview.Id},
+			},
+			initialManuscript.JournalId,
+			signerId,
+			cliIskendria.LoggedIn(),
+			priceEditorPublishManuscript)
+		err = command.RunCommandForTest(cmd, "transactionIdManuscriptPublishAfterWithdraw", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a withdrawn manuscript cannot be judged")
+		}
+		threadReference, err = dao.GetReferenceThread(initialManuscript.ThreadId)
+		if err != nil {
+			t.Error(err)
+		}
+		historicAuthors, err := dao.GetHistoricSignedAuthors(initialManuscript.ThreadId)
+		if err != nil {
+			t.Error(err)
+		}
+		cmd, _ = command.GetCommandManuscriptCreateNewVersion(
+			&command.ManuscriptCreateNewVersion{
+				TheManuscript:        []byte("New version text"),
+				CommitMsg:            "Fixed the proof",
+				Title:                "My manuscript",
+				AuthorId:             []string{signerId},
+				PreviousManuscriptId: initialManuscript.Id,
+				ThreadId:             initialManuscript.ThreadId,
+				JournalId:            initialManuscript.JournalId,
+			},
+			threadReference,
+			historicAuthors,
+			signerId,
+			cliIskendria.LoggedIn(),
+			priceAuthorSubmitNewVersion)
+		err = command.RunCommandForTest(cmd, "transactionIdNewVersionAfterWithdraw", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a withdrawn thread does not accept new versions")
+		}
+		expectedBalance := initialBalance - priceAuthorWithdrawManuscript
+		checkStateBalanceOfKey(expectedBalance, cliIskendria.LoggedIn().PublicKeyStr, t)
+		checkDaoBalanceOfKey(expectedBalance, cliIskendria.LoggedIn().PublicKeyStr, t)
+	}
+	withReviewCreated(f, t)
+}
+
+func checkStateWithdrawnManuscript(manuscript *model.StateManuscript, t *testing.T) {
+	if manuscript.Status != model.ManuscriptStatus_withdrawn {
+		t.Error("Status mismatch")
+	}
+	if manuscript.WithdrawalMsg != "Found an error in the proof" {
+		t.Error("WithdrawalMsg mismatch")
+	}
+}
+
+func checkDaoWithdrawnManuscript(manuscript *dao.Manuscript, t *testing.T) {
+	if manuscript.Status != model.GetManuscriptStatusString(model.ManuscriptStatus_withdrawn) {
+		t.Error("Status mismatch")
+	}
+	if manuscript.WithdrawalMsg != "Found an error in the proof" {
+		t.Error("WithdrawalMsg mismatch")
+	}
+}
+
+func TestReviewInvitation(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestReviewInvitation", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(
+		manuscriptCreate *command.ManuscriptCreate,
+		journal *command.Journal,
+		personCreate *command.PersonCreate,
+		initialBalance int32,
+		t *testing.T) {
+		editorKey := cliIskendria.LoggedIn().PublicKeyStr
+		editorId := getPersonByKey(editorKey, t).Id
+		reviewerId := getPersonByKey(personCreate.PublicKey, t).Id
+		journalId := manuscriptCreate.JournalId
+		cmdManuscriptCreate, manuscriptId := command.GetCommandManuscriptCreate(
+			manuscriptCreate,
+			editorId,
+			cliIskendria.LoggedIn(),
+			priceAuthorSubmitNewManuscript)
+		err := command.RunCommandForTest(
+			cmdManuscriptCreate, "transactionIdManuscriptCreateForInvitation", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		manuscript := runEditorAllowReview(manuscriptId, t)
+		cmd := command.GetCommandJournalUpdateReviewPolicy(
+			journalId, true, editorId, cliIskendria.LoggedIn(), priceEditorEditJournal)
+		err = command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdReviewPolicyV1", blockchainAccess)
+		if err == nil {
+			t.Error("Expected version 1 to reject updating the review policy")
+		}
+		err = command.RunCommandForTest(cmd, "transactionIdReviewPolicy", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		if !getStateJournal(journalId, t).ReviewByInvitationOnly {
+			t.Error("ReviewByInvitationOnly was not set in the state")
+		}
+		if !getTheOnlyDaoJournal(t).ReviewByInvitationOnly {
+			t.Error("ReviewByInvitationOnly was not set in the database")
+		}
+		cmd, _ = command.GetCommandReviewerInvite(
+			manuscriptId, journalId, editorId, editorId, cliIskendria.LoggedIn(), priceEditorInviteReviewer)
+		err = command.RunCommandForTest(cmd, "transactionIdInviteAuthor", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that an author cannot be invited to review")
+		}
+		cmd, acceptedInvitationId := command.GetCommandReviewerInvite(
+			manuscriptId, journalId, reviewerId, editorId, cliIskendria.LoggedIn(), priceEditorInviteReviewer)
+		err = command.RunCommandForTest(cmd, "transactionIdInviteReviewer", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		cmd, declinedInvitationId := command.GetCommandReviewerInvite(
+			manuscriptId, journalId, reviewerId, editorId, cliIskendria.LoggedIn(), priceEditorInviteReviewer)
+		err = command.RunCommandForTest(cmd, "transactionIdInviteReviewerAgain", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		checkStateReviewInvitation(getStateReviewInvitation(acceptedInvitationId, t),
+			manuscript, reviewerId, editorId, model.ReviewInvitationState_invitationOpen, t)
+		openInvitations, err := dao.GetOpenReviewInvitations(reviewerId)
+		if err != nil {
+			t.Error(err)
+		}
+		if len(openInvitations) != 2 {
+			t.Error("Expected two open review invitations")
+		}
+		expectedEditorBalance := initialBalance -
+			priceAuthorSubmitNewManuscript -
+			priceEditorAllowManuscriptReview -
+			priceEditorEditJournal -
+			2*priceEditorInviteReviewer
+		checkStateBalanceOfKey(expectedEditorBalance, editorKey, t)
+		checkDaoBalanceOfKey(expectedEditorBalance, editorKey, t)
+		// Version 1 reviews do not read the journal, so only the minimum
+		// family version stops them from bypassing the review policy.
+		doTestSetMinimumFamilyVersion(model.FamilyVersion2, editorId, t)
+		err = cliIskendria.Login(personPublicKeyFile, personPrivateKeyFile)
+		if err != nil {
+			t.Error(err)
+		}
+		reviewCreate := &command.ReviewCreate{
+			ManuscriptId: manuscriptId,
+			JournalId:    journalId,
+			TheReview:    []byte("Uninvited review"),
+		}
+		cmd, _ = command.GetCommandWritePositiveReview(
+			reviewCreate, reviewerId, cliIskendria.LoggedIn(), priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdUninvitedReview", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a journal reviewing by invitation only rejects an uninvited review")
+		}
+		err = command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdUninvitedReviewV1", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that an uninvited version 1 review is rejected after raising the minimum family version")
+		}
+		cmd = command.GetCommandReviewInvitationDecline(declinedInvitationId, reviewerId, cliIskendria.LoggedIn())
+		err = command.RunCommandForTest(cmd, "transactionIdDeclineInvitation", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		cmd = command.GetCommandReviewInvitationAccept(acceptedInvitationId, reviewerId, cliIskendria.LoggedIn())
+		err = command.RunCommandForTest(cmd, "transactionIdAcceptInvitation", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		err = command.RunCommandForTest(cmd, "transactionIdAcceptInvitationAgain", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that an invitation can only be answered once")
+		}
+		checkStateReviewInvitation(getStateReviewInvitation(acceptedInvitationId, t),
+			manuscript, reviewerId, editorId, model.ReviewInvitationState_invitationAccepted, t)
+		checkStateReviewInvitation(getStateReviewInvitation(declinedInvitationId, t),
+			manuscript, reviewerId, editorId, model.ReviewInvitationState_invitationDeclined, t)
+		daoInvitation, err := dao.GetReviewInvitation(declinedInvitationId)
+		if err != nil {
+			t.Error(err)
+		}
+		if daoInvitation.InvitationState !=
+			model.GetReviewInvitationStateString(model.ReviewInvitationState_invitationDeclined) {
+			t.Error("InvitationState mismatch")
+		}
+		reviewCreate.InvitationId = declinedInvitationId
+		reviewCreate.TheReview = []byte("Review after declining")
+		cmd, _ = command.GetCommandWritePositiveReview(
+			reviewCreate, reviewerId, cliIskendria.LoggedIn(), priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdReviewDeclinedInvitation", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a declined invitation does not allow a review")
+		}
+		reviewCreate.InvitationId = acceptedInvitationId
+		reviewCreate.TheReview = []byte("Invited review")
+		cmd, reviewId := command.GetCommandWritePositiveReview(
+			reviewCreate, reviewerId, cliIskendria.LoggedIn(), priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdInvitedReview", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		if getStateReview(reviewId, t).InvitationId != acceptedInvitationId {
+			t.Error("InvitationId mismatch in the state")
+		}
+		daoReview, err := dao.GetReview(reviewId)
+		if err != nil {
+			t.Error(err)
+		}
+		if daoReview.InvitationId != acceptedInvitationId {
+			t.Error("InvitationId mismatch in the database")
+		}
+		openInvitations, err = dao.GetOpenReviewInvitations(reviewerId)
+		if err != nil {
+			t.Error(err)
+		}
+		if len(openInvitations) != 0 {
+			t.Error("Expected that no open review invitations remain")
+		}
+		expectedReviewerBalance := SUFFICIENT_BALANCE - priceReviewerSubmit
+		checkStateBalanceOfKey(expectedReviewerBalance, cliIskendria.LoggedIn().PublicKeyStr, t)
+		checkDaoBalanceOfKey(expectedReviewerBalance, cliIskendria.LoggedIn().PublicKeyStr, t)
+	}
+	withNewManuscriptCreate(f, 1, t)
+}
+
+func checkStateReviewInvitation(
+	invitation *model.StateReviewInvitation,
+	manuscript *dao.Manuscript,
+	reviewerId, editorId string,
+	expectedState model.ReviewInvitationState,
+	t *testing.T) {
+	if invitation.ManuscriptId != manuscript.Id {
+		t.Error("ManuscriptId mismatch")
+	}
+	if invitation.ThreadId != manuscript.ThreadId {
+		t.Error("ThreadId mismatch")
+	}
+	if invitation.JournalId != manuscript.JournalId {
+		t.Error("JournalId mismatch")
+	}
+	if invitation.ReviewerId != reviewerId {
+		t.Error("ReviewerId mismatch")
+	}
+	if invitation.EditorId != editorId {
+		t.Error("EditorId mismatch")
+	}
+	if invitation.InvitationState != expectedState {
+		t.Error("InvitationState mismatch")
+	}
+}
+
+func TestBlindReview(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestBlindReview", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(
+		manuscriptCreate *command.ManuscriptCreate,
+		journal *command.Journal,
+		personCreate *command.PersonCreate,
+		initialBalance int32,
+		t *testing.T) {
+		editorId := getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id
+		reviewerId := getPersonByKey(personCreate.PublicKey, t).Id
+		reviewer, err := cliIskendria.ReadCryptoIdentity(personPublicKeyFile, personPrivateKeyFile)
+		if err != nil {
+			t.Error(err)
+		}
+		cmdManuscriptCreate, manuscriptId := command.GetCommandManuscriptCreate(
+			manuscriptCreate,
+			editorId,
+			cliIskendria.LoggedIn(),
+			priceAuthorSubmitNewManuscript)
+		err = command.RunCommandForTest(
+			cmdManuscriptCreate, "transactionIdManuscriptCreateForBlindReview", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		_ = runEditorAllowReview(manuscriptId, t)
+		reviewCreate := &command.ReviewCreate{
+			ManuscriptId: manuscriptId,
+			JournalId:    manuscriptCreate.JournalId,
+			TheReview:    []byte("Blind review"),
+		}
+		cmd, secret := command.GetCommandWriteBlindReview(
+			reviewCreate, model.Judgement_NEGATIVE, reviewerId, reviewer)
+		err = command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdBlindReviewV1", blockchainAccess)
+		if err == nil {
+			t.Error("Expected version 1 to reject a blind review")
+		}
+		reviewIdentity := cmd.CryptoIdentity
+		cmd.CryptoIdentity = reviewer
+		err = command.RunCommandForTest(cmd, "transactionIdBlindReviewWithReviewerKey", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a blind review is only accepted when signed with its review key")
+		}
+		cmd.CryptoIdentity = reviewIdentity
+		err = command.RunCommandForTest(cmd, "transactionIdBlindReview", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		checkStateBlindReview(getStateReview(secret.ReviewId, t), manuscriptId, "", t)
+		checkDaoBlindReview(secret.ReviewId, manuscriptId, "", t)
+		checkStateBalanceOfKey(SUFFICIENT_BALANCE, personCreate.PublicKey, t)
+		checkDaoBalanceOfKey(SUFFICIENT_BALANCE, personCreate.PublicKey, t)
+		cmd = command.GetCommandReviewReveal(secret, manuscriptId, reviewer, priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdRevealBeforeJudgement", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a blind review cannot be revealed before the manuscript is judged")
+		}
+		cmd = command.GetCommandManuscriptReject(
+			&command.ManuscriptJudge{
+				ManuscriptId: manuscriptId,
+				ReviewId:     []string{secret.ReviewId},
+			},
+			manuscriptCreate.JournalId,
+			editorId,
+			cliIskendria.LoggedIn(),
+			priceEditorRejectManuscript)
+		err = command.RunCommandForTest(cmd, "transactionIdRejectWithBlindReview", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		stolenSecret := *secret
+		stolenSecret.ReviewerId = editorId
+		cmd = command.GetCommandReviewReveal(&stolenSecret, manuscriptId, cliIskendria.LoggedIn(), priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdRevealByOther", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that only the author can reveal a blind review")
+		}
+		cmd = command.GetCommandReviewReveal(secret, manuscriptId, reviewer, priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdReveal", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		err = command.RunCommandForTest(cmd, "transactionIdRevealAgain", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a blind review can only be revealed once")
+		}
+		checkStateBlindReview(getStateReview(secret.ReviewId, t), manuscriptId, reviewerId, t)
+		checkDaoBlindReview(secret.ReviewId, manuscriptId, reviewerId, t)
+		expectedReviewerBalance := SUFFICIENT_BALANCE - priceReviewerSubmit
+		checkStateBalanceOfKey(expectedReviewerBalance, personCreate.PublicKey, t)
+		checkDaoBalanceOfKey(expectedReviewerBalance, personCreate.PublicKey, t)
+	}
+	withNewManuscriptCreate(f, 1, t)
+}
+
+func checkStateBlindReview(r *model.StateReview, manuscriptId, expectedReviewAuthorId string, t *testing.T) {
+	if r.ManuscriptId != manuscriptId {
+		t.Error("ManuscriptId mismatch")
+	}
+	if !r.IsBlind {
+		t.Error("IsBlind mismatch")
+	}
+	if r.ReviewAuthorId != expectedReviewAuthorId {
+		t.Error("ReviewAuthorId mismatch")
+	}
+	if r.Judgement != model.Judgement_NEGATIVE {
+		t.Error("Judgement mismatch")
+	}
+	if r.ReviewerCommitment == "" || r.ReviewKey == "" || r.SealedProof == "" {
+		t.Error("Commitment, review key or sealed proof is missing")
+	}
+}
+
+func checkDaoBlindReview(reviewId, manuscriptId, expectedReviewAuthorId string, t *testing.T) {
+	manuscriptView, err := dao.GetManuscriptView(manuscriptId)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(manuscriptView.Reviews) != 1 {
+		t.Error("Expected that the manuscript has one review")
+		return
+	}
+	r := manuscriptView.Reviews[0]
+	if r.Id != reviewId {
+		t.Error("Id mismatch")
+	}
+	if !r.IsBlind {
+		t.Error("IsBlind mismatch")
+	}
+	if r.ReviewAuthorId != expectedReviewAuthorId {
+		t.Error("ReviewAuthorId mismatch")
+	}
+	if r.PersonId != expectedReviewAuthorId {
+		t.Error("PersonId mismatch")
+	}
+}
+
+func TestReviewRevision(t *testing.T) {
+	logger = log.New(os.Stdout, "integration.TestReviewRevision", log.Flags())
+	blockchainAccess = command.NewBlockchainStub(dao.HandleEvent, logger)
+	f := func(
+		manuscriptCreate *command.ManuscriptCreate,
+		journal *command.Journal,
+		personCreate *command.PersonCreate,
+		initialBalance int32,
+		t *testing.T) {
+		editorId := getPersonByKey(cliIskendria.LoggedIn().PublicKeyStr, t).Id
+		reviewerId := getPersonByKey(personCreate.PublicKey, t).Id
+		reviewer, err := cliIskendria.ReadCryptoIdentity(personPublicKeyFile, personPrivateKeyFile)
+		if err != nil {
+			t.Error(err)
+		}
+		cmdManuscriptCreate, manuscriptId := command.GetCommandManuscriptCreate(
+			manuscriptCreate,
+			editorId,
+			cliIskendria.LoggedIn(),
+			priceAuthorSubmitNewManuscript)
+		err = command.RunCommandForTest(
+			cmdManuscriptCreate, "transactionIdManuscriptCreateForRevision", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		_ = runEditorAllowReview(manuscriptId, t)
+		cmd, firstReviewId := command.GetCommandWritePositiveReview(&command.ReviewCreate{
+			ManuscriptId: manuscriptId,
+			JournalId:    manuscriptCreate.JournalId,
+			TheReview:    []byte("First revision"),
+		}, reviewerId, reviewer, priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdFirstRevision", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		reviewRevise := &command.ReviewRevise{
+			PreviousReviewId:     firstReviewId,
+			PreviousManuscriptId: manuscriptId,
+			ManuscriptId:         manuscriptId,
+			JournalId:            manuscriptCreate.JournalId,
+			TheReview:            []byte("Second revision"),
+		}
+		cmd, _ = command.GetCommandReviewRevise(
+			reviewRevise, model.Judgement_NEGATIVE, reviewerId, reviewer, priceReviewerSubmit)
+		err = command.RunCommandForTestWithFamilyVersion(
+			cmd, model.FamilyVersion1, "transactionIdRevisionV1", blockchainAccess)
+		if err == nil {
+			t.Error("Expected version 1 to reject a review revision")
+		}
+		cmd, _ = command.GetCommandReviewRevise(
+			reviewRevise, model.Judgement_NEGATIVE, editorId, cliIskendria.LoggedIn(), priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdRevisionByOther", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that only the author can revise a review")
+		}
+		cmd, secondReviewId := command.GetCommandReviewRevise(
+			reviewRevise, model.Judgement_NEGATIVE, reviewerId, reviewer, priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdSecondRevision", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		cmd, _ = command.GetCommandReviewRevise(
+			reviewRevise, model.Judgement_POSITIVE, reviewerId, reviewer, priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdReviseRevisedReview", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that only the latest revision can be revised")
+		}
+		checkStateReviewRevision(getStateReview(firstReviewId, t), "", secondReviewId, t)
+		checkStateReviewRevision(getStateReview(secondReviewId, t), firstReviewId, "", t)
+		checkDaoReviewRevision(manuscriptId, firstReviewId, secondReviewId, t)
+		manuscriptJudge := &command.ManuscriptJudge{
+			ManuscriptId: manuscriptId,
+			ReviewId:     []string{firstReviewId},
+		}
+		cmd = command.GetCommandManuscriptReject(
+			manuscriptJudge, manuscriptCreate.JournalId, editorId, cliIskendria.LoggedIn(), priceEditorRejectManuscript)
+		err = command.RunCommandForTest(cmd, "transactionIdRejectWithRevisedReview", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that the editor can only use the latest revision")
+		}
+		manuscriptJudge.ReviewId = []string{secondReviewId}
+		cmd = command.GetCommandManuscriptReject(
+			manuscriptJudge, manuscriptCreate.JournalId, editorId, cliIskendria.LoggedIn(), priceEditorRejectManuscript)
+		err = command.RunCommandForTest(cmd, "transactionIdRejectWithLatestRevision", blockchainAccess)
+		if err != nil {
+			t.Error(err)
+		}
+		reviewRevise.PreviousReviewId = secondReviewId
+		cmd, _ = command.GetCommandReviewRevise(
+			reviewRevise, model.Judgement_POSITIVE, reviewerId, reviewer, priceReviewerSubmit)
+		err = command.RunCommandForTest(cmd, "transactionIdReviseUsedReview", blockchainAccess)
+		if err == nil {
+			t.Error("Expected that a review used by the editor cannot be revised")
+		}
+		expectedReviewerBalance := SUFFICIENT_BALANCE - 2*priceReviewerSubmit
+		checkStateBalanceOfKey(expectedReviewerBalance, personCreate.PublicKey, t)
+		checkDaoBalanceOfKey(expectedReviewerBalance, personCreate.PublicKey, t)
+	}
+	withNewManuscriptCreate(f, 1, t)
+}
+
+func checkStateReviewRevision(r *model.StateReview, expectedPreviousReviewId, expectedNextReviewId string, t *testing.T) {
+	if r.PreviousReviewId != expectedPreviousReviewId {
+		t.Error("PreviousReviewId mismatch")
+	}
+	if r.NextReviewId != expectedNextReviewId {
+		t.Error("NextReviewId mismatch")
+	}
+}
+
+func checkDaoReviewRevision(manuscriptId, firstReviewId, secondReviewId string, t *testing.T) {
+	manuscriptView, err := dao.GetManuscriptView(manuscriptId)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(manuscriptView.Reviews) != 1 {
+		t.Error("Expected that the manuscript only shows the latest revision")
+		return
+	}
+	r := manuscriptView.Reviews[0]
+	if r.Id != secondReviewId {
+		t.Error("Id mismatch")
+	}
+	if r.PreviousReviewId != firstReviewId {
+		t.Error("PreviousReviewId mismatch")
+	}
+	if r.Judgement != model.GetJudgementString(model.Judgement_NEGATIVE) {
+		t.Error("Judgement mismatch")
+	}
+	reviewView, err := dao.GetReviewDetailsView(secondReviewId)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(reviewView.EarlierRevisions) != 1 || reviewView.EarlierRevisions[0].Id != firstReviewId {
+		t.Error("Expected the first revision as the only earlier revision")
+		return
+	}
+	if reviewView.EarlierRevisions[0].NextReviewId != secondReviewId {
+		t.Error("NextReviewId mismatch")
+	}
+}
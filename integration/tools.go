@@ -42,6 +42,8 @@ const priceEditorCreateVolume int32 = 115
 const priceEditorEditJournal int32 = 116
 const priceEditorAddColleague int32 = 117
 const priceEditorAcceptDuty int32 = 118
+const priceAuthorWithdrawManuscript int32 = 119
+const priceEditorInviteReviewer int32 = 120
 
 var logger *log.Logger
 var blockchainAccess command.BlockchainAccess
@@ -271,6 +273,25 @@ func getStateSettings(t *testing.T) *model.StateSettings {
 	return settings
 }
 
+func getMajorIds(t *testing.T) []string {
+	result, err := dao.GetMajorIds()
+	if err != nil {
+		t.Error("Could not read the majors: " + err.Error())
+	}
+	return result
+}
+
+func checkMajorCount(expected int32, t *testing.T) {
+	if getStateSettings(t).MajorCount != expected {
+		t.Error(fmt.Sprintf("MajorCount mismatch in state, expected %d, got %d",
+			expected, getStateSettings(t).MajorCount))
+	}
+	if getSettings(t).MajorCount != expected {
+		t.Error(fmt.Sprintf("MajorCount mismatch in database, expected %d, got %d",
+			expected, getSettings(t).MajorCount))
+	}
+}
+
 func getStatePerson(personId string, t *testing.T) *model.StatePerson {
 	personData, err := blockchainAccess.GetState([]string{personId})
 	if err != nil {
@@ -307,6 +328,8 @@ func getBootstrap() *command.Bootstrap {
 		PriceEditorEditJournal:               priceEditorEditJournal,
 		PriceEditorAddColleague:              priceEditorAddColleague,
 		PriceEditorAcceptDuty:                priceEditorAcceptDuty,
+		PriceAuthorWithdrawManuscript:        priceAuthorWithdrawManuscript,
+		PriceEditorInviteReviewer:            priceEditorInviteReviewer,
 		Name:                                 majorName,
 		Email:                                "brita@xxx.nl",
 	}
@@ -373,6 +396,12 @@ func checkBootstrapStateSettings(settings *model.StateSettings, t *testing.T) {
 	if settings.PriceList.PriceEditorAcceptDuty != priceEditorAcceptDuty {
 		t.Error("PriceEditorAcceptDuty mismatch")
 	}
+	if settings.PriceList.PriceAuthorWithdrawManuscript != priceAuthorWithdrawManuscript {
+		t.Error("PriceAuthorWithdrawManuscript mismatch")
+	}
+	if settings.PriceList.PriceEditorInviteReviewer != priceEditorInviteReviewer {
+		t.Error("PriceEditorInviteReviewer mismatch")
+	}
 }
 
 func checkBootstrapDaoSettings(settings *dao.Settings, t *testing.T) {
@@ -430,6 +459,12 @@ func checkBootstrapDaoSettings(settings *dao.Settings, t *testing.T) {
 	if settings.PriceEditorAcceptDuty != priceEditorAcceptDuty {
 		t.Error("PriceEditorAcceptDuty mismatch")
 	}
+	if settings.PriceAuthorWithdrawManuscript != priceAuthorWithdrawManuscript {
+		t.Error("PriceAuthorWithdrawManuscript mismatch")
+	}
+	if settings.PriceEditorInviteReviewer != priceEditorInviteReviewer {
+		t.Error("PriceEditorInviteReviewer mismatch")
+	}
 }
 
 func checkBootstrapStatePerson(person *model.StatePerson, t *testing.T) {
@@ -1024,6 +1059,7 @@ func doTestWritePositiveReview(manuscriptCreate *command.ManuscriptCreate, initi
 	_ = runEditorAllowReview(manuscriptId, t)
 	reviewCreate := &command.ReviewCreate{
 		ManuscriptId: manuscriptId,
+		JournalId:    manuscriptCreate.JournalId,
 		TheReview:    []byte("My review"),
 	}
 	cmdWriteReview, reviewId := command.GetCommandWritePositiveReview(
@@ -1142,6 +1178,7 @@ func doTestWriteNegativeReview(manuscriptCreate *command.ManuscriptCreate, initi
 	_ = runEditorAllowReview(manuscriptId, t)
 	reviewCreate := &command.ReviewCreate{
 		ManuscriptId: manuscriptId,
+		JournalId:    manuscriptCreate.JournalId,
 		TheReview:    []byte("My review"),
 	}
 	cmdWriteReview, reviewId := command.GetCommandWriteNegativeReview(
@@ -1188,3 +1225,20 @@ func getStateReview(reviewId string, t *testing.T) *model.StateReview {
 	}
 	return state
 }
+
+func getStateReviewInvitation(invitationId string, t *testing.T) *model.StateReviewInvitation {
+	data, err := blockchainAccess.GetState([]string{invitationId})
+	if err != nil {
+		t.Error(err)
+	}
+	stateBytes, ok := data[invitationId]
+	if !ok {
+		t.Error(errors.New("Review invitation address was not filled: " + invitationId))
+	}
+	state := &model.StateReviewInvitation{}
+	err = proto.Unmarshal(stateBytes, state)
+	if err != nil {
+		t.Error(err)
+	}
+	return state
+}
@@ -0,0 +1,170 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+/*
+This package writes levelled log lines with key/value fields, like
+
+  level=debug msg="Sending event" transactionId=3fa1... signer=02b4... eventType=...
+
+A Logger writes to a *log.Logger, the sink, which the executable sets up
+with the file, prefix and flags it wants. Without a sink, the standard
+logger of package log is used. The fields of a Logger are added to every
+line it writes. Use With to get a Logger with more fields, for example
+the id of the transaction being applied. This way all lines about one
+transaction can be found with grep.
+
+The level is shared by all loggers. It is set by config.Load from the
+logLevel setting, so that every executable can be configured. Lines
+below the level are dropped without formatting them.
+*/
+
+type Level int32
+
+const (
+	LEVEL_DEBUG = Level(0)
+	LEVEL_INFO  = Level(1)
+	LEVEL_WARN  = Level(2)
+	LEVEL_ERROR = Level(3)
+)
+
+var levelNames = map[Level]string{
+	LEVEL_DEBUG: "debug",
+	LEVEL_INFO:  "info",
+	LEVEL_WARN:  "warn",
+	LEVEL_ERROR: "error",
+}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+func ParseLevel(s string) (Level, error) {
+	for level, name := range levelNames {
+		if strings.ToLower(s) == name {
+			return level, nil
+		}
+	}
+	return LEVEL_INFO, errors.New(fmt.Sprintf("Unknown log level %s, expected debug, info, warn or error", s))
+}
+
+var currentLevel = int32(LEVEL_INFO)
+
+func SetLevel(level Level) {
+	atomic.StoreInt32(&currentLevel, int32(level))
+}
+
+func GetLevel() Level {
+	return Level(atomic.LoadInt32(&currentLevel))
+}
+
+func IsEnabled(level Level) bool {
+	return level >= GetLevel()
+}
+
+type Logger struct {
+	sink   *log.Logger
+	fields string
+}
+
+// New returns a Logger that writes to sink. When sink is nil,
+// the standard logger is used.
+func New(sink *log.Logger) *Logger {
+	return &Logger{
+		sink: sink,
+	}
+}
+
+// Default returns a Logger that writes to the standard logger.
+func Default() *Logger {
+	return New(nil)
+}
+
+// With returns a Logger that adds the given keys and values to every line.
+// Keys and values alternate.
+func (l *Logger) With(keyValues ...interface{}) *Logger {
+	return &Logger{
+		sink:   l.sink,
+		fields: l.fields + formatFields(keyValues),
+	}
+}
+
+// Sink returns the *log.Logger written to. It is nil for the standard logger.
+func (l *Logger) Sink() *log.Logger {
+	return l.sink
+}
+
+func (l *Logger) Debug(msg string, keyValues ...interface{}) {
+	l.write(LEVEL_DEBUG, msg, keyValues)
+}
+
+func (l *Logger) Info(msg string, keyValues ...interface{}) {
+	l.write(LEVEL_INFO, msg, keyValues)
+}
+
+func (l *Logger) Warn(msg string, keyValues ...interface{}) {
+	l.write(LEVEL_WARN, msg, keyValues)
+}
+
+func (l *Logger) Error(msg string, keyValues ...interface{}) {
+	l.write(LEVEL_ERROR, msg, keyValues)
+}
+
+// Fatal writes the line irrespective of the level and exits.
+func (l *Logger) Fatal(msg string, keyValues ...interface{}) {
+	l.output(LEVEL_ERROR, msg, keyValues)
+	os.Exit(1)
+}
+
+func (l *Logger) write(level Level, msg string, keyValues []interface{}) {
+	if !IsEnabled(level) {
+		return
+	}
+	l.output(level, msg, keyValues)
+}
+
+func (l *Logger) output(level Level, msg string, keyValues []interface{}) {
+	line := "level=" + level.String() + " msg=" + formatValue(msg) + l.fields + formatFields(keyValues)
+	// Calldepth 3 refers to the caller of Debug, Info, Warn, Error or Fatal
+	if l.sink == nil {
+		_ = log.Output(3, line)
+		return
+	}
+	_ = l.sink.Output(3, line)
+}
+
+func formatFields(keyValues []interface{}) string {
+	result := ""
+	for i := 0; i < len(keyValues); i += 2 {
+		key := fmt.Sprintf("%v", keyValues[i])
+		if i+1 == len(keyValues) {
+			result = result + " " + key + "=" + formatValue("MISSING")
+			break
+		}
+		result = result + " " + key + "=" + formatValue(keyValues[i+1])
+	}
+	return result
+}
+
+func formatValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case error:
+		s = v.Error()
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		s = fmt.Sprintf("%v", v)
+	}
+	if s == "" || strings.ContainsAny(s, " \t\n\"=\\") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
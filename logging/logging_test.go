@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"testing"
+)
+
+func TestFormatAndLevel(t *testing.T) {
+	defer SetLevel(GetLevel())
+	buf := new(bytes.Buffer)
+	logger := New(log.New(buf, "", 0)).With("transactionId", "abc")
+	SetLevel(LEVEL_INFO)
+	logger.Debug("Not written")
+	logger.Info("Transaction rejected", "reason", errors.New("Price should be 5"), "count", 2)
+	logger.With("eventSeq", 0).Warn("Empty", "value", "")
+	expected := `level=info msg="Transaction rejected" transactionId=abc reason="Price should be 5" count=2
+level=warn msg=Empty transactionId=abc eventSeq=0 value=""
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+	buf.Reset()
+	SetLevel(LEVEL_DEBUG)
+	logger.Debug("Written", "odd")
+	if buf.String() != "level=debug msg=Written transactionId=abc odd=MISSING\n" {
+		t.Errorf("Unexpected debug line: %s", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("WARN")
+	if err != nil || level != LEVEL_WARN {
+		t.Errorf("Expected warn, got %s, %v", level, err)
+	}
+	if _, err = ParseLevel("verbose"); err == nil {
+		t.Error("Expected error for unknown level")
+	}
+}
@@ -2,11 +2,12 @@ package main
 
 import (
 	"fmt"
-	"github.com/iskendria-pub/iskendria/blockchain"
 	"github.com/iskendria-pub/iskendria/cli"
 	"github.com/iskendria-pub/iskendria/cliIskendria"
 	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/config"
 	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/model"
 	"log"
 	"os"
 	"strings"
@@ -97,8 +98,65 @@ func main() {
 						Handler:  incBalance,
 						ArgNames: []string{"person id", "amount"},
 					},
+					&cli.SingleLineHandler{
+						Name:     "creditReport",
+						Handler:  creditReport,
+						ArgNames: []string{"person id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "globalCreditReport",
+						Handler:  globalCreditReport,
+						ArgNames: []string{},
+					},
 				),
 			},
+			&cli.Cli{
+				FullDescription: "Welcome to the proposal commands. Proposals need the approval of multiple majors. " +
+					"The expiration of a proposal is advisory, because the blockchain checks it against the times " +
+					"that the clients report.",
+				OneLineDescription: "Proposal",
+				Name:               "proposal",
+				Handlers: []cli.Handler{
+					&cli.StructRunnerHandler{
+						FullDescription:              "Welcome to the dialog to propose a settings update",
+						OneLineDescription:           "Propose settings update",
+						Name:                         "proposeSettingsUpdate",
+						ReferenceValueGetter:         proposeSettingsUpdateReference,
+						ReferenceValueGetterArgNames: []string{"days valid"},
+						Action:                       proposeSettingsUpdate,
+					},
+					&cli.SingleLineHandler{
+						Name:     "proposeSetMajor",
+						Handler:  proposeSetMajor,
+						ArgNames: []string{"person id", "days valid"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "proposeUnsetMajor",
+						Handler:  proposeUnsetMajor,
+						ArgNames: []string{"person id", "days valid"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "proposeIncBalance",
+						Handler:  proposeIncBalance,
+						ArgNames: []string{"person id", "amount", "days valid"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "proposals",
+						Handler:  proposals,
+						ArgNames: []string{},
+					},
+					&cli.SingleLineHandler{
+						Name:     "approve",
+						Handler:  approveProposal,
+						ArgNames: []string{"proposal id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "cancelProposal",
+						Handler:  cancelProposal,
+						ArgNames: []string{"proposal id"},
+					},
+				},
+			},
 			&cli.Cli{
 				FullDescription:    "Welcome to the journal commands.",
 				OneLineDescription: "Journal",
@@ -114,18 +172,40 @@ func main() {
 						Handler:  journalUnsetSigned,
 						ArgNames: []string{"journal id"},
 					},
+					&cli.SingleLineHandler{
+						Name:     "open",
+						Handler:  journalOpen,
+						ArgNames: []string{"journal id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "close",
+						Handler:  journalClose,
+						ArgNames: []string{"journal id"},
+					},
+					&cli.SingleLineHandler{
+						Name:     "archive",
+						Handler:  journalArchive,
+						ArgNames: []string{"journal id"},
+					},
 				),
 			},
 		),
 	}
-	if len(os.Args) >= 2 {
-		cli.InputScript = os.Args[1]
+	defaults := config.GetDefaults()
+	defaults.DatabaseFile = "major.db"
+	defaults.EventLogFile = "./major-events.log"
+	args, err := config.Load(defaults)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(args) >= 1 {
+		cli.InputScript = args[0]
 	}
 	fmt.Print(makeRed)
 	dbLogger := log.New(os.Stdout, "db", log.Flags())
-	dao.Init("major.db", dbLogger)
+	dao.Init(config.Current.DatabaseFile, dbLogger)
 	defer dao.Shutdown(dbLogger)
-	cliIskendria.InitEventStream("./major-events.log", "major")
+	cliIskendria.InitEventStream(config.Current.EventLogFile, "major")
 	context.Run()
 }
 
@@ -135,7 +215,7 @@ func bootstrap(outputter cli.Outputter, bootstrap *command.Bootstrap) {
 		return
 	}
 	cmd := command.GetBootstrapCommand(bootstrap, cliIskendria.LoggedIn())
-	if err := blockchain.SendCommand(cmd, outputter); err != nil {
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err))
 	}
 }
@@ -148,13 +228,19 @@ func settingsUpdateReference(outputter cli.Outputter) *dao.Settings {
 }
 
 func settingsUpdate(outputter cli.Outputter, updated *dao.Settings) {
+	majorIds, err := dao.GetMajorIds()
+	if err != nil {
+		outputter(fmt.Sprintf("Could not read the majors: %s\n", err.Error()))
+		return
+	}
 	theCommand := command.GetSettingsUpdateCommand(
 		cliIskendria.Settings,
 		updated,
+		majorIds,
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceMajorEditSettings)
-	if err := blockchain.SendCommand(theCommand, outputter); err != nil {
+	if err := cliIskendria.SendCommand(theCommand, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err))
 	}
 }
@@ -168,7 +254,7 @@ func personCreate(outputter cli.Outputter, personInput *command.PersonCreate) {
 		cliIskendria.LoggedInPerson.Id,
 		cliIskendria.LoggedIn(),
 		cliIskendria.Settings.PriceMajorCreatePerson)
-	if err := blockchain.SendCommand(cmd, outputter); err != nil {
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
 		outputter(cliIskendria.ToIoError(err) + "\n")
 		return
 	}
@@ -226,6 +312,83 @@ func incBalance(outputter cli.Outputter, personId string, amount int32) {
 	})
 }
 
+// creditReport shows what a person was issued by majors, what was
+// transferred and what the person paid, followed by the balance history.
+func creditReport(outputter cli.Outputter, personId string) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	summary, err := dao.GetCreditSummary(personId)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not read credit summary of person %s: %s\n", personId, err.Error()))
+		return
+	}
+	if summary == nil {
+		outputter(fmt.Sprintf("Person not found: %s\n", personId))
+		return
+	}
+	outputter(cli.StructToTable(summary).String())
+	if !summary.IsReconciled() {
+		outputter("WARNING: The balance does not match the balance changes\n")
+	}
+	outputter("\n")
+	cliIskendria.ShowBalanceHistory(outputter, personId)
+}
+
+// globalCreditReport shows the credit summaries of all persons and the
+// totals. The total issued by majors should equal the total paid for
+// commands plus the total balance of all persons.
+func globalCreditReport(outputter cli.Outputter) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	summaries, err := dao.GetCreditReport()
+	if err != nil {
+		outputter(fmt.Sprintf("Could not read credit report: %s\n", err.Error()))
+		return
+	}
+	total := &dao.CreditSummary{
+		Name: "TOTAL",
+	}
+	var totalBalance int64
+	table := cli.NewTable(len(summaries)+1, 8)
+	for i, s := range summaries {
+		setCreditReportRow(table, i, s)
+		total.Issued += s.Issued
+		total.TransferredIn += s.TransferredIn
+		total.TransferredOut += s.TransferredOut
+		total.Paid += s.Paid
+		total.Unattributed += s.Unattributed
+		totalBalance += int64(s.Balance)
+	}
+	setCreditReportRow(table, len(summaries), total)
+	table.Set(len(summaries), 7, fmt.Sprintf("%d", totalBalance))
+	outputter("Columns: person id, name, issued, transferred in, transferred out, paid, unattributed, balance\n")
+	outputter(table.String())
+	outputter(fmt.Sprintf("Issued by majors: %d, paid for commands: %d, held by persons: %d\n",
+		total.Issued, total.Paid, totalBalance))
+	if total.Issued-total.Paid+total.Unattributed != totalBalance {
+		outputter("WARNING: The balances do not match the balance changes\n")
+	}
+	for _, s := range summaries {
+		if !s.IsReconciled() {
+			outputter(fmt.Sprintf("WARNING: The balance of person %s does not match its balance changes\n",
+				s.PersonId))
+		}
+	}
+}
+
+func setCreditReportRow(table *cli.TableType, row int, s *dao.CreditSummary) {
+	table.Set(row, 0, s.PersonId)
+	table.Set(row, 1, s.Name)
+	table.Set(row, 2, fmt.Sprintf("%d", s.Issued))
+	table.Set(row, 3, fmt.Sprintf("%d", s.TransferredIn))
+	table.Set(row, 4, fmt.Sprintf("%d", s.TransferredOut))
+	table.Set(row, 5, fmt.Sprintf("%d", s.Paid))
+	table.Set(row, 6, fmt.Sprintf("%d", s.Unattributed))
+	table.Set(row, 7, fmt.Sprintf("%d", s.Balance))
+}
+
 func personUpdateReference(outputter cli.Outputter, personId string) *dao.PersonUpdate {
 	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
 		return nil
@@ -258,3 +421,28 @@ func journalChangeAuthorization(outputter cli.Outputter, journalId string, makeS
 			cliIskendria.Settings.PriceMajorChangeJournalAuthorization)
 	})
 }
+
+func journalOpen(outputter cli.Outputter, journalId string) {
+	journalUpdateState(outputter, journalId, model.JournalState_journalOpen)
+}
+
+func journalClose(outputter cli.Outputter, journalId string) {
+	journalUpdateState(outputter, journalId, model.JournalState_journalClosed)
+}
+
+func journalArchive(outputter cli.Outputter, journalId string) {
+	journalUpdateState(outputter, journalId, model.JournalState_journalArchived)
+}
+
+// A major who is also an accepted editor of the journal pays the price
+// for editors, use the client tool in that case.
+func journalUpdateState(outputter cli.Outputter, journalId string, journalState model.JournalState) {
+	cliIskendria.SendCommandAsPerson(outputter, func() *command.Command {
+		return command.GetCommandJournalUpdateState(
+			journalId,
+			journalState,
+			cliIskendria.LoggedInPerson.Id,
+			cliIskendria.LoggedIn(),
+			cliIskendria.Settings.PriceMajorChangeJournalAuthorization)
+	})
+}
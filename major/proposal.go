@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"github.com/iskendria-pub/iskendria/cli"
+	"github.com/iskendria-pub/iskendria/cliIskendria"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/dao"
+	"github.com/iskendria-pub/iskendria/model"
+	"strings"
+	"time"
+)
+
+const secondsPerDay = int64(24 * 60 * 60)
+
+// The settings proposal dialog gets the number of days as an argument of
+// the reference value getter, because the dialog only edits the settings.
+var proposalDaysValid int32
+
+func proposeSettingsUpdateReference(outputter cli.Outputter, daysValid int32) *dao.Settings {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return nil
+	}
+	proposalDaysValid = daysValid
+	return cliIskendria.Settings
+}
+
+func proposeSettingsUpdate(outputter cli.Outputter, updated *dao.Settings) {
+	majorIds, err := dao.GetMajorIds()
+	if err != nil {
+		outputter(fmt.Sprintf("Could not read the majors: %s\n", err.Error()))
+		return
+	}
+	sendProposal(outputter, proposalDaysValid, command.GetSettingsUpdateCommand(
+		cliIskendria.Settings,
+		updated,
+		majorIds,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn(),
+		cliIskendria.Settings.PriceMajorEditSettings))
+}
+
+func proposeSetMajor(outputter cli.Outputter, personId string, daysValid int32) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	sendProposal(outputter, daysValid, command.GetPersonUpdateSetMajorCommand(
+		personId,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn(),
+		cliIskendria.Settings.PriceMajorChangePersonAuthorization))
+}
+
+func proposeUnsetMajor(outputter cli.Outputter, personId string, daysValid int32) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	sendProposal(outputter, daysValid, command.GetPersonUpdateUnsetMajorCommand(
+		personId,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn(),
+		cliIskendria.Settings.PriceMajorChangePersonAuthorization))
+}
+
+func proposeIncBalance(outputter cli.Outputter, personId string, amount int32, daysValid int32) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	sendProposal(outputter, daysValid, command.GetPersonUpdateIncBalanceCommand(
+		personId,
+		amount,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn(),
+		int32(0)))
+}
+
+func sendProposal(outputter cli.Outputter, daysValid int32, governed *command.Command) {
+	if daysValid <= 0 {
+		outputter("The number of days that a proposal is valid should be positive\n")
+		return
+	}
+	expiresOn := model.GetCurrentTime() + int64(daysValid)*secondsPerDay
+	cmd, proposalId, err := command.GetCommandProposalCreate(governed, expiresOn)
+	if err != nil {
+		outputter(err.Error() + "\n")
+		return
+	}
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
+		outputter(cliIskendria.ToIoError(err))
+		return
+	}
+	outputter("The id of the created proposal is: " + proposalId + "\n")
+}
+
+// proposals shows the proposals that can still be approved. When the
+// approval threshold is zero or one, proposals are executed when they
+// are created, so there are no open proposals.
+func proposals(outputter cli.Outputter) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	openProposals, err := dao.GetOpenProposals(model.GetCurrentTime())
+	if err != nil {
+		outputter(fmt.Sprintf("Could not read open proposals: %s\n", err.Error()))
+		return
+	}
+	outputter(fmt.Sprintf("Approval threshold: %d\n", cliIskendria.Settings.ApprovalThreshold))
+	outputter(fmt.Sprintf("Number of majors: %d\n", cliIskendria.Settings.MajorCount))
+	table := cli.NewTable(len(openProposals), 4)
+	for i, p := range openProposals {
+		approvals, err := dao.GetProposalApprovals(p.Id)
+		if err != nil {
+			outputter(fmt.Sprintf("Could not read approvals of proposal %s: %s\n", p.Id, err.Error()))
+			return
+		}
+		approverNames := make([]string, len(approvals))
+		for j, a := range approvals {
+			approverNames[j] = a.ApproverName
+		}
+		table.Set(i, 0, p.Id)
+		table.Set(i, 1, p.Description)
+		table.Set(i, 2, time.Unix(p.ExpiresOn, 0).Format(time.UnixDate))
+		table.Set(i, 3, strings.Join(approverNames, ", "))
+	}
+	outputter("Columns: proposal id, description, expires on (advisory), approved by\n")
+	outputter(table.String())
+}
+
+func approveProposal(outputter cli.Outputter, proposalId string) {
+	if !cliIskendria.CheckBootstrappedAndKnownPerson(outputter) {
+		return
+	}
+	proposal, err := dao.GetProposal(proposalId)
+	if err != nil {
+		outputter(fmt.Sprintf("Could not read proposal %s: %s\n", proposalId, err.Error()))
+		return
+	}
+	if proposal == nil {
+		outputter(fmt.Sprintf("Proposal not found: %s\n", proposalId))
+		return
+	}
+	cmd := command.GetCommandProposalApprove(
+		proposalId,
+		proposal.TargetId,
+		cliIskendria.LoggedInPerson.Id,
+		cliIskendria.LoggedIn())
+	if err := cliIskendria.SendCommand(cmd, outputter); err != nil {
+		outputter(cliIskendria.ToIoError(err))
+	}
+}
+
+func cancelProposal(outputter cli.Outputter, proposalId string) {
+	cliIskendria.SendCommandAsPerson(outputter, func() *command.Command {
+		return command.GetCommandProposalCancel(
+			proposalId,
+			cliIskendria.LoggedInPerson.Id,
+			cliIskendria.LoggedIn())
+	})
+}
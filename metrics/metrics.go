@@ -0,0 +1,273 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+/*
+This package collects counters and histograms and serves them in the
+Prometheus text exposition format, version 0.0.4. Metrics are created
+as package-level variables by the packages that update them. Creating
+a metric registers it, so every metric appears on the metrics page,
+even before it is updated for the first time.
+
+Only what Iskendria needs is supported. Every metric has a fixed list
+of label names. The label values are given in the same order when the
+metric is updated.
+*/
+
+const CONTENT_TYPE = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the upper bounds in seconds of histograms that
+// measure latency.
+var DefaultBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type collector interface {
+	write(w io.Writer) error
+}
+
+var registryMutex sync.Mutex
+var registry = make(map[string]collector)
+
+func register(name string, c collector) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if _, found := registry[name]; found {
+		panic("Metric registered twice: " + name)
+	}
+	registry[name] = c
+}
+
+// WriteText writes all metrics, ordered by name.
+func WriteText(w io.Writer) error {
+	registryMutex.Lock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	registryMutex.Unlock()
+	sort.Strings(names)
+	for _, name := range names {
+		registryMutex.Lock()
+		c := registry[name]
+		registryMutex.Unlock()
+		if err := c.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves the metrics page.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", CONTENT_TYPE)
+		_ = WriteText(w)
+	})
+}
+
+type labels struct {
+	names []string
+}
+
+func (l *labels) getKey(values []string) string {
+	if len(values) != len(l.names) {
+		panic(fmt.Sprintf("Expected label values for %v, got %v", l.names, values))
+	}
+	return strings.Join(values, "\x00")
+}
+
+func (l *labels) format(key string, extraName, extraValue string) string {
+	pairs := make([]string, 0, len(l.names)+1)
+	if len(l.names) >= 1 {
+		for i, value := range strings.Split(key, "\x00") {
+			pairs = append(pairs, l.names[i]+"=\""+escapeLabelValue(value)+"\"")
+		}
+	}
+	if extraName != "" {
+		pairs = append(pairs, extraName+"=\""+extraValue+"\"")
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeLabelValue(value string) string {
+	value = strings.Replace(value, "\\", "\\\\", -1)
+	value = strings.Replace(value, "\"", "\\\"", -1)
+	return strings.Replace(value, "\n", "\\n", -1)
+}
+
+func escapeHelp(help string) string {
+	help = strings.Replace(help, "\\", "\\\\", -1)
+	return strings.Replace(help, "\n", "\\n", -1)
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func getSortedKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+// CounterVec counts events per combination of label values.
+type CounterVec struct {
+	name   string
+	help   string
+	labels labels
+	mutex  sync.Mutex
+	values map[string]float64
+}
+
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	result := &CounterVec{
+		name:   name,
+		help:   help,
+		labels: labels{names: labelNames},
+		values: make(map[string]float64),
+	}
+	register(name, result)
+	return result
+}
+
+func (cv *CounterVec) Inc(labelValues ...string) {
+	key := cv.labels.getKey(labelValues)
+	cv.mutex.Lock()
+	defer cv.mutex.Unlock()
+	cv.values[key]++
+}
+
+func (cv *CounterVec) Get(labelValues ...string) float64 {
+	key := cv.labels.getKey(labelValues)
+	cv.mutex.Lock()
+	defer cv.mutex.Unlock()
+	return cv.values[key]
+}
+
+func (cv *CounterVec) write(w io.Writer) error {
+	cv.mutex.Lock()
+	defer cv.mutex.Unlock()
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", cv.name, escapeHelp(cv.help), cv.name); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(cv.values))
+	for key := range cv.values {
+		keys = append(keys, key)
+	}
+	for _, key := range getSortedKeys(keys) {
+		_, err := fmt.Fprintf(w, "%s%s %s\n", cv.name, cv.labels.format(key, "", ""), formatFloat(cv.values[key]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HistogramVec counts observations in buckets per combination of
+// label values. The buckets are cumulative, like Prometheus expects.
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  labels
+	buckets []float64
+	mutex   sync.Mutex
+	values  map[string]*histogram
+}
+
+type histogram struct {
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+}
+
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	sortedBuckets := make([]float64, len(buckets))
+	copy(sortedBuckets, buckets)
+	sort.Float64s(sortedBuckets)
+	result := &HistogramVec{
+		name:    name,
+		help:    help,
+		labels:  labels{names: labelNames},
+		buckets: sortedBuckets,
+		values:  make(map[string]*histogram),
+	}
+	register(name, result)
+	return result
+}
+
+func (hv *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := hv.labels.getKey(labelValues)
+	hv.mutex.Lock()
+	defer hv.mutex.Unlock()
+	h, found := hv.values[key]
+	if !found {
+		h = &histogram{
+			bucketCounts: make([]uint64, len(hv.buckets)),
+		}
+		hv.values[key] = h
+	}
+	for i, upperBound := range hv.buckets {
+		if value <= upperBound {
+			h.bucketCounts[i]++
+		}
+	}
+	h.count++
+	h.sum += value
+}
+
+// GetCount returns the number of observations.
+func (hv *HistogramVec) GetCount(labelValues ...string) uint64 {
+	key := hv.labels.getKey(labelValues)
+	hv.mutex.Lock()
+	defer hv.mutex.Unlock()
+	if h, found := hv.values[key]; found {
+		return h.count
+	}
+	return uint64(0)
+}
+
+func (hv *HistogramVec) write(w io.Writer) error {
+	hv.mutex.Lock()
+	defer hv.mutex.Unlock()
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", hv.name, escapeHelp(hv.help), hv.name); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(hv.values))
+	for key := range hv.values {
+		keys = append(keys, key)
+	}
+	for _, key := range getSortedKeys(keys) {
+		h := hv.values[key]
+		for i, upperBound := range hv.buckets {
+			_, err := fmt.Fprintf(w, "%s_bucket%s %d\n",
+				hv.name, hv.labels.format(key, "le", formatFloat(upperBound)), h.bucketCounts[i])
+			if err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			hv.name, hv.labels.format(key, "le", "+Inf"), h.count,
+			hv.name, hv.labels.format(key, "", ""), formatFloat(h.sum),
+			hv.name, hv.labels.format(key, "", ""), h.count)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
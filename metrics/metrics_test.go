@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var testCounter = NewCounterVec("test_events_total", "Events of the test.", "kind", "reason")
+
+var testHistogram = NewHistogramVec("test_duration_seconds", "Duration of the test.",
+	[]float64{1, 0.1}, "operation")
+
+func TestTextFormat(t *testing.T) {
+	testCounter.Inc("b", "none")
+	testCounter.Inc("a", "quote \" and \\ and\nnewline")
+	testCounter.Inc("b", "none")
+	testHistogram.Observe(0.05, "get")
+	testHistogram.Observe(0.5, "get")
+	testHistogram.Observe(2, "get")
+	if testCounter.Get("b", "none") != 2 {
+		t.Errorf("Expected count 2, got %f", testCounter.Get("b", "none"))
+	}
+	if testHistogram.GetCount("get") != 3 {
+		t.Errorf("Expected three observations, got %d", testHistogram.GetCount("get"))
+	}
+	buf := new(bytes.Buffer)
+	if err := WriteText(buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := `# HELP test_duration_seconds Duration of the test.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{operation="get",le="0.1"} 1
+test_duration_seconds_bucket{operation="get",le="1"} 2
+test_duration_seconds_bucket{operation="get",le="+Inf"} 3
+test_duration_seconds_sum{operation="get"} 2.55
+test_duration_seconds_count{operation="get"} 3
+# HELP test_events_total Events of the test.
+# TYPE test_events_total counter
+test_events_total{kind="a",reason="quote \" and \\ and\nnewline"} 1
+test_events_total{kind="b",reason="none"} 2
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if recorder.Header().Get("Content-Type") != CONTENT_TYPE {
+		t.Errorf("Unexpected content type: %s", recorder.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(recorder.Body.String(), "# TYPE test_events_total counter") {
+		t.Errorf("Counter missing from metrics page:\n%s", recorder.Body.String())
+	}
+}
@@ -45,6 +45,21 @@ type Command struct {
 	//	*Command_CommandWriteReview
 	//	*Command_CommandManuscriptJudge
 	//	*Command_CommandManuscriptAssign
+	//	*Command_CommandManuscriptWithdraw
+	//	*Command_CommandJournalUpdateReviewPolicy
+	//	*Command_CommandReviewerInvite
+	//	*Command_CommandReviewInvitationAccept
+	//	*Command_CommandReviewInvitationDecline
+	//	*Command_CommandWriteBlindReview
+	//	*Command_CommandReviewReveal
+	//	*Command_CommandReviewRevise
+	//	*Command_CommandJournalUpdateState
+	//	*Command_CommandVolumeUpdate
+	//	*Command_CommandVolumeSeal
+	//	*Command_CommandBalanceTransfer
+	//	*Command_CommandProposalCreate
+	//	*Command_CommandProposalApprove
+	//	*Command_CommandProposalCancel
 	Body                 isCommand_Body `protobuf_oneof:"body"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
@@ -181,6 +196,66 @@ type Command_CommandManuscriptAssign struct {
 	CommandManuscriptAssign *CommandManuscriptAssign `protobuf:"bytes,23,opt,name=commandManuscriptAssign,proto3,oneof"`
 }
 
+type Command_CommandManuscriptWithdraw struct {
+	CommandManuscriptWithdraw *CommandManuscriptWithdraw `protobuf:"bytes,24,opt,name=commandManuscriptWithdraw,proto3,oneof"`
+}
+
+type Command_CommandJournalUpdateReviewPolicy struct {
+	CommandJournalUpdateReviewPolicy *CommandJournalUpdateReviewPolicy `protobuf:"bytes,25,opt,name=commandJournalUpdateReviewPolicy,proto3,oneof"`
+}
+
+type Command_CommandReviewerInvite struct {
+	CommandReviewerInvite *CommandReviewerInvite `protobuf:"bytes,26,opt,name=commandReviewerInvite,proto3,oneof"`
+}
+
+type Command_CommandReviewInvitationAccept struct {
+	CommandReviewInvitationAccept *CommandReviewInvitationAccept `protobuf:"bytes,27,opt,name=commandReviewInvitationAccept,proto3,oneof"`
+}
+
+type Command_CommandReviewInvitationDecline struct {
+	CommandReviewInvitationDecline *CommandReviewInvitationDecline `protobuf:"bytes,28,opt,name=commandReviewInvitationDecline,proto3,oneof"`
+}
+
+type Command_CommandWriteBlindReview struct {
+	CommandWriteBlindReview *CommandWriteBlindReview `protobuf:"bytes,29,opt,name=commandWriteBlindReview,proto3,oneof"`
+}
+
+type Command_CommandReviewReveal struct {
+	CommandReviewReveal *CommandReviewReveal `protobuf:"bytes,30,opt,name=commandReviewReveal,proto3,oneof"`
+}
+
+type Command_CommandReviewRevise struct {
+	CommandReviewRevise *CommandReviewRevise `protobuf:"bytes,31,opt,name=commandReviewRevise,proto3,oneof"`
+}
+
+type Command_CommandJournalUpdateState struct {
+	CommandJournalUpdateState *CommandJournalUpdateState `protobuf:"bytes,32,opt,name=commandJournalUpdateState,proto3,oneof"`
+}
+
+type Command_CommandVolumeUpdate struct {
+	CommandVolumeUpdate *CommandVolumeUpdate `protobuf:"bytes,33,opt,name=commandVolumeUpdate,proto3,oneof"`
+}
+
+type Command_CommandVolumeSeal struct {
+	CommandVolumeSeal *CommandVolumeSeal `protobuf:"bytes,34,opt,name=commandVolumeSeal,proto3,oneof"`
+}
+
+type Command_CommandBalanceTransfer struct {
+	CommandBalanceTransfer *CommandBalanceTransfer `protobuf:"bytes,35,opt,name=commandBalanceTransfer,proto3,oneof"`
+}
+
+type Command_CommandProposalCreate struct {
+	CommandProposalCreate *CommandProposalCreate `protobuf:"bytes,36,opt,name=commandProposalCreate,proto3,oneof"`
+}
+
+type Command_CommandProposalApprove struct {
+	CommandProposalApprove *CommandProposalApprove `protobuf:"bytes,37,opt,name=commandProposalApprove,proto3,oneof"`
+}
+
+type Command_CommandProposalCancel struct {
+	CommandProposalCancel *CommandProposalCancel `protobuf:"bytes,38,opt,name=commandProposalCancel,proto3,oneof"`
+}
+
 func (*Command_Bootstrap) isCommand_Body() {}
 
 func (*Command_CommandJournalCreate) isCommand_Body() {}
@@ -221,6 +296,36 @@ func (*Command_CommandManuscriptJudge) isCommand_Body() {}
 
 func (*Command_CommandManuscriptAssign) isCommand_Body() {}
 
+func (*Command_CommandManuscriptWithdraw) isCommand_Body() {}
+
+func (*Command_CommandJournalUpdateReviewPolicy) isCommand_Body() {}
+
+func (*Command_CommandReviewerInvite) isCommand_Body() {}
+
+func (*Command_CommandReviewInvitationAccept) isCommand_Body() {}
+
+func (*Command_CommandReviewInvitationDecline) isCommand_Body() {}
+
+func (*Command_CommandWriteBlindReview) isCommand_Body() {}
+
+func (*Command_CommandReviewReveal) isCommand_Body() {}
+
+func (*Command_CommandReviewRevise) isCommand_Body() {}
+
+func (*Command_CommandJournalUpdateState) isCommand_Body() {}
+
+func (*Command_CommandVolumeUpdate) isCommand_Body() {}
+
+func (*Command_CommandVolumeSeal) isCommand_Body() {}
+
+func (*Command_CommandBalanceTransfer) isCommand_Body() {}
+
+func (*Command_CommandProposalCreate) isCommand_Body() {}
+
+func (*Command_CommandProposalApprove) isCommand_Body() {}
+
+func (*Command_CommandProposalCancel) isCommand_Body() {}
+
 func (m *Command) GetBody() isCommand_Body {
 	if m != nil {
 		return m.Body
@@ -368,6 +473,111 @@ func (m *Command) GetCommandManuscriptAssign() *CommandManuscriptAssign {
 	return nil
 }
 
+func (m *Command) GetCommandManuscriptWithdraw() *CommandManuscriptWithdraw {
+	if x, ok := m.GetBody().(*Command_CommandManuscriptWithdraw); ok {
+		return x.CommandManuscriptWithdraw
+	}
+	return nil
+}
+
+func (m *Command) GetCommandJournalUpdateReviewPolicy() *CommandJournalUpdateReviewPolicy {
+	if x, ok := m.GetBody().(*Command_CommandJournalUpdateReviewPolicy); ok {
+		return x.CommandJournalUpdateReviewPolicy
+	}
+	return nil
+}
+
+func (m *Command) GetCommandReviewerInvite() *CommandReviewerInvite {
+	if x, ok := m.GetBody().(*Command_CommandReviewerInvite); ok {
+		return x.CommandReviewerInvite
+	}
+	return nil
+}
+
+func (m *Command) GetCommandReviewInvitationAccept() *CommandReviewInvitationAccept {
+	if x, ok := m.GetBody().(*Command_CommandReviewInvitationAccept); ok {
+		return x.CommandReviewInvitationAccept
+	}
+	return nil
+}
+
+func (m *Command) GetCommandReviewInvitationDecline() *CommandReviewInvitationDecline {
+	if x, ok := m.GetBody().(*Command_CommandReviewInvitationDecline); ok {
+		return x.CommandReviewInvitationDecline
+	}
+	return nil
+}
+
+func (m *Command) GetCommandWriteBlindReview() *CommandWriteBlindReview {
+	if x, ok := m.GetBody().(*Command_CommandWriteBlindReview); ok {
+		return x.CommandWriteBlindReview
+	}
+	return nil
+}
+
+func (m *Command) GetCommandReviewReveal() *CommandReviewReveal {
+	if x, ok := m.GetBody().(*Command_CommandReviewReveal); ok {
+		return x.CommandReviewReveal
+	}
+	return nil
+}
+
+func (m *Command) GetCommandReviewRevise() *CommandReviewRevise {
+	if x, ok := m.GetBody().(*Command_CommandReviewRevise); ok {
+		return x.CommandReviewRevise
+	}
+	return nil
+}
+
+func (m *Command) GetCommandJournalUpdateState() *CommandJournalUpdateState {
+	if x, ok := m.GetBody().(*Command_CommandJournalUpdateState); ok {
+		return x.CommandJournalUpdateState
+	}
+	return nil
+}
+
+func (m *Command) GetCommandVolumeUpdate() *CommandVolumeUpdate {
+	if x, ok := m.GetBody().(*Command_CommandVolumeUpdate); ok {
+		return x.CommandVolumeUpdate
+	}
+	return nil
+}
+
+func (m *Command) GetCommandVolumeSeal() *CommandVolumeSeal {
+	if x, ok := m.GetBody().(*Command_CommandVolumeSeal); ok {
+		return x.CommandVolumeSeal
+	}
+	return nil
+}
+
+func (m *Command) GetCommandBalanceTransfer() *CommandBalanceTransfer {
+	if x, ok := m.GetBody().(*Command_CommandBalanceTransfer); ok {
+		return x.CommandBalanceTransfer
+	}
+	return nil
+}
+
+func (m *Command) GetCommandProposalCreate() *CommandProposalCreate {
+	if x, ok := m.GetBody().(*Command_CommandProposalCreate); ok {
+		return x.CommandProposalCreate
+	}
+	return nil
+}
+
+func (m *Command) GetCommandProposalApprove() *CommandProposalApprove {
+	if x, ok := m.GetBody().(*Command_CommandProposalApprove); ok {
+		return x.CommandProposalApprove
+	}
+	return nil
+}
+
+func (m *Command) GetCommandProposalCancel() *CommandProposalCancel {
+	if x, ok := m.GetBody().(*Command_CommandProposalCancel); ok {
+		return x.CommandProposalCancel
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Command) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -391,6 +601,21 @@ func (*Command) XXX_OneofWrappers() []interface{} {
 		(*Command_CommandWriteReview)(nil),
 		(*Command_CommandManuscriptJudge)(nil),
 		(*Command_CommandManuscriptAssign)(nil),
+		(*Command_CommandManuscriptWithdraw)(nil),
+		(*Command_CommandJournalUpdateReviewPolicy)(nil),
+		(*Command_CommandReviewerInvite)(nil),
+		(*Command_CommandReviewInvitationAccept)(nil),
+		(*Command_CommandReviewInvitationDecline)(nil),
+		(*Command_CommandWriteBlindReview)(nil),
+		(*Command_CommandReviewReveal)(nil),
+		(*Command_CommandReviewRevise)(nil),
+		(*Command_CommandJournalUpdateState)(nil),
+		(*Command_CommandVolumeUpdate)(nil),
+		(*Command_CommandVolumeSeal)(nil),
+		(*Command_CommandBalanceTransfer)(nil),
+		(*Command_CommandProposalCreate)(nil),
+		(*Command_CommandProposalApprove)(nil),
+		(*Command_CommandProposalCancel)(nil),
 	}
 }
 
@@ -401,45 +626,62 @@ func init() {
 func init() { proto.RegisterFile("command.proto", fileDescriptor_213c0bb044472049) }
 
 var fileDescriptor_213c0bb044472049 = []byte{
-	// 632 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x95, 0x5d, 0x4f, 0x14, 0x3f,
-	0x14, 0xc6, 0xbb, 0x7f, 0xd8, 0xe5, 0xbf, 0xe5, 0xbd, 0x2c, 0x4b, 0x83, 0x80, 0x0b, 0x7a, 0xb1,
-	0x57, 0x24, 0xea, 0x9d, 0x77, 0x2c, 0x92, 0x14, 0x8c, 0x04, 0xab, 0x62, 0x62, 0xe2, 0xc5, 0x30,
-	0x7b, 0x84, 0x9a, 0x9d, 0xe9, 0xa4, 0xd3, 0x01, 0xf1, 0x7b, 0xf8, 0x7d, 0x0d, 0x6d, 0x87, 0x9d,
-	0x97, 0xce, 0xe2, 0x65, 0xfb, 0x3c, 0xe7, 0xf7, 0xb4, 0xd3, 0xd3, 0x0e, 0x5e, 0x0e, 0x65, 0x14,
-	0x05, 0xf1, 0xf8, 0x30, 0x51, 0x52, 0xcb, 0xed, 0xa5, 0x04, 0x54, 0x2a, 0x63, 0x37, 0x5a, 0xfe,
-	0x29, 0x33, 0x15, 0x07, 0x13, 0x37, 0x5c, 0x49, 0x41, 0x6b, 0x11, 0x5f, 0xa7, 0x6e, 0xbc, 0x16,
-	0x05, 0x71, 0x96, 0x86, 0x4a, 0x24, 0xda, 0xce, 0x1c, 0xfc, 0x59, 0xc5, 0x0b, 0xc7, 0x16, 0x48,
-	0xfa, 0xb8, 0x93, 0x8a, 0xeb, 0x18, 0x14, 0x6d, 0x0d, 0x5a, 0xc3, 0x2e, 0x77, 0x23, 0xd2, 0xc3,
-	0xed, 0x44, 0x89, 0x10, 0xe8, 0x7f, 0x83, 0xd6, 0xb0, 0xcd, 0xed, 0x80, 0xec, 0xe0, 0xae, 0x16,
-	0x11, 0xa4, 0x3a, 0x88, 0x12, 0x3a, 0x37, 0x68, 0x0d, 0xe7, 0xf8, 0x74, 0x82, 0xbc, 0xc2, 0xdd,
-	0x2b, 0x29, 0x75, 0xaa, 0x55, 0x90, 0xd0, 0xf9, 0x41, 0x6b, 0xb8, 0xf8, 0x7a, 0xfd, 0xd0, 0x05,
-	0x8d, 0x72, 0x81, 0x21, 0x3e, 0x75, 0x91, 0xf7, 0xb8, 0xe7, 0xb6, 0x76, 0x66, 0x37, 0x71, 0xac,
-	0x20, 0xd0, 0x40, 0xdb, 0xa6, 0x7a, 0x33, 0xaf, 0x2e, 0x89, 0x0c, 0x71, 0x6f, 0x11, 0x11, 0x78,
-	0xaf, 0x3c, 0xff, 0x25, 0x19, 0x07, 0x1a, 0x2e, 0x94, 0x4c, 0x40, 0x69, 0x01, 0x29, 0xed, 0x18,
-	0xec, 0xf3, 0x0a, 0xb6, 0x6a, 0x63, 0x88, 0x3f, 0x01, 0x22, 0x0a, 0xef, 0xfb, 0x1c, 0x47, 0x99,
-	0xbe, 0x91, 0x4a, 0xfc, 0x0e, 0xb4, 0x90, 0x31, 0x5d, 0x30, 0x69, 0x07, 0xde, 0xb4, 0x92, 0x93,
-	0x21, 0xfe, 0x34, 0xae, 0xbe, 0xbd, 0x93, 0xb1, 0xd0, 0x52, 0x1d, 0x85, 0x21, 0x24, 0xfa, 0x5d,
-	0xa6, 0xef, 0xe9, 0xff, 0xde, 0xed, 0x55, 0x6d, 0xf5, 0xed, 0x55, 0x1d, 0xe4, 0x3b, 0xde, 0xf6,
-	0x39, 0x4e, 0xe3, 0x5b, 0xa1, 0x81, 0x76, 0x4d, 0xcc, 0x33, 0x6f, 0x8c, 0xb5, 0x30, 0xc4, 0x67,
-	0x00, 0x9a, 0xf0, 0x1c, 0x1e, 0x9a, 0x8f, 0xe2, 0x19, 0x78, 0x6b, 0x69, 0xc2, 0x5b, 0x95, 0x30,
-	0xbc, 0xe1, 0xd4, 0x4b, 0x39, 0xc9, 0x22, 0x70, 0x3d, 0xb5, 0x68, 0xb8, 0xbd, 0x9c, 0x5b, 0xd4,
-	0x18, 0xe2, 0xbe, 0x12, 0x72, 0x8e, 0x37, 0xdd, 0xf4, 0x27, 0x77, 0xa9, 0xec, 0xc1, 0xd0, 0x25,
-	0xc3, 0xea, 0xe7, 0xac, 0xb2, 0xca, 0x10, 0xf7, 0x97, 0x91, 0xb7, 0xd8, 0x5d, 0x5d, 0xb7, 0xa4,
-	0xe5, 0xf2, 0x92, 0x2e, 0x0a, 0x1a, 0x43, 0xbc, 0xe4, 0x25, 0x3f, 0xf0, 0x6e, 0x58, 0xb4, 0xd5,
-	0x9a, 0x7b, 0xc5, 0xc0, 0xf6, 0xca, 0x30, 0x4f, 0x6f, 0xcf, 0xc6, 0x90, 0xf0, 0xf1, 0x70, 0x7c,
-	0x3d, 0xbd, 0x6a, 0x42, 0xf6, 0x7d, 0x21, 0xd5, 0x96, 0x9e, 0x81, 0x21, 0xbf, 0xf0, 0x0b, 0xcf,
-	0x2a, 0x46, 0xc1, 0x24, 0x88, 0x43, 0x38, 0x8d, 0x43, 0x05, 0x11, 0xc4, 0x9a, 0xae, 0x99, 0xb4,
-	0x97, 0xbe, 0xb4, 0xaa, 0x97, 0x21, 0xfe, 0x2f, 0x48, 0xf2, 0x19, 0x6f, 0x39, 0xdb, 0x87, 0xc7,
-	0x77, 0xd1, 0x9d, 0xc6, 0xba, 0x49, 0xa3, 0x79, 0x5a, 0x55, 0x67, 0x88, 0x37, 0x95, 0x16, 0xde,
-	0x83, 0xaa, 0x74, 0x0e, 0x77, 0x97, 0xa0, 0xd2, 0x87, 0x6f, 0x47, 0xca, 0xef, 0x41, 0xb3, 0xb3,
-	0xf0, 0x1e, 0x34, 0x9b, 0xbc, 0x99, 0xf6, 0x0e, 0xdb, 0x6f, 0x9d, 0xde, 0x88, 0x84, 0x6e, 0x34,
-	0x65, 0x56, 0x9d, 0xde, 0xcc, 0xaa, 0x89, 0x84, 0x78, 0xa7, 0x6e, 0x9a, 0x4c, 0xe4, 0x1d, 0x87,
-	0x5b, 0x01, 0x77, 0xb4, 0x67, 0xe2, 0x76, 0x3d, 0x71, 0x53, 0x13, 0x43, 0x7c, 0x26, 0x84, 0x9c,
-	0x60, 0xe2, 0xf4, 0xaf, 0x4a, 0x68, 0x70, 0xe8, 0x4d, 0x83, 0xde, 0xc8, 0xd1, 0x05, 0x89, 0x21,
-	0xee, 0x29, 0x20, 0x1f, 0x71, 0xbf, 0x16, 0x73, 0x96, 0x8d, 0xaf, 0x81, 0xf6, 0x0d, 0x6a, 0xab,
-	0xbe, 0x4a, 0x23, 0x33, 0xc4, 0x1b, 0x0a, 0xbd, 0xcd, 0x73, 0x94, 0x9a, 0x57, 0x6b, 0xab, 0xa9,
-	0x79, 0xac, 0xee, 0x6d, 0x1e, 0x2b, 0x8d, 0x3a, 0x78, 0xfe, 0x4a, 0x8e, 0xef, 0x47, 0x0b, 0xdf,
-	0xda, 0x91, 0x1c, 0xc3, 0xe4, 0xaa, 0x63, 0xfe, 0xd3, 0x6f, 0xfe, 0x06, 0x00, 0x00, 0xff, 0xff,
-	0xc6, 0xfa, 0xba, 0xb5, 0xf7, 0x07, 0x00, 0x00,
+	// 911 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x57, 0x5d, 0x4f, 0x1c, 0x37,
+	0x14, 0xf5, 0x36, 0x01, 0x8a, 0x13, 0xd2, 0x60, 0xbe, 0x0c, 0x01, 0xb2, 0x90, 0xb4, 0xe2, 0x69,
+	0xa4, 0xb6, 0x6f, 0x7d, 0x63, 0x27, 0x91, 0x9c, 0x54, 0x8d, 0xa8, 0x49, 0x13, 0x29, 0x52, 0x1f,
+	0x86, 0xd9, 0x1b, 0x70, 0x35, 0x3b, 0x1e, 0x79, 0xbc, 0x6c, 0xe9, 0x9f, 0xe9, 0x5f, 0xad, 0xd6,
+	0xe3, 0x05, 0x7b, 0xc6, 0x9e, 0xed, 0xa3, 0x7d, 0xce, 0x3d, 0xf7, 0xda, 0x73, 0x7c, 0xef, 0x2e,
+	0xde, 0xc8, 0xe5, 0x64, 0x92, 0x95, 0xe3, 0xa4, 0x52, 0x52, 0xcb, 0x83, 0xa7, 0x15, 0xa8, 0x5a,
+	0x96, 0x76, 0xb5, 0xf1, 0x97, 0x9c, 0xaa, 0x32, 0x2b, 0xec, 0xf2, 0x59, 0x0d, 0x5a, 0x8b, 0xf2,
+	0xba, 0xb6, 0xeb, 0xe7, 0x93, 0xac, 0x9c, 0xd6, 0xb9, 0x12, 0x95, 0x5e, 0x30, 0x2a, 0x25, 0x2b,
+	0x59, 0x2f, 0x22, 0x4e, 0xff, 0xdd, 0xc7, 0x6b, 0x69, 0x93, 0x80, 0xec, 0xe2, 0xd5, 0x5a, 0x5c,
+	0x97, 0xa0, 0xe8, 0x60, 0x38, 0x38, 0x5b, 0xe7, 0x76, 0x45, 0xb6, 0xf1, 0x4a, 0xa5, 0x44, 0x0e,
+	0xf4, 0x9b, 0xe1, 0xe0, 0x6c, 0x85, 0x37, 0x0b, 0x72, 0x88, 0xd7, 0xb5, 0x98, 0x40, 0xad, 0xb3,
+	0x49, 0x45, 0x1f, 0x0d, 0x07, 0x67, 0x8f, 0xf8, 0xc3, 0x06, 0xf9, 0x11, 0xaf, 0x5f, 0x49, 0xa9,
+	0x6b, 0xad, 0xb2, 0x8a, 0x3e, 0x1e, 0x0e, 0xce, 0x9e, 0xfc, 0xb4, 0x99, 0xd8, 0x44, 0xa3, 0x05,
+	0xc0, 0x10, 0x7f, 0x60, 0x91, 0x5f, 0xf1, 0xb6, 0x3d, 0xea, 0xfb, 0xe6, 0x50, 0xa9, 0x82, 0x4c,
+	0x03, 0x5d, 0x31, 0xd1, 0x3b, 0x49, 0x1a, 0x00, 0x19, 0xe2, 0xc1, 0x20, 0x22, 0xf0, 0xb1, 0xbf,
+	0xff, 0x47, 0x35, 0xce, 0x34, 0x5c, 0x28, 0x59, 0x81, 0xd2, 0x02, 0x6a, 0xba, 0x6a, 0x64, 0x5f,
+	0x26, 0x69, 0x2f, 0x8d, 0x21, 0xbe, 0x44, 0x88, 0x28, 0x7c, 0x12, 0x62, 0x9c, 0x4f, 0xf5, 0x8d,
+	0x54, 0xe2, 0x9f, 0x4c, 0x0b, 0x59, 0xd2, 0x35, 0x93, 0xed, 0x34, 0x49, 0x97, 0x31, 0x19, 0xe2,
+	0xcb, 0xe5, 0xba, 0xc7, 0x7b, 0x3b, 0x16, 0x5a, 0xaa, 0xf3, 0x3c, 0x87, 0x4a, 0xbf, 0x99, 0xea,
+	0x3b, 0xfa, 0x6d, 0xf0, 0x78, 0x6d, 0x5a, 0xf7, 0x78, 0x6d, 0x06, 0xf9, 0x13, 0x1f, 0x84, 0x18,
+	0xef, 0xca, 0x5b, 0xa1, 0x81, 0xae, 0x9b, 0x34, 0x2f, 0x92, 0x34, 0x4a, 0x61, 0x88, 0xf7, 0x08,
+	0xc4, 0xe4, 0x39, 0xcc, 0xcd, 0x47, 0x71, 0x8f, 0x7c, 0x43, 0x89, 0xc9, 0x37, 0x28, 0x61, 0x78,
+	0xcb, 0xa2, 0x9f, 0x64, 0x31, 0x9d, 0x80, 0xf5, 0xd4, 0x13, 0xa3, 0xbb, 0x9d, 0xa4, 0x5d, 0x8c,
+	0x21, 0x1e, 0x0a, 0x21, 0x1f, 0xf0, 0x8e, 0xdd, 0xbe, 0xb4, 0x8f, 0xac, 0xf9, 0x30, 0xf4, 0xa9,
+	0xd1, 0xda, 0x4d, 0xd2, 0x10, 0xca, 0x10, 0x0f, 0x87, 0x91, 0x5f, 0xb0, 0x7d, 0xca, 0xb6, 0xa4,
+	0x0d, 0xbf, 0xa4, 0x0b, 0x07, 0x63, 0x88, 0x7b, 0x5c, 0xf2, 0x15, 0x1f, 0xe5, 0x2e, 0xad, 0x63,
+	0xee, 0x67, 0x46, 0xec, 0x38, 0x49, 0xfb, 0x58, 0x0c, 0xf1, 0x7e, 0x19, 0x92, 0xdf, 0x7f, 0x9c,
+	0x90, 0xa7, 0xbf, 0x33, 0x49, 0x4e, 0x42, 0x49, 0xda, 0x96, 0xee, 0x91, 0x21, 0x7f, 0xe3, 0x57,
+	0x81, 0x2a, 0x46, 0x59, 0x91, 0x95, 0x39, 0xbc, 0x2b, 0x73, 0x05, 0x13, 0x28, 0x35, 0x7d, 0x6e,
+	0xb2, 0xbd, 0x4e, 0xd2, 0xe5, 0x5c, 0x86, 0xf8, 0xff, 0x91, 0x24, 0x1f, 0xf1, 0x9e, 0xa5, 0xfd,
+	0x76, 0xdf, 0x27, 0xed, 0xd7, 0xd8, 0x34, 0xd9, 0x68, 0x92, 0x86, 0x71, 0x86, 0x78, 0x2c, 0xd4,
+	0xe9, 0x07, 0x6d, 0xe8, 0x03, 0xcc, 0x3e, 0x81, 0xaa, 0xe7, 0x77, 0x47, 0xfc, 0x7e, 0x10, 0x67,
+	0x3a, 0xfd, 0x20, 0x4e, 0x0a, 0xe6, 0x6c, 0xde, 0x70, 0x73, 0xd7, 0xf5, 0x8d, 0xa8, 0xe8, 0x56,
+	0x2c, 0x67, 0x9b, 0x19, 0xcc, 0xd9, 0x26, 0x91, 0x1c, 0x1f, 0x76, 0x49, 0x45, 0x21, 0x67, 0x1c,
+	0x6e, 0x05, 0xcc, 0xe8, 0xb6, 0x49, 0x77, 0x94, 0xa4, 0x3d, 0x24, 0x86, 0x78, 0xaf, 0x08, 0x79,
+	0x8b, 0x89, 0xc5, 0x3f, 0x2b, 0xa1, 0xc1, 0x4a, 0xef, 0x18, 0xe9, 0xad, 0x24, 0xed, 0x40, 0x0c,
+	0xf1, 0x40, 0x00, 0xf9, 0x1d, 0xef, 0x76, 0xd2, 0xbc, 0x9f, 0x8e, 0xaf, 0x81, 0xee, 0x1a, 0xa9,
+	0xbd, 0x24, 0x0d, 0xc2, 0x0c, 0xf1, 0x48, 0x60, 0xd0, 0x3c, 0xe7, 0xb5, 0xe9, 0x5a, 0x7b, 0x31,
+	0xf3, 0x34, 0x78, 0xd0, 0x3c, 0x0d, 0x44, 0xbe, 0xe0, 0xfd, 0x0e, 0xf4, 0x59, 0xe8, 0x9b, 0xb1,
+	0xca, 0x66, 0x94, 0x1a, 0xdd, 0x83, 0x24, 0x8d, 0x31, 0x18, 0xe2, 0xf1, 0x70, 0x22, 0xf1, 0x30,
+	0x34, 0x59, 0x9a, 0x2b, 0xba, 0x90, 0x85, 0xc8, 0xef, 0xe8, 0xbe, 0xff, 0xa6, 0xa3, 0x44, 0x86,
+	0xf8, 0x52, 0x31, 0xa7, 0x65, 0x36, 0xdb, 0xb0, 0x98, 0x1a, 0x07, 0x7e, 0xcb, 0xf4, 0x51, 0xa7,
+	0x65, 0xfa, 0x80, 0xd3, 0xf6, 0x1a, 0xc0, 0x6c, 0x9b, 0x26, 0xd2, 0x98, 0x93, 0xbe, 0xf0, 0xdb,
+	0x5e, 0x98, 0xe5, 0xb4, 0xbd, 0x30, 0xc1, 0x99, 0xae, 0x6d, 0xc2, 0x1b, 0xc8, 0x0b, 0x51, 0x02,
+	0x3d, 0xf4, 0xa7, 0x6b, 0x84, 0xe6, 0x4c, 0xd7, 0x08, 0xc3, 0x71, 0x91, 0xb1, 0xeb, 0xa8, 0x10,
+	0x0b, 0x2e, 0x3d, 0xf2, 0x5d, 0xd4, 0xc6, 0x1d, 0x17, 0xb5, 0x21, 0x67, 0xea, 0x35, 0x1b, 0x1c,
+	0x6e, 0x21, 0x2b, 0xe8, 0xb1, 0x3f, 0x62, 0x5c, 0xcc, 0x99, 0x7a, 0xee, 0x76, 0x48, 0x49, 0xd4,
+	0x40, 0x5f, 0x46, 0x94, 0x44, 0x0d, 0x21, 0x25, 0x51, 0x83, 0xe3, 0x6c, 0xcf, 0x30, 0x97, 0x7a,
+	0xde, 0x6e, 0x87, 0xbe, 0xb3, 0xbb, 0x0c, 0xc7, 0xd9, 0x5d, 0xb0, 0x33, 0xe5, 0x1b, 0x8c, 0x9e,
+	0x84, 0xa6, 0xfc, 0xfd, 0x5c, 0x0e, 0x85, 0x90, 0x11, 0xde, 0xf4, 0xb6, 0x2f, 0xe7, 0xf7, 0x76,
+	0x6a, 0x74, 0x48, 0x92, 0xb6, 0x11, 0x86, 0x78, 0x97, 0xee, 0x34, 0x1b, 0x3b, 0x71, 0x3e, 0xaa,
+	0xac, 0xac, 0xbf, 0x82, 0xa2, 0xaf, 0xfc, 0x66, 0xd3, 0x82, 0x9d, 0x66, 0xd3, 0x42, 0x9c, 0x97,
+	0x74, 0x61, 0x7f, 0xbf, 0xdb, 0x39, 0xf5, 0xda, 0x7f, 0x49, 0x3e, 0xea, 0xbc, 0x24, 0x1f, 0x70,
+	0x4a, 0x5c, 0x00, 0xe7, 0x55, 0xa5, 0xe4, 0x2d, 0xd0, 0xef, 0xfd, 0x12, 0x5b, 0xb0, 0x53, 0x62,
+	0x0b, 0x09, 0x95, 0x38, 0x3f, 0x42, 0x41, 0x7f, 0x88, 0x94, 0x68, 0xd0, 0x50, 0x89, 0x06, 0x18,
+	0xad, 0xe2, 0xc7, 0x57, 0x72, 0x7c, 0x37, 0x5a, 0xfb, 0xb2, 0x32, 0x91, 0x63, 0x28, 0xae, 0x56,
+	0xcd, 0x3f, 0x96, 0x9f, 0xff, 0x1b, 0x00, 0x9a, 0x79, 0x7f, 0x63, 0x11, 0x0d, 0x00, 0x00,
 }
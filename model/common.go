@@ -23,8 +23,111 @@ const (
 	EV_TYPE_TRANSACTION_CONTROL = "evTransactionControl"
 )
 
+// Increment DatabaseSchemaVersion when the tables below or in the other
+// model files change. When a database has another schema version, the
+// tables filled from events are rebuilt from the first block. The local
+// tables batch and blindreviewsecret are kept, so changes to them should
+// be upgrades of existing tables.
+const DatabaseSchemaVersion = 10
+
+// The metadata table has a single row that holds the last block
+// of which all events have been applied to the database.
+var TableCreateMetadata = `
+CREATE TABLE metadata (
+	id varchar primary key not null,
+	lastblockid varchar not null
+)`
+
+// Every transaction of which the events were applied is registered
+// here, so that events of a partially applied block can be replayed.
+var TableCreateAppliedTransaction = `
+CREATE TABLE appliedtransaction (
+	transactionid varchar primary key not null,
+	blockid varchar not null
+)`
+
+// The applied blocks form the branch of the blockchain that is
+// reflected in the database. Field seq is the height of the block.
+var TableCreateAppliedBlock = `
+CREATE TABLE appliedblock (
+	blockid varchar primary key not null,
+	previousblockid varchar not null,
+	seq integer not null
+)`
+
+// The undo log holds the SQL statements that revert the changes
+// made while applying a block. Triggers fill this table.
+var TableCreateUndoLog = `
+CREATE TABLE undolog (
+	seq integer primary key autoincrement,
+	blockid varchar not null default '',
+	statement varchar not null
+)`
+
+// The batches submitted from this computer. This table is not filled
+// from events, so it is kept when the database is rebuilt. Field
+// targetids holds the ids of the objects the commands work on,
+// separated by spaces.
+var TableCreateBatch = `
+CREATE TABLE IF NOT EXISTS batch (
+	seq integer primary key autoincrement,
+	batchid varchar not null,
+	signer varchar not null,
+	commandtype varchar not null,
+	targetids varchar not null,
+	submittime integer not null,
+	status varchar not null,
+	statusmessage varchar not null,
+	signerkey varchar not null default ''
+)`
+
+// The secrets of the blind reviews written on this computer. They are
+// needed to reveal a review. This table is not filled from events.
+var TableCreateBlindReviewSecret = `
+CREATE TABLE IF NOT EXISTS blindreviewsecret (
+	reviewid varchar not null,
+	reviewerid varchar not null,
+	salt varchar not null,
+	authorization varchar not null,
+	PRIMARY KEY (reviewid)
+)`
+
 const FamilyName = "alexandria"
-const FamilyVersion = "1.0"
+
+// A transaction is applied with the rules of the family version in its
+// header, also after the rules have changed. Every incompatible change
+// of the rules therefore gets a new family version. New transactions
+// get FamilyVersion, unless the client is configured otherwise.
+const (
+	FamilyVersion1 = "1.0"
+	FamilyVersion2 = "2.0"
+	FamilyVersion  = FamilyVersion2
+)
+
+var FamilyVersions = []string{FamilyVersion1, FamilyVersion2}
+
+func IsSupportedFamilyVersion(familyVersion string) bool {
+	return getFamilyVersionIndex(familyVersion) >= 0
+}
+
+// IsFamilyVersionBelow tells whether familyVersion is older than minimum.
+// The empty minimum accepts every family version. FamilyVersions is
+// ordered from old to new.
+func IsFamilyVersionBelow(familyVersion, minimum string) bool {
+	if minimum == "" {
+		return false
+	}
+	return getFamilyVersionIndex(familyVersion) < getFamilyVersionIndex(minimum)
+}
+
+func getFamilyVersionIndex(familyVersion string) int {
+	for i, v := range FamilyVersions {
+		if v == familyVersion {
+			return i
+		}
+	}
+	return -1
+}
 
 const AlexandriaPrefix = FamilyName + "/"
 
@@ -57,6 +160,11 @@ func getAddressPrefixFromAddress(address string) string {
 	return address[6:8]
 }
 
+// CreateSalt returns a random string to salt a commitment with.
+func CreateSalt() string {
+	return hexdigestOfUuid(uuid.New())
+}
+
 func HashBytes(bytes []byte) string {
 	hasher := sha512.New()
 	hasher.Write(bytes)
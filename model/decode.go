@@ -0,0 +1,75 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"strings"
+)
+
+// NewStateMessage returns an empty message of the type that is stored
+// at the address. The type follows from the two digits after the
+// namespace.
+func NewStateMessage(address string) (proto.Message, error) {
+	if len(address) != expectedAddressLength || !strings.HasPrefix(address, Namespace) {
+		return nil, errors.New("Not an Iskendria address: " + address)
+	}
+	switch {
+	case address == GetSettingsAddress():
+		return &StateSettings{}, nil
+	case IsPersonAddress(address):
+		return &StatePerson{}, nil
+	case IsManuscriptAddress(address):
+		return &StateManuscript{}, nil
+	case IsManuscriptThreadAddress(address):
+		return &StateManuscriptThread{}, nil
+	case IsJournalAddress(address):
+		return &StateJournal{}, nil
+	case IsVolumeAddress(address):
+		return &StateVolume{}, nil
+	case IsReviewAddress(address):
+		return &StateReview{}, nil
+	case IsReviewInvitationAddress(address):
+		return &StateReviewInvitation{}, nil
+	case IsProposalAddress(address):
+		return &StateProposal{}, nil
+	}
+	return nil, errors.New("Address has unknown type code: " + address)
+}
+
+// UnmarshalState decodes the data that is stored at the address.
+func UnmarshalState(address string, data []byte) (proto.Message, error) {
+	result, err := NewStateMessage(address)
+	if err != nil {
+		return nil, err
+	}
+	if err = proto.Unmarshal(data, result); err != nil {
+		return nil, errors.New("Could not unmarshal state of address " + address + ": " + err.Error())
+	}
+	return result, nil
+}
+
+// UnmarshalBase64Payload decodes a transaction payload as shown by the
+// REST API.
+func UnmarshalBase64Payload(encoded string) (*Command, error) {
+	payload, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.New("Payload is not valid base64: " + err.Error())
+	}
+	result := &Command{}
+	if err = proto.Unmarshal(payload, result); err != nil {
+		return nil, errors.New("Could not unmarshal payload: " + err.Error())
+	}
+	return result, nil
+}
+
+// ToJson renders a message as indented JSON, using the field names
+// of the .proto files.
+func ToJson(message proto.Message) (string, error) {
+	marshaler := &jsonpb.Marshaler{
+		OrigName: true,
+		Indent:   "  ",
+	}
+	return marshaler.MarshalToString(message)
+}
@@ -12,7 +12,9 @@ CREATE TABLE journal (
     modifiedon integer not null,
     title string not null,
     issigned bool not null,
-    descriptionhash string not null
+    descriptionhash string not null,
+    reviewbyinvitationonly bool not null,
+    journalstate VARCHAR not null
 )
 `
 
@@ -36,12 +38,14 @@ const (
 )
 
 const (
-	EV_KEY_JOURNAL_ID               = "journalId"
-	EV_KEY_JOURNAL_TITLE            = "title"
-	EV_KEY_JOURNAL_IS_SIGNED        = "isSigned"
-	EV_KEY_JOURNAL_DESCRIPTION_HASH = "descriptionHash"
-	EV_KEY_EDITOR_ID                = "personId"
-	EV_KEY_EDITOR_STATE             = "editorState"
+	EV_KEY_JOURNAL_ID                        = "journalId"
+	EV_KEY_JOURNAL_TITLE                     = "title"
+	EV_KEY_JOURNAL_IS_SIGNED                 = "isSigned"
+	EV_KEY_JOURNAL_DESCRIPTION_HASH          = "descriptionHash"
+	EV_KEY_JOURNAL_REVIEW_BY_INVITATION_ONLY = "reviewByInvitationOnly"
+	EV_KEY_JOURNAL_STATE                     = "journalState"
+	EV_KEY_EDITOR_ID                         = "personId"
+	EV_KEY_EDITOR_STATE                      = "editorState"
 )
 
 func GetEditorStateString(value EditorState) string {
@@ -54,6 +58,18 @@ func GetEditorStateString(value EditorState) string {
 	panic(fmt.Sprintf("Unknown editor state: %d", value))
 }
 
+func GetJournalStateString(value JournalState) string {
+	switch value {
+	case JournalState_journalOpen:
+		return "OPEN"
+	case JournalState_journalClosed:
+		return "CLOSED"
+	case JournalState_journalArchived:
+		return "ARCHIVED"
+	}
+	panic(fmt.Sprintf("Unknown journal state: %d", value))
+}
+
 var TableCreateVolume = `
 CREATE TABLE volume (
     volumeid VARCHAR not null,
@@ -61,18 +77,38 @@ CREATE TABLE volume (
     journalid VARCHAR not null,
     issue VARCHAR not null,
     logicalpublicationtime integer not null,
+    volumenumber integer not null,
+    issuenumber integer not null,
+    year integer not null,
+    publicationdate VARCHAR not null,
+    issealed bool not null,
     PRIMARY KEY (volumeid),
     FOREIGN KEY (journalid) REFERENCES journal(journalid)
 )
 `
 
-const EV_TYPE_VOLUME_CREATE = "evVolumeCreate"
+const (
+	EV_TYPE_VOLUME_CREATE = "evVolumeCreate"
+	EV_TYPE_VOLUME_UPDATE = "evVolumeUpdate"
+	// The page ranges of a volume are also in the manuscript table, so
+	// the database does not need this event.
+	EV_TYPE_VOLUME_PAGE_RANGE = "evVolumePageRange"
+)
 
 const (
 	EV_KEY_VOLUME_ISSUE                    = "issue"
 	EV_KEY_VOLUME_LOGICAL_PUBLICATION_TIME = "logicalPublicationTime"
+	EV_KEY_VOLUME_NUMBER                   = "volumeNumber"
+	EV_KEY_VOLUME_ISSUE_NUMBER             = "issueNumber"
+	EV_KEY_VOLUME_YEAR                     = "year"
+	EV_KEY_VOLUME_PUBLICATION_DATE         = "publicationDate"
+	EV_KEY_VOLUME_IS_SEALED                = "isSealed"
 )
 
+// PublicationDateLayout is the layout of the publication date of a
+// volume, see package time.
+const PublicationDateLayout = "2006-01-02"
+
 func CreateVolumeAddress() string {
 	var theUuid uuid.UUID = uuid.New()
 	uuidDigest := hexdigestOfUuid(theUuid)
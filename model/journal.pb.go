@@ -20,6 +20,34 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type JournalState int32
+
+const (
+	JournalState_journalOpen     JournalState = 0
+	JournalState_journalClosed   JournalState = 1
+	JournalState_journalArchived JournalState = 2
+)
+
+var JournalState_name = map[int32]string{
+	0: "journalOpen",
+	1: "journalClosed",
+	2: "journalArchived",
+}
+
+var JournalState_value = map[string]int32{
+	"journalOpen":     0,
+	"journalClosed":   1,
+	"journalArchived": 2,
+}
+
+func (x JournalState) String() string {
+	return proto.EnumName(JournalState_name, int32(x))
+}
+
+func (JournalState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{0}
+}
+
 type EditorState int32
 
 const (
@@ -42,20 +70,22 @@ func (x EditorState) String() string {
 }
 
 func (EditorState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_04fd98cceb1b9191, []int{0}
+	return fileDescriptor_04fd98cceb1b9191, []int{1}
 }
 
 type StateJournal struct {
-	Id                   string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedOn            int64         `protobuf:"varint,2,opt,name=createdOn,proto3" json:"createdOn,omitempty"`
-	ModifiedOn           int64         `protobuf:"varint,3,opt,name=modifiedOn,proto3" json:"modifiedOn,omitempty"`
-	Title                string        `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
-	IsSigned             bool          `protobuf:"varint,5,opt,name=isSigned,proto3" json:"isSigned,omitempty"`
-	DescriptionHash      string        `protobuf:"bytes,6,opt,name=descriptionHash,proto3" json:"descriptionHash,omitempty"`
-	EditorInfo           []*EditorInfo `protobuf:"bytes,7,rep,name=editorInfo,proto3" json:"editorInfo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Id                     string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedOn              int64         `protobuf:"varint,2,opt,name=createdOn,proto3" json:"createdOn,omitempty"`
+	ModifiedOn             int64         `protobuf:"varint,3,opt,name=modifiedOn,proto3" json:"modifiedOn,omitempty"`
+	Title                  string        `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	IsSigned               bool          `protobuf:"varint,5,opt,name=isSigned,proto3" json:"isSigned,omitempty"`
+	DescriptionHash        string        `protobuf:"bytes,6,opt,name=descriptionHash,proto3" json:"descriptionHash,omitempty"`
+	EditorInfo             []*EditorInfo `protobuf:"bytes,7,rep,name=editorInfo,proto3" json:"editorInfo,omitempty"`
+	ReviewByInvitationOnly bool          `protobuf:"varint,8,opt,name=reviewByInvitationOnly,proto3" json:"reviewByInvitationOnly,omitempty"`
+	JournalState           JournalState  `protobuf:"varint,9,opt,name=journalState,proto3,enum=JournalState" json:"journalState,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}      `json:"-"`
+	XXX_unrecognized       []byte        `json:"-"`
+	XXX_sizecache          int32         `json:"-"`
 }
 
 func (m *StateJournal) Reset()         { *m = StateJournal{} }
@@ -132,6 +162,20 @@ func (m *StateJournal) GetEditorInfo() []*EditorInfo {
 	return nil
 }
 
+func (m *StateJournal) GetReviewByInvitationOnly() bool {
+	if m != nil {
+		return m.ReviewByInvitationOnly
+	}
+	return false
+}
+
+func (m *StateJournal) GetJournalState() JournalState {
+	if m != nil {
+		return m.JournalState
+	}
+	return JournalState_journalOpen
+}
+
 type EditorInfo struct {
 	EditorId             string      `protobuf:"bytes,1,opt,name=editorId,proto3" json:"editorId,omitempty"`
 	EditorState          EditorState `protobuf:"varint,2,opt,name=editorState,proto3,enum=EditorState" json:"editorState,omitempty"`
@@ -336,6 +380,100 @@ func (m *CommandJournalUpdateAuthorization) GetMakeSigned() bool {
 	return false
 }
 
+type CommandJournalUpdateReviewPolicy struct {
+	JournalId              string   `protobuf:"bytes,1,opt,name=journalId,proto3" json:"journalId,omitempty"`
+	ReviewByInvitationOnly bool     `protobuf:"varint,2,opt,name=reviewByInvitationOnly,proto3" json:"reviewByInvitationOnly,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *CommandJournalUpdateReviewPolicy) Reset()         { *m = CommandJournalUpdateReviewPolicy{} }
+func (m *CommandJournalUpdateReviewPolicy) String() string { return proto.CompactTextString(m) }
+func (*CommandJournalUpdateReviewPolicy) ProtoMessage()    {}
+func (*CommandJournalUpdateReviewPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{5}
+}
+
+func (m *CommandJournalUpdateReviewPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandJournalUpdateReviewPolicy.Unmarshal(m, b)
+}
+func (m *CommandJournalUpdateReviewPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandJournalUpdateReviewPolicy.Marshal(b, m, deterministic)
+}
+func (m *CommandJournalUpdateReviewPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandJournalUpdateReviewPolicy.Merge(m, src)
+}
+func (m *CommandJournalUpdateReviewPolicy) XXX_Size() int {
+	return xxx_messageInfo_CommandJournalUpdateReviewPolicy.Size(m)
+}
+func (m *CommandJournalUpdateReviewPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandJournalUpdateReviewPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandJournalUpdateReviewPolicy proto.InternalMessageInfo
+
+func (m *CommandJournalUpdateReviewPolicy) GetJournalId() string {
+	if m != nil {
+		return m.JournalId
+	}
+	return ""
+}
+
+func (m *CommandJournalUpdateReviewPolicy) GetReviewByInvitationOnly() bool {
+	if m != nil {
+		return m.ReviewByInvitationOnly
+	}
+	return false
+}
+
+type CommandJournalUpdateState struct {
+	JournalId            string       `protobuf:"bytes,1,opt,name=journalId,proto3" json:"journalId,omitempty"`
+	JournalState         JournalState `protobuf:"varint,2,opt,name=journalState,proto3,enum=JournalState" json:"journalState,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CommandJournalUpdateState) Reset()         { *m = CommandJournalUpdateState{} }
+func (m *CommandJournalUpdateState) String() string { return proto.CompactTextString(m) }
+func (*CommandJournalUpdateState) ProtoMessage()    {}
+func (*CommandJournalUpdateState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{6}
+}
+
+func (m *CommandJournalUpdateState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandJournalUpdateState.Unmarshal(m, b)
+}
+func (m *CommandJournalUpdateState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandJournalUpdateState.Marshal(b, m, deterministic)
+}
+func (m *CommandJournalUpdateState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandJournalUpdateState.Merge(m, src)
+}
+func (m *CommandJournalUpdateState) XXX_Size() int {
+	return xxx_messageInfo_CommandJournalUpdateState.Size(m)
+}
+func (m *CommandJournalUpdateState) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandJournalUpdateState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandJournalUpdateState proto.InternalMessageInfo
+
+func (m *CommandJournalUpdateState) GetJournalId() string {
+	if m != nil {
+		return m.JournalId
+	}
+	return ""
+}
+
+func (m *CommandJournalUpdateState) GetJournalState() JournalState {
+	if m != nil {
+		return m.JournalState
+	}
+	return JournalState_journalOpen
+}
+
 type CommandJournalEditorResign struct {
 	JournalId            string   `protobuf:"bytes,1,opt,name=journalId,proto3" json:"journalId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -347,7 +485,7 @@ func (m *CommandJournalEditorResign) Reset()         { *m = CommandJournalEditor
 func (m *CommandJournalEditorResign) String() string { return proto.CompactTextString(m) }
 func (*CommandJournalEditorResign) ProtoMessage()    {}
 func (*CommandJournalEditorResign) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04fd98cceb1b9191, []int{5}
+	return fileDescriptor_04fd98cceb1b9191, []int{7}
 }
 
 func (m *CommandJournalEditorResign) XXX_Unmarshal(b []byte) error {
@@ -387,7 +525,7 @@ func (m *CommandJournalEditorInvite) Reset()         { *m = CommandJournalEditor
 func (m *CommandJournalEditorInvite) String() string { return proto.CompactTextString(m) }
 func (*CommandJournalEditorInvite) ProtoMessage()    {}
 func (*CommandJournalEditorInvite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04fd98cceb1b9191, []int{6}
+	return fileDescriptor_04fd98cceb1b9191, []int{8}
 }
 
 func (m *CommandJournalEditorInvite) XXX_Unmarshal(b []byte) error {
@@ -433,7 +571,7 @@ func (m *CommandJournalEditorAcceptDuty) Reset()         { *m = CommandJournalEd
 func (m *CommandJournalEditorAcceptDuty) String() string { return proto.CompactTextString(m) }
 func (*CommandJournalEditorAcceptDuty) ProtoMessage()    {}
 func (*CommandJournalEditorAcceptDuty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04fd98cceb1b9191, []int{7}
+	return fileDescriptor_04fd98cceb1b9191, []int{9}
 }
 
 func (m *CommandJournalEditorAcceptDuty) XXX_Unmarshal(b []byte) error {
@@ -462,21 +600,27 @@ func (m *CommandJournalEditorAcceptDuty) GetJournalId() string {
 }
 
 type StateVolume struct {
-	Id                     string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedOn              int64    `protobuf:"varint,2,opt,name=createdOn,proto3" json:"createdOn,omitempty"`
-	JournalId              string   `protobuf:"bytes,3,opt,name=journalId,proto3" json:"journalId,omitempty"`
-	Issue                  string   `protobuf:"bytes,4,opt,name=issue,proto3" json:"issue,omitempty"`
-	LogicalPublicationTime int64    `protobuf:"varint,5,opt,name=logicalPublicationTime,proto3" json:"logicalPublicationTime,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
-	XXX_unrecognized       []byte   `json:"-"`
-	XXX_sizecache          int32    `json:"-"`
+	Id                     string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedOn              int64        `protobuf:"varint,2,opt,name=createdOn,proto3" json:"createdOn,omitempty"`
+	JournalId              string       `protobuf:"bytes,3,opt,name=journalId,proto3" json:"journalId,omitempty"`
+	Issue                  string       `protobuf:"bytes,4,opt,name=issue,proto3" json:"issue,omitempty"`
+	LogicalPublicationTime int64        `protobuf:"varint,5,opt,name=logicalPublicationTime,proto3" json:"logicalPublicationTime,omitempty"`
+	VolumeNumber           int32        `protobuf:"varint,6,opt,name=volumeNumber,proto3" json:"volumeNumber,omitempty"`
+	IssueNumber            int32        `protobuf:"varint,7,opt,name=issueNumber,proto3" json:"issueNumber,omitempty"`
+	Year                   int32        `protobuf:"varint,8,opt,name=year,proto3" json:"year,omitempty"`
+	PublicationDate        string       `protobuf:"bytes,9,opt,name=publicationDate,proto3" json:"publicationDate,omitempty"`
+	IsSealed               bool         `protobuf:"varint,10,opt,name=isSealed,proto3" json:"isSealed,omitempty"`
+	PageRange              []*PageRange `protobuf:"bytes,11,rep,name=pageRange,proto3" json:"pageRange,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}     `json:"-"`
+	XXX_unrecognized       []byte       `json:"-"`
+	XXX_sizecache          int32        `json:"-"`
 }
 
 func (m *StateVolume) Reset()         { *m = StateVolume{} }
 func (m *StateVolume) String() string { return proto.CompactTextString(m) }
 func (*StateVolume) ProtoMessage()    {}
 func (*StateVolume) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04fd98cceb1b9191, []int{8}
+	return fileDescriptor_04fd98cceb1b9191, []int{10}
 }
 
 func (m *StateVolume) XXX_Unmarshal(b []byte) error {
@@ -532,11 +676,112 @@ func (m *StateVolume) GetLogicalPublicationTime() int64 {
 	return 0
 }
 
+func (m *StateVolume) GetVolumeNumber() int32 {
+	if m != nil {
+		return m.VolumeNumber
+	}
+	return 0
+}
+
+func (m *StateVolume) GetIssueNumber() int32 {
+	if m != nil {
+		return m.IssueNumber
+	}
+	return 0
+}
+
+func (m *StateVolume) GetYear() int32 {
+	if m != nil {
+		return m.Year
+	}
+	return 0
+}
+
+func (m *StateVolume) GetPublicationDate() string {
+	if m != nil {
+		return m.PublicationDate
+	}
+	return ""
+}
+
+func (m *StateVolume) GetIsSealed() bool {
+	if m != nil {
+		return m.IsSealed
+	}
+	return false
+}
+
+func (m *StateVolume) GetPageRange() []*PageRange {
+	if m != nil {
+		return m.PageRange
+	}
+	return nil
+}
+
+type PageRange struct {
+	ManuscriptId         string   `protobuf:"bytes,1,opt,name=manuscriptId,proto3" json:"manuscriptId,omitempty"`
+	FirstPage            int32    `protobuf:"varint,2,opt,name=firstPage,proto3" json:"firstPage,omitempty"`
+	LastPage             int32    `protobuf:"varint,3,opt,name=lastPage,proto3" json:"lastPage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PageRange) Reset()         { *m = PageRange{} }
+func (m *PageRange) String() string { return proto.CompactTextString(m) }
+func (*PageRange) ProtoMessage()    {}
+func (*PageRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{11}
+}
+
+func (m *PageRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PageRange.Unmarshal(m, b)
+}
+func (m *PageRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PageRange.Marshal(b, m, deterministic)
+}
+func (m *PageRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PageRange.Merge(m, src)
+}
+func (m *PageRange) XXX_Size() int {
+	return xxx_messageInfo_PageRange.Size(m)
+}
+func (m *PageRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_PageRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PageRange proto.InternalMessageInfo
+
+func (m *PageRange) GetManuscriptId() string {
+	if m != nil {
+		return m.ManuscriptId
+	}
+	return ""
+}
+
+func (m *PageRange) GetFirstPage() int32 {
+	if m != nil {
+		return m.FirstPage
+	}
+	return 0
+}
+
+func (m *PageRange) GetLastPage() int32 {
+	if m != nil {
+		return m.LastPage
+	}
+	return 0
+}
+
 type CommandVolumeCreate struct {
 	VolumeId               string   `protobuf:"bytes,1,opt,name=volumeId,proto3" json:"volumeId,omitempty"`
 	JournalId              string   `protobuf:"bytes,2,opt,name=journalId,proto3" json:"journalId,omitempty"`
 	Issue                  string   `protobuf:"bytes,3,opt,name=issue,proto3" json:"issue,omitempty"`
 	LogicalPublicationTime int64    `protobuf:"varint,4,opt,name=logicalPublicationTime,proto3" json:"logicalPublicationTime,omitempty"`
+	VolumeNumber           int32    `protobuf:"varint,5,opt,name=volumeNumber,proto3" json:"volumeNumber,omitempty"`
+	IssueNumber            int32    `protobuf:"varint,6,opt,name=issueNumber,proto3" json:"issueNumber,omitempty"`
+	Year                   int32    `protobuf:"varint,7,opt,name=year,proto3" json:"year,omitempty"`
+	PublicationDate        string   `protobuf:"bytes,8,opt,name=publicationDate,proto3" json:"publicationDate,omitempty"`
 	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
 	XXX_unrecognized       []byte   `json:"-"`
 	XXX_sizecache          int32    `json:"-"`
@@ -546,7 +791,7 @@ func (m *CommandVolumeCreate) Reset()         { *m = CommandVolumeCreate{} }
 func (m *CommandVolumeCreate) String() string { return proto.CompactTextString(m) }
 func (*CommandVolumeCreate) ProtoMessage()    {}
 func (*CommandVolumeCreate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04fd98cceb1b9191, []int{9}
+	return fileDescriptor_04fd98cceb1b9191, []int{12}
 }
 
 func (m *CommandVolumeCreate) XXX_Unmarshal(b []byte) error {
@@ -595,56 +840,228 @@ func (m *CommandVolumeCreate) GetLogicalPublicationTime() int64 {
 	return 0
 }
 
+func (m *CommandVolumeCreate) GetVolumeNumber() int32 {
+	if m != nil {
+		return m.VolumeNumber
+	}
+	return 0
+}
+
+func (m *CommandVolumeCreate) GetIssueNumber() int32 {
+	if m != nil {
+		return m.IssueNumber
+	}
+	return 0
+}
+
+func (m *CommandVolumeCreate) GetYear() int32 {
+	if m != nil {
+		return m.Year
+	}
+	return 0
+}
+
+func (m *CommandVolumeCreate) GetPublicationDate() string {
+	if m != nil {
+		return m.PublicationDate
+	}
+	return ""
+}
+
+type CommandVolumeUpdate struct {
+	VolumeId              string        `protobuf:"bytes,1,opt,name=volumeId,proto3" json:"volumeId,omitempty"`
+	IssueUpdate           *StringUpdate `protobuf:"bytes,2,opt,name=issueUpdate,proto3" json:"issueUpdate,omitempty"`
+	VolumeNumberUpdate    *IntUpdate    `protobuf:"bytes,3,opt,name=volumeNumberUpdate,proto3" json:"volumeNumberUpdate,omitempty"`
+	IssueNumberUpdate     *IntUpdate    `protobuf:"bytes,4,opt,name=issueNumberUpdate,proto3" json:"issueNumberUpdate,omitempty"`
+	YearUpdate            *IntUpdate    `protobuf:"bytes,5,opt,name=yearUpdate,proto3" json:"yearUpdate,omitempty"`
+	PublicationDateUpdate *StringUpdate `protobuf:"bytes,6,opt,name=publicationDateUpdate,proto3" json:"publicationDateUpdate,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}      `json:"-"`
+	XXX_unrecognized      []byte        `json:"-"`
+	XXX_sizecache         int32         `json:"-"`
+}
+
+func (m *CommandVolumeUpdate) Reset()         { *m = CommandVolumeUpdate{} }
+func (m *CommandVolumeUpdate) String() string { return proto.CompactTextString(m) }
+func (*CommandVolumeUpdate) ProtoMessage()    {}
+func (*CommandVolumeUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{13}
+}
+
+func (m *CommandVolumeUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandVolumeUpdate.Unmarshal(m, b)
+}
+func (m *CommandVolumeUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandVolumeUpdate.Marshal(b, m, deterministic)
+}
+func (m *CommandVolumeUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandVolumeUpdate.Merge(m, src)
+}
+func (m *CommandVolumeUpdate) XXX_Size() int {
+	return xxx_messageInfo_CommandVolumeUpdate.Size(m)
+}
+func (m *CommandVolumeUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandVolumeUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandVolumeUpdate proto.InternalMessageInfo
+
+func (m *CommandVolumeUpdate) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *CommandVolumeUpdate) GetIssueUpdate() *StringUpdate {
+	if m != nil {
+		return m.IssueUpdate
+	}
+	return nil
+}
+
+func (m *CommandVolumeUpdate) GetVolumeNumberUpdate() *IntUpdate {
+	if m != nil {
+		return m.VolumeNumberUpdate
+	}
+	return nil
+}
+
+func (m *CommandVolumeUpdate) GetIssueNumberUpdate() *IntUpdate {
+	if m != nil {
+		return m.IssueNumberUpdate
+	}
+	return nil
+}
+
+func (m *CommandVolumeUpdate) GetYearUpdate() *IntUpdate {
+	if m != nil {
+		return m.YearUpdate
+	}
+	return nil
+}
+
+func (m *CommandVolumeUpdate) GetPublicationDateUpdate() *StringUpdate {
+	if m != nil {
+		return m.PublicationDateUpdate
+	}
+	return nil
+}
+
+type CommandVolumeSeal struct {
+	VolumeId             string   `protobuf:"bytes,1,opt,name=volumeId,proto3" json:"volumeId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandVolumeSeal) Reset()         { *m = CommandVolumeSeal{} }
+func (m *CommandVolumeSeal) String() string { return proto.CompactTextString(m) }
+func (*CommandVolumeSeal) ProtoMessage()    {}
+func (*CommandVolumeSeal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{14}
+}
+
+func (m *CommandVolumeSeal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandVolumeSeal.Unmarshal(m, b)
+}
+func (m *CommandVolumeSeal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandVolumeSeal.Marshal(b, m, deterministic)
+}
+func (m *CommandVolumeSeal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandVolumeSeal.Merge(m, src)
+}
+func (m *CommandVolumeSeal) XXX_Size() int {
+	return xxx_messageInfo_CommandVolumeSeal.Size(m)
+}
+func (m *CommandVolumeSeal) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandVolumeSeal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandVolumeSeal proto.InternalMessageInfo
+
+func (m *CommandVolumeSeal) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterEnum("JournalState", JournalState_name, JournalState_value)
 	proto.RegisterEnum("EditorState", EditorState_name, EditorState_value)
 	proto.RegisterType((*StateJournal)(nil), "StateJournal")
 	proto.RegisterType((*EditorInfo)(nil), "EditorInfo")
 	proto.RegisterType((*CommandJournalCreate)(nil), "CommandJournalCreate")
 	proto.RegisterType((*CommandJournalUpdateProperties)(nil), "CommandJournalUpdateProperties")
 	proto.RegisterType((*CommandJournalUpdateAuthorization)(nil), "CommandJournalUpdateAuthorization")
+	proto.RegisterType((*CommandJournalUpdateReviewPolicy)(nil), "CommandJournalUpdateReviewPolicy")
+	proto.RegisterType((*CommandJournalUpdateState)(nil), "CommandJournalUpdateState")
 	proto.RegisterType((*CommandJournalEditorResign)(nil), "CommandJournalEditorResign")
 	proto.RegisterType((*CommandJournalEditorInvite)(nil), "CommandJournalEditorInvite")
 	proto.RegisterType((*CommandJournalEditorAcceptDuty)(nil), "CommandJournalEditorAcceptDuty")
 	proto.RegisterType((*StateVolume)(nil), "StateVolume")
+	proto.RegisterType((*PageRange)(nil), "PageRange")
 	proto.RegisterType((*CommandVolumeCreate)(nil), "CommandVolumeCreate")
+	proto.RegisterType((*CommandVolumeUpdate)(nil), "CommandVolumeUpdate")
+	proto.RegisterType((*CommandVolumeSeal)(nil), "CommandVolumeSeal")
 }
 
 func init() { proto.RegisterFile("journal.proto", fileDescriptor_04fd98cceb1b9191) }
 
 var fileDescriptor_04fd98cceb1b9191 = []byte{
-	// 533 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xc6, 0x76, 0xd3, 0xa6, 0xe3, 0x34, 0xad, 0x96, 0x82, 0xac, 0x08, 0x55, 0xc1, 0x27, 0x0b,
-	0xa4, 0x20, 0x05, 0xc1, 0x81, 0x03, 0x52, 0x09, 0x95, 0x28, 0x17, 0x2a, 0x07, 0x10, 0xe2, 0xb6,
-	0xf5, 0x6e, 0xd3, 0x01, 0xdb, 0x6b, 0xad, 0xd7, 0x95, 0xe0, 0x5d, 0x38, 0xf3, 0x02, 0xbc, 0x12,
-	0xef, 0x81, 0xbc, 0xeb, 0xd8, 0x4e, 0xe4, 0x62, 0xb8, 0x79, 0xbe, 0x99, 0xd9, 0xf9, 0xe6, 0xe7,
-	0x33, 0x1c, 0x7c, 0x11, 0x85, 0x4c, 0x69, 0x3c, 0xcb, 0xa4, 0x50, 0x62, 0x32, 0x8a, 0x44, 0x92,
-	0x88, 0xd4, 0x58, 0xfe, 0x6f, 0x0b, 0x46, 0x4b, 0x45, 0x15, 0x7f, 0x6b, 0x82, 0xc8, 0x18, 0x6c,
-	0x64, 0x9e, 0x35, 0xb5, 0x82, 0xfd, 0xd0, 0x46, 0x46, 0x1e, 0xc0, 0x7e, 0x24, 0x39, 0x55, 0x9c,
-	0xbd, 0x4b, 0x3d, 0x7b, 0x6a, 0x05, 0x4e, 0xd8, 0x00, 0xe4, 0x04, 0x20, 0x11, 0x0c, 0xaf, 0x50,
-	0xbb, 0x1d, 0xed, 0x6e, 0x21, 0xe4, 0x18, 0x06, 0x0a, 0x55, 0xcc, 0xbd, 0x1d, 0xfd, 0xa0, 0x31,
-	0xc8, 0x04, 0x86, 0x98, 0x2f, 0x71, 0x95, 0x72, 0xe6, 0x0d, 0xa6, 0x56, 0x30, 0x0c, 0x6b, 0x9b,
-	0x04, 0x70, 0xc8, 0x78, 0x1e, 0x49, 0xcc, 0x14, 0x8a, 0xf4, 0x0d, 0xcd, 0xaf, 0xbd, 0x5d, 0x9d,
-	0xbb, 0x0d, 0x93, 0xc7, 0x00, 0x9c, 0xa1, 0x12, 0xf2, 0x3c, 0xbd, 0x12, 0xde, 0xde, 0xd4, 0x09,
-	0xdc, 0xb9, 0x3b, 0x3b, 0xab, 0xa1, 0xb0, 0xe5, 0xf6, 0x3f, 0x01, 0x34, 0x9e, 0x92, 0x40, 0xe5,
-	0x5b, 0xb7, 0x5a, 0xdb, 0x64, 0x06, 0xae, 0xf9, 0xd6, 0x63, 0xd1, 0x2d, 0x8f, 0xe7, 0xa3, 0xea,
-	0x5d, 0x8d, 0x85, 0xed, 0x00, 0x5f, 0xc1, 0xf1, 0x42, 0x24, 0x09, 0x4d, 0x59, 0x35, 0xc2, 0x85,
-	0x9e, 0x4e, 0x39, 0xb8, 0x6a, 0xf0, 0x75, 0x91, 0x06, 0x68, 0x06, 0x63, 0xb7, 0x07, 0xd3, 0xd1,
-	0xbc, 0xd3, 0xd9, 0xbc, 0xff, 0xcb, 0x82, 0x93, 0xcd, 0xb2, 0x1f, 0x32, 0x46, 0x15, 0xbf, 0x90,
-	0x22, 0xe3, 0x52, 0x21, 0xcf, 0x7b, 0x08, 0x3c, 0x01, 0x57, 0xd7, 0x34, 0x69, 0x9a, 0x86, 0x3b,
-	0x3f, 0x98, 0x2d, 0x95, 0xc4, 0x74, 0x65, 0xc0, 0xb0, 0x1d, 0x41, 0x16, 0x70, 0x6f, 0x8b, 0x44,
-	0x95, 0xea, 0x74, 0xa5, 0x76, 0xc7, 0xfa, 0x14, 0x1e, 0x76, 0xb1, 0x3e, 0x2d, 0xd4, 0xb5, 0x90,
-	0xf8, 0x9d, 0x96, 0xe1, 0x3d, 0xc4, 0xcb, 0x93, 0xa3, 0x5f, 0x79, 0x75, 0x3e, 0xb6, 0x3e, 0x9f,
-	0x16, 0xe2, 0xbf, 0x80, 0xc9, 0x66, 0x09, 0xb3, 0xb9, 0x90, 0xe7, 0xb8, 0xea, 0x79, 0xdb, 0x67,
-	0xdd, 0xb9, 0xe7, 0xe9, 0x0d, 0xf6, 0x6e, 0x34, 0x80, 0x43, 0xd4, 0x71, 0xec, 0x6c, 0x7d, 0x5a,
-	0x66, 0xb7, 0xdb, 0xb0, 0xff, 0x72, 0x7b, 0x75, 0xc6, 0x73, 0x1a, 0x45, 0x3c, 0x53, 0xaf, 0x0b,
-	0xf5, 0xad, 0x87, 0xe5, 0x4f, 0x0b, 0x5c, 0x7d, 0x7b, 0x1f, 0x45, 0x5c, 0x24, 0xfc, 0x3f, 0x25,
-	0xbb, 0xf1, 0xb6, 0xd3, 0x71, 0x97, 0x98, 0xe7, 0x45, 0x2d, 0x58, 0x6d, 0x90, 0xe7, 0x70, 0x3f,
-	0x16, 0x2b, 0x8c, 0x68, 0x7c, 0x51, 0x5c, 0xc6, 0x18, 0xe9, 0x3d, 0xbd, 0xc7, 0x84, 0x6b, 0xf9,
-	0x3a, 0xe1, 0x2d, 0x5e, 0xff, 0x87, 0x05, 0x77, 0xab, 0x56, 0x0d, 0xd7, 0x4a, 0x1b, 0x13, 0x18,
-	0xde, 0x68, 0xbb, 0xd1, 0xdf, 0xda, 0xde, 0xe4, 0x67, 0xdf, 0xca, 0xcf, 0xf9, 0x37, 0x7e, 0x3b,
-	0x7f, 0xe3, 0xf7, 0xe8, 0x19, 0xb8, 0x2d, 0x5d, 0x13, 0x02, 0x63, 0xa3, 0xec, 0x52, 0x45, 0x22,
-	0xe7, 0xec, 0xe8, 0x4e, 0x83, 0x99, 0xf5, 0x70, 0x76, 0x64, 0xbd, 0xda, 0xfb, 0x3c, 0x48, 0x04,
-	0xe3, 0xf1, 0xe5, 0xae, 0xfe, 0x89, 0x3e, 0xfd, 0x13, 0x00, 0x00, 0xff, 0xff, 0x0a, 0xff, 0x20,
-	0x9d, 0x63, 0x05, 0x00, 0x00,
+	// 863 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcd, 0x6e, 0xe4, 0x44,
+	0x10, 0xde, 0xb1, 0xe3, 0x64, 0xa6, 0x3c, 0xf9, 0xeb, 0x5d, 0x90, 0x89, 0xd0, 0x6a, 0xf0, 0xc9,
+	0x0a, 0x92, 0x23, 0x82, 0x40, 0x68, 0x0f, 0x48, 0xd9, 0x6c, 0x04, 0xd9, 0x03, 0x89, 0x3a, 0x80,
+	0x10, 0xb7, 0x8e, 0xdd, 0x99, 0x34, 0xd8, 0x6e, 0xcb, 0x6e, 0x07, 0xc2, 0xdb, 0xf0, 0x0e, 0xbc,
+	0x04, 0x37, 0x5e, 0x85, 0x37, 0x40, 0x2e, 0xf7, 0xf8, 0x2f, 0x9e, 0x31, 0xb9, 0xb9, 0xbe, 0xaa,
+	0xea, 0xaa, 0xae, 0xaf, 0xfc, 0xd9, 0xb0, 0xfb, 0x8b, 0x2c, 0xb2, 0x84, 0x45, 0x7e, 0x9a, 0x49,
+	0x25, 0x8f, 0xe6, 0x81, 0x8c, 0x63, 0x99, 0x54, 0x96, 0xfb, 0x8f, 0x01, 0xf3, 0x1b, 0xc5, 0x14,
+	0x7f, 0x5f, 0x05, 0x91, 0x3d, 0x30, 0x44, 0xe8, 0x4c, 0x16, 0x13, 0x6f, 0x46, 0x0d, 0x11, 0x92,
+	0x8f, 0x61, 0x16, 0x64, 0x9c, 0x29, 0x1e, 0x5e, 0x25, 0x8e, 0xb1, 0x98, 0x78, 0x26, 0x6d, 0x00,
+	0xf2, 0x1a, 0x20, 0x96, 0xa1, 0xb8, 0x13, 0xe8, 0x36, 0xd1, 0xdd, 0x42, 0xc8, 0x2b, 0xb0, 0x94,
+	0x50, 0x11, 0x77, 0xb6, 0xf0, 0xc0, 0xca, 0x20, 0x47, 0x30, 0x15, 0xf9, 0x8d, 0x58, 0x26, 0x3c,
+	0x74, 0xac, 0xc5, 0xc4, 0x9b, 0xd2, 0xda, 0x26, 0x1e, 0xec, 0x87, 0x3c, 0x0f, 0x32, 0x91, 0x2a,
+	0x21, 0x93, 0x6f, 0x59, 0x7e, 0xef, 0x6c, 0x63, 0x6e, 0x1f, 0x26, 0x9f, 0x02, 0xf0, 0x50, 0x28,
+	0x99, 0x5d, 0x26, 0x77, 0xd2, 0xd9, 0x59, 0x98, 0x9e, 0x7d, 0x6a, 0xfb, 0x17, 0x35, 0x44, 0x5b,
+	0x6e, 0xf2, 0x25, 0x7c, 0x98, 0xf1, 0x07, 0xc1, 0x7f, 0x7b, 0xfb, 0x78, 0x99, 0x3c, 0x08, 0xc5,
+	0xca, 0x63, 0xae, 0x92, 0xe8, 0xd1, 0x99, 0x62, 0x03, 0x6b, 0xbc, 0xe4, 0x33, 0x98, 0xeb, 0xf1,
+	0xe1, 0x94, 0x9c, 0xd9, 0x62, 0xe2, 0xed, 0x9d, 0xee, 0xfa, 0xef, 0x5b, 0x20, 0xed, 0x84, 0xb8,
+	0x3f, 0x01, 0x34, 0x4d, 0x94, 0x77, 0xd5, 0x6d, 0xac, 0xa6, 0x5a, 0xdb, 0xc4, 0x07, 0xbb, 0x7a,
+	0xae, 0xce, 0x36, 0xf0, 0xec, 0xb9, 0x7f, 0xd1, 0x60, 0xb4, 0x1d, 0xe0, 0x2a, 0x78, 0x75, 0x2e,
+	0xe3, 0x98, 0x25, 0xa1, 0x2e, 0x7f, 0x8e, 0x44, 0x94, 0x1c, 0xe9, 0x0e, 0xea, 0x22, 0x0d, 0xd0,
+	0x70, 0x60, 0xb4, 0x39, 0x18, 0x98, 0xb3, 0x39, 0x38, 0x67, 0xf7, 0xaf, 0x09, 0xbc, 0xee, 0x96,
+	0xfd, 0x21, 0x0d, 0x99, 0xe2, 0xd7, 0x99, 0x4c, 0x79, 0xa6, 0x04, 0xcf, 0x47, 0x1a, 0x38, 0x01,
+	0x1b, 0x6b, 0x56, 0x69, 0xd8, 0x86, 0x7d, 0xba, 0xeb, 0xdf, 0xa8, 0x4c, 0x24, 0xcb, 0x0a, 0xa4,
+	0xed, 0x08, 0x72, 0x0e, 0x1f, 0xf4, 0x9a, 0xd0, 0xa9, 0xe6, 0x50, 0xea, 0x70, 0xac, 0xcb, 0xe0,
+	0x93, 0xa1, 0xae, 0xcf, 0x0a, 0x75, 0x2f, 0x33, 0xf1, 0x07, 0x52, 0x3c, 0xd2, 0x78, 0xb9, 0xdd,
+	0xec, 0x57, 0xae, 0x37, 0xd5, 0xc0, 0x45, 0x69, 0x21, 0xee, 0xef, 0xb0, 0x18, 0x2a, 0x41, 0x71,
+	0x95, 0xae, 0x65, 0x24, 0x82, 0xc7, 0x91, 0x0a, 0xeb, 0xd7, 0xd2, 0xd8, 0xb4, 0x96, 0x6e, 0x04,
+	0x1f, 0x0d, 0x55, 0xc6, 0x35, 0x19, 0x29, 0xd9, 0xdf, 0x68, 0x63, 0x7c, 0xa3, 0xdf, 0xc0, 0x51,
+	0xb7, 0x5a, 0xb5, 0xa1, 0x94, 0xe7, 0x62, 0x39, 0x32, 0x43, 0x37, 0x1c, 0xce, 0xc5, 0xfb, 0x8c,
+	0xb5, 0xea, 0xc1, 0xbe, 0xc0, 0xb8, 0xf0, 0x62, 0xf5, 0x0a, 0x55, 0x3b, 0xdc, 0x87, 0xdd, 0xaf,
+	0xfb, 0x2b, 0x5a, 0x79, 0xce, 0x82, 0x80, 0xa7, 0xea, 0x5d, 0xa1, 0x46, 0x78, 0x70, 0xff, 0x35,
+	0xc0, 0xc6, 0xbb, 0xfe, 0x28, 0xa3, 0x22, 0xe6, 0xcf, 0x54, 0xc1, 0xce, 0xd9, 0xe6, 0xc0, 0xfb,
+	0x27, 0xf2, 0xbc, 0xa8, 0x35, 0x10, 0x8d, 0x92, 0xf9, 0x48, 0x2e, 0x45, 0xc0, 0xa2, 0xeb, 0xe2,
+	0x36, 0x12, 0x01, 0x72, 0xfb, 0xbd, 0x88, 0x39, 0x2a, 0xa2, 0x49, 0xd7, 0x78, 0x89, 0x0b, 0xf3,
+	0x07, 0xec, 0xf1, 0xbb, 0x22, 0xbe, 0xe5, 0x19, 0x8a, 0xa3, 0x45, 0x3b, 0x18, 0x59, 0x80, 0x8d,
+	0x45, 0x74, 0xc8, 0x0e, 0x86, 0xb4, 0x21, 0x42, 0x60, 0xeb, 0x91, 0xb3, 0x0c, 0xc5, 0xcf, 0xa2,
+	0xf8, 0x5c, 0x4e, 0x3b, 0x6d, 0x8a, 0xbd, 0x5b, 0xa9, 0xdd, 0x8c, 0xf6, 0x61, 0xad, 0xdf, 0x9c,
+	0x45, 0x3c, 0x74, 0xa0, 0xd6, 0x6f, 0xb4, 0x89, 0x07, 0xb3, 0x94, 0x2d, 0x39, 0x65, 0xc9, 0x92,
+	0x3b, 0x36, 0x8a, 0x32, 0xf8, 0xd7, 0x2b, 0x84, 0x36, 0x4e, 0x57, 0xc0, 0xac, 0xc6, 0xcb, 0x6b,
+	0xc5, 0x2c, 0x29, 0xaa, 0x17, 0xb9, 0x66, 0xa8, 0x83, 0x95, 0x63, 0xbe, 0x13, 0x59, 0xae, 0xca,
+	0x2c, 0x24, 0xc1, 0xa2, 0x0d, 0x50, 0x36, 0x15, 0x31, 0xed, 0x34, 0xd1, 0x59, 0xdb, 0xee, 0x9f,
+	0x06, 0xbc, 0xd4, 0xfb, 0x51, 0x11, 0xac, 0x85, 0xf3, 0x08, 0xa6, 0xd5, 0xe0, 0x1a, 0x71, 0x5e,
+	0xd9, 0x5d, 0x52, 0x8d, 0xb5, 0xa4, 0x9a, 0xff, 0x8f, 0xd4, 0xad, 0x67, 0x91, 0x6a, 0x8d, 0x93,
+	0xba, 0xbd, 0x9e, 0xd4, 0x9d, 0xcd, 0xa4, 0x4e, 0x07, 0x49, 0x75, 0xff, 0xee, 0xcf, 0x48, 0x8b,
+	0xf1, 0xa6, 0x19, 0x9d, 0xe8, 0x9e, 0x36, 0x2a, 0x7b, 0x2b, 0x82, 0xbc, 0x01, 0xd2, 0xbe, 0x54,
+	0x47, 0xd6, 0xc1, 0xbf, 0x4c, 0x94, 0x4e, 0x1a, 0x88, 0x22, 0x5f, 0xc1, 0x61, 0xeb, 0xb6, 0x3a,
+	0x75, 0xeb, 0x49, 0xea, 0xd3, 0x20, 0x72, 0x0c, 0x50, 0x0e, 0x43, 0xa7, 0x58, 0x4f, 0x52, 0x5a,
+	0xde, 0xf2, 0xdb, 0xd3, 0x9b, 0x8c, 0x4e, 0xdb, 0x1e, 0xfc, 0xf6, 0x0c, 0xc6, 0xba, 0x27, 0x70,
+	0xd8, 0x19, 0x65, 0xf9, 0x6e, 0x6c, 0x1a, 0xe4, 0xf1, 0x37, 0x30, 0x6f, 0xeb, 0x2f, 0xd9, 0x07,
+	0x5b, 0xef, 0xda, 0x55, 0xca, 0x93, 0x83, 0x17, 0xe4, 0xb0, 0xfe, 0x8d, 0x3b, 0x8f, 0x64, 0xce,
+	0xc3, 0x83, 0x09, 0x79, 0x09, 0xfb, 0x1a, 0x3a, 0xcb, 0x82, 0x7b, 0xf1, 0xc0, 0xc3, 0x03, 0xe3,
+	0xf8, 0x0b, 0xb0, 0x5b, 0xbf, 0x0f, 0x84, 0xc0, 0x5e, 0xf5, 0x03, 0x51, 0x7e, 0xac, 0x31, 0xef,
+	0x45, 0x83, 0x55, 0xea, 0x58, 0x9e, 0xf5, 0x76, 0xe7, 0x67, 0x2b, 0x96, 0x21, 0x8f, 0x6e, 0xb7,
+	0xf1, 0xb7, 0xf0, 0xf3, 0xff, 0x06, 0x00, 0x17, 0x47, 0x8d, 0xa4, 0x35, 0x0a, 0x00, 0x00,
 }
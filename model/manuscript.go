@@ -15,6 +15,7 @@ CREATE TABLE manuscript (
     volumeid VARCHAR not null,
     firstpage VARCHAR not null,
     lastpage VARCHAR not null,
+    withdrawalmsg VARCHAR not null,
     isreviewable bool not null
 )
 `
@@ -44,21 +45,49 @@ CREATE TABLE review (
     hash VARCHAR not null,
     judgement VARCHAR not null,
     isusedbyeditor bool not null,
+    invitationid VARCHAR not null,
+    isblind bool not null,
+    previousreviewid VARCHAR not null,
+    nextreviewid VARCHAR not null,
     PRIMARY KEY (id),
     FOREIGN KEY (manuscriptid) REFERENCES manuscript(id),
     FOREIGN KEY (reviewauthorid) REFERENCES person(id)
 )
 `
 
+var TableCreateReviewInvitation = `
+CREATE TABLE reviewinvitation (
+    id VARCHAR not null,
+    createdon integer not null,
+    modifiedon integer not null,
+    manuscriptid VARCHAR not null,
+    threadid VARCHAR not null,
+    journalid VARCHAR not null,
+    reviewerid VARCHAR not null,
+    editorid VARCHAR not null,
+    invitationstate VARCHAR not null,
+    PRIMARY KEY (id),
+    FOREIGN KEY (manuscriptid) REFERENCES manuscript(id),
+    FOREIGN KEY (journalid) REFERENCES journal(journalid),
+    FOREIGN KEY (reviewerid) REFERENCES person(id),
+    FOREIGN KEY (editorid) REFERENCES person(id)
+)
+`
+
 const (
-	EV_TYPE_MANUSCRIPT_CREATE            = "evManuscriptCreate"
-	EV_TYPE_MANUSCRIPT_UPDATE            = "evManuscriptUpdate"
-	EV_TYPE_MANUSCRIPT_MODIFICATION_TIME = "evManuscriptModificationTime"
-	EV_TYPE_AUTHOR_CREATE                = "evAuthorCreate"
-	EV_TYPE_AUTHOR_UPDATE                = "evAuthorUpdate"
-	EV_TYPE_MANUSCRIPT_THREAD_UPDATE     = "evManuscriptThreadUpdate"
-	EV_TYPE_REVIEW_CREATE                = "evTypeReviewCreate"
-	EV_TYPE_REVIEW_USE_BY_EDITOR         = "evTypeReviewUpdate"
+	EV_TYPE_MANUSCRIPT_CREATE                   = "evManuscriptCreate"
+	EV_TYPE_MANUSCRIPT_UPDATE                   = "evManuscriptUpdate"
+	EV_TYPE_MANUSCRIPT_MODIFICATION_TIME        = "evManuscriptModificationTime"
+	EV_TYPE_AUTHOR_CREATE                       = "evAuthorCreate"
+	EV_TYPE_AUTHOR_UPDATE                       = "evAuthorUpdate"
+	EV_TYPE_MANUSCRIPT_THREAD_UPDATE            = "evManuscriptThreadUpdate"
+	EV_TYPE_REVIEW_CREATE                       = "evTypeReviewCreate"
+	EV_TYPE_REVIEW_USE_BY_EDITOR                = "evTypeReviewUpdate"
+	EV_TYPE_REVIEW_REVEAL                       = "evTypeReviewReveal"
+	EV_TYPE_REVIEW_SUPERSEDE                    = "evTypeReviewSupersede"
+	EV_TYPE_REVIEW_INVITATION_CREATE            = "evReviewInvitationCreate"
+	EV_TYPE_REVIEW_INVITATION_UPDATE            = "evReviewInvitationUpdate"
+	EV_TYPE_REVIEW_INVITATION_MODIFICATION_TIME = "evReviewInvitationModificationTime"
 )
 
 const (
@@ -71,6 +100,7 @@ const (
 	EV_KEY_VOLUME_ID                 = "volumeId"
 	EV_KEY_MANUSCRIPT_FIRST_PAGE     = "firstPage"
 	EV_KEY_MANUSCRIPT_LAST_PAGE      = "lastPage"
+	EV_KEY_MANUSCRIPT_WITHDRAWAL_MSG = "withdrawalMsg"
 )
 
 const (
@@ -81,9 +111,19 @@ const (
 )
 
 const (
-	EV_KEY_REVIEW_AUTHOR_ID = "reviewAuthorId"
-	EV_KEY_REVIEW_HASH      = "hash"
-	EV_KEY_REVIEW_JUDGEMENT = "judgement"
+	EV_KEY_REVIEW_AUTHOR_ID     = "reviewAuthorId"
+	EV_KEY_REVIEW_HASH          = "hash"
+	EV_KEY_REVIEW_JUDGEMENT     = "judgement"
+	EV_KEY_REVIEW_INVITATION_ID = "invitationId"
+	EV_KEY_REVIEW_IS_BLIND      = "isBlind"
+	EV_KEY_REVIEW_PREVIOUS_ID   = "previousReviewId"
+	EV_KEY_REVIEW_NEXT_ID       = "nextReviewId"
+)
+
+const (
+	EV_KEY_REVIEW_INVITATION_REVIEWER_ID = "reviewerId"
+	EV_KEY_REVIEW_INVITATION_EDITOR_ID   = "editorId"
+	EV_KEY_REVIEW_INVITATION_STATE       = "invitationState"
 )
 
 func GetManuscriptStatusString(status ManuscriptStatus) string {
@@ -100,6 +140,8 @@ func GetManuscriptStatusString(status ManuscriptStatus) string {
 		return "PUBLISHED"
 	case ManuscriptStatus_assigned:
 		return "ASSIGNED"
+	case ManuscriptStatus_withdrawn:
+		return "WITHDRAWN"
 	default:
 		panic("Invalud manuscript status")
 	}
@@ -113,6 +155,7 @@ func GetManuscriptStatusCode(s string) ManuscriptStatus {
 		ManuscriptStatus_rejected,
 		ManuscriptStatus_published,
 		ManuscriptStatus_assigned,
+		ManuscriptStatus_withdrawn,
 	}
 	for _, status := range possibleResults {
 		if GetManuscriptStatusString(status) == s {
@@ -143,3 +186,35 @@ func GetJudgementString(judgement Judgement) string {
 		panic("Invalid review judgement")
 	}
 }
+
+func GetReviewInvitationStateString(state ReviewInvitationState) string {
+	switch state {
+	case ReviewInvitationState_invitationOpen:
+		return "OPEN"
+	case ReviewInvitationState_invitationAccepted:
+		return "ACCEPTED"
+	case ReviewInvitationState_invitationDeclined:
+		return "DECLINED"
+	default:
+		panic("Invalid review invitation state")
+	}
+}
+
+// GetReviewerCommitment returns the commitment to the author of a blind
+// review. The salt prevents others from finding the author by trying
+// all person ids.
+func GetReviewerCommitment(reviewerId, salt string) string {
+	return HashBytes([]byte(reviewerId + ":" + salt))
+}
+
+// GetReviewAuthorizationMessage returns the message that the author of
+// a blind review signs with their own key, to authorize the key that
+// signs the review. The blind review holds the hash of that signature,
+// the sealed proof, which is checked when the author reveals the review.
+func GetReviewAuthorizationMessage(reviewId, reviewKey string) []byte {
+	return []byte(reviewId + ":" + reviewKey)
+}
+
+func GetReviewSealedProof(authorization string) string {
+	return HashBytes([]byte(authorization))
+}
@@ -29,6 +29,7 @@ const (
 	ManuscriptStatus_rejected   ManuscriptStatus = 3
 	ManuscriptStatus_published  ManuscriptStatus = 4
 	ManuscriptStatus_assigned   ManuscriptStatus = 5
+	ManuscriptStatus_withdrawn  ManuscriptStatus = 6
 )
 
 var ManuscriptStatus_name = map[int32]string{
@@ -38,6 +39,7 @@ var ManuscriptStatus_name = map[int32]string{
 	3: "rejected",
 	4: "published",
 	5: "assigned",
+	6: "withdrawn",
 }
 
 var ManuscriptStatus_value = map[string]int32{
@@ -47,6 +49,7 @@ var ManuscriptStatus_value = map[string]int32{
 	"rejected":   3,
 	"published":  4,
 	"assigned":   5,
+	"withdrawn":  6,
 }
 
 func (x ManuscriptStatus) String() string {
@@ -57,6 +60,34 @@ func (ManuscriptStatus) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_fb127795525a7311, []int{0}
 }
 
+type ReviewInvitationState int32
+
+const (
+	ReviewInvitationState_invitationOpen     ReviewInvitationState = 0
+	ReviewInvitationState_invitationAccepted ReviewInvitationState = 1
+	ReviewInvitationState_invitationDeclined ReviewInvitationState = 2
+)
+
+var ReviewInvitationState_name = map[int32]string{
+	0: "invitationOpen",
+	1: "invitationAccepted",
+	2: "invitationDeclined",
+}
+
+var ReviewInvitationState_value = map[string]int32{
+	"invitationOpen":     0,
+	"invitationAccepted": 1,
+	"invitationDeclined": 2,
+}
+
+func (x ReviewInvitationState) String() string {
+	return proto.EnumName(ReviewInvitationState_name, int32(x))
+}
+
+func (ReviewInvitationState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fb127795525a7311, []int{1}
+}
+
 type ManuscriptJudgement int32
 
 const (
@@ -79,7 +110,7 @@ func (x ManuscriptJudgement) String() string {
 }
 
 func (ManuscriptJudgement) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_fb127795525a7311, []int{1}
+	return fileDescriptor_fb127795525a7311, []int{2}
 }
 
 type StateManuscript struct {
@@ -97,6 +128,7 @@ type StateManuscript struct {
 	VolumeId             string           `protobuf:"bytes,12,opt,name=volumeId,proto3" json:"volumeId,omitempty"`
 	FirstPage            string           `protobuf:"bytes,13,opt,name=firstPage,proto3" json:"firstPage,omitempty"`
 	LastPage             string           `protobuf:"bytes,14,opt,name=lastPage,proto3" json:"lastPage,omitempty"`
+	WithdrawalMsg        string           `protobuf:"bytes,15,opt,name=withdrawalMsg,proto3" json:"withdrawalMsg,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -225,6 +257,13 @@ func (m *StateManuscript) GetLastPage() string {
 	return ""
 }
 
+func (m *StateManuscript) GetWithdrawalMsg() string {
+	if m != nil {
+		return m.WithdrawalMsg
+	}
+	return ""
+}
+
 type Author struct {
 	AuthorId             string   `protobuf:"bytes,1,opt,name=authorId,proto3" json:"authorId,omitempty"`
 	DidSign              bool     `protobuf:"varint,2,opt,name=didSign,proto3" json:"didSign,omitempty"`
@@ -343,6 +382,13 @@ type StateReview struct {
 	Hash                 string    `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
 	Judgement            Judgement `protobuf:"varint,6,opt,name=judgement,proto3,enum=Judgement" json:"judgement,omitempty"`
 	IsUsedByEditor       bool      `protobuf:"varint,7,opt,name=isUsedByEditor,proto3" json:"isUsedByEditor,omitempty"`
+	InvitationId         string    `protobuf:"bytes,8,opt,name=invitationId,proto3" json:"invitationId,omitempty"`
+	IsBlind              bool      `protobuf:"varint,9,opt,name=isBlind,proto3" json:"isBlind,omitempty"`
+	ReviewerCommitment   string    `protobuf:"bytes,10,opt,name=reviewerCommitment,proto3" json:"reviewerCommitment,omitempty"`
+	ReviewKey            string    `protobuf:"bytes,11,opt,name=reviewKey,proto3" json:"reviewKey,omitempty"`
+	SealedProof          string    `protobuf:"bytes,12,opt,name=sealedProof,proto3" json:"sealedProof,omitempty"`
+	PreviousReviewId     string    `protobuf:"bytes,13,opt,name=previousReviewId,proto3" json:"previousReviewId,omitempty"`
+	NextReviewId         string    `protobuf:"bytes,14,opt,name=nextReviewId,proto3" json:"nextReviewId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -422,6 +468,55 @@ func (m *StateReview) GetIsUsedByEditor() bool {
 	return false
 }
 
+func (m *StateReview) GetInvitationId() string {
+	if m != nil {
+		return m.InvitationId
+	}
+	return ""
+}
+
+func (m *StateReview) GetIsBlind() bool {
+	if m != nil {
+		return m.IsBlind
+	}
+	return false
+}
+
+func (m *StateReview) GetReviewerCommitment() string {
+	if m != nil {
+		return m.ReviewerCommitment
+	}
+	return ""
+}
+
+func (m *StateReview) GetReviewKey() string {
+	if m != nil {
+		return m.ReviewKey
+	}
+	return ""
+}
+
+func (m *StateReview) GetSealedProof() string {
+	if m != nil {
+		return m.SealedProof
+	}
+	return ""
+}
+
+func (m *StateReview) GetPreviousReviewId() string {
+	if m != nil {
+		return m.PreviousReviewId
+	}
+	return ""
+}
+
+func (m *StateReview) GetNextReviewId() string {
+	if m != nil {
+		return m.NextReviewId
+	}
+	return ""
+}
+
 type CommandManuscriptCreate struct {
 	ManuscriptId         string   `protobuf:"bytes,1,opt,name=manuscriptId,proto3" json:"manuscriptId,omitempty"`
 	ManuscriptThreadId   string   `protobuf:"bytes,2,opt,name=manuscriptThreadId,proto3" json:"manuscriptThreadId,omitempty"`
@@ -698,6 +793,61 @@ func (m *CommandManuscriptAllowReview) GetThreadReference() []*ThreadReferenceIt
 	return nil
 }
 
+type CommandManuscriptWithdraw struct {
+	ManuscriptId         string                 `protobuf:"bytes,1,opt,name=manuscriptId,proto3" json:"manuscriptId,omitempty"`
+	CommitMsg            string                 `protobuf:"bytes,2,opt,name=commitMsg,proto3" json:"commitMsg,omitempty"`
+	ThreadReference      []*ThreadReferenceItem `protobuf:"bytes,3,rep,name=threadReference,proto3" json:"threadReference,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *CommandManuscriptWithdraw) Reset()         { *m = CommandManuscriptWithdraw{} }
+func (m *CommandManuscriptWithdraw) String() string { return proto.CompactTextString(m) }
+func (*CommandManuscriptWithdraw) ProtoMessage()    {}
+func (*CommandManuscriptWithdraw) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb127795525a7311, []int{8}
+}
+
+func (m *CommandManuscriptWithdraw) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandManuscriptWithdraw.Unmarshal(m, b)
+}
+func (m *CommandManuscriptWithdraw) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandManuscriptWithdraw.Marshal(b, m, deterministic)
+}
+func (m *CommandManuscriptWithdraw) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandManuscriptWithdraw.Merge(m, src)
+}
+func (m *CommandManuscriptWithdraw) XXX_Size() int {
+	return xxx_messageInfo_CommandManuscriptWithdraw.Size(m)
+}
+func (m *CommandManuscriptWithdraw) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandManuscriptWithdraw.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandManuscriptWithdraw proto.InternalMessageInfo
+
+func (m *CommandManuscriptWithdraw) GetManuscriptId() string {
+	if m != nil {
+		return m.ManuscriptId
+	}
+	return ""
+}
+
+func (m *CommandManuscriptWithdraw) GetCommitMsg() string {
+	if m != nil {
+		return m.CommitMsg
+	}
+	return ""
+}
+
+func (m *CommandManuscriptWithdraw) GetThreadReference() []*ThreadReferenceItem {
+	if m != nil {
+		return m.ThreadReference
+	}
+	return nil
+}
+
 type ThreadReferenceItem struct {
 	ManuscriptId         string           `protobuf:"bytes,1,opt,name=manuscriptId,proto3" json:"manuscriptId,omitempty"`
 	ManuscriptStatus     ManuscriptStatus `protobuf:"varint,2,opt,name=manuscriptStatus,proto3,enum=ManuscriptStatus" json:"manuscriptStatus,omitempty"`
@@ -710,7 +860,7 @@ func (m *ThreadReferenceItem) Reset()         { *m = ThreadReferenceItem{} }
 func (m *ThreadReferenceItem) String() string { return proto.CompactTextString(m) }
 func (*ThreadReferenceItem) ProtoMessage()    {}
 func (*ThreadReferenceItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fb127795525a7311, []int{8}
+	return fileDescriptor_fb127795525a7311, []int{9}
 }
 
 func (m *ThreadReferenceItem) XXX_Unmarshal(b []byte) error {
@@ -750,6 +900,7 @@ type CommandWriteReview struct {
 	ManuscriptId         string    `protobuf:"bytes,2,opt,name=manuscriptId,proto3" json:"manuscriptId,omitempty"`
 	Hash                 string    `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
 	Judgement            Judgement `protobuf:"varint,4,opt,name=judgement,proto3,enum=Judgement" json:"judgement,omitempty"`
+	InvitationId         string    `protobuf:"bytes,5,opt,name=invitationId,proto3" json:"invitationId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -759,7 +910,7 @@ func (m *CommandWriteReview) Reset()         { *m = CommandWriteReview{} }
 func (m *CommandWriteReview) String() string { return proto.CompactTextString(m) }
 func (*CommandWriteReview) ProtoMessage()    {}
 func (*CommandWriteReview) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fb127795525a7311, []int{9}
+	return fileDescriptor_fb127795525a7311, []int{10}
 }
 
 func (m *CommandWriteReview) XXX_Unmarshal(b []byte) error {
@@ -808,6 +959,462 @@ func (m *CommandWriteReview) GetJudgement() Judgement {
 	return Judgement_NEGATIVE
 }
 
+func (m *CommandWriteReview) GetInvitationId() string {
+	if m != nil {
+		return m.InvitationId
+	}
+	return ""
+}
+
+type CommandReviewRevise struct {
+	ReviewId             string    `protobuf:"bytes,1,opt,name=reviewId,proto3" json:"reviewId,omitempty"`
+	PreviousReviewId     string    `protobuf:"bytes,2,opt,name=previousReviewId,proto3" json:"previousReviewId,omitempty"`
+	ManuscriptId         string    `protobuf:"bytes,3,opt,name=manuscriptId,proto3" json:"manuscriptId,omitempty"`
+	Hash                 string    `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Judgement            Judgement `protobuf:"varint,5,opt,name=judgement,proto3,enum=Judgement" json:"judgement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CommandReviewRevise) Reset()         { *m = CommandReviewRevise{} }
+func (m *CommandReviewRevise) String() string { return proto.CompactTextString(m) }
+func (*CommandReviewRevise) ProtoMessage()    {}
+func (*CommandReviewRevise) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb127795525a7311, []int{11}
+}
+
+func (m *CommandReviewRevise) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandReviewRevise.Unmarshal(m, b)
+}
+func (m *CommandReviewRevise) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandReviewRevise.Marshal(b, m, deterministic)
+}
+func (m *CommandReviewRevise) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandReviewRevise.Merge(m, src)
+}
+func (m *CommandReviewRevise) XXX_Size() int {
+	return xxx_messageInfo_CommandReviewRevise.Size(m)
+}
+func (m *CommandReviewRevise) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandReviewRevise.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandReviewRevise proto.InternalMessageInfo
+
+func (m *CommandReviewRevise) GetReviewId() string {
+	if m != nil {
+		return m.ReviewId
+	}
+	return ""
+}
+
+func (m *CommandReviewRevise) GetPreviousReviewId() string {
+	if m != nil {
+		return m.PreviousReviewId
+	}
+	return ""
+}
+
+func (m *CommandReviewRevise) GetManuscriptId() string {
+	if m != nil {
+		return m.ManuscriptId
+	}
+	return ""
+}
+
+func (m *CommandReviewRevise) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *CommandReviewRevise) GetJudgement() Judgement {
+	if m != nil {
+		return m.Judgement
+	}
+	return Judgement_NEGATIVE
+}
+
+type CommandWriteBlindReview struct {
+	ReviewId             string    `protobuf:"bytes,1,opt,name=reviewId,proto3" json:"reviewId,omitempty"`
+	ManuscriptId         string    `protobuf:"bytes,2,opt,name=manuscriptId,proto3" json:"manuscriptId,omitempty"`
+	Hash                 string    `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	Judgement            Judgement `protobuf:"varint,4,opt,name=judgement,proto3,enum=Judgement" json:"judgement,omitempty"`
+	ReviewerCommitment   string    `protobuf:"bytes,5,opt,name=reviewerCommitment,proto3" json:"reviewerCommitment,omitempty"`
+	ReviewKey            string    `protobuf:"bytes,6,opt,name=reviewKey,proto3" json:"reviewKey,omitempty"`
+	SealedProof          string    `protobuf:"bytes,7,opt,name=sealedProof,proto3" json:"sealedProof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CommandWriteBlindReview) Reset()         { *m = CommandWriteBlindReview{} }
+func (m *CommandWriteBlindReview) String() string { return proto.CompactTextString(m) }
+func (*CommandWriteBlindReview) ProtoMessage()    {}
+func (*CommandWriteBlindReview) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb127795525a7311, []int{12}
+}
+
+func (m *CommandWriteBlindReview) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandWriteBlindReview.Unmarshal(m, b)
+}
+func (m *CommandWriteBlindReview) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandWriteBlindReview.Marshal(b, m, deterministic)
+}
+func (m *CommandWriteBlindReview) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandWriteBlindReview.Merge(m, src)
+}
+func (m *CommandWriteBlindReview) XXX_Size() int {
+	return xxx_messageInfo_CommandWriteBlindReview.Size(m)
+}
+func (m *CommandWriteBlindReview) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandWriteBlindReview.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandWriteBlindReview proto.InternalMessageInfo
+
+func (m *CommandWriteBlindReview) GetReviewId() string {
+	if m != nil {
+		return m.ReviewId
+	}
+	return ""
+}
+
+func (m *CommandWriteBlindReview) GetManuscriptId() string {
+	if m != nil {
+		return m.ManuscriptId
+	}
+	return ""
+}
+
+func (m *CommandWriteBlindReview) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *CommandWriteBlindReview) GetJudgement() Judgement {
+	if m != nil {
+		return m.Judgement
+	}
+	return Judgement_NEGATIVE
+}
+
+func (m *CommandWriteBlindReview) GetReviewerCommitment() string {
+	if m != nil {
+		return m.ReviewerCommitment
+	}
+	return ""
+}
+
+func (m *CommandWriteBlindReview) GetReviewKey() string {
+	if m != nil {
+		return m.ReviewKey
+	}
+	return ""
+}
+
+func (m *CommandWriteBlindReview) GetSealedProof() string {
+	if m != nil {
+		return m.SealedProof
+	}
+	return ""
+}
+
+type CommandReviewReveal struct {
+	ReviewId             string   `protobuf:"bytes,1,opt,name=reviewId,proto3" json:"reviewId,omitempty"`
+	Salt                 string   `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	Authorization        string   `protobuf:"bytes,3,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandReviewReveal) Reset()         { *m = CommandReviewReveal{} }
+func (m *CommandReviewReveal) String() string { return proto.CompactTextString(m) }
+func (*CommandReviewReveal) ProtoMessage()    {}
+func (*CommandReviewReveal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb127795525a7311, []int{13}
+}
+
+func (m *CommandReviewReveal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandReviewReveal.Unmarshal(m, b)
+}
+func (m *CommandReviewReveal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandReviewReveal.Marshal(b, m, deterministic)
+}
+func (m *CommandReviewReveal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandReviewReveal.Merge(m, src)
+}
+func (m *CommandReviewReveal) XXX_Size() int {
+	return xxx_messageInfo_CommandReviewReveal.Size(m)
+}
+func (m *CommandReviewReveal) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandReviewReveal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandReviewReveal proto.InternalMessageInfo
+
+func (m *CommandReviewReveal) GetReviewId() string {
+	if m != nil {
+		return m.ReviewId
+	}
+	return ""
+}
+
+func (m *CommandReviewReveal) GetSalt() string {
+	if m != nil {
+		return m.Salt
+	}
+	return ""
+}
+
+func (m *CommandReviewReveal) GetAuthorization() string {
+	if m != nil {
+		return m.Authorization
+	}
+	return ""
+}
+
+type StateReviewInvitation struct {
+	Id                   string                `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedOn            int64                 `protobuf:"varint,2,opt,name=createdOn,proto3" json:"createdOn,omitempty"`
+	ModifiedOn           int64                 `protobuf:"varint,3,opt,name=modifiedOn,proto3" json:"modifiedOn,omitempty"`
+	ManuscriptId         string                `protobuf:"bytes,4,opt,name=manuscriptId,proto3" json:"manuscriptId,omitempty"`
+	ThreadId             string                `protobuf:"bytes,5,opt,name=threadId,proto3" json:"threadId,omitempty"`
+	JournalId            string                `protobuf:"bytes,6,opt,name=journalId,proto3" json:"journalId,omitempty"`
+	ReviewerId           string                `protobuf:"bytes,7,opt,name=reviewerId,proto3" json:"reviewerId,omitempty"`
+	EditorId             string                `protobuf:"bytes,8,opt,name=editorId,proto3" json:"editorId,omitempty"`
+	InvitationState      ReviewInvitationState `protobuf:"varint,9,opt,name=invitationState,proto3,enum=ReviewInvitationState" json:"invitationState,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *StateReviewInvitation) Reset()         { *m = StateReviewInvitation{} }
+func (m *StateReviewInvitation) String() string { return proto.CompactTextString(m) }
+func (*StateReviewInvitation) ProtoMessage()    {}
+func (*StateReviewInvitation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb127795525a7311, []int{14}
+}
+
+func (m *StateReviewInvitation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateReviewInvitation.Unmarshal(m, b)
+}
+func (m *StateReviewInvitation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateReviewInvitation.Marshal(b, m, deterministic)
+}
+func (m *StateReviewInvitation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateReviewInvitation.Merge(m, src)
+}
+func (m *StateReviewInvitation) XXX_Size() int {
+	return xxx_messageInfo_StateReviewInvitation.Size(m)
+}
+func (m *StateReviewInvitation) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateReviewInvitation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateReviewInvitation proto.InternalMessageInfo
+
+func (m *StateReviewInvitation) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *StateReviewInvitation) GetCreatedOn() int64 {
+	if m != nil {
+		return m.CreatedOn
+	}
+	return 0
+}
+
+func (m *StateReviewInvitation) GetModifiedOn() int64 {
+	if m != nil {
+		return m.ModifiedOn
+	}
+	return 0
+}
+
+func (m *StateReviewInvitation) GetManuscriptId() string {
+	if m != nil {
+		return m.ManuscriptId
+	}
+	return ""
+}
+
+func (m *StateReviewInvitation) GetThreadId() string {
+	if m != nil {
+		return m.ThreadId
+	}
+	return ""
+}
+
+func (m *StateReviewInvitation) GetJournalId() string {
+	if m != nil {
+		return m.JournalId
+	}
+	return ""
+}
+
+func (m *StateReviewInvitation) GetReviewerId() string {
+	if m != nil {
+		return m.ReviewerId
+	}
+	return ""
+}
+
+func (m *StateReviewInvitation) GetEditorId() string {
+	if m != nil {
+		return m.EditorId
+	}
+	return ""
+}
+
+func (m *StateReviewInvitation) GetInvitationState() ReviewInvitationState {
+	if m != nil {
+		return m.InvitationState
+	}
+	return ReviewInvitationState_invitationOpen
+}
+
+type CommandReviewerInvite struct {
+	InvitationId         string   `protobuf:"bytes,1,opt,name=invitationId,proto3" json:"invitationId,omitempty"`
+	ManuscriptId         string   `protobuf:"bytes,2,opt,name=manuscriptId,proto3" json:"manuscriptId,omitempty"`
+	ReviewerId           string   `protobuf:"bytes,3,opt,name=reviewerId,proto3" json:"reviewerId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandReviewerInvite) Reset()         { *m = CommandReviewerInvite{} }
+func (m *CommandReviewerInvite) String() string { return proto.CompactTextString(m) }
+func (*CommandReviewerInvite) ProtoMessage()    {}
+func (*CommandReviewerInvite) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb127795525a7311, []int{15}
+}
+
+func (m *CommandReviewerInvite) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandReviewerInvite.Unmarshal(m, b)
+}
+func (m *CommandReviewerInvite) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandReviewerInvite.Marshal(b, m, deterministic)
+}
+func (m *CommandReviewerInvite) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandReviewerInvite.Merge(m, src)
+}
+func (m *CommandReviewerInvite) XXX_Size() int {
+	return xxx_messageInfo_CommandReviewerInvite.Size(m)
+}
+func (m *CommandReviewerInvite) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandReviewerInvite.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandReviewerInvite proto.InternalMessageInfo
+
+func (m *CommandReviewerInvite) GetInvitationId() string {
+	if m != nil {
+		return m.InvitationId
+	}
+	return ""
+}
+
+func (m *CommandReviewerInvite) GetManuscriptId() string {
+	if m != nil {
+		return m.ManuscriptId
+	}
+	return ""
+}
+
+func (m *CommandReviewerInvite) GetReviewerId() string {
+	if m != nil {
+		return m.ReviewerId
+	}
+	return ""
+}
+
+type CommandReviewInvitationAccept struct {
+	InvitationId         string   `protobuf:"bytes,1,opt,name=invitationId,proto3" json:"invitationId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandReviewInvitationAccept) Reset()         { *m = CommandReviewInvitationAccept{} }
+func (m *CommandReviewInvitationAccept) String() string { return proto.CompactTextString(m) }
+func (*CommandReviewInvitationAccept) ProtoMessage()    {}
+func (*CommandReviewInvitationAccept) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb127795525a7311, []int{16}
+}
+
+func (m *CommandReviewInvitationAccept) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandReviewInvitationAccept.Unmarshal(m, b)
+}
+func (m *CommandReviewInvitationAccept) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandReviewInvitationAccept.Marshal(b, m, deterministic)
+}
+func (m *CommandReviewInvitationAccept) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandReviewInvitationAccept.Merge(m, src)
+}
+func (m *CommandReviewInvitationAccept) XXX_Size() int {
+	return xxx_messageInfo_CommandReviewInvitationAccept.Size(m)
+}
+func (m *CommandReviewInvitationAccept) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandReviewInvitationAccept.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandReviewInvitationAccept proto.InternalMessageInfo
+
+func (m *CommandReviewInvitationAccept) GetInvitationId() string {
+	if m != nil {
+		return m.InvitationId
+	}
+	return ""
+}
+
+type CommandReviewInvitationDecline struct {
+	InvitationId         string   `protobuf:"bytes,1,opt,name=invitationId,proto3" json:"invitationId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandReviewInvitationDecline) Reset()         { *m = CommandReviewInvitationDecline{} }
+func (m *CommandReviewInvitationDecline) String() string { return proto.CompactTextString(m) }
+func (*CommandReviewInvitationDecline) ProtoMessage()    {}
+func (*CommandReviewInvitationDecline) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb127795525a7311, []int{17}
+}
+
+func (m *CommandReviewInvitationDecline) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandReviewInvitationDecline.Unmarshal(m, b)
+}
+func (m *CommandReviewInvitationDecline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandReviewInvitationDecline.Marshal(b, m, deterministic)
+}
+func (m *CommandReviewInvitationDecline) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandReviewInvitationDecline.Merge(m, src)
+}
+func (m *CommandReviewInvitationDecline) XXX_Size() int {
+	return xxx_messageInfo_CommandReviewInvitationDecline.Size(m)
+}
+func (m *CommandReviewInvitationDecline) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandReviewInvitationDecline.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandReviewInvitationDecline proto.InternalMessageInfo
+
+func (m *CommandReviewInvitationDecline) GetInvitationId() string {
+	if m != nil {
+		return m.InvitationId
+	}
+	return ""
+}
+
 type CommandManuscriptJudge struct {
 	ManuscriptId         string              `protobuf:"bytes,1,opt,name=manuscriptId,proto3" json:"manuscriptId,omitempty"`
 	ReviewId             []string            `protobuf:"bytes,2,rep,name=reviewId,proto3" json:"reviewId,omitempty"`
@@ -821,7 +1428,7 @@ func (m *CommandManuscriptJudge) Reset()         { *m = CommandManuscriptJudge{}
 func (m *CommandManuscriptJudge) String() string { return proto.CompactTextString(m) }
 func (*CommandManuscriptJudge) ProtoMessage()    {}
 func (*CommandManuscriptJudge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fb127795525a7311, []int{10}
+	return fileDescriptor_fb127795525a7311, []int{18}
 }
 
 func (m *CommandManuscriptJudge) XXX_Unmarshal(b []byte) error {
@@ -877,7 +1484,7 @@ func (m *CommandManuscriptAssign) Reset()         { *m = CommandManuscriptAssign
 func (m *CommandManuscriptAssign) String() string { return proto.CompactTextString(m) }
 func (*CommandManuscriptAssign) ProtoMessage()    {}
 func (*CommandManuscriptAssign) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fb127795525a7311, []int{11}
+	return fileDescriptor_fb127795525a7311, []int{19}
 }
 
 func (m *CommandManuscriptAssign) XXX_Unmarshal(b []byte) error {
@@ -928,6 +1535,7 @@ func (m *CommandManuscriptAssign) GetLastPage() string {
 
 func init() {
 	proto.RegisterEnum("ManuscriptStatus", ManuscriptStatus_name, ManuscriptStatus_value)
+	proto.RegisterEnum("ReviewInvitationState", ReviewInvitationState_name, ReviewInvitationState_value)
 	proto.RegisterEnum("ManuscriptJudgement", ManuscriptJudgement_name, ManuscriptJudgement_value)
 	proto.RegisterType((*StateManuscript)(nil), "StateManuscript")
 	proto.RegisterType((*Author)(nil), "Author")
@@ -937,8 +1545,16 @@ func init() {
 	proto.RegisterType((*CommandManuscriptCreateNewVersion)(nil), "CommandManuscriptCreateNewVersion")
 	proto.RegisterType((*CommandManuscriptAcceptAuthorship)(nil), "CommandManuscriptAcceptAuthorship")
 	proto.RegisterType((*CommandManuscriptAllowReview)(nil), "CommandManuscriptAllowReview")
+	proto.RegisterType((*CommandManuscriptWithdraw)(nil), "CommandManuscriptWithdraw")
 	proto.RegisterType((*ThreadReferenceItem)(nil), "ThreadReferenceItem")
 	proto.RegisterType((*CommandWriteReview)(nil), "CommandWriteReview")
+	proto.RegisterType((*CommandReviewRevise)(nil), "CommandReviewRevise")
+	proto.RegisterType((*CommandWriteBlindReview)(nil), "CommandWriteBlindReview")
+	proto.RegisterType((*CommandReviewReveal)(nil), "CommandReviewReveal")
+	proto.RegisterType((*StateReviewInvitation)(nil), "StateReviewInvitation")
+	proto.RegisterType((*CommandReviewerInvite)(nil), "CommandReviewerInvite")
+	proto.RegisterType((*CommandReviewInvitationAccept)(nil), "CommandReviewInvitationAccept")
+	proto.RegisterType((*CommandReviewInvitationDecline)(nil), "CommandReviewInvitationDecline")
 	proto.RegisterType((*CommandManuscriptJudge)(nil), "CommandManuscriptJudge")
 	proto.RegisterType((*CommandManuscriptAssign)(nil), "CommandManuscriptAssign")
 }
@@ -946,59 +1562,83 @@ func init() {
 func init() { proto.RegisterFile("manuscript.proto", fileDescriptor_fb127795525a7311) }
 
 var fileDescriptor_fb127795525a7311 = []byte{
-	// 850 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xcd, 0x6e, 0xeb, 0x44,
-	0x14, 0xbe, 0xb6, 0xf3, 0xe3, 0x9c, 0xa6, 0xb9, 0xbe, 0x73, 0x73, 0xc1, 0x8a, 0xae, 0x20, 0x58,
-	0x08, 0x85, 0x2e, 0xb2, 0x08, 0x6b, 0x90, 0xd2, 0x8a, 0x45, 0x90, 0x5a, 0x90, 0x5b, 0x40, 0x62,
-	0x85, 0xe3, 0x99, 0xc6, 0x53, 0xd9, 0x9e, 0xc8, 0x33, 0x6e, 0x80, 0x67, 0x60, 0x05, 0x4f, 0xc1,
-	0xb3, 0xf0, 0x2c, 0x88, 0x57, 0x40, 0x9e, 0xf1, 0x7f, 0xdc, 0x2a, 0xdd, 0xb0, 0xcb, 0xf9, 0xce,
-	0x19, 0x9f, 0xbf, 0xef, 0x9c, 0x13, 0xb0, 0x22, 0x2f, 0x4e, 0xb9, 0x9f, 0xd0, 0xbd, 0x58, 0xee,
-	0x13, 0x26, 0xd8, 0x6c, 0xec, 0xb3, 0x28, 0x62, 0xb1, 0x92, 0x9c, 0xbf, 0x0c, 0x78, 0x7d, 0x2b,
-	0x3c, 0x41, 0xae, 0x4b, 0x3b, 0x34, 0x01, 0x9d, 0x62, 0x5b, 0x9b, 0x6b, 0x8b, 0x91, 0xab, 0x53,
-	0x8c, 0xde, 0xc3, 0xc8, 0x4f, 0x88, 0x27, 0x08, 0xfe, 0x36, 0xb6, 0xf5, 0xb9, 0xb6, 0x30, 0xdc,
-	0x0a, 0x40, 0x1f, 0x01, 0x44, 0x0c, 0xd3, 0x7b, 0x2a, 0xd5, 0x86, 0x54, 0xd7, 0x10, 0x84, 0xa0,
-	0x17, 0x78, 0x3c, 0xb0, 0x7b, 0xf2, 0x7b, 0xf2, 0x37, 0x9a, 0x81, 0x29, 0x82, 0x84, 0x78, 0x78,
-	0x83, 0xed, 0xbe, 0xc4, 0x4b, 0x19, 0x7d, 0x0a, 0xe7, 0x8f, 0x24, 0xe1, 0x94, 0xc5, 0x37, 0x69,
-	0xb4, 0x25, 0x89, 0x3d, 0x98, 0x6b, 0x8b, 0xbe, 0xdb, 0x04, 0x65, 0x4c, 0x2c, 0x8a, 0xa8, 0xb8,
-	0xe6, 0x3b, 0x7b, 0x28, 0x3f, 0x51, 0x01, 0x68, 0x0a, 0x7d, 0x41, 0x45, 0x48, 0x6c, 0x53, 0x6a,
-	0x94, 0x80, 0x3e, 0x86, 0x81, 0x97, 0x8a, 0x80, 0x25, 0xf6, 0x68, 0x6e, 0x2c, 0xce, 0x56, 0xc3,
-	0xe5, 0x5a, 0x8a, 0x6e, 0x0e, 0xa3, 0xcf, 0x61, 0xc0, 0x85, 0x27, 0x52, 0x6e, 0xc3, 0x5c, 0x5b,
-	0x4c, 0x56, 0x6f, 0x96, 0x55, 0x55, 0x6e, 0xa5, 0xc2, 0xcd, 0x0d, 0x32, 0xff, 0x0f, 0x2c, 0x4d,
-	0x62, 0x2f, 0xdc, 0x60, 0xfb, 0x4c, 0xf9, 0x2f, 0x81, 0x2c, 0xbf, 0x47, 0x16, 0xa6, 0x11, 0xd9,
-	0x60, 0x7b, 0xac, 0xf2, 0x2b, 0xe4, 0xec, 0xe5, 0x3d, 0x4d, 0xb8, 0xf8, 0xce, 0xdb, 0x11, 0xfb,
-	0x5c, 0xbd, 0x2c, 0x81, 0xec, 0x65, 0xe8, 0xe5, 0xca, 0x89, 0x7a, 0x59, 0xc8, 0xce, 0x16, 0x06,
-	0x2a, 0xe0, 0xcc, 0x4a, 0x85, 0xbc, 0x29, 0xfa, 0x54, 0xca, 0xc8, 0x86, 0x21, 0xa6, 0xf8, 0x96,
-	0xee, 0x54, 0xaf, 0x4c, 0xb7, 0x10, 0x91, 0x03, 0x63, 0x65, 0x95, 0x17, 0xd6, 0x90, 0x85, 0x6d,
-	0x60, 0x0e, 0x83, 0x77, 0x2d, 0x3a, 0xdc, 0xc9, 0xc6, 0x1c, 0x91, 0xc2, 0x81, 0x71, 0x45, 0xad,
-	0x0d, 0xb6, 0xf5, 0xb9, 0xb1, 0x18, 0xb9, 0x0d, 0x2c, 0xb3, 0xa1, 0xdc, 0x25, 0x8f, 0x94, 0x1c,
-	0xbc, 0x6d, 0x48, 0xa4, 0x43, 0xd3, 0x6d, 0x60, 0xce, 0x3f, 0x1a, 0x9c, 0x49, 0x8f, 0x0a, 0x7b,
-	0x21, 0xf9, 0xda, 0x51, 0x18, 0xf2, 0x5d, 0x33, 0x8a, 0xcf, 0x60, 0x92, 0xc8, 0x6f, 0xaf, 0x8b,
-	0x92, 0x29, 0x2a, 0xb6, 0xd0, 0x92, 0xa8, 0xfd, 0x1a, 0x51, 0x17, 0x30, 0x7a, 0x48, 0xf1, 0x8e,
-	0x44, 0x24, 0x16, 0x92, 0x88, 0x93, 0x15, 0x2c, 0xbf, 0x29, 0x10, 0xb7, 0x52, 0x66, 0x5e, 0x28,
-	0xff, 0x9e, 0x13, 0x7c, 0xf9, 0xeb, 0xd7, 0x98, 0x0a, 0x96, 0x48, 0x56, 0x9a, 0x6e, 0x0b, 0x75,
-	0xfe, 0xd5, 0xe0, 0xc3, 0x2b, 0x16, 0x45, 0x5e, 0x8c, 0xab, 0x1a, 0x5f, 0xc9, 0x84, 0x8e, 0xb2,
-	0xd1, 0x3a, 0xb2, 0x59, 0x02, 0x8a, 0x5a, 0xbd, 0x91, 0xd5, 0xcf, 0x2c, 0x3b, 0x34, 0x65, 0x56,
-	0x46, 0x2d, 0xab, 0xc6, 0xf0, 0xf4, 0x9e, 0x1c, 0x9e, 0x7e, 0x7d, 0x78, 0xea, 0x94, 0x1b, 0xc8,
-	0x5e, 0x57, 0x94, 0x6b, 0x0c, 0xc3, 0xb0, 0x35, 0x0c, 0xce, 0xdf, 0x3a, 0x7c, 0xf2, 0x44, 0xc6,
-	0x37, 0xe4, 0xf0, 0x83, 0x1a, 0xeb, 0x93, 0x72, 0x5f, 0xc1, 0x74, 0x9f, 0x35, 0x8d, 0xa5, 0xfc,
-	0xba, 0xc9, 0xbd, 0xcc, 0xb6, 0x53, 0xf7, 0xbf, 0xe4, 0xff, 0x15, 0xbc, 0x56, 0xeb, 0xcb, 0x25,
-	0xf7, 0x24, 0x21, 0xb1, 0x4f, 0xec, 0xa1, 0xdc, 0x30, 0xd3, 0xe5, 0x5d, 0x13, 0xdf, 0x08, 0x12,
-	0xb9, 0x6d, 0x63, 0x74, 0x01, 0x56, 0x40, 0xb9, 0x60, 0x09, 0xf5, 0x4b, 0x8e, 0x9a, 0xd2, 0xc7,
-	0x11, 0xee, 0x04, 0x1d, 0xc5, 0x5c, 0xfb, 0x3e, 0xd9, 0x0b, 0x65, 0xc2, 0x03, 0xba, 0x3f, 0xa9,
-	0x98, 0xd5, 0x36, 0xd4, 0x3b, 0xb7, 0xa1, 0xf3, 0x1b, 0xbc, 0x3f, 0xf6, 0x14, 0x86, 0xec, 0x90,
-	0x4f, 0xea, 0x0c, 0xcc, 0x92, 0x7f, 0xf9, 0x12, 0x2a, 0x59, 0xd7, 0x51, 0x11, 0xfd, 0x05, 0x15,
-	0x71, 0x7e, 0x81, 0xb7, 0x1d, 0x76, 0x27, 0xe5, 0xf5, 0x65, 0xfd, 0xe6, 0xa9, 0xad, 0x2d, 0x09,
-	0xd2, 0xb9, 0xce, 0x8f, 0x4c, 0x9d, 0x3f, 0x34, 0x40, 0x79, 0xda, 0x3f, 0x26, 0xb4, 0x5c, 0x4b,
-	0x33, 0x30, 0xd5, 0xba, 0xa8, 0x92, 0x2d, 0xe4, 0x8e, 0x55, 0x78, 0x1c, 0x55, 0x17, 0x0d, 0x1b,
-	0xcb, 0xa5, 0xf7, 0xcc, 0x72, 0x71, 0x7e, 0xd7, 0xe0, 0x83, 0xa3, 0x5e, 0x48, 0xcb, 0x93, 0x4a,
-	0x52, 0x0f, 0x5e, 0xed, 0xe9, 0x2a, 0xf8, 0x55, 0x3d, 0x08, 0x43, 0x06, 0x31, 0x5d, 0xb6, 0x9c,
-	0xb4, 0xc3, 0xf9, 0xb3, 0x6b, 0x87, 0xad, 0x39, 0xcf, 0x8f, 0xcc, 0x29, 0xf1, 0x94, 0xe7, 0x51,
-	0x7f, 0xee, 0x3c, 0x1a, 0xcf, 0x9d, 0xc7, 0x5e, 0xf3, 0x3c, 0x5e, 0xfc, 0x0c, 0x56, 0xbb, 0xbf,
-	0xc8, 0x84, 0x1e, 0x8d, 0xa9, 0xb0, 0x5e, 0xa1, 0x21, 0x18, 0x31, 0x39, 0x58, 0x1a, 0x9a, 0x00,
-	0x24, 0xe5, 0xf9, 0xb1, 0x74, 0x34, 0xce, 0x8a, 0xf3, 0x40, 0x7c, 0x41, 0xb0, 0x65, 0xa0, 0x73,
-	0x18, 0xed, 0xd3, 0x6d, 0x48, 0x79, 0x40, 0xb0, 0xd5, 0xcb, 0x94, 0x9e, 0xcc, 0x8b, 0x60, 0xab,
-	0x7f, 0x71, 0x05, 0x6f, 0x3b, 0x2a, 0x83, 0xde, 0xc1, 0x9b, 0xb2, 0x36, 0x6e, 0xf1, 0xa9, 0x57,
-	0x0d, 0x58, 0x4d, 0x28, 0xc1, 0x96, 0x76, 0x39, 0xfc, 0xa9, 0x1f, 0x31, 0x4c, 0xc2, 0xed, 0x40,
-	0xfe, 0x03, 0xfb, 0xe2, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x2c, 0x30, 0x11, 0xa3, 0x09,
+	// 1234 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x6f, 0x23, 0x45,
+	0x10, 0xce, 0xcc, 0xf8, 0x59, 0x71, 0x9c, 0xd9, 0xce, 0x63, 0x87, 0x68, 0x09, 0x66, 0x84, 0x90,
+	0xc9, 0xc1, 0x07, 0x73, 0x06, 0x91, 0x64, 0x39, 0x18, 0x94, 0xdd, 0xd5, 0x64, 0x61, 0x25, 0x38,
+	0x4d, 0xdc, 0x9d, 0xb8, 0xc3, 0x3c, 0xac, 0x99, 0x76, 0xbc, 0xbb, 0x17, 0x8e, 0x5c, 0xb8, 0x71,
+	0xe6, 0x6f, 0x70, 0x45, 0x9c, 0x39, 0xf0, 0x43, 0x38, 0xf0, 0x17, 0x50, 0x77, 0xcf, 0xab, 0x67,
+	0xc6, 0x8e, 0x23, 0x21, 0xb4, 0x97, 0xc8, 0xf5, 0x75, 0x4d, 0x77, 0x3d, 0xbe, 0xae, 0xaa, 0x0e,
+	0x98, 0xbe, 0x1b, 0x2c, 0xe2, 0x69, 0x44, 0xe7, 0x6c, 0x34, 0x8f, 0x42, 0x16, 0x1e, 0xf5, 0xa6,
+	0xa1, 0xef, 0x87, 0x81, 0x94, 0xec, 0xbf, 0x0c, 0xd8, 0xbd, 0x64, 0x2e, 0x23, 0x17, 0x99, 0x1e,
+	0xea, 0x83, 0x4e, 0xb1, 0xa5, 0x0d, 0xb4, 0x61, 0xd7, 0xd1, 0x29, 0x46, 0x4f, 0xa0, 0x3b, 0x8d,
+	0x88, 0xcb, 0x08, 0x7e, 0x1e, 0x58, 0xfa, 0x40, 0x1b, 0x1a, 0x4e, 0x0e, 0xa0, 0x63, 0x00, 0x3f,
+	0xc4, 0xf4, 0x9a, 0x8a, 0x65, 0x43, 0x2c, 0x17, 0x10, 0x84, 0xa0, 0x31, 0x73, 0xe3, 0x99, 0xd5,
+	0x10, 0xfb, 0x89, 0xdf, 0xe8, 0x08, 0x3a, 0x6c, 0x16, 0x11, 0x17, 0x4f, 0xb0, 0xd5, 0x14, 0x78,
+	0x26, 0xa3, 0x8f, 0x60, 0xe7, 0x8e, 0x44, 0x31, 0x0d, 0x83, 0x67, 0x0b, 0xff, 0x8a, 0x44, 0x56,
+	0x6b, 0xa0, 0x0d, 0x9b, 0x8e, 0x0a, 0x0a, 0x9b, 0x42, 0xdf, 0xa7, 0xec, 0x22, 0xbe, 0xb1, 0xda,
+	0x62, 0x8b, 0x1c, 0x40, 0xfb, 0xd0, 0x64, 0x94, 0x79, 0xc4, 0xea, 0x88, 0x15, 0x29, 0xa0, 0x0f,
+	0xa0, 0xe5, 0x2e, 0xd8, 0x2c, 0x8c, 0xac, 0xee, 0xc0, 0x18, 0x6e, 0x8f, 0xdb, 0xa3, 0x53, 0x21,
+	0x3a, 0x09, 0x8c, 0x3e, 0x81, 0x56, 0xcc, 0x5c, 0xb6, 0x88, 0x2d, 0x18, 0x68, 0xc3, 0xfe, 0xf8,
+	0xd1, 0x28, 0x8f, 0xca, 0xa5, 0x58, 0x70, 0x12, 0x05, 0x7e, 0xfe, 0x6d, 0xb8, 0x88, 0x02, 0xd7,
+	0x9b, 0x60, 0x6b, 0x5b, 0x9e, 0x9f, 0x01, 0xdc, 0xbf, 0xbb, 0xd0, 0x5b, 0xf8, 0x64, 0x82, 0xad,
+	0x9e, 0xf4, 0x2f, 0x95, 0xf9, 0x97, 0xd7, 0x34, 0x8a, 0xd9, 0x0b, 0xf7, 0x86, 0x58, 0x3b, 0xf2,
+	0xcb, 0x0c, 0xe0, 0x5f, 0x7a, 0x6e, 0xb2, 0xd8, 0x97, 0x5f, 0xa6, 0x32, 0x8f, 0xcc, 0x92, 0xb2,
+	0x19, 0x8e, 0xdc, 0xa5, 0xeb, 0x71, 0xbf, 0x77, 0x85, 0x82, 0x0a, 0xda, 0x57, 0xd0, 0x92, 0x6e,
+	0xf1, 0xbd, 0xa4, 0x63, 0x93, 0x34, 0x9b, 0x99, 0x8c, 0x2c, 0x68, 0x63, 0x8a, 0x2f, 0xe9, 0x8d,
+	0xcc, 0x68, 0xc7, 0x49, 0x45, 0x64, 0x43, 0x4f, 0x6a, 0x25, 0xe1, 0x37, 0x44, 0xf8, 0x15, 0xcc,
+	0x0e, 0xe1, 0xa0, 0x44, 0x9a, 0x97, 0x22, 0x7d, 0x15, 0xea, 0xd8, 0xd0, 0xcb, 0x09, 0x38, 0xc1,
+	0x96, 0x3e, 0x30, 0x86, 0x5d, 0x47, 0xc1, 0xb8, 0x0e, 0x8d, 0x1d, 0x72, 0x47, 0xc9, 0xd2, 0xbd,
+	0xf2, 0x88, 0x38, 0xb0, 0xe3, 0x28, 0x98, 0xfd, 0xb7, 0x01, 0xdb, 0xe2, 0x44, 0x89, 0x3d, 0x90,
+	0xa2, 0x65, 0x2b, 0x0c, 0xf1, 0x9d, 0x6a, 0xc5, 0xc7, 0xd0, 0x8f, 0xc4, 0xde, 0xa7, 0x69, 0xc8,
+	0x24, 0x61, 0x4b, 0x68, 0x46, 0xe7, 0x66, 0x81, 0xce, 0x43, 0xe8, 0xde, 0x2e, 0xf0, 0x0d, 0xf1,
+	0x49, 0xc0, 0x04, 0x5d, 0xfb, 0x63, 0x18, 0x7d, 0x95, 0x22, 0x4e, 0xbe, 0xc8, 0x4f, 0xa1, 0xf1,
+	0x37, 0x31, 0xc1, 0x67, 0x6f, 0xbe, 0xc4, 0x94, 0x85, 0x91, 0xe0, 0x6e, 0xc7, 0x29, 0xa1, 0x22,
+	0x26, 0xc1, 0x1d, 0x65, 0x2e, 0xa3, 0x61, 0x30, 0xc1, 0x09, 0x8f, 0x15, 0x8c, 0xa7, 0x90, 0xc6,
+	0x67, 0x1e, 0x0d, 0xb0, 0xd5, 0x95, 0x29, 0x4c, 0x44, 0x34, 0x02, 0x24, 0xad, 0x26, 0xd1, 0xb9,
+	0xb8, 0x13, 0xc2, 0x30, 0x10, 0x7b, 0xd4, 0xac, 0xf0, 0xe8, 0x49, 0xf4, 0x6b, 0xf2, 0x26, 0x25,
+	0x73, 0x06, 0xa0, 0x01, 0x6c, 0xc7, 0xc4, 0xf5, 0x08, 0x7e, 0x11, 0x85, 0xe1, 0x75, 0xc2, 0xe7,
+	0x22, 0x84, 0x4e, 0xc0, 0x9c, 0x73, 0xfd, 0x70, 0x91, 0xe4, 0x6c, 0x82, 0x13, 0x66, 0x57, 0x70,
+	0xee, 0x59, 0x40, 0x5e, 0xb3, 0x4c, 0x4f, 0x92, 0x5c, 0xc1, 0xec, 0x7f, 0x34, 0x78, 0xcc, 0xcd,
+	0x73, 0x03, 0x9c, 0x33, 0xec, 0x5c, 0xa4, 0xb3, 0x92, 0x4b, 0xad, 0x26, 0x97, 0x23, 0x40, 0x7e,
+	0x89, 0x99, 0x82, 0x7b, 0xc2, 0xff, 0xea, 0x4a, 0x96, 0x53, 0xa3, 0x90, 0x53, 0xa5, 0xc0, 0x34,
+	0x56, 0x16, 0x98, 0x66, 0xb1, 0xc0, 0x14, 0x2f, 0x5c, 0x4b, 0x30, 0x3d, 0x93, 0xd5, 0x82, 0xd1,
+	0x2e, 0x15, 0x0c, 0xfb, 0x4f, 0x1d, 0x3e, 0x5c, 0xe1, 0xf1, 0x33, 0xb2, 0xfc, 0x56, 0x96, 0xbe,
+	0x8d, 0x7c, 0x1f, 0xc3, 0x7e, 0x1a, 0xf3, 0x0b, 0xf5, 0xe6, 0x71, 0xdd, 0xda, 0xb5, 0xff, 0xc5,
+	0xff, 0xcf, 0x61, 0x57, 0x96, 0x78, 0x87, 0x5c, 0x93, 0x88, 0x04, 0x53, 0x62, 0xb5, 0x45, 0x15,
+	0xde, 0x1f, 0xbd, 0x54, 0xf1, 0x09, 0x23, 0xbe, 0x53, 0x56, 0xe6, 0x1c, 0x9b, 0xd1, 0x98, 0x85,
+	0x11, 0x9d, 0x66, 0x37, 0xb4, 0x23, 0xce, 0xa8, 0xe0, 0xf6, 0xac, 0x26, 0x98, 0xa7, 0xd3, 0x29,
+	0x99, 0x33, 0xa9, 0x12, 0xcf, 0xe8, 0x7c, 0xa3, 0x60, 0xe6, 0x1d, 0x43, 0xaf, 0xed, 0x18, 0xf6,
+	0x5b, 0x78, 0x52, 0x3d, 0xc9, 0xf3, 0xc2, 0x65, 0x52, 0xa7, 0x8e, 0xa0, 0x93, 0xf1, 0x2f, 0x29,
+	0xc1, 0xa9, 0x5c, 0x17, 0x11, 0xfd, 0x01, 0x11, 0xb1, 0x7f, 0xd5, 0xe0, 0xbd, 0xca, 0xe1, 0xaf,
+	0x92, 0x5e, 0xb0, 0x91, 0x7b, 0x4a, 0x8e, 0xf5, 0x72, 0x8e, 0x6b, 0xec, 0x33, 0x1e, 0x62, 0xdf,
+	0x6b, 0xd8, 0xab, 0xd1, 0xdb, 0xc8, 0xb0, 0xcf, 0x8a, 0x73, 0x8b, 0xec, 0xbc, 0x96, 0xbe, 0xaa,
+	0x25, 0x57, 0x54, 0xed, 0xdf, 0x34, 0x40, 0x49, 0x64, 0x5e, 0x45, 0x34, 0x6b, 0x1a, 0x47, 0xd0,
+	0x89, 0xd2, 0xb2, 0x93, 0x24, 0x23, 0x2a, 0x94, 0x25, 0xbf, 0x7a, 0x5d, 0x7a, 0xfe, 0x7d, 0xd7,
+	0x44, 0x29, 0xfd, 0x8d, 0x75, 0xa5, 0xbf, 0x5c, 0xd2, 0x9b, 0xd5, 0x92, 0x6e, 0xff, 0xae, 0xc1,
+	0x5e, 0x62, 0xb8, 0xb4, 0x99, 0xff, 0x8d, 0xc9, 0x5a, 0xcb, 0xeb, 0x8a, 0xaf, 0xbe, 0xba, 0xf8,
+	0xde, 0xdb, 0x08, 0xeb, 0xe6, 0x35, 0xc5, 0xcb, 0xe6, 0x1a, 0x2f, 0xed, 0x9f, 0x74, 0x78, 0x5c,
+	0x0c, 0xbd, 0x68, 0x48, 0xef, 0x44, 0xfc, 0xeb, 0x9b, 0x62, 0x73, 0xb3, 0xa6, 0xd8, 0xba, 0xa7,
+	0x29, 0xb6, 0x2b, 0x4d, 0xd1, 0xfe, 0xa1, 0x9a, 0x4a, 0xe2, 0x7a, 0x6b, 0x83, 0x80, 0xa0, 0x11,
+	0xbb, 0x1e, 0x4b, 0x9c, 0x17, 0xbf, 0xf9, 0xd0, 0x27, 0x6b, 0x0d, 0x7d, 0x2b, 0x58, 0x92, 0x78,
+	0xaf, 0x82, 0xf6, 0x1f, 0x7a, 0x32, 0x91, 0x25, 0xa9, 0xce, 0x48, 0xf5, 0x1f, 0x0f, 0xf3, 0xe5,
+	0x34, 0x35, 0x6a, 0xd2, 0xb4, 0x6e, 0xb8, 0x57, 0xba, 0x60, 0xab, 0x3c, 0x36, 0x1f, 0x03, 0xa4,
+	0x89, 0xc8, 0x9a, 0x64, 0x01, 0xe1, 0x3b, 0x13, 0x31, 0x1f, 0x65, 0x13, 0x51, 0x26, 0xa3, 0x2f,
+	0x60, 0x37, 0xbf, 0x4a, 0x22, 0x14, 0x62, 0x2a, 0xea, 0x8f, 0x0f, 0x47, 0xe5, 0x98, 0xc8, 0x40,
+	0x95, 0xd5, 0xed, 0x1f, 0xe1, 0x40, 0x49, 0x18, 0x89, 0xc4, 0x27, 0xa4, 0x72, 0x73, 0xb5, 0x9a,
+	0x61, 0x6c, 0x13, 0xfe, 0xaa, 0xee, 0x19, 0x65, 0xf7, 0xec, 0x73, 0x78, 0x5f, 0x31, 0x20, 0xb7,
+	0x58, 0x36, 0xaf, 0x4d, 0x0c, 0xb1, 0x9f, 0xc2, 0xf1, 0x8a, 0x4d, 0x9e, 0x92, 0xa9, 0x47, 0x83,
+	0x8d, 0xdc, 0xb1, 0x7f, 0xd6, 0xe0, 0xb0, 0xd2, 0x5b, 0xc4, 0xb5, 0xda, 0xa8, 0x7e, 0x17, 0x49,
+	0x2e, 0x47, 0xfe, 0x4c, 0x46, 0xe3, 0xe2, 0x8d, 0x35, 0x44, 0x8a, 0xf6, 0x47, 0xa5, 0x43, 0xca,
+	0x55, 0xe5, 0x97, 0xba, 0x81, 0xf0, 0x34, 0x8e, 0x93, 0xf7, 0xca, 0x26, 0xf6, 0x64, 0xef, 0x31,
+	0x7d, 0xdd, 0x7b, 0xcc, 0x58, 0xf7, 0x1e, 0x6b, 0xa8, 0xef, 0xb1, 0x93, 0x10, 0xcc, 0x72, 0x33,
+	0x42, 0x1d, 0x68, 0xd0, 0x80, 0x32, 0x73, 0x0b, 0xb5, 0xc1, 0x08, 0xc8, 0xd2, 0xd4, 0x50, 0x3f,
+	0x4d, 0x3b, 0x7f, 0xc9, 0x98, 0x3a, 0xea, 0xf1, 0xe0, 0xdc, 0x92, 0x29, 0x23, 0xd8, 0x34, 0xd0,
+	0x0e, 0x74, 0xe7, 0x8b, 0x2b, 0x8f, 0xc6, 0x33, 0x82, 0xcd, 0x06, 0x5f, 0x74, 0x85, 0x5f, 0x04,
+	0x9b, 0x4d, 0xbe, 0x98, 0x3e, 0xee, 0x02, 0xb3, 0x75, 0xf2, 0x3d, 0x1c, 0xd4, 0x72, 0x19, 0x21,
+	0xe8, 0xe7, 0xe9, 0x7b, 0x3e, 0x27, 0x81, 0xb9, 0x85, 0x0e, 0x01, 0xd1, 0x12, 0x81, 0x08, 0x36,
+	0x35, 0x15, 0x4f, 0x38, 0x81, 0x4d, 0xfd, 0xe4, 0x1c, 0xf6, 0x6a, 0xb2, 0x80, 0x0e, 0xe0, 0x51,
+	0x96, 0x07, 0x27, 0x35, 0x7b, 0x4b, 0x81, 0xf3, 0xcd, 0xcf, 0xda, 0xdf, 0x35, 0xfd, 0x10, 0x13,
+	0xef, 0xaa, 0x25, 0xfe, 0xbd, 0xf0, 0xe9, 0xbf, 0x03, 0x00, 0x9e, 0x9a, 0x39, 0x40, 0x80, 0x10,
 	0x00, 0x00,
 }
@@ -16,6 +16,9 @@ func initAllEnumMinMax() {
 	minimum, maximum = getManuscriptJudgementMinMax()
 	MinManuscriptJudgement = minimum
 	MaxManuscriptJudgement = maximum
+	minimum, maximum = getJournalStateMinMax()
+	MinJournalState = minimum
+	MaxJournalState = maximum
 }
 
 var MinManuscriptStatus int32
@@ -90,6 +93,30 @@ func getManuscriptJudgementMinMax() (int32, int32) {
 	return minimum, maximum
 }
 
+var MinJournalState int32
+var MaxJournalState int32
+
+func getJournalStateMinMax() (int32, int32) {
+	var minimum int32
+	var maximum int32
+	isFirst := true
+	for testValue := range JournalState_name {
+		if isFirst {
+			minimum = testValue
+			maximum = testValue
+			isFirst = false
+		} else {
+			if testValue < minimum {
+				minimum = testValue
+			}
+			if testValue > maximum {
+				maximum = testValue
+			}
+		}
+	}
+	return minimum, maximum
+}
+
 const journalAddressPrefix = "20"
 
 func CreateJournalAddress() string {
@@ -138,6 +165,30 @@ func IsReviewAddress(address string) bool {
 	return getAddressPrefixFromAddress(address) == reviewAddressPrefix
 }
 
+const reviewInvitationAddressPrefix = "38"
+
+func CreateReviewInvitationAddress() string {
+	var theUuid uuid.UUID = uuid.New()
+	uuidDigest := hexdigestOfUuid(theUuid)
+	return Namespace + reviewInvitationAddressPrefix + uuidDigest[:62]
+}
+
+func IsReviewInvitationAddress(address string) bool {
+	return getAddressPrefixFromAddress(address) == reviewInvitationAddressPrefix
+}
+
+const proposalAddressPrefix = "48"
+
+func CreateProposalAddress() string {
+	var theUuid uuid.UUID = uuid.New()
+	uuidDigest := hexdigestOfUuid(theUuid)
+	return Namespace + proposalAddressPrefix + uuidDigest[:62]
+}
+
+func IsProposalAddress(address string) bool {
+	return getAddressPrefixFromAddress(address) == proposalAddressPrefix
+}
+
 const personAddressPrefix = "01"
 
 func CreatePersonAddress() string {
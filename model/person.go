@@ -23,6 +23,22 @@ CREATE TABLE person (
 	extrainfo varchar not null
 )`
 
+// The balance ledger has a row for every change of the balance
+// of a person. Field amount is the change, field balance is the
+// balance after the change. Field commandtype is the type of
+// the command that caused the change, like CommandManuscriptCreate.
+// It is empty for events that were issued before the ledger existed.
+var TableCreateBalanceChange = `
+CREATE TABLE balancechange (
+	transactionid varchar not null,
+	eventseq integer not null,
+	personid varchar not null,
+	amount integer not null,
+	balance integer not null,
+	commandtype varchar not null,
+	timestamp integer not null
+)`
+
 const (
 	EV_TYPE_PERSON_CREATE            = "evPersonCreate"
 	EV_TYPE_PERSON_UPDATE            = "evPersonUpdate"
@@ -43,4 +59,14 @@ const (
 	EV_KEY_PERSON_POSTAL_CODE    = "postalCode"
 	EV_KEY_PERSON_COUNTRY        = "country"
 	EV_KEY_PERSON_EXTRA_INFO     = "extraInfo"
+	EV_KEY_PERSON_BALANCE_AMOUNT = "balanceAmount"
+	EV_KEY_PERSON_COMMAND_TYPE   = "commandType"
+)
+
+// Balance changes caused by these command types do not pay the
+// price of a command. Balance increments are issued by majors and
+// balance transfers move balance between persons.
+const (
+	COMMAND_TYPE_BALANCE_INCREMENT = "CommandPersonUpdateBalanceIncrement"
+	COMMAND_TYPE_BALANCE_TRANSFER  = "CommandBalanceTransfer"
 )
@@ -463,54 +463,104 @@ func (m *CommandPersonUpdateBalanceIncrement) GetBalanceIncrement() int32 {
 	return 0
 }
 
+type CommandBalanceTransfer struct {
+	ToPersonId           string   `protobuf:"bytes,1,opt,name=toPersonId,proto3" json:"toPersonId,omitempty"`
+	Amount               int32    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandBalanceTransfer) Reset()         { *m = CommandBalanceTransfer{} }
+func (m *CommandBalanceTransfer) String() string { return proto.CompactTextString(m) }
+func (*CommandBalanceTransfer) ProtoMessage()    {}
+func (*CommandBalanceTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c9e10cf24b1156d, []int{5}
+}
+
+func (m *CommandBalanceTransfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandBalanceTransfer.Unmarshal(m, b)
+}
+func (m *CommandBalanceTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandBalanceTransfer.Marshal(b, m, deterministic)
+}
+func (m *CommandBalanceTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandBalanceTransfer.Merge(m, src)
+}
+func (m *CommandBalanceTransfer) XXX_Size() int {
+	return xxx_messageInfo_CommandBalanceTransfer.Size(m)
+}
+func (m *CommandBalanceTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandBalanceTransfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandBalanceTransfer proto.InternalMessageInfo
+
+func (m *CommandBalanceTransfer) GetToPersonId() string {
+	if m != nil {
+		return m.ToPersonId
+	}
+	return ""
+}
+
+func (m *CommandBalanceTransfer) GetAmount() int32 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*StatePerson)(nil), "StatePerson")
 	proto.RegisterType((*CommandPersonCreate)(nil), "CommandPersonCreate")
 	proto.RegisterType((*CommandPersonUpdateProperties)(nil), "CommandPersonUpdateProperties")
 	proto.RegisterType((*CommandPersonUpdateAuthorization)(nil), "CommandPersonUpdateAuthorization")
 	proto.RegisterType((*CommandPersonUpdateBalanceIncrement)(nil), "CommandPersonUpdateBalanceIncrement")
+	proto.RegisterType((*CommandBalanceTransfer)(nil), "CommandBalanceTransfer")
 }
 
 func init() { proto.RegisterFile("person.proto", fileDescriptor_4c9e10cf24b1156d) }
 
 var fileDescriptor_4c9e10cf24b1156d = []byte{
-	// 601 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0x51, 0x6f, 0xd3, 0x3c,
-	0x14, 0x55, 0x9a, 0x66, 0x6d, 0x6f, 0xda, 0x6d, 0x9f, 0xf7, 0x3d, 0x58, 0x13, 0xa0, 0x28, 0xf0,
-	0x50, 0x40, 0x0c, 0x69, 0x7b, 0x40, 0x3c, 0x20, 0xc4, 0xf6, 0xc2, 0x84, 0x10, 0x28, 0x13, 0x2f,
-	0xbc, 0xb9, 0x89, 0xd7, 0x1a, 0x12, 0x3b, 0x72, 0x5c, 0x41, 0x79, 0xe1, 0x5f, 0xf0, 0xaf, 0xf8,
-	0x25, 0xfc, 0x09, 0x64, 0xc7, 0x49, 0x93, 0xd6, 0xec, 0xad, 0xf7, 0xdc, 0x73, 0x7a, 0xaf, 0x7d,
-	0x4e, 0x0c, 0xd3, 0x92, 0xca, 0x4a, 0xf0, 0xb3, 0x52, 0x0a, 0x25, 0x4e, 0xa7, 0xa9, 0x28, 0x8a,
-	0xa6, 0x8a, 0x7f, 0xfb, 0x10, 0xde, 0x28, 0xa2, 0xe8, 0x47, 0xc3, 0x41, 0x87, 0x30, 0x60, 0x19,
-	0xf6, 0x22, 0x6f, 0x3e, 0x49, 0x06, 0x2c, 0x43, 0xf7, 0x60, 0x92, 0x4a, 0x4a, 0x14, 0xcd, 0x3e,
-	0x70, 0x3c, 0x88, 0xbc, 0xb9, 0x9f, 0x6c, 0x01, 0xf4, 0x00, 0xa0, 0x10, 0x19, 0xbb, 0x65, 0xa6,
-	0xed, 0x9b, 0x76, 0x07, 0xd1, 0xea, 0x72, 0xbd, 0xc8, 0x59, 0xfa, 0x8e, 0x6e, 0xf0, 0xd0, 0xfc,
-	0xe9, 0x16, 0x40, 0x08, 0x86, 0x9c, 0x14, 0x14, 0x07, 0xa6, 0x61, 0x7e, 0xa3, 0xff, 0x21, 0xa0,
-	0x05, 0x61, 0x39, 0x3e, 0x30, 0x60, 0x5d, 0x20, 0x0c, 0x23, 0x56, 0xbd, 0x27, 0x5f, 0x84, 0xc4,
-	0xa3, 0xc8, 0x9b, 0x8f, 0x93, 0xa6, 0x44, 0xa7, 0x30, 0x66, 0xd5, 0x0d, 0x5b, 0x72, 0x9a, 0xe1,
-	0xb1, 0x69, 0xb5, 0xb5, 0x56, 0x2d, 0x48, 0x4e, 0x78, 0x4a, 0xf1, 0x24, 0xf2, 0xe6, 0x41, 0xd2,
-	0x94, 0xe8, 0x11, 0xcc, 0x16, 0x4c, 0x2c, 0x25, 0x29, 0x57, 0x9b, 0xb7, 0xa4, 0x5a, 0x61, 0x30,
-	0xd3, 0xfa, 0x20, 0x8a, 0x61, 0x2a, 0xe4, 0x92, 0x70, 0xf6, 0x83, 0x28, 0x26, 0x38, 0x9e, 0x1a,
-	0x52, 0x0f, 0xd3, 0x27, 0x54, 0x34, 0xa7, 0xe5, 0x4a, 0x70, 0x8a, 0x67, 0xf5, 0x09, 0x5b, 0x40,
-	0x6f, 0x40, 0xb2, 0x4c, 0xd2, 0xaa, 0xc2, 0x87, 0xa6, 0xd7, 0x94, 0xfa, 0xe6, 0x4a, 0x51, 0x29,
-	0x92, 0x5f, 0x89, 0x8c, 0xe2, 0x23, 0xd3, 0xec, 0x20, 0x5a, 0x99, 0x8a, 0x35, 0x57, 0x72, 0x83,
-	0x8f, 0x6b, 0xa5, 0x2d, 0xf5, 0x44, 0xfa, 0x5d, 0x49, 0x72, 0xcd, 0x6f, 0x05, 0xfe, 0xaf, 0x9e,
-	0xd8, 0x02, 0xf1, 0x4f, 0x38, 0xb9, 0x12, 0x45, 0x41, 0x78, 0x56, 0x1b, 0x7a, 0x65, 0xbc, 0x42,
-	0x11, 0x84, 0x9c, 0x7e, 0xab, 0xa1, 0xeb, 0xc6, 0xdf, 0x2e, 0xd4, 0xb7, 0x6a, 0xf0, 0x2f, 0xab,
-	0x7c, 0x97, 0x55, 0xc3, 0x8e, 0x55, 0xf1, 0x9f, 0x21, 0xdc, 0xef, 0x6d, 0xf0, 0xa9, 0xcc, 0x74,
-	0xbc, 0xa4, 0x28, 0xa9, 0x54, 0x8c, 0x56, 0xda, 0xb2, 0xb2, 0xbf, 0x48, 0x5b, 0xa3, 0x17, 0x70,
-	0xd4, 0x0e, 0xad, 0x85, 0x66, 0x97, 0xf0, 0x7c, 0x76, 0x76, 0xa3, 0x24, 0xe3, 0xcb, 0x1a, 0x4c,
-	0x76, 0x59, 0xe8, 0x19, 0x80, 0x5e, 0xca, 0x6a, 0x7c, 0x97, 0xa6, 0x43, 0x40, 0xcf, 0x21, 0x34,
-	0xeb, 0x5a, 0xfe, 0xd0, 0xc5, 0xef, 0x32, 0xd0, 0x6b, 0x38, 0xe9, 0x85, 0xc3, 0x0a, 0x03, 0x97,
-	0xd0, 0xc5, 0x44, 0xaf, 0x00, 0x75, 0x83, 0x63, 0xf5, 0x07, 0x2e, 0xbd, 0x83, 0xa8, 0x2f, 0xa6,
-	0x8d, 0x95, 0xd5, 0x8e, 0x9c, 0x17, 0xb3, 0xc3, 0x42, 0x17, 0x30, 0xb3, 0x99, 0xb3, 0xb2, 0xb1,
-	0x4b, 0xd6, 0xe7, 0xa0, 0x97, 0x70, 0xbc, 0xcd, 0xa2, 0xd5, 0x4d, 0x5c, 0xba, 0x3d, 0x9a, 0x9e,
-	0x67, 0x93, 0x6a, 0x75, 0xe0, 0x9c, 0xd7, 0xe3, 0xe8, 0xd3, 0xb5, 0x11, 0xb6, 0xb2, 0xd0, 0x79,
-	0xba, 0x1d, 0x56, 0xfc, 0xcb, 0x83, 0xc8, 0x91, 0xb6, 0x37, 0x6b, 0xb5, 0x12, 0xb2, 0xf9, 0x46,
-	0xef, 0x0a, 0xdc, 0x63, 0x98, 0x14, 0xe4, 0x2b, 0xad, 0xdf, 0x16, 0x1d, 0xb5, 0xc3, 0xf3, 0xf0,
-	0xec, 0x52, 0x08, 0xeb, 0x7b, 0xb2, 0xed, 0xa2, 0xa7, 0x00, 0xba, 0xb0, 0x8f, 0x8d, 0xbf, 0xcf,
-	0xed, 0xb4, 0xe3, 0x02, 0x1e, 0x3a, 0xf6, 0xba, 0xac, 0xdf, 0x9f, 0x6b, 0x9e, 0x4a, 0x5a, 0x50,
-	0xae, 0xee, 0x5c, 0xed, 0x09, 0x1c, 0x2f, 0x76, 0xf8, 0x66, 0xc3, 0x20, 0xd9, 0xc3, 0x2f, 0x47,
-	0x9f, 0x83, 0x42, 0x64, 0x34, 0x5f, 0x1c, 0x98, 0x67, 0xfd, 0xe2, 0x6f, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0xcc, 0xc8, 0x0a, 0x74, 0xf4, 0x05, 0x00, 0x00,
+	// 628 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0x5f, 0x6f, 0xd3, 0x3e,
+	0x14, 0x55, 0xff, 0xb7, 0x37, 0xed, 0xb6, 0x9f, 0xf7, 0xd3, 0x64, 0x4d, 0x80, 0xa2, 0xc0, 0x43,
+	0x01, 0x31, 0xa4, 0xed, 0x01, 0xf1, 0x80, 0x10, 0xdb, 0x0b, 0x13, 0x42, 0x4c, 0x19, 0xbc, 0xf0,
+	0xe6, 0x26, 0x5e, 0x6b, 0x88, 0xed, 0xc8, 0x71, 0x05, 0xe3, 0x85, 0x6f, 0xc1, 0xb7, 0xe2, 0x93,
+	0xf0, 0x25, 0x90, 0x1d, 0x27, 0x4d, 0x5a, 0xb3, 0xb7, 0x9e, 0x73, 0xcf, 0xc9, 0xbd, 0xce, 0x3d,
+	0x71, 0x61, 0x9a, 0x53, 0x55, 0x48, 0x71, 0x92, 0x2b, 0xa9, 0xe5, 0xf1, 0x34, 0x91, 0x9c, 0x57,
+	0x28, 0xfa, 0xdd, 0x83, 0xe0, 0x5a, 0x13, 0x4d, 0xaf, 0xac, 0x06, 0xed, 0x41, 0x97, 0xa5, 0xb8,
+	0x13, 0x76, 0xe6, 0x93, 0xb8, 0xcb, 0x52, 0x74, 0x0f, 0x26, 0x89, 0xa2, 0x44, 0xd3, 0xf4, 0x83,
+	0xc0, 0xdd, 0xb0, 0x33, 0xef, 0xc5, 0x1b, 0x02, 0x3d, 0x00, 0xe0, 0x32, 0x65, 0x37, 0xcc, 0x96,
+	0x7b, 0xb6, 0xdc, 0x60, 0x8c, 0x3b, 0x5f, 0x2f, 0x32, 0x96, 0xbc, 0xa3, 0xb7, 0xb8, 0x6f, 0x1f,
+	0xba, 0x21, 0x10, 0x82, 0xbe, 0x20, 0x9c, 0xe2, 0x81, 0x2d, 0xd8, 0xdf, 0xe8, 0x7f, 0x18, 0x50,
+	0x4e, 0x58, 0x86, 0x87, 0x96, 0x2c, 0x01, 0xc2, 0x30, 0x62, 0xc5, 0x7b, 0xf2, 0x45, 0x2a, 0x3c,
+	0x0a, 0x3b, 0xf3, 0x71, 0x5c, 0x41, 0x74, 0x0c, 0x63, 0x56, 0x5c, 0xb3, 0xa5, 0xa0, 0x29, 0x1e,
+	0xdb, 0x52, 0x8d, 0x8d, 0x6b, 0x41, 0x32, 0x22, 0x12, 0x8a, 0x27, 0x61, 0x67, 0x3e, 0x88, 0x2b,
+	0x88, 0x1e, 0xc1, 0x6c, 0xc1, 0xe4, 0x52, 0x91, 0x7c, 0x75, 0xfb, 0x96, 0x14, 0x2b, 0x0c, 0xb6,
+	0x5b, 0x9b, 0x44, 0x11, 0x4c, 0xa5, 0x5a, 0x12, 0xc1, 0x7e, 0x10, 0xcd, 0xa4, 0xc0, 0x53, 0x2b,
+	0x6a, 0x71, 0xe6, 0x84, 0x9a, 0x66, 0x34, 0x5f, 0x49, 0x41, 0xf1, 0xac, 0x3c, 0x61, 0x4d, 0x98,
+	0x09, 0x48, 0x9a, 0x2a, 0x5a, 0x14, 0x78, 0xcf, 0xd6, 0x2a, 0x68, 0xde, 0x5c, 0x2e, 0x0b, 0x4d,
+	0xb2, 0x0b, 0x99, 0x52, 0xbc, 0x6f, 0x8b, 0x0d, 0xc6, 0x38, 0x13, 0xb9, 0x16, 0x5a, 0xdd, 0xe2,
+	0x83, 0xd2, 0xe9, 0xa0, 0xe9, 0x48, 0xbf, 0x6b, 0x45, 0x2e, 0xc5, 0x8d, 0xc4, 0xff, 0x95, 0x1d,
+	0x6b, 0x22, 0xfa, 0x09, 0x87, 0x17, 0x92, 0x73, 0x22, 0xd2, 0x72, 0xa1, 0x17, 0x76, 0x57, 0x28,
+	0x84, 0x40, 0xd0, 0x6f, 0x25, 0x75, 0x59, 0xed, 0xb7, 0x49, 0xb5, 0x57, 0xd5, 0xfd, 0xd7, 0xaa,
+	0x7a, 0xbe, 0x55, 0xf5, 0x1b, 0xab, 0x8a, 0xfe, 0xf4, 0xe1, 0x7e, 0x6b, 0x82, 0x4f, 0x79, 0x6a,
+	0xe2, 0xa5, 0x64, 0x4e, 0x95, 0x66, 0xb4, 0x30, 0x2b, 0xcb, 0xdb, 0x83, 0xd4, 0x18, 0xbd, 0x80,
+	0xfd, 0xba, 0x69, 0x69, 0xb4, 0xb3, 0x04, 0xa7, 0xb3, 0x93, 0x6b, 0xad, 0x98, 0x58, 0x96, 0x64,
+	0xbc, 0xad, 0x42, 0xcf, 0x00, 0xcc, 0x50, 0xce, 0xd3, 0xf3, 0x79, 0x1a, 0x02, 0xf4, 0x1c, 0x02,
+	0x3b, 0xae, 0xd3, 0xf7, 0x7d, 0xfa, 0xa6, 0x02, 0xbd, 0x86, 0xc3, 0x56, 0x38, 0x9c, 0x71, 0xe0,
+	0x33, 0xfa, 0x94, 0xe8, 0x15, 0xa0, 0x66, 0x70, 0x9c, 0x7f, 0xe8, 0xf3, 0x7b, 0x84, 0xe6, 0xc5,
+	0xd4, 0xb1, 0x72, 0xde, 0x91, 0xf7, 0xc5, 0x6c, 0xa9, 0xd0, 0x19, 0xcc, 0x5c, 0xe6, 0x9c, 0x6d,
+	0xec, 0xb3, 0xb5, 0x35, 0xe8, 0x25, 0x1c, 0x6c, 0xb2, 0xe8, 0x7c, 0x13, 0x9f, 0x6f, 0x47, 0x66,
+	0xfa, 0xb9, 0xa4, 0x3a, 0x1f, 0x78, 0xfb, 0xb5, 0x34, 0xe6, 0x74, 0x75, 0x84, 0x9d, 0x2d, 0xf0,
+	0x9e, 0x6e, 0x4b, 0x15, 0xfd, 0xea, 0x40, 0xe8, 0x49, 0xdb, 0x9b, 0xb5, 0x5e, 0x49, 0x55, 0x7d,
+	0xa3, 0x77, 0x05, 0xee, 0x31, 0x4c, 0x38, 0xf9, 0x4a, 0xcb, 0xbb, 0xc5, 0x44, 0x6d, 0xef, 0x34,
+	0x38, 0x39, 0x97, 0xd2, 0xed, 0x3d, 0xde, 0x54, 0xd1, 0x53, 0x00, 0x03, 0xdc, 0x65, 0xd3, 0xdb,
+	0xd5, 0x36, 0xca, 0x11, 0x87, 0x87, 0x9e, 0xb9, 0xce, 0xcb, 0xfb, 0xe7, 0x52, 0x24, 0x8a, 0x72,
+	0x2a, 0xf4, 0x9d, 0xa3, 0x3d, 0x81, 0x83, 0xc5, 0x96, 0xde, 0x4e, 0x38, 0x88, 0x77, 0xf8, 0xe8,
+	0x0a, 0x8e, 0x5c, 0x3b, 0xd7, 0xe2, 0xa3, 0x22, 0xa2, 0xb8, 0xa1, 0xca, 0x5c, 0x34, 0x5a, 0x6e,
+	0x7d, 0xf8, 0x0d, 0x06, 0x1d, 0xc1, 0x90, 0x70, 0xb9, 0xae, 0x9f, 0xed, 0xd0, 0xf9, 0xe8, 0xf3,
+	0x80, 0xcb, 0x94, 0x66, 0x8b, 0xa1, 0xfd, 0xa3, 0x38, 0xfb, 0x3b, 0x00, 0x11, 0x69, 0xa7, 0x32,
+	0x46, 0x06, 0x00, 0x00,
 }
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"fmt"
+)
+
+// A proposal wraps a governance command that is executed when enough
+// majors approved it. Field commandtype is the type of the wrapped
+// command, like CommandSettingsUpdate. Field targetid is the person
+// that the wrapped command modifies, it is empty for settings updates.
+var TableCreateProposal = `
+CREATE TABLE proposal (
+    id VARCHAR not null,
+    createdon integer not null,
+    modifiedon integer not null,
+    proposerid VARCHAR not null,
+    commandtype VARCHAR not null,
+    targetid VARCHAR not null,
+    description VARCHAR not null,
+    expireson integer not null,
+    proposalstate VARCHAR not null,
+    PRIMARY KEY (id),
+    FOREIGN KEY (proposerid) REFERENCES person(id)
+)
+`
+
+// The proposer is the first approver of a proposal.
+var TableCreateProposalApproval = `
+CREATE TABLE proposalapproval (
+    proposalid VARCHAR not null,
+    approverid VARCHAR not null,
+    createdon integer not null,
+    PRIMARY KEY (proposalid, approverid),
+    FOREIGN KEY (proposalid) REFERENCES proposal(id),
+    FOREIGN KEY (approverid) REFERENCES person(id)
+)
+`
+
+const (
+	EV_TYPE_PROPOSAL_CREATE            = "evProposalCreate"
+	EV_TYPE_PROPOSAL_APPROVE           = "evProposalApprove"
+	EV_TYPE_PROPOSAL_UPDATE            = "evProposalUpdate"
+	EV_TYPE_PROPOSAL_MODIFICATION_TIME = "evProposalModificationTime"
+)
+
+const (
+	EV_KEY_PROPOSAL_PROPOSER_ID  = "proposerId"
+	EV_KEY_PROPOSAL_COMMAND_TYPE = "commandType"
+	EV_KEY_PROPOSAL_TARGET_ID    = "targetId"
+	EV_KEY_PROPOSAL_DESCRIPTION  = "description"
+	EV_KEY_PROPOSAL_EXPIRES_ON   = "expiresOn"
+	EV_KEY_PROPOSAL_APPROVER_ID  = "approverId"
+	EV_KEY_PROPOSAL_STATE        = "proposalState"
+)
+
+func GetProposalStateString(value ProposalState) string {
+	switch value {
+	case ProposalState_proposalOpen:
+		return "OPEN"
+	case ProposalState_proposalExecuted:
+		return "EXECUTED"
+	case ProposalState_proposalCancelled:
+		return "CANCELLED"
+	}
+	panic(fmt.Sprintf("Unknown proposal state: %d", value))
+}
@@ -0,0 +1,415 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: proposal.proto
+
+package model
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ProposalState int32
+
+const (
+	ProposalState_proposalOpen      ProposalState = 0
+	ProposalState_proposalExecuted  ProposalState = 1
+	ProposalState_proposalCancelled ProposalState = 2
+)
+
+var ProposalState_name = map[int32]string{
+	0: "proposalOpen",
+	1: "proposalExecuted",
+	2: "proposalCancelled",
+}
+
+var ProposalState_value = map[string]int32{
+	"proposalOpen":      0,
+	"proposalExecuted":  1,
+	"proposalCancelled": 2,
+}
+
+func (x ProposalState) String() string {
+	return proto.EnumName(ProposalState_name, int32(x))
+}
+
+func (ProposalState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c3ac5ce23bf32d05, []int{0}
+}
+
+type StateProposal struct {
+	Id                   string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedOn            int64            `protobuf:"varint,2,opt,name=createdOn,proto3" json:"createdOn,omitempty"`
+	ModifiedOn           int64            `protobuf:"varint,3,opt,name=modifiedOn,proto3" json:"modifiedOn,omitempty"`
+	ProposerId           string           `protobuf:"bytes,4,opt,name=proposerId,proto3" json:"proposerId,omitempty"`
+	ProposedCommand      *ProposedCommand `protobuf:"bytes,5,opt,name=proposedCommand,proto3" json:"proposedCommand,omitempty"`
+	ApproverId           []string         `protobuf:"bytes,6,rep,name=approverId,proto3" json:"approverId,omitempty"`
+	ExpiresOn            int64            `protobuf:"varint,7,opt,name=expiresOn,proto3" json:"expiresOn,omitempty"`
+	ProposalState        ProposalState    `protobuf:"varint,8,opt,name=proposalState,proto3,enum=ProposalState" json:"proposalState,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *StateProposal) Reset()         { *m = StateProposal{} }
+func (m *StateProposal) String() string { return proto.CompactTextString(m) }
+func (*StateProposal) ProtoMessage()    {}
+func (*StateProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3ac5ce23bf32d05, []int{0}
+}
+
+func (m *StateProposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateProposal.Unmarshal(m, b)
+}
+func (m *StateProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateProposal.Marshal(b, m, deterministic)
+}
+func (m *StateProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateProposal.Merge(m, src)
+}
+func (m *StateProposal) XXX_Size() int {
+	return xxx_messageInfo_StateProposal.Size(m)
+}
+func (m *StateProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateProposal proto.InternalMessageInfo
+
+func (m *StateProposal) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *StateProposal) GetCreatedOn() int64 {
+	if m != nil {
+		return m.CreatedOn
+	}
+	return 0
+}
+
+func (m *StateProposal) GetModifiedOn() int64 {
+	if m != nil {
+		return m.ModifiedOn
+	}
+	return 0
+}
+
+func (m *StateProposal) GetProposerId() string {
+	if m != nil {
+		return m.ProposerId
+	}
+	return ""
+}
+
+func (m *StateProposal) GetProposedCommand() *ProposedCommand {
+	if m != nil {
+		return m.ProposedCommand
+	}
+	return nil
+}
+
+func (m *StateProposal) GetApproverId() []string {
+	if m != nil {
+		return m.ApproverId
+	}
+	return nil
+}
+
+func (m *StateProposal) GetExpiresOn() int64 {
+	if m != nil {
+		return m.ExpiresOn
+	}
+	return 0
+}
+
+func (m *StateProposal) GetProposalState() ProposalState {
+	if m != nil {
+		return m.ProposalState
+	}
+	return ProposalState_proposalOpen
+}
+
+type ProposedCommand struct {
+	// Types that are valid to be assigned to Body:
+	//	*ProposedCommand_CommandSettingsUpdate
+	//	*ProposedCommand_CommandUpdateAuthorization
+	//	*ProposedCommand_CommandPersonUpdateBalanceIncrement
+	Body                 isProposedCommand_Body `protobuf_oneof:"body"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ProposedCommand) Reset()         { *m = ProposedCommand{} }
+func (m *ProposedCommand) String() string { return proto.CompactTextString(m) }
+func (*ProposedCommand) ProtoMessage()    {}
+func (*ProposedCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3ac5ce23bf32d05, []int{1}
+}
+
+func (m *ProposedCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProposedCommand.Unmarshal(m, b)
+}
+func (m *ProposedCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProposedCommand.Marshal(b, m, deterministic)
+}
+func (m *ProposedCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProposedCommand.Merge(m, src)
+}
+func (m *ProposedCommand) XXX_Size() int {
+	return xxx_messageInfo_ProposedCommand.Size(m)
+}
+func (m *ProposedCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProposedCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProposedCommand proto.InternalMessageInfo
+
+type isProposedCommand_Body interface {
+	isProposedCommand_Body()
+}
+
+type ProposedCommand_CommandSettingsUpdate struct {
+	CommandSettingsUpdate *CommandSettingsUpdate `protobuf:"bytes,1,opt,name=commandSettingsUpdate,proto3,oneof"`
+}
+
+type ProposedCommand_CommandUpdateAuthorization struct {
+	CommandUpdateAuthorization *CommandPersonUpdateAuthorization `protobuf:"bytes,2,opt,name=commandUpdateAuthorization,proto3,oneof"`
+}
+
+type ProposedCommand_CommandPersonUpdateBalanceIncrement struct {
+	CommandPersonUpdateBalanceIncrement *CommandPersonUpdateBalanceIncrement `protobuf:"bytes,3,opt,name=commandPersonUpdateBalanceIncrement,proto3,oneof"`
+}
+
+func (*ProposedCommand_CommandSettingsUpdate) isProposedCommand_Body() {}
+
+func (*ProposedCommand_CommandUpdateAuthorization) isProposedCommand_Body() {}
+
+func (*ProposedCommand_CommandPersonUpdateBalanceIncrement) isProposedCommand_Body() {}
+
+func (m *ProposedCommand) GetBody() isProposedCommand_Body {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *ProposedCommand) GetCommandSettingsUpdate() *CommandSettingsUpdate {
+	if x, ok := m.GetBody().(*ProposedCommand_CommandSettingsUpdate); ok {
+		return x.CommandSettingsUpdate
+	}
+	return nil
+}
+
+func (m *ProposedCommand) GetCommandUpdateAuthorization() *CommandPersonUpdateAuthorization {
+	if x, ok := m.GetBody().(*ProposedCommand_CommandUpdateAuthorization); ok {
+		return x.CommandUpdateAuthorization
+	}
+	return nil
+}
+
+func (m *ProposedCommand) GetCommandPersonUpdateBalanceIncrement() *CommandPersonUpdateBalanceIncrement {
+	if x, ok := m.GetBody().(*ProposedCommand_CommandPersonUpdateBalanceIncrement); ok {
+		return x.CommandPersonUpdateBalanceIncrement
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ProposedCommand) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ProposedCommand_CommandSettingsUpdate)(nil),
+		(*ProposedCommand_CommandUpdateAuthorization)(nil),
+		(*ProposedCommand_CommandPersonUpdateBalanceIncrement)(nil),
+	}
+}
+
+type CommandProposalCreate struct {
+	ProposalId           string           `protobuf:"bytes,1,opt,name=proposalId,proto3" json:"proposalId,omitempty"`
+	ProposedCommand      *ProposedCommand `protobuf:"bytes,2,opt,name=proposedCommand,proto3" json:"proposedCommand,omitempty"`
+	ExpiresOn            int64            `protobuf:"varint,3,opt,name=expiresOn,proto3" json:"expiresOn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CommandProposalCreate) Reset()         { *m = CommandProposalCreate{} }
+func (m *CommandProposalCreate) String() string { return proto.CompactTextString(m) }
+func (*CommandProposalCreate) ProtoMessage()    {}
+func (*CommandProposalCreate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3ac5ce23bf32d05, []int{2}
+}
+
+func (m *CommandProposalCreate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandProposalCreate.Unmarshal(m, b)
+}
+func (m *CommandProposalCreate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandProposalCreate.Marshal(b, m, deterministic)
+}
+func (m *CommandProposalCreate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandProposalCreate.Merge(m, src)
+}
+func (m *CommandProposalCreate) XXX_Size() int {
+	return xxx_messageInfo_CommandProposalCreate.Size(m)
+}
+func (m *CommandProposalCreate) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandProposalCreate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandProposalCreate proto.InternalMessageInfo
+
+func (m *CommandProposalCreate) GetProposalId() string {
+	if m != nil {
+		return m.ProposalId
+	}
+	return ""
+}
+
+func (m *CommandProposalCreate) GetProposedCommand() *ProposedCommand {
+	if m != nil {
+		return m.ProposedCommand
+	}
+	return nil
+}
+
+func (m *CommandProposalCreate) GetExpiresOn() int64 {
+	if m != nil {
+		return m.ExpiresOn
+	}
+	return 0
+}
+
+type CommandProposalApprove struct {
+	ProposalId           string   `protobuf:"bytes,1,opt,name=proposalId,proto3" json:"proposalId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandProposalApprove) Reset()         { *m = CommandProposalApprove{} }
+func (m *CommandProposalApprove) String() string { return proto.CompactTextString(m) }
+func (*CommandProposalApprove) ProtoMessage()    {}
+func (*CommandProposalApprove) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3ac5ce23bf32d05, []int{3}
+}
+
+func (m *CommandProposalApprove) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandProposalApprove.Unmarshal(m, b)
+}
+func (m *CommandProposalApprove) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandProposalApprove.Marshal(b, m, deterministic)
+}
+func (m *CommandProposalApprove) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandProposalApprove.Merge(m, src)
+}
+func (m *CommandProposalApprove) XXX_Size() int {
+	return xxx_messageInfo_CommandProposalApprove.Size(m)
+}
+func (m *CommandProposalApprove) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandProposalApprove.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandProposalApprove proto.InternalMessageInfo
+
+func (m *CommandProposalApprove) GetProposalId() string {
+	if m != nil {
+		return m.ProposalId
+	}
+	return ""
+}
+
+type CommandProposalCancel struct {
+	ProposalId           string   `protobuf:"bytes,1,opt,name=proposalId,proto3" json:"proposalId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandProposalCancel) Reset()         { *m = CommandProposalCancel{} }
+func (m *CommandProposalCancel) String() string { return proto.CompactTextString(m) }
+func (*CommandProposalCancel) ProtoMessage()    {}
+func (*CommandProposalCancel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3ac5ce23bf32d05, []int{4}
+}
+
+func (m *CommandProposalCancel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandProposalCancel.Unmarshal(m, b)
+}
+func (m *CommandProposalCancel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandProposalCancel.Marshal(b, m, deterministic)
+}
+func (m *CommandProposalCancel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandProposalCancel.Merge(m, src)
+}
+func (m *CommandProposalCancel) XXX_Size() int {
+	return xxx_messageInfo_CommandProposalCancel.Size(m)
+}
+func (m *CommandProposalCancel) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandProposalCancel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandProposalCancel proto.InternalMessageInfo
+
+func (m *CommandProposalCancel) GetProposalId() string {
+	if m != nil {
+		return m.ProposalId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("ProposalState", ProposalState_name, ProposalState_value)
+	proto.RegisterType((*StateProposal)(nil), "StateProposal")
+	proto.RegisterType((*ProposedCommand)(nil), "ProposedCommand")
+	proto.RegisterType((*CommandProposalCreate)(nil), "CommandProposalCreate")
+	proto.RegisterType((*CommandProposalApprove)(nil), "CommandProposalApprove")
+	proto.RegisterType((*CommandProposalCancel)(nil), "CommandProposalCancel")
+}
+
+func init() { proto.RegisterFile("proposal.proto", fileDescriptor_c3ac5ce23bf32d05) }
+
+var fileDescriptor_c3ac5ce23bf32d05 = []byte{
+	// 444 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0x8d, 0x9d, 0x36, 0xa5, 0x93, 0xc6, 0x35, 0x2b, 0x52, 0x59, 0x15, 0x42, 0xc6, 0x70, 0x88,
+	0x38, 0xf8, 0x10, 0x90, 0x40, 0xdc, 0x9a, 0x08, 0x29, 0xb9, 0x34, 0x91, 0x2b, 0x2e, 0xdc, 0xb6,
+	0xde, 0x01, 0x56, 0xb2, 0x77, 0x57, 0xeb, 0x2d, 0x0a, 0xfc, 0x05, 0x1f, 0xc2, 0x7f, 0xf0, 0x59,
+	0xc8, 0x6b, 0x9b, 0xc4, 0xa9, 0xd5, 0xf4, 0x96, 0x7d, 0x6f, 0xe6, 0xe5, 0xcd, 0xcc, 0x33, 0x78,
+	0x4a, 0x4b, 0x25, 0x0b, 0x9a, 0xc5, 0x4a, 0x4b, 0x23, 0x2f, 0xcf, 0x14, 0xea, 0x42, 0x8a, 0xfa,
+	0xe5, 0x15, 0x68, 0x0c, 0x17, 0xdf, 0x8a, 0xea, 0x1d, 0xfd, 0x71, 0x61, 0x74, 0x63, 0xa8, 0xc1,
+	0x75, 0xdd, 0x45, 0x3c, 0x70, 0x39, 0x0b, 0x9c, 0xd0, 0x99, 0x9c, 0x26, 0x2e, 0x67, 0xe4, 0x39,
+	0x9c, 0xa6, 0x1a, 0xa9, 0x41, 0xb6, 0x12, 0x81, 0x1b, 0x3a, 0x93, 0x7e, 0xb2, 0x05, 0xc8, 0x0b,
+	0x80, 0x5c, 0x32, 0xfe, 0x95, 0x5b, 0xba, 0x6f, 0xe9, 0x1d, 0xa4, 0xe4, 0x2b, 0x3f, 0xa8, 0x97,
+	0x2c, 0x38, 0xb2, 0xaa, 0x3b, 0x08, 0xf9, 0x08, 0xe7, 0xf5, 0x8b, 0xcd, 0x65, 0x9e, 0x53, 0xc1,
+	0x82, 0xe3, 0xd0, 0x99, 0x0c, 0xa7, 0x7e, 0xbc, 0x6e, 0xe3, 0xc9, 0x7e, 0x61, 0xa9, 0x4d, 0x95,
+	0xd2, 0xf2, 0x87, 0xd5, 0x1e, 0x84, 0xfd, 0x52, 0x7b, 0x8b, 0x94, 0xce, 0x71, 0xa3, 0xb8, 0xc6,
+	0x62, 0x25, 0x82, 0x93, 0xca, 0xf9, 0x7f, 0x80, 0xbc, 0x83, 0x51, 0xb3, 0x29, 0xbb, 0x80, 0xe0,
+	0x49, 0xe8, 0x4c, 0xbc, 0xa9, 0x17, 0xaf, 0x77, 0xd1, 0xa4, 0x5d, 0x14, 0xfd, 0x75, 0xe1, 0x7c,
+	0xcf, 0x18, 0xb9, 0x86, 0x71, 0x5a, 0xfd, 0xbc, 0xa9, 0x97, 0xfb, 0x59, 0xb1, 0x52, 0xd1, 0xb1,
+	0x93, 0x5c, 0xc4, 0xf3, 0x2e, 0x76, 0xd1, 0x4b, 0xba, 0xdb, 0x48, 0x0a, 0x97, 0x35, 0x51, 0x01,
+	0x57, 0x77, 0xe6, 0xbb, 0xd4, 0xfc, 0x17, 0x35, 0x5c, 0x56, 0x27, 0x18, 0x4e, 0x5f, 0x36, 0xa2,
+	0x6b, 0x7b, 0xdd, 0x8e, 0xc2, 0x45, 0x2f, 0x79, 0x40, 0x86, 0x6c, 0xe0, 0x55, 0x7a, 0x5f, 0x61,
+	0x46, 0x33, 0x2a, 0x52, 0x5c, 0x8a, 0x54, 0x63, 0x8e, 0xc2, 0xd8, 0x8b, 0x0e, 0xa7, 0xaf, 0xe3,
+	0xf9, 0xe1, 0xda, 0x45, 0x2f, 0x79, 0x8c, 0xe4, 0x6c, 0x00, 0x47, 0xb7, 0x92, 0xfd, 0x8c, 0x7e,
+	0x3b, 0x30, 0x6e, 0x64, 0xeb, 0x1d, 0xcf, 0x6d, 0xae, 0xb6, 0xa1, 0xa1, 0xd9, 0xb2, 0x89, 0xe2,
+	0x0e, 0xd2, 0x15, 0x1a, 0xf7, 0xb1, 0xa1, 0x69, 0x85, 0xa2, 0xbf, 0x17, 0x8a, 0xe8, 0x03, 0x5c,
+	0xec, 0x59, 0xba, 0xaa, 0xf2, 0x74, 0xc8, 0x53, 0xf4, 0xfe, 0xfe, 0x30, 0xe5, 0xd8, 0xd9, 0xa1,
+	0xc6, 0x37, 0xd7, 0x30, 0x6a, 0x25, 0x8e, 0xf8, 0x70, 0xd6, 0xd0, 0x2b, 0x85, 0xc2, 0xef, 0x91,
+	0x67, 0xe0, 0x37, 0xc8, 0xa7, 0x0d, 0xa6, 0x77, 0x06, 0x99, 0xef, 0x90, 0x31, 0x3c, 0x55, 0xad,
+	0xbf, 0xca, 0x90, 0xf9, 0xee, 0xec, 0xe4, 0xcb, 0x71, 0x2e, 0x19, 0x66, 0xb7, 0x03, 0xfb, 0x85,
+	0xbf, 0xfd, 0x37, 0x00, 0xbe, 0xf2, 0xed, 0x06, 0x11, 0x04, 0x00, 0x00,
+}
@@ -29,7 +29,12 @@ var TableCreateSettings = `
 	priceeditorcreatevolume integer not null,
 	priceeditoreditjournal integer not null,
 	priceeditoraddcolleague integer not null,
-	priceeditoracceptduty integer not null)
+	priceeditoracceptduty integer not null,
+	priceauthorwithdrawmanuscript integer not null,
+	priceeditorinvitereviewer integer not null,
+	approvalthreshold integer not null,
+	minimumfamilyversion varchar not null,
+	majorcount integer not null)
 `
 
 const (
@@ -57,6 +62,11 @@ const (
 	EV_KEY_PRICE_EDITOR_EDIT_JOURNAL                = "priceEditorEditJournal"
 	EV_KEY_PRICE_EDITOR_ADD_COLLEAGUE               = "priceEditorAddColleague"
 	EV_KEY_PRICE_EDITOR_ACCEPT_DUTY                 = "priceEditorAcceptDuty"
+	EV_KEY_PRICE_AUTHOR_WITHDRAW_MANUSCRIPT         = "priceAuthorWithdrawManuscript"
+	EV_KEY_PRICE_EDITOR_INVITE_REVIEWER             = "priceEditorInviteReviewer"
+	EV_KEY_APPROVAL_THRESHOLD                       = "approvalThreshold"
+	EV_KEY_MINIMUM_FAMILY_VERSION                   = "minimumFamilyVersion"
+	EV_KEY_MAJOR_COUNT                              = "majorCount"
 )
 
 func GetSettingsAddress() string {
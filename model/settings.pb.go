@@ -24,6 +24,9 @@ type StateSettings struct {
 	CreatedOn            int64      `protobuf:"varint,1,opt,name=createdOn,proto3" json:"createdOn,omitempty"`
 	ModifiedOn           int64      `protobuf:"varint,2,opt,name=modifiedOn,proto3" json:"modifiedOn,omitempty"`
 	PriceList            *PriceList `protobuf:"bytes,3,opt,name=priceList,proto3" json:"priceList,omitempty"`
+	ApprovalThreshold    int32      `protobuf:"varint,4,opt,name=approvalThreshold,proto3" json:"approvalThreshold,omitempty"`
+	MinimumFamilyVersion string     `protobuf:"bytes,5,opt,name=minimumFamilyVersion,proto3" json:"minimumFamilyVersion,omitempty"`
+	MajorCount           int32      `protobuf:"varint,6,opt,name=majorCount,proto3" json:"majorCount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
@@ -75,6 +78,27 @@ func (m *StateSettings) GetPriceList() *PriceList {
 	return nil
 }
 
+func (m *StateSettings) GetApprovalThreshold() int32 {
+	if m != nil {
+		return m.ApprovalThreshold
+	}
+	return 0
+}
+
+func (m *StateSettings) GetMinimumFamilyVersion() string {
+	if m != nil {
+		return m.MinimumFamilyVersion
+	}
+	return ""
+}
+
+func (m *StateSettings) GetMajorCount() int32 {
+	if m != nil {
+		return m.MajorCount
+	}
+	return 0
+}
+
 type PriceList struct {
 	PriceMajorEditSettings               int32    `protobuf:"varint,1,opt,name=priceMajorEditSettings,proto3" json:"priceMajorEditSettings,omitempty"`
 	PriceMajorCreatePerson               int32    `protobuf:"varint,2,opt,name=priceMajorCreatePerson,proto3" json:"priceMajorCreatePerson,omitempty"`
@@ -94,6 +118,8 @@ type PriceList struct {
 	PriceEditorEditJournal               int32    `protobuf:"varint,16,opt,name=priceEditorEditJournal,proto3" json:"priceEditorEditJournal,omitempty"`
 	PriceEditorAddColleague              int32    `protobuf:"varint,17,opt,name=priceEditorAddColleague,proto3" json:"priceEditorAddColleague,omitempty"`
 	PriceEditorAcceptDuty                int32    `protobuf:"varint,18,opt,name=priceEditorAcceptDuty,proto3" json:"priceEditorAcceptDuty,omitempty"`
+	PriceAuthorWithdrawManuscript        int32    `protobuf:"varint,19,opt,name=priceAuthorWithdrawManuscript,proto3" json:"priceAuthorWithdrawManuscript,omitempty"`
+	PriceEditorInviteReviewer            int32    `protobuf:"varint,20,opt,name=priceEditorInviteReviewer,proto3" json:"priceEditorInviteReviewer,omitempty"`
 	XXX_NoUnkeyedLiteral                 struct{} `json:"-"`
 	XXX_unrecognized                     []byte   `json:"-"`
 	XXX_sizecache                        int32    `json:"-"`
@@ -250,6 +276,20 @@ func (m *PriceList) GetPriceEditorAcceptDuty() int32 {
 	return 0
 }
 
+func (m *PriceList) GetPriceAuthorWithdrawManuscript() int32 {
+	if m != nil {
+		return m.PriceAuthorWithdrawManuscript
+	}
+	return 0
+}
+
+func (m *PriceList) GetPriceEditorInviteReviewer() int32 {
+	if m != nil {
+		return m.PriceEditorInviteReviewer
+	}
+	return 0
+}
+
 type CommandBootstrap struct {
 	PriceList            *PriceList           `protobuf:"bytes,1,opt,name=priceList,proto3" json:"priceList,omitempty"`
 	FirstMajor           *CommandPersonCreate `protobuf:"bytes,2,opt,name=firstMajor,proto3" json:"firstMajor,omitempty"`
@@ -298,27 +338,32 @@ func (m *CommandBootstrap) GetFirstMajor() *CommandPersonCreate {
 }
 
 type CommandSettingsUpdate struct {
-	PriceMajorEditSettingsUpdate               *IntUpdate `protobuf:"bytes,1,opt,name=priceMajorEditSettingsUpdate,proto3" json:"priceMajorEditSettingsUpdate,omitempty"`
-	PriceMajorCreatePersonUpdate               *IntUpdate `protobuf:"bytes,2,opt,name=priceMajorCreatePersonUpdate,proto3" json:"priceMajorCreatePersonUpdate,omitempty"`
-	PriceMajorChangePersonAuthorizationUpdate  *IntUpdate `protobuf:"bytes,3,opt,name=priceMajorChangePersonAuthorizationUpdate,proto3" json:"priceMajorChangePersonAuthorizationUpdate,omitempty"`
-	PriceMajorChangeJournalAuthorizationUpdate *IntUpdate `protobuf:"bytes,4,opt,name=priceMajorChangeJournalAuthorizationUpdate,proto3" json:"priceMajorChangeJournalAuthorizationUpdate,omitempty"`
-	PricePersonEditUpdate                      *IntUpdate `protobuf:"bytes,5,opt,name=pricePersonEditUpdate,proto3" json:"pricePersonEditUpdate,omitempty"`
-	PriceAuthorSubmitNewManuscriptUpdate       *IntUpdate `protobuf:"bytes,6,opt,name=priceAuthorSubmitNewManuscriptUpdate,proto3" json:"priceAuthorSubmitNewManuscriptUpdate,omitempty"`
-	PriceAuthorSubmitNewVersionUpdate          *IntUpdate `protobuf:"bytes,7,opt,name=priceAuthorSubmitNewVersionUpdate,proto3" json:"priceAuthorSubmitNewVersionUpdate,omitempty"`
-	PriceAuthorAcceptAuthorshipUpdate          *IntUpdate `protobuf:"bytes,8,opt,name=priceAuthorAcceptAuthorshipUpdate,proto3" json:"priceAuthorAcceptAuthorshipUpdate,omitempty"`
-	PriceReviewerSubmitUpdate                  *IntUpdate `protobuf:"bytes,9,opt,name=priceReviewerSubmitUpdate,proto3" json:"priceReviewerSubmitUpdate,omitempty"`
-	PriceEditorAllowManuscriptReviewUpdate     *IntUpdate `protobuf:"bytes,10,opt,name=priceEditorAllowManuscriptReviewUpdate,proto3" json:"priceEditorAllowManuscriptReviewUpdate,omitempty"`
-	PriceEditorRejectManuscriptUpdate          *IntUpdate `protobuf:"bytes,11,opt,name=priceEditorRejectManuscriptUpdate,proto3" json:"priceEditorRejectManuscriptUpdate,omitempty"`
-	PriceEditorPublishManuscriptUpdate         *IntUpdate `protobuf:"bytes,12,opt,name=priceEditorPublishManuscriptUpdate,proto3" json:"priceEditorPublishManuscriptUpdate,omitempty"`
-	PriceEditorAssignManuscriptUpdate          *IntUpdate `protobuf:"bytes,13,opt,name=priceEditorAssignManuscriptUpdate,proto3" json:"priceEditorAssignManuscriptUpdate,omitempty"`
-	PriceEditorCreateJournalUpdate             *IntUpdate `protobuf:"bytes,14,opt,name=priceEditorCreateJournalUpdate,proto3" json:"priceEditorCreateJournalUpdate,omitempty"`
-	PriceEditorCreateVolumeUpdate              *IntUpdate `protobuf:"bytes,15,opt,name=priceEditorCreateVolumeUpdate,proto3" json:"priceEditorCreateVolumeUpdate,omitempty"`
-	PriceEditorEditJournalUpdate               *IntUpdate `protobuf:"bytes,16,opt,name=priceEditorEditJournalUpdate,proto3" json:"priceEditorEditJournalUpdate,omitempty"`
-	PriceEditorAddColleagueUpdate              *IntUpdate `protobuf:"bytes,17,opt,name=priceEditorAddColleagueUpdate,proto3" json:"priceEditorAddColleagueUpdate,omitempty"`
-	PriceEditorAcceptDutyUpdate                *IntUpdate `protobuf:"bytes,18,opt,name=priceEditorAcceptDutyUpdate,proto3" json:"priceEditorAcceptDutyUpdate,omitempty"`
-	XXX_NoUnkeyedLiteral                       struct{}   `json:"-"`
-	XXX_unrecognized                           []byte     `json:"-"`
-	XXX_sizecache                              int32      `json:"-"`
+	PriceMajorEditSettingsUpdate               *IntUpdate    `protobuf:"bytes,1,opt,name=priceMajorEditSettingsUpdate,proto3" json:"priceMajorEditSettingsUpdate,omitempty"`
+	PriceMajorCreatePersonUpdate               *IntUpdate    `protobuf:"bytes,2,opt,name=priceMajorCreatePersonUpdate,proto3" json:"priceMajorCreatePersonUpdate,omitempty"`
+	PriceMajorChangePersonAuthorizationUpdate  *IntUpdate    `protobuf:"bytes,3,opt,name=priceMajorChangePersonAuthorizationUpdate,proto3" json:"priceMajorChangePersonAuthorizationUpdate,omitempty"`
+	PriceMajorChangeJournalAuthorizationUpdate *IntUpdate    `protobuf:"bytes,4,opt,name=priceMajorChangeJournalAuthorizationUpdate,proto3" json:"priceMajorChangeJournalAuthorizationUpdate,omitempty"`
+	PricePersonEditUpdate                      *IntUpdate    `protobuf:"bytes,5,opt,name=pricePersonEditUpdate,proto3" json:"pricePersonEditUpdate,omitempty"`
+	PriceAuthorSubmitNewManuscriptUpdate       *IntUpdate    `protobuf:"bytes,6,opt,name=priceAuthorSubmitNewManuscriptUpdate,proto3" json:"priceAuthorSubmitNewManuscriptUpdate,omitempty"`
+	PriceAuthorSubmitNewVersionUpdate          *IntUpdate    `protobuf:"bytes,7,opt,name=priceAuthorSubmitNewVersionUpdate,proto3" json:"priceAuthorSubmitNewVersionUpdate,omitempty"`
+	PriceAuthorAcceptAuthorshipUpdate          *IntUpdate    `protobuf:"bytes,8,opt,name=priceAuthorAcceptAuthorshipUpdate,proto3" json:"priceAuthorAcceptAuthorshipUpdate,omitempty"`
+	PriceReviewerSubmitUpdate                  *IntUpdate    `protobuf:"bytes,9,opt,name=priceReviewerSubmitUpdate,proto3" json:"priceReviewerSubmitUpdate,omitempty"`
+	PriceEditorAllowManuscriptReviewUpdate     *IntUpdate    `protobuf:"bytes,10,opt,name=priceEditorAllowManuscriptReviewUpdate,proto3" json:"priceEditorAllowManuscriptReviewUpdate,omitempty"`
+	PriceEditorRejectManuscriptUpdate          *IntUpdate    `protobuf:"bytes,11,opt,name=priceEditorRejectManuscriptUpdate,proto3" json:"priceEditorRejectManuscriptUpdate,omitempty"`
+	PriceEditorPublishManuscriptUpdate         *IntUpdate    `protobuf:"bytes,12,opt,name=priceEditorPublishManuscriptUpdate,proto3" json:"priceEditorPublishManuscriptUpdate,omitempty"`
+	PriceEditorAssignManuscriptUpdate          *IntUpdate    `protobuf:"bytes,13,opt,name=priceEditorAssignManuscriptUpdate,proto3" json:"priceEditorAssignManuscriptUpdate,omitempty"`
+	PriceEditorCreateJournalUpdate             *IntUpdate    `protobuf:"bytes,14,opt,name=priceEditorCreateJournalUpdate,proto3" json:"priceEditorCreateJournalUpdate,omitempty"`
+	PriceEditorCreateVolumeUpdate              *IntUpdate    `protobuf:"bytes,15,opt,name=priceEditorCreateVolumeUpdate,proto3" json:"priceEditorCreateVolumeUpdate,omitempty"`
+	PriceEditorEditJournalUpdate               *IntUpdate    `protobuf:"bytes,16,opt,name=priceEditorEditJournalUpdate,proto3" json:"priceEditorEditJournalUpdate,omitempty"`
+	PriceEditorAddColleagueUpdate              *IntUpdate    `protobuf:"bytes,17,opt,name=priceEditorAddColleagueUpdate,proto3" json:"priceEditorAddColleagueUpdate,omitempty"`
+	PriceEditorAcceptDutyUpdate                *IntUpdate    `protobuf:"bytes,18,opt,name=priceEditorAcceptDutyUpdate,proto3" json:"priceEditorAcceptDutyUpdate,omitempty"`
+	PriceAuthorWithdrawManuscriptUpdate        *IntUpdate    `protobuf:"bytes,19,opt,name=priceAuthorWithdrawManuscriptUpdate,proto3" json:"priceAuthorWithdrawManuscriptUpdate,omitempty"`
+	PriceEditorInviteReviewerUpdate            *IntUpdate    `protobuf:"bytes,20,opt,name=priceEditorInviteReviewerUpdate,proto3" json:"priceEditorInviteReviewerUpdate,omitempty"`
+	ApprovalThresholdUpdate                    *IntUpdate    `protobuf:"bytes,21,opt,name=approvalThresholdUpdate,proto3" json:"approvalThresholdUpdate,omitempty"`
+	MinimumFamilyVersionUpdate                 *StringUpdate `protobuf:"bytes,22,opt,name=minimumFamilyVersionUpdate,proto3" json:"minimumFamilyVersionUpdate,omitempty"`
+	MajorIds                                   []string      `protobuf:"bytes,23,rep,name=majorIds,proto3" json:"majorIds,omitempty"`
+	XXX_NoUnkeyedLiteral                       struct{}      `json:"-"`
+	XXX_unrecognized                           []byte        `json:"-"`
+	XXX_sizecache                              int32         `json:"-"`
 }
 
 func (m *CommandSettingsUpdate) Reset()         { *m = CommandSettingsUpdate{} }
@@ -472,6 +517,41 @@ func (m *CommandSettingsUpdate) GetPriceEditorAcceptDutyUpdate() *IntUpdate {
 	return nil
 }
 
+func (m *CommandSettingsUpdate) GetPriceAuthorWithdrawManuscriptUpdate() *IntUpdate {
+	if m != nil {
+		return m.PriceAuthorWithdrawManuscriptUpdate
+	}
+	return nil
+}
+
+func (m *CommandSettingsUpdate) GetPriceEditorInviteReviewerUpdate() *IntUpdate {
+	if m != nil {
+		return m.PriceEditorInviteReviewerUpdate
+	}
+	return nil
+}
+
+func (m *CommandSettingsUpdate) GetApprovalThresholdUpdate() *IntUpdate {
+	if m != nil {
+		return m.ApprovalThresholdUpdate
+	}
+	return nil
+}
+
+func (m *CommandSettingsUpdate) GetMinimumFamilyVersionUpdate() *StringUpdate {
+	if m != nil {
+		return m.MinimumFamilyVersionUpdate
+	}
+	return nil
+}
+
+func (m *CommandSettingsUpdate) GetMajorIds() []string {
+	if m != nil {
+		return m.MajorIds
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*StateSettings)(nil), "StateSettings")
 	proto.RegisterType((*PriceList)(nil), "PriceList")
@@ -482,54 +562,65 @@ func init() {
 func init() { proto.RegisterFile("settings.proto", fileDescriptor_6c7cab62fa432213) }
 
 var fileDescriptor_6c7cab62fa432213 = []byte{
-	// 773 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x96, 0x5f, 0x4f, 0xdb, 0x48,
-	0x14, 0xc5, 0x15, 0x20, 0x40, 0x6e, 0xc2, 0xbf, 0xbb, 0xcb, 0xae, 0x77, 0x4b, 0x11, 0x4d, 0xab,
-	0x2a, 0xed, 0x03, 0xaa, 0x28, 0xaa, 0xaa, 0x3e, 0x11, 0x68, 0xab, 0x16, 0x01, 0x8d, 0x8c, 0x8a,
-	0x2a, 0x1e, 0x2a, 0x39, 0xf1, 0x90, 0x0c, 0xb2, 0x3d, 0x96, 0x3d, 0x2e, 0x6a, 0xbf, 0x58, 0x3f,
-	0x5c, 0x5f, 0xaa, 0x8c, 0x6f, 0xc2, 0xc4, 0x63, 0x3b, 0xee, 0x0b, 0x22, 0x73, 0xce, 0xf9, 0xcd,
-	0xb5, 0x65, 0x9f, 0x31, 0xac, 0xc7, 0x4c, 0x4a, 0x1e, 0x0c, 0xe3, 0xfd, 0x30, 0x12, 0x52, 0xfc,
-	0xdf, 0x1a, 0x08, 0xdf, 0x17, 0xc1, 0xe4, 0x57, 0xc8, 0xa2, 0x78, 0xf2, 0xab, 0x7d, 0x07, 0x6b,
-	0x97, 0xd2, 0x91, 0xec, 0x92, 0x22, 0xb8, 0x03, 0x8d, 0x41, 0xc4, 0x1c, 0xc9, 0xdc, 0x4f, 0x81,
-	0x55, 0xdb, 0xab, 0x75, 0x16, 0xed, 0xfb, 0x05, 0xdc, 0x05, 0xf0, 0x85, 0xcb, 0x6f, 0xb8, 0x92,
-	0x17, 0x94, 0xac, 0xad, 0x60, 0x07, 0x1a, 0x61, 0xc4, 0x07, 0xec, 0x8c, 0xc7, 0xd2, 0x5a, 0xdc,
-	0xab, 0x75, 0x9a, 0x07, 0xb0, 0xdf, 0x9b, 0xac, 0xd8, 0xf7, 0x62, 0xfb, 0xd7, 0x2a, 0x34, 0xa6,
-	0x02, 0xbe, 0x82, 0x7f, 0x94, 0x74, 0xee, 0xdc, 0x8a, 0xe8, 0x9d, 0xcb, 0xe5, 0x64, 0x1e, 0x35,
-	0x42, 0xdd, 0x2e, 0x50, 0x67, 0x73, 0x27, 0x6a, 0xcc, 0x9e, 0xba, 0x3c, 0x35, 0xdb, 0x4c, 0x4e,
-	0x57, 0xb1, 0x07, 0x8f, 0x35, 0x65, 0xe4, 0x04, 0x43, 0x52, 0xba, 0x89, 0x1c, 0x89, 0x88, 0xff,
-	0x70, 0x24, 0x17, 0x81, 0xba, 0x82, 0xba, 0x5d, 0xc5, 0x8a, 0x36, 0x3c, 0xc9, 0xda, 0x4e, 0x45,
-	0x12, 0x05, 0x8e, 0x37, 0x8b, 0x5c, 0x52, 0xc8, 0x4a, 0x5e, 0xec, 0xc0, 0x86, 0xf2, 0xa5, 0xfb,
-	0x8d, 0x2f, 0xdc, 0xaa, 0xab, 0x78, 0x76, 0x19, 0xdf, 0xc3, 0xae, 0x5a, 0x4a, 0xf3, 0x97, 0x49,
-	0xdf, 0xe7, 0xf2, 0x82, 0xdd, 0x9d, 0x3b, 0x41, 0x12, 0x0f, 0x22, 0x1e, 0x4a, 0x6b, 0x59, 0x05,
-	0xe7, 0xb8, 0xf0, 0x08, 0x1e, 0xe4, 0x39, 0xae, 0x58, 0x14, 0x8f, 0x87, 0x5f, 0x51, 0x90, 0x32,
-	0x4b, 0x86, 0xd0, 0x1d, 0x0c, 0x58, 0x28, 0xd3, 0xff, 0xe3, 0x11, 0x0f, 0xad, 0x55, 0x83, 0x90,
-	0xb5, 0xe0, 0x0b, 0xf8, 0x4b, 0xc9, 0x36, 0xfb, 0xc6, 0xd9, 0x1d, 0xa3, 0x2d, 0xac, 0x86, 0x4a,
-	0xe6, 0x49, 0x78, 0x0a, 0x7b, 0x6a, 0x79, 0x7c, 0x2b, 0x44, 0xd4, 0xf5, 0x3c, 0xa1, 0x5d, 0x53,
-	0xea, 0xb5, 0x40, 0xc5, 0xe7, 0xfa, 0xa6, 0xf3, 0xa7, 0x1e, 0x9b, 0xdd, 0xb2, 0x81, 0xd4, 0x6e,
-	0x63, 0x53, 0x9b, 0x3f, 0xdf, 0x82, 0xc7, 0xb0, 0xa3, 0xc9, 0xbd, 0xa4, 0xef, 0xf1, 0x78, 0xa4,
-	0x21, 0x5a, 0x0a, 0x51, 0xea, 0xc9, 0x4c, 0xd1, 0x8d, 0x63, 0x3e, 0x0c, 0x34, 0xc4, 0x9a, 0x31,
-	0x45, 0xd6, 0x82, 0x6f, 0xc0, 0xd2, 0xe4, 0xf4, 0xe1, 0xa7, 0x87, 0xcc, 0x5a, 0x57, 0xf1, 0x42,
-	0x1d, 0x5f, 0xc3, 0xbf, 0x86, 0x76, 0x25, 0xbc, 0xc4, 0x67, 0xd6, 0x86, 0x8a, 0x16, 0xc9, 0xd3,
-	0xf7, 0x31, 0x95, 0xc6, 0x7f, 0x27, 0x7b, 0x6e, 0x6a, 0xef, 0xa3, 0xa1, 0x66, 0x76, 0xec, 0xba,
-	0xee, 0x89, 0xf0, 0x3c, 0xe6, 0x0c, 0x13, 0x66, 0x6d, 0x19, 0x3b, 0xea, 0x32, 0x1e, 0xc2, 0xb6,
-	0x2e, 0xa9, 0x87, 0xe9, 0x6d, 0x22, 0xbf, 0x5b, 0xa8, 0x72, 0xf9, 0x62, 0x3b, 0x82, 0xcd, 0x13,
-	0xe1, 0xfb, 0x4e, 0xe0, 0x1e, 0x0b, 0x21, 0x63, 0x19, 0x39, 0xe1, 0x6c, 0x77, 0xd5, 0x4a, 0xba,
-	0x0b, 0x0f, 0x01, 0x6e, 0x78, 0x14, 0x4b, 0xf5, 0xfe, 0xaa, 0xa6, 0x69, 0x1e, 0xfc, 0xbd, 0x4f,
-	0xc0, 0xf4, 0xad, 0x4c, 0xef, 0x8a, 0xad, 0xf9, 0xda, 0x3f, 0x5b, 0xb0, 0x4d, 0x9e, 0x49, 0x7f,
-	0x7d, 0x0e, 0x5d, 0x47, 0x32, 0xbc, 0xa0, 0x27, 0xc6, 0xe8, 0xb7, 0x54, 0x9f, 0x0e, 0xf3, 0x31,
-	0x90, 0xe9, 0x8a, 0x5d, 0xea, 0x9f, 0xe5, 0xe9, 0xbd, 0x47, 0xbc, 0x85, 0x32, 0x9e, 0xe9, 0xc7,
-	0x11, 0x3c, 0xab, 0x50, 0x81, 0x04, 0x5f, 0x34, 0xe0, 0xd5, 0xc3, 0x78, 0x0b, 0xcf, 0xab, 0x34,
-	0x23, 0x6d, 0xb5, 0x64, 0x6c, 0xf5, 0x07, 0x69, 0x3c, 0xa2, 0x27, 0xe7, 0xbe, 0x46, 0x09, 0x5b,
-	0x37, 0xb0, 0xf9, 0x46, 0xfc, 0x4a, 0x9d, 0x5f, 0xd8, 0xa7, 0x04, 0x5c, 0x36, 0x80, 0x95, 0x72,
-	0xf8, 0x05, 0x1e, 0x95, 0x54, 0x2d, 0xc1, 0x57, 0x0c, 0xf8, 0xfc, 0x50, 0x86, 0x9c, 0xad, 0x60,
-	0x22, 0xaf, 0x96, 0x92, 0xf3, 0x43, 0xf8, 0x01, 0xfe, 0xcb, 0xa9, 0x68, 0x22, 0x36, 0x0c, 0x62,
-	0xb1, 0x19, 0xfb, 0xf0, 0x74, 0x5e, 0x5b, 0x13, 0x16, 0x0c, 0x6c, 0xc5, 0xe4, 0xf4, 0x3e, 0xe4,
-	0x57, 0x39, 0xe1, 0x9b, 0x05, 0xf7, 0xa1, 0x2c, 0x84, 0xd7, 0xd0, 0x2e, 0x6b, 0x78, 0x42, 0xb7,
-	0x0c, 0x74, 0x85, 0x54, 0x66, 0xea, 0x6c, 0xf5, 0x13, 0x7a, 0xad, 0x74, 0xea, 0xfc, 0x10, 0xda,
-	0xf4, 0x1d, 0x91, 0x73, 0x2a, 0x10, 0x76, 0xdd, 0xc0, 0xce, 0x49, 0x60, 0x0f, 0x1e, 0x16, 0x1c,
-	0x17, 0x84, 0xdc, 0x30, 0x90, 0xe5, 0x81, 0x69, 0xbf, 0x19, 0xe7, 0x08, 0x01, 0x37, 0x0b, 0xfa,
-	0xad, 0xc0, 0x9f, 0x99, 0x50, 0x3f, 0x5e, 0x08, 0xb8, 0x55, 0x3a, 0xa1, 0x19, 0xc0, 0xb3, 0xd9,
-	0xf3, 0x7b, 0x7a, 0xf0, 0x10, 0x0f, 0x0d, 0x5e, 0x99, 0xfd, 0x78, 0xe5, 0xba, 0xee, 0x0b, 0x97,
-	0x79, 0xfd, 0x65, 0xf5, 0xd1, 0xfe, 0xf2, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0xbf, 0x96, 0x68,
-	0x8f, 0xe2, 0x0b, 0x00, 0x00,
+	// 945 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x97, 0xef, 0x6f, 0xdb, 0x44,
+	0x18, 0xc7, 0x95, 0x65, 0x69, 0x9b, 0xa7, 0xbf, 0x9f, 0xb6, 0xab, 0x29, 0x5b, 0x09, 0x01, 0xa1,
+	0x80, 0x50, 0x85, 0xca, 0x84, 0x10, 0xe2, 0xc5, 0xd2, 0x96, 0x89, 0x4e, 0xeb, 0x88, 0xdc, 0x31,
+	0xd0, 0x84, 0x90, 0xdc, 0xf8, 0x96, 0x5c, 0x65, 0xfb, 0xac, 0xf3, 0xb9, 0xd3, 0xf8, 0x5b, 0xf8,
+	0x47, 0x79, 0x05, 0xca, 0xf9, 0x89, 0x7b, 0xf1, 0xd9, 0x8e, 0x79, 0x53, 0xd5, 0xf7, 0xfd, 0x7e,
+	0x3f, 0x77, 0x3e, 0x9f, 0x9f, 0x27, 0x86, 0xad, 0x84, 0x29, 0xc5, 0xa3, 0x49, 0x72, 0x12, 0x4b,
+	0xa1, 0xc4, 0xd1, 0xc6, 0x58, 0x84, 0xa1, 0x88, 0xe6, 0x57, 0x31, 0x93, 0xc9, 0xfc, 0xaa, 0xff,
+	0x4f, 0x0b, 0x36, 0xaf, 0x95, 0xa7, 0xd8, 0x35, 0x65, 0xf0, 0x31, 0x74, 0xc7, 0x92, 0x79, 0x8a,
+	0xf9, 0xbf, 0x44, 0x4e, 0xab, 0xd7, 0x1a, 0xb4, 0xdd, 0xfb, 0x01, 0x3c, 0x06, 0x08, 0x85, 0xcf,
+	0xdf, 0x71, 0x2d, 0x3f, 0xd0, 0xb2, 0x31, 0x82, 0x03, 0xe8, 0xc6, 0x92, 0x8f, 0xd9, 0x4b, 0x9e,
+	0x28, 0xa7, 0xdd, 0x6b, 0x0d, 0xd6, 0x4f, 0xe1, 0x64, 0x34, 0x1f, 0x71, 0xef, 0x45, 0xfc, 0x1a,
+	0x76, 0xbd, 0x38, 0x96, 0xe2, 0xce, 0x0b, 0x5e, 0x4f, 0x25, 0x4b, 0xa6, 0x22, 0xf0, 0x9d, 0x87,
+	0xbd, 0xd6, 0xa0, 0xe3, 0xda, 0x02, 0x9e, 0xc2, 0x7e, 0xc8, 0x23, 0x1e, 0xa6, 0xe1, 0x73, 0x2f,
+	0xe4, 0xc1, 0x87, 0x37, 0x4c, 0x26, 0x5c, 0x44, 0x4e, 0xa7, 0xd7, 0x1a, 0x74, 0xdd, 0x52, 0x4d,
+	0xaf, 0xd5, 0xbb, 0x15, 0xf2, 0x5c, 0xa4, 0x91, 0x72, 0x56, 0x34, 0xda, 0x18, 0xe9, 0xff, 0xdb,
+	0x85, 0x6e, 0xbe, 0x34, 0xfc, 0x0e, 0x1e, 0xe9, 0xc5, 0x5d, 0xcd, 0x0c, 0x3f, 0xf9, 0x5c, 0xcd,
+	0x77, 0x44, 0x6f, 0x42, 0xc7, 0xad, 0x50, 0x17, 0x73, 0xe7, 0x7a, 0xa3, 0x46, 0x7a, 0x87, 0x9d,
+	0x07, 0xc5, 0x9c, 0xa9, 0xe2, 0x08, 0x3e, 0x33, 0x94, 0xa9, 0x17, 0x4d, 0x48, 0x19, 0xa6, 0x6a,
+	0x2a, 0x24, 0xff, 0xcb, 0x53, 0xb3, 0x1b, 0x6c, 0x6b, 0x48, 0x13, 0x2b, 0xba, 0xf0, 0x79, 0xd1,
+	0xf6, 0x42, 0xa4, 0x32, 0xf2, 0x82, 0x45, 0x64, 0xb6, 0xc9, 0x8d, 0xbc, 0x38, 0x80, 0x6d, 0xed,
+	0xcb, 0xe6, 0x9b, 0xdd, 0xb8, 0xde, 0xf2, 0x8e, 0x5b, 0x1c, 0xc6, 0xe7, 0x70, 0xac, 0x87, 0xb2,
+	0xfc, 0x75, 0x7a, 0x13, 0x72, 0xf5, 0x8a, 0xbd, 0xbf, 0xf2, 0xa2, 0x34, 0x19, 0x4b, 0x1e, 0xcf,
+	0x9f, 0xc0, 0x12, 0x17, 0x3e, 0x83, 0x8f, 0xcb, 0x1c, 0xf3, 0x07, 0xbe, 0xaa, 0x21, 0x75, 0x96,
+	0x02, 0x61, 0x38, 0x1e, 0xb3, 0x58, 0x65, 0xff, 0x27, 0x53, 0x1e, 0x3b, 0x6b, 0x16, 0xa1, 0x68,
+	0xc1, 0x6f, 0x60, 0x4f, 0xcb, 0x2e, 0xbb, 0xe3, 0xec, 0x3d, 0xa3, 0x29, 0x9c, 0xae, 0x4e, 0x96,
+	0x49, 0xf8, 0x02, 0x7a, 0x7a, 0x78, 0xb6, 0x15, 0x42, 0x0e, 0x83, 0x40, 0x18, 0xf7, 0x94, 0x79,
+	0x1d, 0xd0, 0xf1, 0xa5, 0xbe, 0x7c, 0xfd, 0x99, 0xc7, 0x65, 0xb7, 0x6c, 0xac, 0x8c, 0x6d, 0x5c,
+	0x37, 0xd6, 0x5f, 0x6e, 0xc1, 0x33, 0x78, 0x6c, 0xc8, 0xa3, 0xf4, 0x26, 0xe0, 0xc9, 0xd4, 0x40,
+	0x6c, 0x68, 0x44, 0xad, 0xa7, 0xb0, 0x8a, 0x61, 0x92, 0xf0, 0x49, 0x64, 0x20, 0x36, 0xad, 0x55,
+	0x14, 0x2d, 0xf8, 0x03, 0x38, 0x86, 0x9c, 0x1d, 0x7e, 0x3a, 0x64, 0xce, 0x96, 0x8e, 0x57, 0xea,
+	0xf8, 0x3d, 0x1c, 0x5a, 0xda, 0x1b, 0x11, 0xa4, 0x21, 0x73, 0xb6, 0x75, 0xb4, 0x4a, 0xce, 0xdf,
+	0xc7, 0x4c, 0x9a, 0xfd, 0x9d, 0xcf, 0xb9, 0x63, 0xbc, 0x8f, 0x96, 0x5a, 0x98, 0x71, 0xe8, 0xfb,
+	0xe7, 0x22, 0x08, 0x98, 0x37, 0x49, 0x99, 0xb3, 0x6b, 0xcd, 0x68, 0xca, 0xf8, 0x14, 0x0e, 0x4c,
+	0x49, 0x1f, 0xa6, 0x8b, 0x54, 0x7d, 0x70, 0x50, 0xe7, 0xca, 0x45, 0xbc, 0x80, 0x27, 0xc6, 0x11,
+	0xfc, 0x8d, 0xab, 0xa9, 0x2f, 0x3d, 0xf3, 0x75, 0xd9, 0xd3, 0xe9, 0x7a, 0x13, 0xfe, 0x08, 0x1f,
+	0x19, 0xf8, 0xcb, 0xe8, 0x8e, 0xab, 0xfc, 0x68, 0x3a, 0xfb, 0x9a, 0x50, 0x6d, 0xe8, 0x4b, 0xd8,
+	0x39, 0x17, 0x61, 0xe8, 0x45, 0xfe, 0x99, 0x10, 0x2a, 0x51, 0xd2, 0x8b, 0x17, 0x2b, 0x78, 0xab,
+	0xae, 0x82, 0x3f, 0x05, 0x78, 0xc7, 0x65, 0xa2, 0x74, 0x0d, 0xd1, 0xd5, 0x6e, 0xfd, 0x74, 0xff,
+	0x84, 0x80, 0x59, 0x65, 0xc8, 0x9e, 0x8c, 0x6b, 0xf8, 0xfa, 0x7f, 0x6f, 0xc3, 0x01, 0x79, 0xe6,
+	0x35, 0xf4, 0xd7, 0xd8, 0xf7, 0x14, 0xc3, 0x57, 0x74, 0x6a, 0xad, 0x1a, 0x9b, 0xe9, 0xf9, 0x62,
+	0x2e, 0x23, 0x95, 0x8d, 0xb8, 0xb5, 0xfe, 0x45, 0x9e, 0x59, 0x7b, 0x89, 0xf7, 0xa0, 0x8e, 0x67,
+	0xfb, 0x71, 0x0a, 0x5f, 0x36, 0x28, 0xc3, 0x04, 0x6f, 0x5b, 0xf0, 0xe6, 0x61, 0xbc, 0x85, 0xaf,
+	0x9a, 0x54, 0x67, 0x9a, 0xea, 0xa1, 0x35, 0xd5, 0xff, 0x48, 0xe3, 0x33, 0x3a, 0xbd, 0xf7, 0xa5,
+	0x9c, 0xb0, 0x1d, 0x0b, 0x5b, 0x6e, 0xc4, 0x3f, 0xa9, 0xef, 0x54, 0xd6, 0x74, 0x02, 0xae, 0x58,
+	0xc0, 0x46, 0x39, 0xfc, 0x1d, 0x3e, 0xad, 0x29, 0xf7, 0x04, 0x5f, 0xb5, 0xe0, 0xcb, 0x43, 0x05,
+	0x72, 0xb1, 0x0d, 0x10, 0x79, 0xad, 0x96, 0x5c, 0x1e, 0xc2, 0x9f, 0xe9, 0xbd, 0x5c, 0x6c, 0x13,
+	0x44, 0xec, 0x5a, 0xc4, 0x6a, 0x33, 0xde, 0xc0, 0x17, 0xcb, 0x3a, 0x06, 0x61, 0xc1, 0xc2, 0x36,
+	0x4c, 0xe6, 0xfb, 0x50, 0xde, 0x4e, 0x08, 0xbf, 0x5e, 0xb1, 0x0f, 0x75, 0x21, 0x7c, 0x0b, 0xfd,
+	0xba, 0x2e, 0x43, 0xe8, 0x0d, 0x0b, 0xdd, 0x20, 0x55, 0x58, 0x75, 0xb1, 0xfd, 0x10, 0x7a, 0xb3,
+	0x76, 0xd5, 0xe5, 0x21, 0x74, 0xe1, 0xd8, 0x30, 0x2d, 0x74, 0x26, 0xc2, 0x6e, 0x59, 0xd8, 0x25,
+	0x09, 0x1c, 0xc1, 0x13, 0xcb, 0x91, 0xb5, 0x2c, 0x42, 0x6e, 0x5b, 0xc8, 0xfa, 0x40, 0x5e, 0xdf,
+	0xac, 0x5e, 0x46, 0xc0, 0x9d, 0x8a, 0xfa, 0x56, 0xe1, 0x2f, 0xac, 0xd0, 0x6c, 0x71, 0x04, 0xdc,
+	0xad, 0x5d, 0xa1, 0x1d, 0xc0, 0x97, 0x8b, 0xbf, 0x21, 0xf2, 0xe6, 0x47, 0x3c, 0xb4, 0x78, 0x75,
+	0x76, 0xfc, 0x83, 0x7e, 0x31, 0x57, 0x35, 0x43, 0xa2, 0xee, 0x59, 0xd4, 0x26, 0x31, 0x7c, 0x0d,
+	0x9f, 0x54, 0x36, 0x4a, 0x22, 0xef, 0x5b, 0xe4, 0x65, 0x11, 0xbc, 0x80, 0x43, 0xeb, 0x63, 0x86,
+	0x68, 0x07, 0x16, 0xad, 0xca, 0x8a, 0x57, 0x70, 0x54, 0xf6, 0x85, 0x43, 0xa0, 0x47, 0x1a, 0xb4,
+	0x79, 0x72, 0xad, 0x24, 0x8f, 0x26, 0xc4, 0xaa, 0x09, 0xe0, 0x11, 0xac, 0xe9, 0xcf, 0xa0, 0x4b,
+	0x3f, 0x71, 0x0e, 0x7b, 0xed, 0x41, 0xd7, 0xcd, 0xaf, 0xcf, 0x56, 0xdf, 0x76, 0x42, 0xe1, 0xb3,
+	0xe0, 0x66, 0x45, 0x7f, 0x20, 0x7e, 0xfb, 0xdf, 0x00, 0xbe, 0x8f, 0xd3, 0x02, 0x4e, 0x0e, 0x00,
+	0x00,
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"errors"
+	"github.com/iskendria-pub/iskendria/config"
 	"github.com/iskendria-pub/iskendria/model"
 	"io/ioutil"
 	"os"
@@ -10,7 +11,7 @@ import (
 
 func initDocuments() {
 	theDocuments = &documents{
-		path: "./documents",
+		path: config.Current.DocumentDir,
 	}
 	theDocuments.init()
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/gorilla/mux"
 	"github.com/iskendria-pub/iskendria/cliIskendria"
+	"github.com/iskendria-pub/iskendria/config"
 	"github.com/iskendria-pub/iskendria/dao"
 	"github.com/iskendria-pub/iskendria/model"
 	"github.com/iskendria-pub/iskendria/portal/components/manageDocument"
@@ -43,7 +44,8 @@ var journalsTemplate = `
 {{- define "journalsTemplate" -}}
   {{range .}}
   <div class="journal">
-    <div class="title"><a href="/journal/{{.JournalId}}" {{if not .IsSigned}}class="muted"{{end}}>{{.Title}}</a></div>
+    <div class="title"><a href="/journal/{{.JournalId}}" {{if not .IsSigned}}class="muted"{{end}}>{{.Title}}</a>
+    {{- if ne .JournalState "OPEN"}} <span class="muted">{{.JournalState}}</span>{{end}}</div>
     <div class="editors">{{template "editors" .AcceptedEditors}}</div>
   </div>
   {{end}}
@@ -91,6 +93,10 @@ var journalTemplate = `
       <td>Editors:</td>
       <td><div>{{template "editors" .AcceptedEditors}}</div></td>
     </tr>
+    <tr>
+      <td>State:</td>
+      <td>{{.JournalState}}</td>
+    </tr>
   </table>
   <h2>Description</h2>
   <div id="descriptionId">{{.InitialDescription}}</div>
@@ -196,6 +202,34 @@ var volumeTemplate = `
       <td>Volume id:</td>
       <td>{{.Volume.VolumeId}}</td>
     </tr>
+    {{if .Volume.VolumeNumber}}
+    <tr>
+      <td>Volume:</td>
+      <td>{{.Volume.VolumeNumber}}</td>
+    </tr>
+    {{end}}
+    {{if .Volume.IssueNumber}}
+    <tr>
+      <td>Issue:</td>
+      <td>{{.Volume.IssueNumber}}</td>
+    </tr>
+    {{end}}
+    {{if .Volume.Year}}
+    <tr>
+      <td>Year:</td>
+      <td>{{.Volume.Year}}</td>
+    </tr>
+    {{end}}
+    {{if .Volume.PublicationDate}}
+    <tr>
+      <td>Publication date:</td>
+      <td>{{.Volume.PublicationDate}}</td>
+    </tr>
+    {{end}}
+    <tr>
+      <td>Sealed:</td>
+      <td>{{.Volume.IsSealed}}</td>
+    </tr>
   </table>
   <br />
   {{with .Manuscripts}}
@@ -248,11 +282,21 @@ var manuscriptTemplate = `
   <h2>{{.Title}}</h2>
   <div class="authors">{{template "authors" .Authors}}</div>
   <p/>
+  {{if eq .Status "WITHDRAWN"}}
+  <div class="withdrawn">This manuscript was withdrawn by its authors.</div>
+  <p/>
+  {{end}}
   <table>
     <tr>
       <td>Status:</td>
       <td>{{.Status}}</td>
     </tr>
+    {{if .WithdrawalMsg}}
+    <tr>
+      <td>Reason for withdrawal:</td>
+      <td>{{.WithdrawalMsg}}</td>
+    </tr>
+    {{end}}
     <tr>
       <td>Version number:</td>
       <td>{{.VersionNumber}}</td>
@@ -330,6 +374,8 @@ var reviewPageTemplate = `
       {{- end -}}
       {{- with .ReviewAuthor -}}
       <td><a href="/person/{{.PersonId}}" {{if not .PersonIsSigned}}class="muted"{{end}}>{{.PersonName}}</a></td>
+      {{- else -}}
+      <td class="muted">Blind review, the author is not revealed. The review is not authenticated, because anyone can write a blind review</td>
       {{- end -}}
     </tr>
     <tr>
@@ -339,10 +385,27 @@ var reviewPageTemplate = `
     </tr>
     <tr>
       <td>Used by editor:</td>
-      <td>{{.IsUsedByEditor}}
+      <td>{{.IsUsedByEditor}}{{if and .IsUsedByEditor (not $.ReviewAuthor)}}, <span class="muted">but not authenticated</span>{{end}}
     </tr>
+    {{- if .NextReviewId}}
+    <tr>
+      <td>Revised by:</td>
+      <td><a href="/review/{{.NextReviewId}}">{{.NextReviewId}}</a></td>
+    </tr>
+    {{- end}}
   </table>
   {{- end -}}
+  {{- if .EarlierRevisions}}
+  <h2>Earlier revisions</h2>
+  <table>
+    {{- range .EarlierRevisions}}
+    <tr>
+      <td><a href="/review/{{.Id}}">{{.Id}}</a></td>
+      <td>{{.Judgement}}</td>
+    </tr>
+    {{- end}}
+  </table>
+  {{- end}}
   <h2>Review text</h2>
   <div id="reviewTextId">{{.ReviewText}}</div>
   <p>
@@ -355,7 +418,11 @@ var reviewListTemplate = `
 {{range .}}
 <table>
 <tr><td>
+{{if .PersonId -}}
 <a href="/person/{{.PersonId}}" {{if not .PersonIsSigned}}class="muted"{{end}}>{{.PersonName}}</a>
+{{- else -}}
+<span class="muted">Blind review, the author is not revealed. The review is not authenticated, because anyone can write a blind review</span>
+{{- end}}
 </td></tr>
 <tr><td>
 <div id="reviewTextId">{{.ReviewText}}</div>
@@ -364,8 +431,13 @@ var reviewListTemplate = `
 {{.Judgement}}
 </td></tr>
 <tr><td>
-{{if .IsUsedByEditor}}Used by editor to judge{{end}}
+{{if .IsUsedByEditor}}Used by editor to judge{{if not .PersonId}}, <span class="muted">but not authenticated</span>{{end}}{{end}}
+</td></tr>
+{{if .PreviousReviewId -}}
+<tr><td>
+<span class="muted">Revised review, the earlier revisions are on the manage page</span>
 </td></tr>
+{{- end}}
 <tr><td>
 <a href="/review/{{.Id}}">Manage</a>
 </tr></td>
@@ -379,6 +451,12 @@ const manageDocumentsJsUrl = "/manageDocument/manageDocument.js"
 const manageManuscriptsJsUrl = "/manageManuscript/manageManuscript.js"
 
 func main() {
+	defaults := config.GetDefaults()
+	defaults.DatabaseFile = "portal.db"
+	defaults.EventLogFile = "./portal-events.log"
+	if _, err := config.Load(defaults); err != nil {
+		log.Fatal(err)
+	}
 	dbLogger := log.New(os.Stdout, "db", log.Flags())
 	initialize(dbLogger)
 	defer dao.Shutdown(dbLogger)
@@ -386,8 +464,8 @@ func main() {
 }
 
 func initialize(dbLogger *log.Logger) {
-	dao.Init("portal.db", dbLogger)
-	cliIskendria.InitEventStream("./portal-events.log", "portal")
+	dao.Init(config.Current.DatabaseFile, dbLogger)
+	cliIskendria.InitEventStream(config.Current.EventLogFile, "portal")
 	go func() {
 		for {
 			_ = cliIskendria.ReadEventStreamStatus()
@@ -419,7 +497,7 @@ func runHttpServer() {
 	r.PathPrefix("/manageManuscript/").Handler(
 		http.StripPrefix("/manageManuscript/", http.FileServer(http.Dir("./components/manageManuscript"))))
 	http.Handle("/", r)
-	err := http.ListenAndServe(":8080", nil)
+	err := http.ListenAndServe(config.Current.PortalAddress, nil)
 	if err != nil {
 		fmt.Println(err)
 	}
@@ -487,6 +565,7 @@ func journalToJournalContext(journal *dao.Journal, volumes []dao.Volume) *Journa
 			JournalId:       journal.JournalId,
 			Title:           journal.Title,
 			AcceptedEditors: journal.AcceptedEditors,
+			JournalState:    journal.JournalState,
 		},
 		ManageDocument: manageDocument.ManageDocumentContext{
 			SubjectId:            journal.JournalId,
@@ -526,24 +605,35 @@ type JournalView struct {
 	JournalId          string
 	Title              string
 	AcceptedEditors    []*dao.Editor
+	JournalState       string
 	InitialDescription string
 }
 
 type VolumeView struct {
-	VolumeId     string
-	Issue        string
-	JournalId    string
-	JournalTitle string
+	VolumeId        string
+	Issue           string
+	VolumeNumber    int32
+	IssueNumber     int32
+	Year            int32
+	PublicationDate string
+	IsSealed        bool
+	JournalId       string
+	JournalTitle    string
 }
 
 func volumesToVolumeViews(volumes []dao.Volume, journalId, journalTitle string) []*VolumeView {
 	result := make([]*VolumeView, 0, len(volumes))
 	for _, volume := range volumes {
 		result = append(result, &VolumeView{
-			VolumeId:     volume.VolumeId,
-			Issue:        volume.Issue,
-			JournalId:    journalId,
-			JournalTitle: journalTitle,
+			VolumeId:        volume.VolumeId,
+			Issue:           volume.Issue,
+			VolumeNumber:    volume.VolumeNumber,
+			IssueNumber:     volume.IssueNumber,
+			Year:            volume.Year,
+			PublicationDate: volume.PublicationDate,
+			IsSealed:        volume.IsSealed,
+			JournalId:       journalId,
+			JournalTitle:    journalTitle,
 		})
 	}
 	return result
@@ -899,10 +989,15 @@ func handleVolume(w http.ResponseWriter, r *http.Request) {
 func volumeToVolumeContext(volume *dao.VolumeView) *VolumeContext {
 	return &VolumeContext{
 		Volume: &VolumeView{
-			VolumeId:     volume.Volume.VolumeId,
-			Issue:        volume.Volume.Issue,
-			JournalId:    volume.Journal.JournalId,
-			JournalTitle: volume.Journal.Title,
+			VolumeId:        volume.Volume.VolumeId,
+			Issue:           volume.Volume.Issue,
+			VolumeNumber:    volume.Volume.VolumeNumber,
+			IssueNumber:     volume.Volume.IssueNumber,
+			Year:            volume.Volume.Year,
+			PublicationDate: volume.Volume.PublicationDate,
+			IsSealed:        volume.Volume.IsSealed,
+			JournalId:       volume.Journal.JournalId,
+			JournalTitle:    volume.Journal.Title,
 		},
 		Journal:     []*dao.Journal{volume.Journal},
 		Manuscripts: volume.Manuscripts,
@@ -1013,13 +1108,14 @@ type ManuscriptContext struct {
 }
 
 type ReviewListItem struct {
-	PersonId       string
-	PersonIsSigned bool
-	PersonName     string
-	Id             string
-	ReviewText     string
-	Judgement      string
-	IsUsedByEditor bool
+	PersonId         string
+	PersonIsSigned   bool
+	PersonName       string
+	Id               string
+	ReviewText       string
+	Judgement        string
+	IsUsedByEditor   bool
+	PreviousReviewId string
 }
 
 func manuscriptToManuscriptContext(manuscript *dao.ManuscriptView, hasExistingManuscript bool) *ManuscriptContext {
@@ -1049,13 +1145,14 @@ func extendedReviewsToReviewListItems(source []*dao.ExtendedReview) []*ReviewLis
 			reviewText = []byte("ERROR getting review text: " + err.Error())
 		}
 		result[i] = &ReviewListItem{
-			PersonId:       s.PersonId,
-			PersonIsSigned: s.PersonIsSigned,
-			PersonName:     s.PersonName,
-			Id:             s.Id,
-			ReviewText:     string(reviewText),
-			Judgement:      s.Judgement,
-			IsUsedByEditor: s.IsUsedByEditor,
+			PersonId:         s.PersonId,
+			PersonIsSigned:   s.PersonIsSigned,
+			PersonName:       s.PersonName,
+			Id:               s.Id,
+			ReviewText:       string(reviewText),
+			Judgement:        s.Judgement,
+			IsUsedByEditor:   s.IsUsedByEditor,
+			PreviousReviewId: s.PreviousReviewId,
 		}
 	}
 	return result
@@ -1189,11 +1286,12 @@ func handleReviewDetail(w http.ResponseWriter, r *http.Request) {
 }
 
 type ReviewDetailsView struct {
-	Manuscripts    []*dao.Manuscript
-	Review         *dao.Review
-	ReviewAuthor   *dao.ReviewEditor
-	ReviewText     string
-	ManageDocument *manageDocument.ManageDocumentContext
+	Manuscripts      []*dao.Manuscript
+	Review           *dao.Review
+	ReviewAuthor     *dao.ReviewEditor
+	ReviewText       string
+	EarlierRevisions []*dao.Review
+	ManageDocument   *manageDocument.ManageDocumentContext
 }
 
 func reviewToReviewDetailsView(reviewView *dao.ReviewView, hasReviewText bool, reviewText string) *ReviewDetailsView {
@@ -1201,13 +1299,10 @@ func reviewToReviewDetailsView(reviewView *dao.ReviewView, hasReviewText bool, r
 		Manuscripts: []*dao.Manuscript{
 			reviewView.Manuscript,
 		},
-		Review: reviewView.Review,
-		ReviewAuthor: &dao.ReviewEditor{
-			PersonId:       reviewView.ReviewAuthor.Id,
-			PersonName:     reviewView.ReviewAuthor.Name,
-			PersonIsSigned: reviewView.ReviewAuthor.IsSigned,
-		},
-		ReviewText: reviewText,
+		Review:           reviewView.Review,
+		ReviewAuthor:     getReviewAuthor(reviewView.ReviewAuthor),
+		ReviewText:       reviewText,
+		EarlierRevisions: reviewView.EarlierRevisions,
 		ManageDocument: &manageDocument.ManageDocumentContext{
 			SubjectId:             reviewView.Review.Id,
 			InitialIsUploadNeeded: !hasReviewText,
@@ -1220,6 +1315,18 @@ func reviewToReviewDetailsView(reviewView *dao.ReviewView, hasReviewText bool, r
 	}
 }
 
+// getReviewAuthor returns nil for a blind review that was not revealed.
+func getReviewAuthor(person *dao.Person) *dao.ReviewEditor {
+	if person == nil {
+		return nil
+	}
+	return &dao.ReviewEditor{
+		PersonId:       person.Id,
+		PersonName:     person.Name,
+		PersonIsSigned: person.IsSigned,
+	}
+}
+
 func reviewUpdate(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Entering reviewUpdate...\n")
 	defer log.Printf("Leaving reviewUpdate\n")
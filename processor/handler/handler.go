@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"github.com/hyperledger/sawtooth-sdk-go/processor"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/processor_pb2"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/logging"
+	"github.com/iskendria-pub/iskendria/model"
+	"time"
+)
+
+type AlexandriaHandler struct{}
+
+var _ processor.TransactionHandler = new(AlexandriaHandler)
+
+func (ch *AlexandriaHandler) FamilyName() string {
+	return model.FamilyName
+}
+
+func (ch *AlexandriaHandler) FamilyVersions() []string {
+	return model.FamilyVersions
+}
+
+func (ch *AlexandriaHandler) Namespaces() []string {
+	return []string{model.Namespace}
+}
+
+func (ch *AlexandriaHandler) Apply(request *processor_pb2.TpProcessRequest, context *processor.Context) error {
+	return ch.ApplyRequest(request, context)
+}
+
+// ApplyRequest is Apply for any kind of blockchain access. It allows
+// the devnet to apply transactions without a validator. Only the
+// addresses declared in the transaction header can be accessed.
+// The validator only checks declared addresses against prefixes.
+// The outcome and the duration are recorded in the metrics.
+func (ch *AlexandriaHandler) ApplyRequest(request *processor_pb2.TpProcessRequest, ba command.BlockchainAccess) error {
+	logger := logging.Default().With("transactionId", request.Signature)
+	logger.Debug("Entering Apply")
+	start := time.Now()
+	cmd, err := ch.applyRequest(request, ba)
+	commandType := getCommandType(cmd)
+	recordApply(commandType, start, err)
+	logOutcome(logger.With("command", commandType), commandType, err)
+	return err
+}
+
+// Rejected transactions are logged at info level, because the
+// blockchain works as intended when it refuses them. Internal errors
+// need the attention of an operator.
+func logOutcome(logger *logging.Logger, commandType string, err error) {
+	if err == nil {
+		logger.Debug("Left Apply")
+		return
+	}
+	reason := getRejectReason(commandType, err)
+	if reason == REASON_INTERNAL {
+		logger.Error("Transaction failed", "reason", reason, "error", err)
+	} else {
+		logger.Info("Transaction rejected", "reason", reason, "error", err)
+	}
+}
+
+func (ch *AlexandriaHandler) applyRequest(
+	request *processor_pb2.TpProcessRequest, ba command.BlockchainAccess) (*model.Command, error) {
+	cmd, err := command.ParsePayload(request.Header.FamilyVersion, request.Payload)
+	if err != nil {
+		return nil, &processor.InvalidTransactionError{
+			Msg: err.Error(),
+		}
+	}
+	timedAccess := &timingDecorator{delegate: ba}
+	checkedAccess := command.NewAccessCheckingDecorator(timedAccess, request.Header.Inputs, request.Header.Outputs)
+	err = command.ApplyModelCommand(
+		cmd, request.Header.FamilyVersion, request.Header.SignerPublicKey, request.Signature, checkedAccess)
+	return cmd, toProcessorError(err)
+}
+
+func toProcessorError(err error) error {
+	if err == nil {
+		return nil
+	}
+	// The validator retries transactions that failed with an internal error.
+	if command.IsInternalError(err) {
+		return &processor.InternalError{
+			Msg: err.Error(),
+		}
+	}
+	return &processor.InvalidTransactionError{
+		Msg: err.Error(),
+	}
+}
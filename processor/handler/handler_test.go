@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/sawtooth-sdk-go/processor"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/events_pb2"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/processor_pb2"
+	"github.com/hyperledger/sawtooth-sdk-go/protobuf/transaction_pb2"
+	"github.com/hyperledger/sawtooth-sdk-go/signing"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/model"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+)
+
+type unavailableBlockchainAccess struct{}
+
+var _ command.BlockchainAccess = new(unavailableBlockchainAccess)
+
+func (uba *unavailableBlockchainAccess) GetState(addresses []string) (map[string][]byte, error) {
+	return nil, errors.New("Validator unavailable")
+}
+
+func (uba *unavailableBlockchainAccess) SetState(pairs map[string][]byte) ([]string, error) {
+	return nil, errors.New("Validator unavailable")
+}
+
+func (uba *unavailableBlockchainAccess) AddEvent(
+	eventType string, attributes []processor.Attribute, eventData []byte) error {
+	return errors.New("Validator unavailable")
+}
+
+func getBootstrapRequest(t *testing.T) *processor_pb2.TpProcessRequest {
+	context := signing.NewSecp256k1Context()
+	privateKey := context.NewRandomPrivateKey()
+	publicKey := context.GetPublicKey(privateKey)
+	cmd := command.GetBootstrapCommand(&command.Bootstrap{
+		Name:  "Major",
+		Email: "major@xxx.nl",
+	}, &command.CryptoIdentity{
+		PublicKeyStr: publicKey.AsHex(),
+		PublicKey:    publicKey,
+		PrivateKey:   privateKey,
+	})
+	payload, err := proto.Marshal(cmd.Command)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &processor_pb2.TpProcessRequest{
+		Header: &transaction_pb2.TransactionHeader{
+			SignerPublicKey: publicKey.AsHex(),
+			FamilyVersion:   model.FamilyVersion,
+			Inputs:          cmd.InputAddresses,
+			Outputs:         cmd.OutputAddresses,
+		},
+		Payload:   payload,
+		Signature: "transaction",
+	}
+}
+
+func TestUnavailableValidatorGivesInternalError(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	err := new(AlexandriaHandler).ApplyRequest(getBootstrapRequest(t), &unavailableBlockchainAccess{})
+	if _, isInternal := err.(*processor.InternalError); !isInternal {
+		t.Errorf("Expected processor.InternalError, got: %v", err)
+	}
+}
+
+func TestUnparsablePayloadGivesInvalidTransaction(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	request := getBootstrapRequest(t)
+	request.Payload = []byte("not a command")
+	err := new(AlexandriaHandler).ApplyRequest(request, &unavailableBlockchainAccess{})
+	if _, isInvalid := err.(*processor.InvalidTransactionError); !isInvalid {
+		t.Errorf("Expected processor.InvalidTransactionError, got: %v", err)
+	}
+}
+
+func TestUnsupportedFamilyVersionGivesInvalidTransaction(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	request := getBootstrapRequest(t)
+	request.Header.FamilyVersion = "0.9"
+	err := new(AlexandriaHandler).ApplyRequest(request, &unavailableBlockchainAccess{})
+	if _, isInvalid := err.(*processor.InvalidTransactionError); !isInvalid {
+		t.Errorf("Expected processor.InvalidTransactionError, got: %v", err)
+	}
+}
+
+func TestOutcomesAreCounted(t *testing.T) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	const bootstrap = "Bootstrap"
+	appliedBefore := transactionsApplied.Get(bootstrap)
+	invalidBefore := transactionsRejected.Get(bootstrap, REASON_INVALID)
+	internalBefore := transactionsRejected.Get(bootstrap, REASON_INTERNAL)
+	malformedBefore := transactionsRejected.Get(COMMAND_UNKNOWN, REASON_MALFORMED)
+	durationsBefore := applyDuration.GetCount(bootstrap)
+	getStateBefore := stateAccessDuration.GetCount("get")
+	setStateBefore := stateAccessDuration.GetCount("set")
+	h := new(AlexandriaHandler)
+	ba := command.NewBlockchainStub(func(*events_pb2.Event, *log.Logger) error { return nil }, nil)
+	request := getBootstrapRequest(t)
+	if err := h.ApplyRequest(request, ba); err != nil {
+		t.Fatal(err)
+	}
+	// Bootstrapping twice is invalid
+	_ = h.ApplyRequest(request, ba)
+	_ = h.ApplyRequest(getBootstrapRequest(t), &unavailableBlockchainAccess{})
+	request.Payload = []byte("not a command")
+	_ = h.ApplyRequest(request, ba)
+	if transactionsApplied.Get(bootstrap)-appliedBefore != 1 {
+		t.Error("Expected one applied bootstrap")
+	}
+	if transactionsRejected.Get(bootstrap, REASON_INVALID)-invalidBefore != 1 {
+		t.Error("Expected one invalid bootstrap")
+	}
+	if transactionsRejected.Get(bootstrap, REASON_INTERNAL)-internalBefore != 1 {
+		t.Error("Expected one bootstrap with an internal error")
+	}
+	if transactionsRejected.Get(COMMAND_UNKNOWN, REASON_MALFORMED)-malformedBefore != 1 {
+		t.Error("Expected one malformed transaction")
+	}
+	if applyDuration.GetCount(bootstrap)-durationsBefore != 3 {
+		t.Error("Expected the duration of three bootstraps")
+	}
+	if stateAccessDuration.GetCount("get")-getStateBefore != 3 {
+		t.Error("Expected every bootstrap to read the state once")
+	}
+	if stateAccessDuration.GetCount("set")-setStateBefore != 1 {
+		t.Error("Expected one bootstrap to write the state")
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"github.com/hyperledger/sawtooth-sdk-go/processor"
+	"github.com/iskendria-pub/iskendria/command"
+	"github.com/iskendria-pub/iskendria/metrics"
+	"github.com/iskendria-pub/iskendria/model"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// The reasons why a transaction is rejected
+const (
+	REASON_MALFORMED = "malformed"
+	REASON_INVALID   = "invalid"
+	REASON_INTERNAL  = "internal"
+)
+
+// The command label of a transaction of which the payload could not be parsed
+const COMMAND_UNKNOWN = "unknown"
+
+var transactionsApplied = metrics.NewCounterVec(
+	"iskendria_transactions_applied_total",
+	"Transactions that were applied successfully, per command type.",
+	"command")
+
+var transactionsRejected = metrics.NewCounterVec(
+	"iskendria_transactions_rejected_total",
+	"Transactions that were rejected, per command type and reason. Internal errors are retried by the validator.",
+	"command", "reason")
+
+var applyDuration = metrics.NewHistogramVec(
+	"iskendria_apply_duration_seconds",
+	"Time needed to apply a transaction, per command type.",
+	metrics.DefaultBuckets,
+	"command")
+
+var stateAccessDuration = metrics.NewHistogramVec(
+	"iskendria_state_access_duration_seconds",
+	"Duration of the GetState and SetState round trips to the validator.",
+	metrics.DefaultBuckets,
+	"operation")
+
+// getCommandType gives the name of the body type, like CommandManuscriptCreate.
+func getCommandType(cmd *model.Command) string {
+	if cmd == nil || cmd.Body == nil {
+		return COMMAND_UNKNOWN
+	}
+	name := reflect.TypeOf(cmd.Body).Elem().Name()
+	return strings.TrimPrefix(name, "Command_")
+}
+
+func recordApply(commandType string, start time.Time, err error) {
+	applyDuration.Observe(time.Since(start).Seconds(), commandType)
+	if err == nil {
+		transactionsApplied.Inc(commandType)
+	} else {
+		transactionsRejected.Inc(commandType, getRejectReason(commandType, err))
+	}
+}
+
+func getRejectReason(commandType string, err error) string {
+	switch err.(type) {
+	case *processor.InternalError:
+		return REASON_INTERNAL
+	default:
+		if commandType == COMMAND_UNKNOWN {
+			return REASON_MALFORMED
+		}
+		return REASON_INVALID
+	}
+}
+
+// timingDecorator measures the round trips to the validator.
+type timingDecorator struct {
+	delegate command.BlockchainAccess
+}
+
+var _ command.BlockchainAccess = new(timingDecorator)
+
+func (td *timingDecorator) GetState(addresses []string) (map[string][]byte, error) {
+	start := time.Now()
+	defer func() { stateAccessDuration.Observe(time.Since(start).Seconds(), "get") }()
+	return td.delegate.GetState(addresses)
+}
+
+func (td *timingDecorator) SetState(pairs map[string][]byte) ([]string, error) {
+	start := time.Now()
+	defer func() { stateAccessDuration.Observe(time.Since(start).Seconds(), "set") }()
+	return td.delegate.SetState(pairs)
+}
+
+func (td *timingDecorator) AddEvent(eventType string, attributes []processor.Attribute, eventData []byte) error {
+	return td.delegate.AddEvent(eventType, attributes, eventData)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"github.com/iskendria-pub/iskendria/config"
+	"github.com/iskendria-pub/iskendria/logging"
+	"github.com/iskendria-pub/iskendria/metrics"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const VALIDATOR_DIAL_TIMEOUT = time.Second
+
+/*
+The Sawtooth SDK registers the transaction processor with the validator
+inside Start. Start does not return while the processor runs, and the
+SDK does not report the registration response. A processor of which
+Start is running is registering or registered. It only stays registered
+while the validator can be reached. The readiness probe therefore checks
+that Start is running and that the ZMQ endpoint of the validator accepts
+connections. The validator only sends transactions to a registered
+processor, so registration is confirmed by the first transaction that
+is received. An idle processor is ready without that confirmation, and
+the answer of the probe says so.
+*/
+type readiness struct {
+	mutex        sync.Mutex
+	isStarted    bool
+	isStopped    bool
+	stopError    error
+	isRegistered bool
+}
+
+var theReadiness = &readiness{}
+
+func (r *readiness) setStarted() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.isStarted = true
+}
+
+func (r *readiness) setRegistered() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.isRegistered = true
+}
+
+func (r *readiness) setStopped(err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.isStopped = true
+	r.stopError = err
+}
+
+// check returns why the processor is not ready, or the empty string.
+func (r *readiness) check(zmqUrl string, dial func(address string) error) string {
+	r.mutex.Lock()
+	isStarted, isStopped, stopError := r.isStarted, r.isStopped, r.stopError
+	r.mutex.Unlock()
+	switch {
+	case isStopped && stopError != nil:
+		return "Transaction processor stopped: " + stopError.Error()
+	case isStopped:
+		return "Transaction processor stopped"
+	case !isStarted:
+		return "Transaction processor not started"
+	}
+	parsed, err := url.Parse(zmqUrl)
+	if err != nil {
+		return "Invalid ZMQ URL: " + zmqUrl
+	}
+	if err = dial(parsed.Host); err != nil {
+		return fmt.Sprintf("Validator at %s cannot be reached: %s", zmqUrl, err.Error())
+	}
+	return ""
+}
+
+func dialValidator(address string) error {
+	connection, err := net.DialTimeout("tcp", address, VALIDATOR_DIAL_TIMEOUT)
+	if err != nil {
+		return err
+	}
+	return connection.Close()
+}
+
+func (r *readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if reason := r.check(config.Current.ZmqUrl, dialValidator); reason != "" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, reason)
+		return
+	}
+	fmt.Fprintln(w, r.describeReady(config.Current.ZmqUrl))
+}
+
+func (r *readiness) describeReady(zmqUrl string) string {
+	r.mutex.Lock()
+	isRegistered := r.isRegistered
+	r.mutex.Unlock()
+	if isRegistered {
+		return "Registered with validator at " + zmqUrl + ", transactions were received"
+	}
+	return "Started and validator at " + zmqUrl + " accepts connections, " +
+		"registration is not confirmed because no transaction was received yet"
+}
+
+func serveMetrics(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/ready", theReadiness)
+	logger := logging.Default().With("address", address)
+	logger.Info("Serving metrics on /metrics and readiness on /ready")
+	if err := http.ListenAndServe(address, mux); err != nil {
+		logger.Error("Metrics endpoint stopped", "error", err)
+	}
+}
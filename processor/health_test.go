@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadiness(t *testing.T) {
+	reachable := func(string) error { return nil }
+	unreachable := func(string) error { return errors.New("connection refused") }
+	r := &readiness{}
+	if reason := r.check("tcp://validator:4004", reachable); !strings.Contains(reason, "not started") {
+		t.Errorf("Expected not started, got: %s", reason)
+	}
+	r.setStarted()
+	var dialed string
+	reason := r.check("tcp://validator:4004", func(address string) error {
+		dialed = address
+		return nil
+	})
+	if reason != "" {
+		t.Errorf("Expected ready, got: %s", reason)
+	}
+	if dialed != "validator:4004" {
+		t.Errorf("Expected to dial validator:4004, dialed %s", dialed)
+	}
+	if description := r.describeReady("tcp://validator:4004"); !strings.Contains(description, "not confirmed") {
+		t.Errorf("Expected unconfirmed registration, got: %s", description)
+	}
+	r.setRegistered()
+	if description := r.describeReady("tcp://validator:4004"); !strings.HasPrefix(description, "Registered") {
+		t.Errorf("Expected confirmed registration, got: %s", description)
+	}
+	if reason := r.check("tcp://validator:4004", unreachable); !strings.Contains(reason, "cannot be reached") {
+		t.Errorf("Expected unreachable validator, got: %s", reason)
+	}
+	r.setStopped(errors.New("registration failed"))
+	if reason := r.check("tcp://validator:4004", reachable); !strings.Contains(reason, "registration failed") {
+		t.Errorf("Expected stop error, got: %s", reason)
+	}
+}
@@ -0,0 +1,39 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+/*
+This package collects the outcome of a check of the blockchain, like
+the audit of the state or the check of a recreated archive. Notes are
+informational. Divergences mean that the check failed.
+*/
+
+type Report struct {
+	Notes       []string
+	Divergences []string
+}
+
+func (r *Report) AddNote(format string, args ...interface{}) {
+	r.Notes = append(r.Notes, fmt.Sprintf(format, args...))
+}
+
+func (r *Report) AddDivergence(format string, args ...interface{}) {
+	r.Divergences = append(r.Divergences, fmt.Sprintf(format, args...))
+}
+
+func (r *Report) Write(w io.Writer) {
+	for _, n := range r.Notes {
+		fmt.Fprintln(w, n)
+	}
+	if len(r.Divergences) == 0 {
+		fmt.Fprintln(w, "No divergences found")
+		return
+	}
+	fmt.Fprintf(w, "Found %d divergence(s):\n", len(r.Divergences))
+	for _, d := range r.Divergences {
+		fmt.Fprintln(w, "  "+d)
+	}
+}
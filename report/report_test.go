@@ -0,0 +1,22 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	r := &Report{}
+	r.AddNote("Replayed %d blocks", 3)
+	var b bytes.Buffer
+	r.Write(&b)
+	if b.String() != "Replayed 3 blocks\nNo divergences found\n" {
+		t.Errorf("Unexpected report without divergences: %q", b.String())
+	}
+	r.AddDivergence("Address %s differs", "a1ec84")
+	b.Reset()
+	r.Write(&b)
+	if b.String() != "Replayed 3 blocks\nFound 1 divergence(s):\n  Address a1ec84 differs\n" {
+		t.Errorf("Unexpected report with a divergence: %q", b.String())
+	}
+}